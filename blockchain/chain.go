@@ -24,15 +24,18 @@ type Chain struct {
 	ChainID string
 	root    common.Hash
 
+	pendingRewardRecords map[common.Hash]*pendingRewardRecords // Reward records of the blocks not finalized yet
+
 	mu *sync.RWMutex
 }
 
 // NewChain creates a new Chain instance.
 func NewChain(chainID string, store store.Store, root *core.Block) *Chain {
 	chain := &Chain{
-		ChainID: chainID,
-		store:   store,
-		mu:      &sync.RWMutex{},
+		ChainID:              chainID,
+		store:                store,
+		pendingRewardRecords: make(map[common.Hash]*pendingRewardRecords),
+		mu:                   &sync.RWMutex{},
 	}
 	rootBlock, err := chain.FindBlock(root.Hash())
 	if err != nil {
@@ -132,7 +135,7 @@ func (ch *Chain) FixMissingChildren(block *core.ExtendedBlock) {
 	for _, hash := range block.Children {
 		_, err := ch.findBlock(hash)
 		if err != nil {
-			logger.Warningf("Removing dead link from block $v to block %v", block.Hash().Hex(), hash.Hex())
+			logger.Warningf("Removing dead link from block %v to block %v", block.Hash().Hex(), hash.Hex())
 		} else {
 			newChildren = append(newChildren, hash)
 		}
@@ -274,11 +277,15 @@ func (ch *Chain) FinalizePreviousBlocks(hash common.Hash) error {
 	ch.mu.Lock()
 	defer ch.mu.Unlock()
 
+	finalizedHeight := uint64(0)
 	status := core.BlockStatusDirectlyFinalized
 	for !hash.IsEmpty() {
 		block, err := ch.findBlock(hash)
 		if err != nil || block.Status.IsFinalized() {
-			return nil
+			break
+		}
+		if status == core.BlockStatusDirectlyFinalized {
+			finalizedHeight = block.Height
 		}
 		if block.Status == core.BlockStatusDisposed {
 			return errors.New("Cannot finalize disposed branch")
@@ -293,9 +300,11 @@ func (ch *Chain) FinalizePreviousBlocks(hash common.Hash) error {
 		// Force update TX index on block finalization so that the index doesn't point to
 		// duplicate TX in fork.
 		ch.AddTxsToIndex(block, true)
+		ch.saveRewardRecords(block)
 
 		hash = block.Parent
 	}
+	ch.discardPendingRewardRecords(finalizedHeight)
 	return nil
 }
 
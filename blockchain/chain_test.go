@@ -5,6 +5,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/core"
 )
 
@@ -167,3 +168,39 @@ func TestBlockIndex(t *testing.T) {
 	assert.Equal(core.GetTestBlock("a2").Hash(), blocks[0].Hash())
 	assert.Equal(core.GetTestBlock("b2").Hash(), blocks[1].Hash())
 }
+
+func TestCheckBlockIndex(t *testing.T) {
+	assert := assert.New(t)
+	core.ResetTestBlocks()
+
+	chain := CreateTestChainByBlocks([]string{
+		"a1", "a0",
+		"a2", "a1",
+		"b2", "a1",
+		"a3", "a2"})
+
+	issues := chain.CheckBlockIndex(false, nil)
+	assert.Equal(0, len(issues))
+
+	missingBlock := common.BytesToHash([]byte("missing"))
+	a1 := core.GetTestBlock("a1").Hash()
+	a2 := core.GetTestBlock("a2").Hash()
+
+	chain.RemoveBlockByHeightIndex(2, a2)
+	chain.AddBlockByHeightIndex(3, missingBlock)
+	chain.AddBlockByHeightIndex(4, a1)
+	block, _ := chain.FindBlock(a1)
+	block.Children = append(block.Children, missingBlock)
+	chain.SaveBlock(block)
+
+	issues = chain.CheckBlockIndex(true, nil)
+	assert.Equal(4, len(issues))
+	assert.Equal(BlockIndexIssue{Type: BlockIndexIssueDeadChild, Height: 1, Hash: a1, Target: missingBlock}, issues[0])
+	assert.Equal(BlockIndexIssue{Type: BlockIndexIssueMissingEntry, Height: 2, Hash: a2}, issues[1])
+	assert.Equal(BlockIndexIssue{Type: BlockIndexIssueDeadEntry, Height: 3, Hash: missingBlock}, issues[2])
+	assert.Equal(BlockIndexIssue{Type: BlockIndexIssueWrongHeight, Height: 4, Hash: a1}, issues[3])
+
+	issues = chain.CheckBlockIndex(false, nil)
+	assert.Equal(0, len(issues))
+	assert.Equal(2, len(chain.FindBlocksByHeight(2)))
+}
@@ -0,0 +1,165 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+)
+
+// BlockIndexIssueType is the type of inconsistency found between the stored
+// blocks and the block-by-height index.
+type BlockIndexIssueType byte
+
+const (
+	// BlockIndexIssueMissingEntry indicates a block is not listed in the index at its height.
+	BlockIndexIssueMissingEntry BlockIndexIssueType = iota
+	// BlockIndexIssueDeadEntry indicates the index lists a block that is not stored.
+	BlockIndexIssueDeadEntry
+	// BlockIndexIssueWrongHeight indicates the index lists a block at a height other than its own.
+	BlockIndexIssueWrongHeight
+	// BlockIndexIssueDeadChild indicates a block links to a child block that is not stored.
+	BlockIndexIssueDeadChild
+)
+
+func (t BlockIndexIssueType) String() string {
+	switch t {
+	case BlockIndexIssueMissingEntry:
+		return "missing index entry"
+	case BlockIndexIssueDeadEntry:
+		return "index entry for missing block"
+	case BlockIndexIssueWrongHeight:
+		return "index entry at wrong height"
+	case BlockIndexIssueDeadChild:
+		return "link to missing child"
+	default:
+		return "unknown"
+	}
+}
+
+// BlockIndexIssue describes an inconsistency found by CheckBlockIndex.
+type BlockIndexIssue struct {
+	Type   BlockIndexIssueType
+	Height uint64
+	Hash   common.Hash // the block the issue was found on
+	Target common.Hash // the missing child for BlockIndexIssueDeadChild
+}
+
+func (issue BlockIndexIssue) String() string {
+	if issue.Type == BlockIndexIssueDeadChild {
+		return fmt.Sprintf("%v: height %v, block %v, child %v", issue.Type, issue.Height, issue.Hash.Hex(), issue.Target.Hex())
+	}
+	return fmt.Sprintf("%v: height %v, block %v", issue.Type, issue.Height, issue.Hash.Hex())
+}
+
+// FindBlockHashesByHeight returns the block hashes listed in the index at the
+// given height, including those of blocks missing from the store.
+func (ch *Chain) FindBlockHashesByHeight(height uint64) []common.Hash {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	blockByHeightIndexEntry := BlockByHeightIndexEntry{
+		Blocks: []common.Hash{},
+	}
+	ch.store.Get(blockByHeightIndexKey(height), &blockByHeightIndexEntry)
+	return blockByHeightIndexEntry.Blocks
+}
+
+// RemoveBlockByHeightIndex removes a block from the index at the given height.
+func (ch *Chain) RemoveBlockByHeightIndex(height uint64, block common.Hash) {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	key := blockByHeightIndexKey(height)
+	blockByHeightIndexEntry := BlockByHeightIndexEntry{
+		Blocks: []common.Hash{},
+	}
+	ch.store.Get(key, &blockByHeightIndexEntry)
+
+	blocks := []common.Hash{}
+	for _, b := range blockByHeightIndexEntry.Blocks {
+		if b != block {
+			blocks = append(blocks, b)
+		}
+	}
+	blockByHeightIndexEntry.Blocks = blocks
+
+	err := ch.store.Put(key, blockByHeightIndexEntry)
+	if err != nil {
+		logger.Panic(err)
+	}
+}
+
+// CheckBlockIndex verifies the block-by-height index against the blocks reachable
+// from the root, and the children links of those blocks. Index entries above the
+// highest reachable block are checked until the first empty height. If fix is set,
+// the issues found are repaired with FixBlockIndex, FixMissingChildren and
+// RemoveBlockByHeightIndex. The progress callback, if not nil, is called with
+// every height checked.
+func (ch *Chain) CheckBlockIndex(fix bool, progress func(height uint64)) []BlockIndexIssue {
+	issues := []BlockIndexIssue{}
+
+	root := ch.Root()
+	maxHeight := root.Height
+	queue := []*core.ExtendedBlock{root}
+	for len(queue) > 0 {
+		block := queue[0]
+		queue = queue[1:]
+		if block.Height > maxHeight {
+			maxHeight = block.Height
+		}
+
+		indexed := false
+		for _, hash := range ch.FindBlockHashesByHeight(block.Height) {
+			if hash == block.Hash() {
+				indexed = true
+				break
+			}
+		}
+		if !indexed {
+			issues = append(issues, BlockIndexIssue{Type: BlockIndexIssueMissingEntry, Height: block.Height, Hash: block.Hash()})
+			if fix {
+				ch.FixBlockIndex(block)
+			}
+		}
+
+		deadChild := false
+		for _, hash := range block.Children {
+			child, err := ch.FindBlock(hash)
+			if err != nil {
+				deadChild = true
+				issues = append(issues, BlockIndexIssue{Type: BlockIndexIssueDeadChild, Height: block.Height, Hash: block.Hash(), Target: hash})
+				continue
+			}
+			queue = append(queue, child)
+		}
+		if deadChild && fix {
+			ch.FixMissingChildren(block)
+		}
+	}
+
+	for height := root.Height; ; height++ {
+		if progress != nil {
+			progress(height)
+		}
+		hashes := ch.FindBlockHashesByHeight(height)
+		if len(hashes) == 0 && height > maxHeight {
+			break
+		}
+		for _, hash := range hashes {
+			block, err := ch.FindBlock(hash)
+			if err != nil {
+				issues = append(issues, BlockIndexIssue{Type: BlockIndexIssueDeadEntry, Height: height, Hash: hash})
+			} else if block.Height != height {
+				issues = append(issues, BlockIndexIssue{Type: BlockIndexIssueWrongHeight, Height: height, Hash: hash})
+			} else {
+				continue
+			}
+			if fix {
+				ch.RemoveBlockByHeightIndex(height, hash)
+			}
+		}
+	}
+
+	return issues
+}
@@ -0,0 +1,153 @@
+package blockchain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/store"
+)
+
+// ---------------- Reward Records ---------------
+
+// RewardType is the kind of a staking reward.
+type RewardType byte
+
+const (
+	// RewardTypeBlock is the block reward of the Theta stakes of the validators and guardians.
+	RewardTypeBlock RewardType = iota
+	// RewardTypeEliteEdgeNode is the uptime mining reward of the TFuel stakes of the elite edge nodes.
+	RewardTypeEliteEdgeNode
+)
+
+func (t RewardType) String() string {
+	switch t {
+	case RewardTypeBlock:
+		return "block"
+	case RewardTypeEliteEdgeNode:
+		return "elite_edge_node"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(t))
+	}
+}
+
+// RewardRecord records the TFuel reward granted by a block for a stake. Before the stake
+// reward distribution was enabled, the reward was calculated over all the stakes of the
+// staker, in which case StakeHolder is empty.
+type RewardRecord struct {
+	Type        RewardType
+	Staker      common.Address // Source of the stake
+	StakeHolder common.Address // Validator, guardian or elite edge node holding the stake
+	Amount      *big.Int       // TFuelWei credited to the staker
+	Beneficiary common.Address // Beneficiary of the reward split of the stake holder, empty if none
+	SplitAmount *big.Int       // TFuelWei credited to the beneficiary
+}
+
+// NewRewardRecord creates a reward record without split.
+func NewRewardRecord(rewardType RewardType, staker common.Address, holder common.Address, amount *big.Int) *RewardRecord {
+	return &RewardRecord{
+		Type:        rewardType,
+		Staker:      staker,
+		StakeHolder: holder,
+		Amount:      amount,
+		SplitAmount: big.NewInt(0),
+	}
+}
+
+// rewardRecordsKey constructs the DB key for the reward records of the given coinbase transaction.
+func rewardRecordsKey(hash common.Hash) common.Bytes {
+	return append(common.Bytes("rwd/"), hash[:]...)
+}
+
+// RewardRecordsEntry holds the reward records of a block.
+type RewardRecordsEntry struct {
+	Records []*RewardRecord
+}
+
+// pendingRewardRecords holds the reward records of a block until the block is finalized.
+type pendingRewardRecords struct {
+	height  uint64
+	records []*RewardRecord
+}
+
+// coinbaseTxHash returns the hash of the coinbase transaction, which is unique to the block.
+func coinbaseTxHash(coinbaseTx *types.CoinbaseTx) common.Hash {
+	raw, err := types.TxToBytes(coinbaseTx)
+	if err != nil {
+		// Should never happen
+		logger.Panic(err)
+	}
+	return crypto.Keccak256Hash(raw)
+}
+
+// AddPendingRewardRecords keeps the reward records of the block at the height with the given
+// coinbase transaction in memory. They are only saved once the block is finalized, so the records
+// of the forks and the proposals never make it to the store.
+func (ch *Chain) AddPendingRewardRecords(coinbaseTx *types.CoinbaseTx, height uint64, records []*RewardRecord) {
+	hash := coinbaseTxHash(coinbaseTx)
+
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	ch.pendingRewardRecords[hash] = &pendingRewardRecords{
+		height:  height,
+		records: records,
+	}
+}
+
+// saveRewardRecords saves the pending reward records of the finalized block, indexed by its
+// coinbase transaction.
+func (ch *Chain) saveRewardRecords(block *core.ExtendedBlock) {
+	for _, rawTx := range block.Txs {
+		hash := crypto.Keccak256Hash(rawTx)
+		pending, ok := ch.pendingRewardRecords[hash]
+		if !ok {
+			continue
+		}
+		delete(ch.pendingRewardRecords, hash)
+
+		err := ch.store.Put(rewardRecordsKey(hash), RewardRecordsEntry{Records: pending.records})
+		if err != nil {
+			logger.Panic(err)
+		}
+		return
+	}
+}
+
+// discardPendingRewardRecords drops the pending reward records up to the finalized height,
+// which belong to the blocks that will never be finalized.
+func (ch *Chain) discardPendingRewardRecords(finalizedHeight uint64) {
+	for hash, pending := range ch.pendingRewardRecords {
+		if pending.height <= finalizedHeight {
+			delete(ch.pendingRewardRecords, hash)
+		}
+	}
+}
+
+// FindRewardRecords looks up the reward records of the given block. It returns false if the
+// block granted no reward, or if it was processed while the records were disabled.
+func (ch *Chain) FindRewardRecords(block *core.ExtendedBlock) ([]*RewardRecord, bool) {
+	for _, rawTx := range block.Txs {
+		tx, err := types.TxFromBytes(rawTx)
+		if err != nil {
+			continue
+		}
+		if _, ok := tx.(*types.CoinbaseTx); !ok {
+			continue
+		}
+
+		entry := &RewardRecordsEntry{}
+		err = ch.store.Get(rewardRecordsKey(crypto.Keccak256Hash(rawTx)), entry)
+		if err != nil {
+			if err != store.ErrKeyNotFound {
+				logger.Error(err)
+			}
+			return nil, false
+		}
+		return entry.Records, true
+	}
+	return nil, false
+}
@@ -0,0 +1,84 @@
+package blockchain
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// addTestCoinbaseBlock adds a test block carrying a coinbase transaction to the chain.
+func addTestCoinbaseBlock(chain *Chain, name string, parent string) *types.CoinbaseTx {
+	block := core.CreateTestBlock(name, parent)
+	coinbaseTx := &types.CoinbaseTx{
+		Proposer:    types.TxInput{Address: common.HexToAddress(name)},
+		BlockHeight: block.Height - 1,
+	}
+	raw, err := types.TxToBytes(coinbaseTx)
+	if err != nil {
+		panic(err)
+	}
+	block.Txs = []common.Bytes{raw}
+	block.UpdateHash()
+
+	b, err := chain.AddBlock(block)
+	if err != nil {
+		panic(err)
+	}
+	b.Status = core.BlockStatusValid
+	chain.saveBlock(b)
+	return coinbaseTx
+}
+
+func TestRewardRecordsSavedOnFinalization(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	core.ResetTestBlocks()
+
+	chain := CreateTestChain()
+	staker := common.HexToAddress("0x1")
+	for _, pair := range [][]string{{"a1", "a0"}, {"a2", "a1"}, {"b2", "a1"}, {"a3", "a2"}} {
+		coinbaseTx := addTestCoinbaseBlock(chain, pair[0], pair[1])
+		block := core.GetTestBlock(pair[0])
+		records := []*RewardRecord{NewRewardRecord(RewardTypeBlock, staker, common.Address{}, big.NewInt(int64(block.Height)))}
+		chain.AddPendingRewardRecords(coinbaseTx, block.Height, records)
+	}
+
+	findRecords := func(name string) ([]*RewardRecord, bool) {
+		block, err := chain.FindBlock(core.GetTestBlock(name).Hash())
+		require.Nil(err)
+		return chain.FindRewardRecords(block)
+	}
+
+	// Nothing is saved before the blocks are finalized
+	for _, name := range []string{"a1", "a2", "b2", "a3"} {
+		_, found := findRecords(name)
+		assert.False(found)
+	}
+
+	// Finalizing a2 saves the records of a1 and a2, and discards the records of the fork
+	require.Nil(chain.FinalizePreviousBlocks(core.GetTestBlock("a2").Hash()))
+	for _, name := range []string{"a1", "a2"} {
+		records, found := findRecords(name)
+		require.True(found)
+		require.Equal(1, len(records))
+		assert.Equal(staker, records[0].Staker)
+		assert.Equal(int64(core.GetTestBlock(name).Height), records[0].Amount.Int64())
+	}
+	_, found := findRecords("b2")
+	assert.False(found)
+	_, found = findRecords("a3")
+	assert.False(found)
+	assert.Equal(1, len(chain.pendingRewardRecords))
+
+	// The records of a3 are kept until it is finalized
+	require.Nil(chain.FinalizePreviousBlocks(core.GetTestBlock("a3").Hash()))
+	records, found := findRecords("a3")
+	require.True(found)
+	assert.Equal(int64(3), records[0].Amount.Int64())
+	assert.Equal(0, len(chain.pendingRewardRecords))
+}
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// checkIndexCmd represents the check_index command.
+// Example:
+//		theta db check_index --config=../privatenet/node --fix
+var checkIndexCmd = &cobra.Command{
+	Use:   "check_index",
+	Short: "Verify the block-by-height index against the stored blocks",
+	Long: `Verify the block-by-height index against the blocks reachable from the root,
+and the children links of those blocks. With --fix, missing index entries are
+added, and dead index entries and children links are removed.`,
+	Example: `theta db check_index --config=../privatenet/node --fix`,
+	Run:     runCheckIndex,
+}
+
+func init() {
+	checkIndexCmd.Flags().BoolVar(&fixFlag, "fix", false, "Repair the issues found")
+}
+
+func runCheckIndex(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	chain, _ := loadChain(db)
+	issues := chain.CheckBlockIndex(fixFlag, func(height uint64) {
+		if height%progressInterval == 0 {
+			fmt.Printf("Checked block index up to height %v\n", height)
+		}
+	})
+
+	for _, issue := range issues {
+		fmt.Println(issue)
+	}
+	if fixFlag {
+		fmt.Printf("Found and fixed %v issues\n", len(issues))
+	} else {
+		fmt.Printf("Found %v issues\n", len(issues))
+	}
+}
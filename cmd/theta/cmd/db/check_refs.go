@@ -0,0 +1,72 @@
+package db
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/store"
+)
+
+// checkRefsCmd represents the check_refs command.
+// Example:
+//		theta db check_refs --config=../privatenet/node --height=1000
+var checkRefsCmd = &cobra.Command{
+	Use:   "check_refs",
+	Short: "Report reference count anomalies of a state trie",
+	Long: `Report the nodes of a state trie, including the account storage tries, that
+have no or a zero reference count, and hence would be deleted when pruning
+another state sharing them. Only the nodes stored in the main database are
+checked, the rolling database layers do not count references. The state is
+selected as for check_state.`,
+	Example: `theta db check_refs --config=../privatenet/node --height=1000`,
+	Run:     runCheckRefs,
+}
+
+func init() {
+	checkRefsCmd.Flags().StringVar(&rootFlag, "root", "", "State root hash")
+	checkRefsCmd.Flags().Uint64Var(&heightFlag, "height", 0, "Height of the finalized block")
+}
+
+func runCheckRefs(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	root, height := stateRootFromFlags(db)
+	fmt.Printf("Checking reference counts of state %v, height %v\n", root.Hex(), height)
+
+	nodes, skipped, anomalies := 0, 0, 0
+	err := state.WalkStateNodes(root, rdb, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			return true
+		}
+		nodes++
+		if nodes%progressInterval == 0 {
+			fmt.Printf("Checked %v nodes\n", nodes)
+		}
+
+		if has, _ := db.Has(hash[:]); !has {
+			skipped++
+			return true
+		}
+		ref, err := db.CountReference(hash[:])
+		if err == store.ErrKeyNotFound {
+			anomalies++
+			fmt.Printf("No reference count: %v\n", hash.Hex())
+		} else if err != nil {
+			log.Fatalf("Failed to count references of %v: %v", hash.Hex(), err)
+		} else if ref <= 0 {
+			anomalies++
+			fmt.Printf("Zero reference count: %v\n", hash.Hex())
+		}
+		return true
+	})
+	if err != nil {
+		log.Fatalf("Failed to walk state %v: %v", root.Hex(), err)
+	}
+
+	fmt.Printf("Checked %v nodes, %v in rolling layers skipped, %v anomalies\n", nodes, skipped, anomalies)
+}
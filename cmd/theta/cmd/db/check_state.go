@@ -0,0 +1,56 @@
+package db
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/state"
+)
+
+// checkStateCmd represents the check_state command.
+// Example:
+//		theta db check_state --config=../privatenet/node --height=1000
+var checkStateCmd = &cobra.Command{
+	Use:   "check_state",
+	Short: "Verify that a state trie is complete",
+	Long: `Verify that all the nodes of a state trie, including the account storage tries,
+are present in the database. The state is selected by --root, or by the finalized
+block at --height, and defaults to the state of the last finalized block.`,
+	Example: `theta db check_state --config=../privatenet/node --height=1000`,
+	Run:     runCheckState,
+}
+
+func init() {
+	checkStateCmd.Flags().StringVar(&rootFlag, "root", "", "State root hash")
+	checkStateCmd.Flags().Uint64Var(&heightFlag, "height", 0, "Height of the finalized block")
+}
+
+func runCheckState(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	root, height := stateRootFromFlags(db)
+	fmt.Printf("Checking state %v, height %v\n", root.Hex(), height)
+
+	nodes, missing := 0, 0
+	err := state.WalkStateNodes(root, rdb, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			missing++
+			fmt.Printf("Missing node: %v\n", hash.Hex())
+			return true
+		}
+		nodes++
+		if nodes%progressInterval == 0 {
+			fmt.Printf("Checked %v nodes\n", nodes)
+		}
+		return true
+	})
+	if err != nil {
+		log.Fatalf("Failed to walk state %v: %v", root.Hex(), err)
+	}
+
+	fmt.Printf("Checked %v nodes, %v missing\n", nodes, missing)
+}
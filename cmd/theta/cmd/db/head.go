@@ -0,0 +1,90 @@
+package db
+
+import (
+	"encoding/json"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/blockchain"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/rlp"
+)
+
+// headCmd represents the head command.
+// Example:
+//		theta db head --config=../privatenet/node
+var headCmd = &cobra.Command{
+	Use:     "head",
+	Short:   "Print the chain head and finalized pointers",
+	Long:    `Print the snapshot, root, highest CC and last finalized blocks recorded in the database.`,
+	Example: `theta db head --config=../privatenet/node`,
+	Run:     runHead,
+}
+
+type blockPointer struct {
+	Hash      common.Hash
+	Height    common.JSONUint64
+	Epoch     common.JSONUint64
+	StateHash common.Hash
+	Status    core.BlockStatus
+}
+
+type headResult struct {
+	ChainID            string
+	Snapshot           *blockPointer `json:",omitempty"`
+	Root               *blockPointer
+	HighestCCBlock     *blockPointer
+	LastFinalizedBlock *blockPointer
+	Epoch              common.JSONUint64
+}
+
+func runHead(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	chain, stub := loadChain(db)
+	result := headResult{
+		ChainID:            chain.ChainID,
+		Root:               findPointer(chain, stub.Root),
+		HighestCCBlock:     findPointer(chain, stub.HighestCCBlock),
+		LastFinalizedBlock: findPointer(chain, stub.LastFinalizedBlock),
+		Epoch:              common.JSONUint64(stub.Epoch),
+	}
+
+	raw, err := db.Get([]byte("/snapshot_blockheader"))
+	if err == nil {
+		snapshotHeader := &core.BlockHeader{}
+		if err := rlp.DecodeBytes(raw, snapshotHeader); err == nil {
+			result.Snapshot = &blockPointer{
+				Hash:      snapshotHeader.Hash(),
+				Height:    common.JSONUint64(snapshotHeader.Height),
+				Epoch:     common.JSONUint64(snapshotHeader.Epoch),
+				StateHash: snapshotHeader.StateHash,
+			}
+		}
+	}
+
+	json, err := json.MarshalIndent(result, "", "    ")
+	if err != nil {
+		log.Fatalf("Failed to encode result: %v", err)
+	}
+	fmt.Println(string(json))
+}
+
+func findPointer(chain *blockchain.Chain, hash common.Hash) *blockPointer {
+	block, err := chain.FindBlock(hash)
+	if err != nil {
+		log.Warnf("Block %v not found: %v", hash.Hex(), err)
+		return &blockPointer{Hash: hash}
+	}
+	return &blockPointer{
+		Hash:      hash,
+		Height:    common.JSONUint64(block.Height),
+		Epoch:     common.JSONUint64(block.Epoch),
+		StateHash: block.StateHash,
+		Status:    block.Status,
+	}
+}
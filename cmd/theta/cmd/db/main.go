@@ -0,0 +1,117 @@
+package db
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/blockchain"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/store/database"
+	"github.com/thetatoken/theta/store/database/backend"
+	"github.com/thetatoken/theta/store/kvstore"
+	"github.com/thetatoken/theta/store/rollingdb"
+)
+
+var (
+	heightFlag uint64
+	rootFlag   string
+	fixFlag    bool
+)
+
+// progressInterval is the number of items processed between progress reports.
+const progressInterval = 100000
+
+// DBCmd represents the db command
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Inspect and repair the database of a stopped node",
+	Long:  `Inspect and repair the database of a stopped node.`,
+}
+
+func init() {
+	DBCmd.AddCommand(headCmd)
+	DBCmd.AddCommand(checkIndexCmd)
+	DBCmd.AddCommand(checkStateCmd)
+	DBCmd.AddCommand(checkRefsCmd)
+	DBCmd.AddCommand(rewindCmd)
+	DBCmd.AddCommand(pruneCmd)
+	DBCmd.AddCommand(migrateCmd)
+}
+
+// openDB opens the main database and the rolling database layers of the node.
+// The databases lock their directories, so this fails while the node is running.
+func openDB() (database.Database, *rollingdb.RollingDB) {
+	if _, err := common.LoadForkSchedule(viper.GetString(common.CfgGenesisChainID)); err != nil {
+		log.Fatalf("Failed to load the fork schedule: %v", err)
+	}
+
+	dbPath := dataPath()
+	kind := viper.GetString(common.CfgStorageBackend)
+	db, err := backend.NewNodeDatabase(dbPath, kind,
+		viper.GetInt(common.CfgStorageLevelDBCacheSize),
+		viper.GetInt(common.CfgStorageLevelDBHandles))
+	if err != nil {
+		mainDBPath, refDBPath := backend.DatabasePaths(dbPath, kind)
+		log.Fatalf("Failed to open the db, is the node stopped? main: %v, ref: %v, err: %v",
+			mainDBPath, refDBPath, err)
+	}
+	rdb := rollingdb.NewRollingDB(dbPath, db)
+	return db, rdb
+}
+
+// dataPath returns the data directory of the node.
+func dataPath() string {
+	dbPath := viper.GetString(common.CfgDataPath)
+	if dbPath == "" {
+		dbPath = viper.GetString(common.CfgConfigPath)
+	}
+	return dbPath
+}
+
+// loadChain loads the chain and the consensus state stub saved in the database.
+func loadChain(db database.Database) (*blockchain.Chain, *consensus.StateStub) {
+	store := kvstore.NewKVStore(db)
+	stub := &consensus.StateStub{}
+	if err := store.Get([]byte(consensus.DBStateStubKey), stub); err != nil {
+		log.Fatalf("Failed to load the consensus state: %v", err)
+	}
+	root := &core.ExtendedBlock{}
+	if err := store.Get(stub.Root[:], root); err != nil {
+		log.Fatalf("Failed to load the root block %v: %v", stub.Root.Hex(), err)
+	}
+	chain := blockchain.NewChain(root.ChainID, store, root.Block)
+	return chain, stub
+}
+
+// findFinalizedBlock returns the finalized block at the given height.
+func findFinalizedBlock(chain *blockchain.Chain, height uint64) *core.ExtendedBlock {
+	for _, block := range chain.FindBlocksByHeight(height) {
+		if block.Status.IsFinalized() {
+			return block
+		}
+	}
+	log.Fatalf("Finalized block not found for height %v", height)
+	return nil
+}
+
+// stateRootFromFlags returns the state root given by --root, or the state root
+// of the finalized block at --height, defaulting to the last finalized block.
+func stateRootFromFlags(db database.Database) (common.Hash, uint64) {
+	if rootFlag != "" {
+		return common.HexToHash(rootFlag), 0
+	}
+	chain, stub := loadChain(db)
+	var block *core.ExtendedBlock
+	if heightFlag != 0 {
+		block = findFinalizedBlock(chain, heightFlag)
+	} else {
+		lastFinalized, err := chain.FindBlock(stub.LastFinalizedBlock)
+		if err != nil {
+			log.Fatalf("Failed to load the last finalized block: %v", err)
+		}
+		block = lastFinalized
+	}
+	return block.StateHash, block.Height
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/store/database/backend"
+)
+
+var (
+	fromFlag string
+	toFlag   string
+)
+
+// migrateCmd represents the migrate command.
+// Example:
+//		theta db migrate --config=../privatenet/node --from=leveldb --to=pebble
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy the main database to another storage backend",
+	Long: `Copy all the entries of the main database, with their reference counts, from
+the --from storage backend to an empty database of the --to storage backend.
+The source database is left untouched. Once the copy is verified, set
+storage.backend in the config to the new backend. The rolling database layers
+are shared by both backends and are not copied.`,
+	Example: `theta db migrate --config=../privatenet/node --from=leveldb --to=pebble`,
+	Run:     runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().StringVar(&fromFlag, "from", backend.LevelDB, "Storage backend to copy from")
+	migrateCmd.Flags().StringVar(&toFlag, "to", backend.Pebble, "Storage backend to copy to")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) {
+	if fromFlag == toFlag {
+		log.Fatalf("The source and target backends are both %v", fromFlag)
+	}
+	cache := viper.GetInt(common.CfgStorageLevelDBCacheSize)
+	handles := viper.GetInt(common.CfgStorageLevelDBHandles)
+
+	srcPath, srcRefPath := backend.DatabasePaths(dataPath(), fromFlag)
+	src, err := backend.NewDatabase(fromFlag, srcPath, srcRefPath, cache, handles)
+	if err != nil {
+		log.Fatalf("Failed to open the source db, is the node stopped? main: %v, ref: %v, err: %v",
+			srcPath, srcRefPath, err)
+	}
+	defer src.Close()
+
+	dstPath, dstRefPath := backend.DatabasePaths(dataPath(), toFlag)
+	dst, err := backend.NewDatabase(toFlag, dstPath, dstRefPath, cache, handles)
+	if err != nil {
+		log.Fatalf("Failed to open the target db, main: %v, ref: %v, err: %v", dstPath, dstRefPath, err)
+	}
+	defer dst.Close()
+
+	if !backend.IsEmpty(dst) {
+		log.Fatalf("The target db %v is not empty", dstPath)
+	}
+
+	start := time.Now()
+	entries, refs, err := backend.CopyDatabase(src, dst, printProgress("Copied"))
+	if err != nil {
+		log.Fatalf("Failed to copy the db: %v", err)
+	}
+	fmt.Printf("Copied %v entries and %v reference counts from %v to %v in %v\n",
+		entries, refs, srcPath, dstPath, time.Since(start))
+
+	start = time.Now()
+	if _, err = backend.VerifyCopy(src, dst, printProgress("Verified")); err != nil {
+		log.Fatalf("Failed to verify the copied db: %v", err)
+	}
+	fmt.Printf("Verified %v entries in %v\n", entries, time.Since(start))
+	fmt.Printf("Set %v to %v in the config to use the migrated db\n", common.CfgStorageBackend, toFlag)
+}
+
+// printProgress returns a progress callback printing the number of entries processed
+// at regular intervals.
+func printProgress(action string) func(entries int) {
+	return func(entries int) {
+		if entries%progressInterval == 0 {
+			fmt.Printf("%v %v entries\n", action, entries)
+		}
+	}
+}
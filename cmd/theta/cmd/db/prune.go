@@ -0,0 +1,199 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/blockchain"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/rlp"
+	"github.com/thetatoken/theta/store/database"
+	"github.com/thetatoken/theta/store/kvstore"
+)
+
+var (
+	retainFlag         uint64
+	dryRunFlag         bool
+	skipCompactionFlag bool
+)
+
+// pruneCmd represents the prune command.
+// Example:
+//		theta db prune --config=../privatenet/node --retain=2048 --dry_run
+var pruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Prune the states older than the retained blocks",
+	Long: `Delete the state trie nodes of the blocks more than --retain blocks below the
+last finalized block, which are not shared with the retained states, and then
+compact the database. Like the online pruning, the states at the stake
+transaction heights, of the blocks with validator updates and, unless disabled
+in the config, of the checkpoints are retained as well. With --dry_run, only
+the number of nodes that would be deleted is reported.`,
+	Example: `theta db prune --config=../privatenet/node --retain=2048 --dry_run`,
+	Run:     runPrune,
+}
+
+func init() {
+	pruneCmd.Flags().Uint64Var(&retainFlag, "retain", 0, "Number of blocks below the last finalized block whose states are retained")
+	pruneCmd.Flags().BoolVar(&dryRunFlag, "dry_run", false, "Report what would be pruned without modifying the database")
+	pruneCmd.Flags().BoolVar(&skipCompactionFlag, "skip_compaction", false, "Do not compact the database after pruning")
+	pruneCmd.MarkFlagRequired("retain")
+}
+
+func runPrune(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	chain, stub := loadChain(db)
+	lastFinalized, err := chain.FindBlock(stub.LastFinalizedBlock)
+	if err != nil {
+		log.Fatalf("Failed to load the last finalized block: %v", err)
+	}
+	rootHeight := chain.Root().Height
+	if lastFinalized.Height < rootHeight+retainFlag {
+		fmt.Printf("Nothing to prune, the chain has %v finalized blocks above the root height %v\n",
+			lastFinalized.Height-rootHeight, rootHeight)
+		return
+	}
+	cutoff := lastFinalized.Height - retainFlag
+
+	retained, pruned := selectStates(chain, db, rdb, stub, lastFinalized, cutoff)
+	fmt.Printf("Retaining %v states, pruning %v states below height %v\n", len(retained), len(pruned), cutoff)
+
+	start := time.Now()
+	pruner := state.NewStatePruner(db, rdb, dryRunFlag)
+	for _, root := range retained {
+		err := pruner.Retain(root, func(nodes uint64) {
+			if nodes%progressInterval == 0 {
+				fmt.Printf("Marked %v retained nodes\n", nodes)
+			}
+		})
+		if err != nil {
+			log.Fatalf("Failed to walk retained state %v: %v", root.Hex(), err)
+		}
+	}
+	fmt.Printf("Marked %v retained nodes in %v\n", pruner.RetainedNodes, time.Since(start))
+
+	start = time.Now()
+	for i, root := range pruned {
+		err := pruner.Prune(root, func(nodes uint64) {
+			if nodes%progressInterval == 0 {
+				fmt.Printf("Pruned %v nodes, %v/%v states\n", nodes, i, len(pruned))
+			}
+		})
+		if err != nil {
+			log.Fatalf("Failed to prune state %v: %v", root.Hex(), err)
+		}
+	}
+	if err := pruner.Commit(); err != nil {
+		log.Fatalf("Failed to write the pruned state: %v", err)
+	}
+
+	if dryRunFlag {
+		fmt.Printf("Would delete %v nodes (%v bytes) and dereference %v nodes\n",
+			pruner.DeletedNodes, pruner.DeletedBytes, pruner.DereferencedNodes)
+		return
+	}
+	fmt.Printf("Deleted %v nodes (%v bytes) and dereferenced %v nodes in %v\n",
+		pruner.DeletedNodes, pruner.DeletedBytes, pruner.DereferencedNodes, time.Since(start))
+
+	if skipCompactionFlag {
+		return
+	}
+	fmt.Println("Compacting the database")
+	start = time.Now()
+	if err := rdb.Compact(nil, nil); err != nil {
+		log.Fatalf("Failed to compact the database: %v", err)
+	}
+	fmt.Printf("Compacted the database in %v\n", time.Since(start))
+}
+
+// selectStates returns the state roots to retain and to prune, following the
+// same rules as the online pruning of the ledger.
+func selectStates(chain *blockchain.Chain, db database.Database, rdb database.Database, stub *consensus.StateStub,
+	lastFinalized *core.ExtendedBlock, cutoff uint64) (retained []common.Hash, pruned []common.Hash) {
+	keep := make(map[common.Hash]bool)
+	retain := func(root common.Hash) {
+		if root == (common.Hash{}) || keep[root] {
+			return
+		}
+		keep[root] = true
+		retained = append(retained, root)
+	}
+
+	retain(chain.Root().StateHash)
+	if raw, err := db.Get([]byte("/snapshot_blockheader")); err == nil {
+		snapshotHeader := &core.BlockHeader{}
+		if err := rlp.DecodeBytes(raw, snapshotHeader); err == nil {
+			retain(snapshotHeader.StateHash)
+		}
+	}
+
+	// States referred to by the stake transaction height list
+	kvStore := kvstore.NewKVStore(rdb)
+	sv := state.NewStoreView(lastFinalized.Height, lastFinalized.StateHash, rdb)
+	if sv == nil {
+		log.Fatalf("Failed to load the state of the last finalized block %v", lastFinalized.Hash().Hex())
+	}
+	if hl := sv.GetStakeTransactionHeightList(); hl != nil {
+		for _, height := range hl.Heights {
+			blockTrio := &core.SnapshotBlockTrio{}
+			blockTrioKey := []byte(core.BlockTrioStoreKeyPrefix + strconv.FormatUint(height, 10))
+			if err := kvStore.Get(blockTrioKey, blockTrio); err == nil {
+				retain(blockTrio.First.Header.StateHash)
+				continue
+			}
+			for _, block := range chain.FindBlocksByHeight(height) {
+				if height == core.GenesisBlockHeight || block.Status.IsDirectlyFinalized() {
+					retain(block.StateHash)
+					break
+				}
+			}
+		}
+	}
+
+	skipCheckpoints := viper.GetBool(common.CfgStorageStatePruningSkipCheckpoints)
+	candidates := []common.Hash{}
+	maxHeight := lastFinalized.Height
+	if highestCC, err := chain.FindBlock(stub.HighestCCBlock); err == nil && highestCC.Height > maxHeight {
+		maxHeight = highestCC.Height
+	}
+	for height := chain.Root().Height; ; height++ {
+		blocks := chain.FindBlocksByHeight(height)
+		if len(blocks) == 0 && height > maxHeight {
+			break
+		}
+		for _, block := range blocks {
+			if height >= cutoff || block.HasValidatorUpdate ||
+				(skipCheckpoints && common.IsCheckPointHeight(height+1)) {
+				retain(block.StateHash)
+				continue
+			}
+			if block.Status.IsPending() || block.Status.IsInvalid() || block.Status.IsTrusted() {
+				continue // The state of the block was never saved
+			}
+			candidates = append(candidates, block.StateHash)
+		}
+		if height%progressInterval == 0 {
+			fmt.Printf("Selected states up to height %v\n", height)
+		}
+	}
+
+	seen := make(map[common.Hash]bool)
+	for _, root := range candidates {
+		if keep[root] || seen[root] {
+			continue
+		}
+		seen[root] = true
+		pruned = append(pruned, root)
+	}
+	return retained, pruned
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/store/kvstore"
+)
+
+// rewindCmd represents the rewind command.
+// Example:
+//		theta db rewind --config=../privatenet/node --height=1000
+var rewindCmd = &cobra.Command{
+	Use:   "rewind",
+	Short: "Rewind the chain head to a finalized height",
+	Long: `Rewind the highest CC and last finalized block pointers of the consensus state
+to the finalized block at the given height. The blocks above it are marked valid
+instead of finalized, so that they can be finalized again once the node restarts.`,
+	Example: `theta db rewind --config=../privatenet/node --height=1000`,
+	Run:     runRewind,
+}
+
+func init() {
+	rewindCmd.Flags().Uint64Var(&heightFlag, "height", 0, "Height of the finalized block to rewind to")
+	rewindCmd.MarkFlagRequired("height")
+}
+
+func runRewind(cmd *cobra.Command, args []string) {
+	db, rdb := openDB()
+	defer db.Close()
+	defer rdb.Close()
+
+	chain, stub := loadChain(db)
+	lastFinalized, err := chain.FindBlock(stub.LastFinalizedBlock)
+	if err != nil {
+		log.Fatalf("Failed to load the last finalized block: %v", err)
+	}
+	if heightFlag > lastFinalized.Height {
+		log.Fatalf("Cannot rewind to height %v above the last finalized height %v", heightFlag, lastFinalized.Height)
+	}
+	if heightFlag < chain.Root().Height {
+		log.Fatalf("Cannot rewind to height %v below the root height %v", heightFlag, chain.Root().Height)
+	}
+
+	target := findFinalizedBlock(chain, heightFlag)
+	if has, _ := rdb.Has(target.StateHash[:]); !has {
+		log.Fatalf("State %v of block %v is not available", target.StateHash.Hex(), target.Hash().Hex())
+	}
+
+	maxHeight := lastFinalized.Height
+	if highestCC, err := chain.FindBlock(stub.HighestCCBlock); err == nil && highestCC.Height > maxHeight {
+		maxHeight = highestCC.Height
+	}
+	demoted := 0
+	for height := target.Height + 1; ; height++ {
+		blocks := chain.FindBlocksByHeight(height)
+		if len(blocks) == 0 && height > maxHeight {
+			break
+		}
+		for _, block := range blocks {
+			if block.Status.IsFinalized() && !block.Status.IsTrusted() {
+				chain.MarkBlockValid(block.Hash())
+				demoted++
+			}
+		}
+	}
+
+	state := consensus.NewState(kvstore.NewKVStore(db), chain)
+	if err := state.SetLastFinalizedBlock(target); err != nil {
+		log.Fatalf("Failed to update the last finalized block: %v", err)
+	}
+	if err := state.SetHighestCCBlock(target); err != nil {
+		log.Fatalf("Failed to update the highest CC block: %v", err)
+	}
+
+	fmt.Printf("Rewound from height %v to height %v, block %v, %v blocks no longer finalized\n",
+		lastFinalized.Height, target.Height, target.Hash().Hex(), demoted)
+}
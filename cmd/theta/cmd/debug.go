@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/node"
+	"github.com/thetatoken/theta/p2p/simulation"
+	msgl "github.com/thetatoken/theta/p2pl/messenger"
+	"github.com/thetatoken/theta/rlp"
+	"github.com/thetatoken/theta/store/database/backend"
+	"github.com/thetatoken/theta/store/rollingdb"
+)
+
+var (
+	walFlag  string
+	stepFlag bool
+)
+
+// debugCmd represents the debug command
+var debugCmd = &cobra.Command{
+	Use:   "debug",
+	Short: "Debugging tools for node operators",
+}
+
+// replayConsensusCmd represents the replay-consensus command.
+// Example:
+//		theta debug replay-consensus --config=../privatenet/node --password=qwertyuiop
+var replayConsensusCmd = &cobra.Command{
+	Use:   "replay-consensus",
+	Short: "Replay the consensus WAL against a copy of the database",
+	Long: `Replay the messages and timer events recorded in the consensus WAL (enabled by
+consensus.walEnabled) in a fresh consensus engine, printing the state of the engine
+after each of them. The engine runs against a temporary copy of the database, which
+should be in the state it was in when the recorded run started, e.g. a backup taken
+before the node was started. The node must be stopped.`,
+	Example: `theta debug replay-consensus --config=../privatenet/node --password=qwertyuiop --step`,
+	Run:     runReplayConsensus,
+}
+
+func init() {
+	replayConsensusCmd.Flags().StringVar(&walFlag, "wal", "", "Path of the WAL (default is wal/consensus under the data path)")
+	replayConsensusCmd.Flags().BoolVar(&stepFlag, "step", false, "Wait for the Enter key after each entry")
+	debugCmd.AddCommand(replayConsensusCmd)
+	RootCmd.AddCommand(debugCmd)
+}
+
+func runReplayConsensus(cmd *cobra.Command, args []string) {
+	privKey, err := loadOrCreateKey()
+	if err != nil {
+		log.Fatalf("Failed to load key: %v", err)
+	}
+	if _, err := common.LoadForkSchedule(viper.GetString(common.CfgGenesisChainID)); err != nil {
+		log.Fatalf("Failed to load the fork schedule: %v", err)
+	}
+
+	dbPath := viper.GetString(common.CfgDataPath)
+	if dbPath == "" {
+		dbPath = cfgPath
+	}
+	walPath := walFlag
+	if walPath == "" {
+		walPath = consensus.WALPath(dbPath)
+	}
+	reader, err := consensus.NewWALReader(walPath)
+	if err != nil {
+		log.Fatalf("Failed to open the WAL: %v", err)
+	}
+	defer reader.Close()
+
+	replayPath, err := ioutil.TempDir("", "theta-replay")
+	if err != nil {
+		log.Fatalf("Failed to create a temporary directory: %v", err)
+	}
+	defer os.RemoveAll(replayPath)
+	fmt.Printf("Copying the database to %v\n", replayPath)
+	if err := copyDir(path.Join(dbPath, "db"), path.Join(replayPath, "db")); err != nil {
+		log.Fatalf("Failed to copy the database: %v", err)
+	}
+
+	db, err := backend.NewNodeDatabase(replayPath, viper.GetString(common.CfgStorageBackend),
+		viper.GetInt(common.CfgStorageLevelDBCacheSize),
+		viper.GetInt(common.CfgStorageLevelDBHandles))
+	if err != nil {
+		log.Fatalf("Failed to open the copy of the database: %v", err)
+	}
+	defer db.Close()
+	rdb := rollingdb.NewRollingDB(replayPath, db)
+
+	raw, err := db.Get([]byte("/snapshot_blockheader"))
+	if err != nil {
+		log.Fatalf("Failed to load the snapshot header, has the node been started before? %v", err)
+	}
+	rootHeader := &core.BlockHeader{}
+	if err := rlp.DecodeBytes(raw, rootHeader); err != nil {
+		log.Fatalf("Failed to decode the snapshot header: %v", err)
+	}
+
+	// The replayed engine is cut off from the network, what it sends goes nowhere
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	simnet := simulation.NewSimnet()
+	endpoint := simnet.AddEndpoint(privKey.PublicKey().Address().Hex())
+	simnet.Start(ctx)
+	var network *msgl.Messenger
+
+	viper.Set(common.CfgRPCEnabled, false)
+	n := node.NewNode(&node.Params{
+		ChainID:    rootHeader.ChainID,
+		PrivateKey: privKey,
+		Root:       &core.Block{BlockHeader: rootHeader},
+		NetworkOld: endpoint,
+		Network:    network,
+		DB:         db,
+		RollingDB:  rdb,
+	})
+
+	stdin := bufio.NewReader(os.Stdin)
+	step := func(entry *consensus.WALEntry) {
+		e := n.Consensus
+		fmt.Printf("%v  %-36v epoch: %v, highest CC: %v, last finalized: %v\n",
+			entry.Timestamp().Format("2006-01-02 15:04:05.000"), describeWALEntry(entry),
+			e.GetEpoch(), e.State().GetHighestCCBlock().Height, e.GetLastFinalizedBlock().Height)
+		if stepFlag {
+			stdin.ReadString('\n')
+		}
+	}
+	if err := n.Consensus.Replay(ctx, reader, step); err != nil {
+		log.Fatalf("Replay stopped: %v", err)
+	}
+	fmt.Println("Reached the end of the WAL")
+}
+
+// describeWALEntry returns a one line description of the entry.
+func describeWALEntry(entry *consensus.WALEntry) string {
+	msg, err := entry.Message()
+	if err != nil {
+		return entry.Type.String()
+	}
+	switch m := msg.(type) {
+	case core.Vote:
+		return fmt.Sprintf("vote %v by %v, epoch %v", m.Block.Hex()[:10], m.ID.Hex()[:10], m.Epoch)
+	case *core.Block:
+		return fmt.Sprintf("block %v at height %v, epoch %v", m.Hash().Hex()[:10], m.Height, m.Epoch)
+	default:
+		return entry.Type.String()
+	}
+}
+
+// copyDir copies the files under src to dst.
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, filePath)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+		in, err := os.Open(filePath)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.Create(target)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(out, in); err != nil {
+			out.Close()
+			return err
+		}
+		return out.Close()
+	})
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"path"
+
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/snapshot"
+	"github.com/thetatoken/theta/store/database/backend"
+)
+
+// devChainID is the chain ID of the developer mode chain
+const devChainID = "devnet"
+
+var (
+	devMode      bool
+	devAccounts  []string
+	devBalance   uint64
+	devBlockTime int
+	devInMemory  bool
+)
+
+func init() {
+	startCmd.Flags().BoolVar(&devMode, "dev", false, "Start a single validator development chain with all the features enabled from genesis")
+	startCmd.Flags().StringSliceVar(&devAccounts, "dev_accounts", []string{}, "Addresses prefunded in the development chain")
+	startCmd.Flags().Uint64Var(&devBalance, "dev_balance", 1000000, "Theta and TFuel balance of each prefunded account in the development chain")
+	startCmd.Flags().IntVar(&devBlockTime, "dev_block_time", 0, "Block interval in seconds of the development chain, 0 to produce blocks when transactions arrive")
+	startCmd.Flags().BoolVar(&devInMemory, "dev_in_memory", false, "Keep the development chain in memory instead of a temporary directory")
+}
+
+// setupDevChain creates a fresh development chain in a temporary directory. The node
+// runs the only validator of the chain with a newly generated key, and the validator
+// and the dev accounts are funded in the genesis block.
+func setupDevChain() (*crypto.PrivateKey, string, error) {
+	for _, account := range devAccounts {
+		if !common.IsHexAddress(account) {
+			return nil, "", fmt.Errorf("Invalid dev account address: %v", account)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "theta-dev")
+	if err != nil {
+		return nil, "", err
+	}
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	if err != nil {
+		return nil, dir, err
+	}
+	validator := privKey.PublicKey().Address()
+
+	// The block headers are encoded according to the fork schedule, register it first
+	common.RegisterForkSchedule(devChainID, common.DevForkSchedule)
+
+	ten18 := new(big.Int).SetUint64(1000000000000000000)
+	amount := new(big.Int).Mul(new(big.Int).SetUint64(devBalance), ten18)
+	balances := make(map[common.Address]types.Coins)
+	for _, account := range append([]string{validator.Hex()}, devAccounts...) {
+		balances[common.HexToAddress(account)] = types.Coins{
+			ThetaWei: new(big.Int).Set(amount),
+			TFuelWei: new(big.Int).Set(amount),
+		}
+	}
+	stake := new(big.Int).Set(core.MinValidatorStakeDeposit)
+	balances[validator].ThetaWei.Add(balances[validator].ThetaWei, stake)
+
+	sv, metadata, err := snapshot.GenerateGenesisSnapshot(devChainID, balances,
+		[]snapshot.GenesisStake{{Source: validator, Holder: validator, Amount: stake}})
+	if err != nil {
+		return nil, dir, err
+	}
+	genesisPath := path.Join(dir, "snapshot")
+	if err := snapshot.WriteGenesisSnapshot(sv, metadata, genesisPath); err != nil {
+		return nil, dir, err
+	}
+
+	cfgPath = dir
+	snapshotPath = genesisPath
+	viper.Set(common.CfgDataPath, dir)
+	viper.Set(common.CfgGenesisChainID, devChainID)
+	viper.Set(common.CfgGenesisHash, metadata.TailTrio.Second.Header.Hash().Hex())
+
+	viper.Set(common.CfgRPCEnabled, true)
+	viper.Set(common.CfgP2PSeeds, "")
+	viper.Set(common.CfgLibP2PSeeds, "")
+	// Short epochs let the lone validator get past the genesis block quickly
+	viper.Set(common.CfgConsensusMinBlockInterval, devBlockTime)
+	viper.Set(common.CfgConsensusMaxEpochLength, devBlockTime+2)
+	if devInMemory {
+		viper.Set(common.CfgStorageBackend, backend.Memory)
+	}
+	viper.Set(common.CfgStorageRollingEnabled, false)
+
+	printDevBanner(privKey)
+
+	return privKey, dir, nil
+}
+
+func printDevBanner(privKey *crypto.PrivateKey) {
+	fmt.Println("")
+	fmt.Println("-----------------------------------------------------------------------------------------------------")
+	fmt.Println("Running a development chain. All data is discarded on exit.")
+	fmt.Printf("Chain ID:               %v\n", devChainID)
+	fmt.Printf("Validator address:      %v\n", privKey.PublicKey().Address().Hex())
+	fmt.Printf("Validator private key:  %v\n", common.Bytes2Hex(privKey.ToBytes()))
+	for _, account := range devAccounts {
+		fmt.Printf("Prefunded account:      %v\n", common.HexToAddress(account).Hex())
+	}
+	if devBlockTime == 0 {
+		fmt.Println("Blocks are produced when transactions arrive.")
+	} else {
+		fmt.Printf("Blocks are produced every %v seconds.\n", devBlockTime)
+	}
+	fmt.Println("-----------------------------------------------------------------------------------------------------")
+	fmt.Println("")
+}
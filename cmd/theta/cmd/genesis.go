@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/snapshot"
+)
+
+var (
+	genesisOutputFlag   string
+	genesisHashFileFlag string
+	genesisSpecFlag     string
+	genesisHashFlag     string
+)
+
+// genesisCmd represents the genesis command
+var genesisCmd = &cobra.Command{
+	Use:   "genesis",
+	Short: "Build and verify the genesis snapshot of a new chain",
+}
+
+// genesisBuildCmd represents the genesis build command.
+// Example:
+//		theta genesis build spec.json --output=./genesis
+var genesisBuildCmd = &cobra.Command{
+	Use:   "build <spec.json>",
+	Short: "Build a genesis snapshot from a spec",
+	Long: `Build the genesis snapshot of a new chain from a JSON spec, which sets the chain ID,
+the account allocations, the validator, guardian and elite edge node stakes, the contracts
+deployed at genesis and the fork schedule. The amounts are decimal strings in wei, e.g.
+
+{
+  "chain_id": "privatenet",
+  "timestamp": 1700000000,
+  "forks": {"preset": "dev", "heights": {"governance": 1000}},
+  "accounts": [
+    {"address": "0x2E833968E5bB786Ae419c4d13189fB081Cc43bab", "theta_wei": "1000000000000000000000000", "tfuel_wei": "5000000000000000000000000"},
+    {"address": "0x...", "code": "0x6080...", "storage": {"0x00...00": "0x00...2a"}}
+  ],
+  "validators": [{"source": "0x2E83...", "holder": "0x2E83...", "amount": "200000000000000000000000"}],
+  "guardians": [{"source": "0x...", "holder": "0x...", "amount": "...", "bls_pubkey": "0x...", "bls_pop": "0x..."}],
+  "elite_edge_nodes": [{"source": "0x...", "holder": "0x...", "amount": "...", "bls_pubkey": "0x...", "bls_pop": "0x..."}]
+}
+
+The stakes are deducted from the balances of their sources. The command prints the hash of the
+genesis block, to be set as genesis.hash in the config of the nodes, along with the fork schedule.`,
+	Example: `theta genesis build spec.json --output=./genesis --hash_file=./genesis.hash`,
+	Args:    cobra.ExactArgs(1),
+	Run:     runGenesisBuild,
+}
+
+// genesisVerifyCmd represents the genesis verify command.
+// Example:
+//		theta genesis verify ./genesis --hash=0xd5f9...
+var genesisVerifyCmd = &cobra.Command{
+	Use:   "verify <snapshot>",
+	Short: "Verify a genesis snapshot",
+	Long: `Verify that the state of a genesis snapshot matches its genesis block, that the genesis
+block hash is the expected one and that the chain has validators. The expected hash is the one
+given by --hash, or genesis.hash of the config. The block header is decoded with the fork
+schedule of the config, forks.preset and forks.heights. With --spec, the fork schedule of the
+spec is used instead, and the snapshot is also checked to be the one built from the spec.`,
+	Example: `theta genesis verify ./genesis --spec=spec.json`,
+	Args:    cobra.ExactArgs(1),
+	Run:     runGenesisVerify,
+}
+
+func init() {
+	genesisBuildCmd.Flags().StringVar(&genesisOutputFlag, "output", "./genesis", "Path of the genesis snapshot")
+	genesisBuildCmd.Flags().StringVar(&genesisHashFileFlag, "hash_file", "", "Path of a file to write the genesis block hash to")
+	genesisVerifyCmd.Flags().StringVar(&genesisSpecFlag, "spec", "", "Path of the spec the snapshot was built from")
+	genesisVerifyCmd.Flags().StringVar(&genesisHashFlag, "hash", "", "Expected genesis block hash (default is genesis.hash of the config)")
+	genesisCmd.AddCommand(genesisBuildCmd)
+	genesisCmd.AddCommand(genesisVerifyCmd)
+	RootCmd.AddCommand(genesisCmd)
+}
+
+func runGenesisBuild(cmd *cobra.Command, args []string) {
+	spec, err := snapshot.LoadGenesisSpec(args[0])
+	if err != nil {
+		log.Fatalf("Failed to load the genesis spec: %v", err)
+	}
+
+	sv, metadata, err := snapshot.BuildGenesisSnapshot(spec)
+	if err != nil {
+		log.Fatalf("Failed to build the genesis snapshot: %v", err)
+	}
+	if _, err := os.Stat(genesisOutputFlag); err == nil {
+		log.Fatalf("%v already exists", genesisOutputFlag)
+	}
+	if err := snapshot.WriteGenesisSnapshot(sv, metadata, genesisOutputFlag); err != nil {
+		log.Fatalf("Failed to write the genesis snapshot: %v", err)
+	}
+
+	genesisHash := metadata.TailTrio.Second.Header.Hash().Hex()
+	if genesisHashFileFlag != "" {
+		if err := ioutil.WriteFile(genesisHashFileFlag, []byte(genesisHash+"\n"), 0644); err != nil {
+			log.Fatalf("Failed to write the genesis hash: %v", err)
+		}
+	}
+
+	fmt.Printf("Genesis snapshot written to %v\n", genesisOutputFlag)
+	fmt.Printf("Genesis block hash: %v\n\n", genesisHash)
+	fmt.Printf("Config of the nodes:\n\n")
+	fmt.Printf("genesis:\n  chainID: %v\n  hash: %v\n", spec.ChainID, genesisHash)
+	if spec.Forks != nil {
+		fmt.Printf("forks:\n")
+		if spec.Forks.Preset != "" {
+			fmt.Printf("  preset: %v\n", spec.Forks.Preset)
+		}
+		if len(spec.Forks.Heights) > 0 {
+			fmt.Printf("  heights:\n")
+			names := make([]string, 0, len(spec.Forks.Heights))
+			for name := range spec.Forks.Heights {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				fmt.Printf("    %v: %v\n", name, spec.Forks.Heights[name])
+			}
+		}
+	}
+}
+
+func runGenesisVerify(cmd *cobra.Command, args []string) {
+	var spec *snapshot.GenesisSpec
+	if genesisSpecFlag != "" {
+		var err error
+		spec, err = snapshot.LoadGenesisSpec(genesisSpecFlag)
+		if err != nil {
+			log.Fatalf("Failed to load the genesis spec: %v", err)
+		}
+	} else if _, err := common.LoadForkSchedule(viper.GetString(common.CfgGenesisChainID)); err != nil {
+		log.Fatalf("Failed to load the fork schedule: %v", err)
+	}
+
+	if genesisHashFlag != "" {
+		viper.Set(common.CfgGenesisHash, genesisHashFlag)
+	}
+	if spec == nil && viper.GetString(common.CfgGenesisHash) == "" {
+		log.Fatalf("The expected genesis hash is not set, use --hash or --spec")
+	}
+
+	if spec != nil {
+		hash, err := snapshot.CheckGenesisSpec(args[0], spec)
+		if err != nil {
+			log.Fatalf("Genesis snapshot verification failed: %v", err)
+		}
+		// The genesis built from the spec is expected unless the config sets another one
+		if viper.GetString(common.CfgGenesisHash) == "" {
+			viper.Set(common.CfgGenesisHash, hash.Hex())
+		}
+	}
+
+	header, err := snapshot.VerifyGenesisSnapshot(args[0])
+	if err != nil {
+		log.Fatalf("Genesis snapshot verification failed: %v", err)
+	}
+	fmt.Printf("Genesis snapshot verified, chain ID: %v, genesis block hash: %v\n", header.ChainID, header.Hash().Hex())
+}
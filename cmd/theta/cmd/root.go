@@ -6,6 +6,7 @@ import (
 	"path"
 	"strings"
 
+	"github.com/thetatoken/theta/cmd/theta/cmd/db"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/util"
 
@@ -56,6 +57,8 @@ func init() {
 	RootCmd.PersistentFlags().String("key", "", "key path (default to config path)")
 	viper.BindPFlag(common.CfgKeyPath, RootCmd.PersistentFlags().Lookup("key"))
 
+	RootCmd.AddCommand(db.DBCmd)
+
 }
 
 // initConfig is called when cmd.Execute() is called. reads in config file and ENV variables if set.
@@ -70,6 +73,7 @@ func initConfig() {
 	cfgPath = viper.GetString(common.CfgConfigPath)
 	if cfgPath == "" {
 		cfgPath = getDefaultConfigPath()
+		viper.Set(common.CfgConfigPath, cfgPath)
 	}
 
 	viper.AddConfigPath(cfgPath)
@@ -18,6 +18,7 @@ import (
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/util"
+	"github.com/thetatoken/theta/consensus"
 	"github.com/thetatoken/theta/core"
 	"github.com/thetatoken/theta/crypto"
 	"github.com/thetatoken/theta/node"
@@ -47,9 +48,26 @@ func runStart(cmd *cobra.Command, args []string) {
 	var network *msgl.Messenger
 	var err error
 
-	privKey, err := loadOrCreateKey()
-	if err != nil {
-		log.Fatalf("Failed to load or create key: %v", err)
+	var privKey *crypto.PrivateKey
+	if devMode {
+		var devDir string
+		privKey, devDir, err = setupDevChain()
+		if devDir != "" {
+			defer os.RemoveAll(devDir)
+		}
+		if err != nil {
+			log.Fatalf("Failed to set up the development chain: %v", err)
+		}
+	} else {
+		privKey, err = loadOrCreateKey()
+		if err != nil {
+			log.Fatalf("Failed to load or create key: %v", err)
+		}
+	}
+
+	// The fork schedule determines how the blocks are decoded, load it first
+	if _, err := common.LoadForkSchedule(viper.GetString(common.CfgGenesisChainID)); err != nil {
+		log.Fatalf("Failed to load the fork schedule: %v", err)
 	}
 
 	// Open database
@@ -58,19 +76,22 @@ func runStart(cmd *cobra.Command, args []string) {
 		dbPath = cfgPath
 	}
 
-	mainDBPath := path.Join(dbPath, "db", "main")
-	refDBPath := path.Join(dbPath, "db", "ref")
-	db, err := backend.NewLDBDatabase(mainDBPath, refDBPath,
+	db, err := backend.NewNodeDatabase(dbPath, viper.GetString(common.CfgStorageBackend),
 		viper.GetInt(common.CfgStorageLevelDBCacheSize),
 		viper.GetInt(common.CfgStorageLevelDBHandles))
-
-	rdb := rollingdb.NewRollingDB(dbPath, db)
-
 	if err != nil {
+		mainDBPath, refDBPath := backend.DatabasePaths(dbPath, viper.GetString(common.CfgStorageBackend))
 		log.Fatalf("Failed to connect to the db. main: %v, ref: %v, err: %v",
 			mainDBPath, refDBPath, err)
 	}
 
+	if devMode {
+		// Close the database before the development chain directory is removed
+		defer db.Close()
+	}
+
+	rdb := rollingdb.NewRollingDB(dbPath, db)
+
 	// load snapshot
 	if len(snapshotPath) == 0 {
 		snapshotPath = path.Join(cfgPath, "snapshot")
@@ -149,15 +170,28 @@ func runStart(cmd *cobra.Command, args []string) {
 	}
 
 	n := node.NewNode(params)
+	if devMode && devBlockTime == 0 {
+		n.Consensus.EnableBlocksOnDemand(n.Mempool.Size)
+	}
+	if viper.GetBool(common.CfgConsensusWALEnabled) {
+		wal, err := consensus.OpenWAL(consensus.WALPath(dbPath))
+		if err != nil {
+			log.Fatalf("Failed to open the consensus WAL: %v", err)
+		}
+		defer wal.Close()
+		n.Consensus.SetWAL(wal)
+	}
 
-	c := make(chan os.Signal)
+	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	done := make(chan struct{})
 	go func() {
 		<-c
 		signal.Stop(c)
 		cancel()
-		network.Stop()
+		if network != nil {
+			network.Stop()
+		}
 		// Wait at most 5 seconds before forcefully shutting down.
 		<-time.After(time.Duration(5) * time.Second)
 		close(done)
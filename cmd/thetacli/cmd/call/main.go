@@ -14,6 +14,9 @@ var (
 	gasPriceFlag string
 	gasLimitFlag uint64
 	dataFlag     string
+	abiFlag      string
+	methodFlag   string
+	argFlags     []string
 	verboseFlag  bool
 )
 
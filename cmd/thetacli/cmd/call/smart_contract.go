@@ -11,8 +11,10 @@ import (
 	rpcc "github.com/ybbus/jsonrpc"
 
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils/contract"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/ledger/vm/abi"
 	"github.com/thetatoken/theta/rpc"
 )
 
@@ -24,6 +26,10 @@ import (
 //		thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --value=1680 --gas_price=3 --gas_limit=50000 --data=600a600c600039600a6000f3600360135360016013f3
 //   * Call an API of a smart contract (local only)
 //		thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000
+//   * Call an API of a smart contract with the ABI, which decodes the return value and the events (local only)
+//		thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --abi=Token.json --method=balanceOf --arg=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab
+//   * Deploy a smart contract from the compiled artifact with the constructor arguments (local only)
+//		thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --gas_price=3 --gas_limit=500000 --abi=Token.json --arg="Token" --arg=1000000
 
 var smartContractCmd = &cobra.Command{
 	Use:   "smart_contract",
@@ -34,6 +40,12 @@ var smartContractCmd = &cobra.Command{
 	
 	[Call an API of a smart contract (local only)]
 	thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000
+
+	[Call an API of a smart contract with the ABI, which decodes the return value and the events (local only)]
+	thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --abi=Token.json --method=balanceOf --arg=0x2E833968E5bB786Ae419c4d13189fB081Cc43bab
+
+	[Deploy a smart contract from the compiled artifact with the constructor arguments (local only)]
+	thetacli call smart_contract --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --gas_price=3 --gas_limit=500000 --abi=Token.json --arg="Token" --arg=1000000
 	`,
 	Long: `smartContractCmd represents the smart_contract command, which can be used to calls the specified smart contract.
 		However, calling a smart contract does NOT modify the globally consensus state. It can be used for dry run, or for retrieving info from smart contracts without actually spending gas.`,
@@ -59,9 +71,9 @@ func doSmartContractCmd(cmd *cobra.Command, args []string) {
 		utils.Error("Failed to parse gas price")
 	}
 
-	data, err := hex.DecodeString(dataFlag)
+	data, artifact, method, err := contract.ParseSmartContractData(dataFlag, abiFlag, toFlag, methodFlag, argFlags)
 	if err != nil {
-		utils.Error("Failed to decode data: %v, err: %v\n", dataFlag, err)
+		utils.Error("Failed to encode data: %v\n", err)
 	}
 
 	sctx := &types.SmartContractTx{
@@ -93,6 +105,10 @@ func doSmartContractCmd(cmd *cobra.Command, args []string) {
 	if res.Error != nil {
 		utils.Error("Failed to execute smart contract: %v\n", res.Error)
 	}
+	if artifact != nil {
+		printDecodedCallResult(res, artifact, method)
+		return
+	}
 	json, err := json.MarshalIndent(res.Result, "", "    ")
 	if err != nil {
 		utils.Error("Failed to parse server response: %v\n%s\n", err, string(json))
@@ -100,6 +116,40 @@ func doSmartContractCmd(cmd *cobra.Command, args []string) {
 	fmt.Println(string(json))
 }
 
+// decodedCallResult is the call result with the return value and the events decoded with the local ABI.
+type decodedCallResult struct {
+	*rpc.CallSmartContractResult
+	DecodedLogs []*abi.DecodedLog `json:"decoded_logs"`
+}
+
+func printDecodedCallResult(res *rpcc.RPCResponse, artifact *contract.ContractArtifact, method *abi.Method) {
+	result := &rpc.CallSmartContractResult{}
+	err := res.GetObject(result)
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n", err)
+	}
+
+	if method != nil && result.VmError == "" {
+		vmRet, err := hex.DecodeString(result.VmReturn)
+		if err != nil {
+			utils.Error("Failed to parse return value: %v\n", err)
+		}
+		result.DecodedReturn, err = method.Outputs.UnpackValues(vmRet)
+		if err != nil {
+			utils.Error("Failed to decode return value of %v: %v\n", method.Sig(), err)
+		}
+	}
+
+	formatted, err := json.MarshalIndent(&decodedCallResult{
+		CallSmartContractResult: result,
+		DecodedLogs:             artifact.DecodeLogs(result.Logs),
+	}, "", "    ")
+	if err != nil {
+		utils.Error("Failed to format result: %v\n", err)
+	}
+	fmt.Println(string(formatted))
+}
+
 func init() {
 	smartContractCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
 	smartContractCmd.Flags().StringVar(&fromFlag, "from", "", "The caller address")
@@ -108,6 +158,9 @@ func init() {
 	smartContractCmd.Flags().StringVar(&gasPriceFlag, "gas_price", fmt.Sprintf("%dwei", types.MinimumGasPriceJune2021), "The gas price")
 	smartContractCmd.Flags().Uint64Var(&gasLimitFlag, "gas_limit", 0, "The gas limit")
 	smartContractCmd.Flags().StringVar(&dataFlag, "data", "", "The data for the smart contract")
+	smartContractCmd.Flags().StringVar(&abiFlag, "abi", "", "The ABI JSON file, or the compiled contract artifact with the ABI and the bytecode")
+	smartContractCmd.Flags().StringVar(&methodFlag, "method", "", "The method to call, the name or the signature if overloaded, e.g. transfer(address,uint256)")
+	smartContractCmd.Flags().StringArrayVar(&argFlags, "arg", []string{}, "The method or the constructor argument, repeated in order. Arrays and tuples are JSON arrays")
 	smartContractCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
 	smartContractCmd.Flags().BoolVar(&verboseFlag, "verbose", false, "")
 
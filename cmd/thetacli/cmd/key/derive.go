@@ -0,0 +1,57 @@
+package key
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/wallet"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
+	wtypes "github.com/thetatoken/theta/wallet/types"
+)
+
+// deriveCmd derives a key from the mnemonic seed of the key corresponding to the given address
+var deriveCmd = &cobra.Command{
+	Use:   "derive",
+	Short: "Derive a key from the mnemonic of another key",
+	Long: `Derive the key at the derivation path from the BIP-39 mnemonic of a key created with
+"thetacli key new --mnemonic" or "thetacli key recover", whose seed is stored with it. The derived
+key is stored in the keystore, encrypted with the password of that key.`,
+	Example: `thetacli key derive 1d8E1191E0a97C1aDa4940B79188D3B1f6f5C695 --path="m/44'/60'/0'/0/1"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			utils.Error("Usage: thetacli key derive <address> --path=<derivation path>\n")
+		}
+		address := common.HexToAddress(args[0])
+
+		path, err := wtypes.ParseDerivationPath(pathFlag)
+		if err != nil {
+			utils.Error("Failed to parse the derivation path: %v\n", err)
+		}
+
+		cfgPath := cmd.Flag("config").Value.String()
+		wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
+		if err != nil {
+			utils.Error("Failed to open wallet: %v\n", err)
+		}
+
+		password, err := utils.GetPassword("Please enter password: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+
+		derivedAddress, err := wallet.(*sw.SoftWallet).DeriveKey(address, password, path)
+		if err != nil {
+			utils.Error("Failed to derive key: %v\n", err)
+		}
+
+		fmt.Printf("Successfully derived key: %v\n", derivedAddress.Hex())
+		fmt.Printf("Derivation path: %v\n", path)
+	},
+}
+
+func init() {
+	deriveCmd.Flags().StringVar(&pathFlag, "path", "", "Derivation path of the key")
+	deriveCmd.MarkFlagRequired("path")
+}
@@ -0,0 +1,82 @@
+package key
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/wallet"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
+	ks "github.com/thetatoken/theta/wallet/softwallet/keystore"
+	wtypes "github.com/thetatoken/theta/wallet/types"
+)
+
+// exportCmd exports the key corresponding to the given address as an Ethereum V3 key file
+var exportCmd = &cobra.Command{
+	Use:   "export <address>",
+	Short: "Export a key as an Ethereum V3 key file",
+	Long: `Export a key as an Ethereum V3 key file, which can be imported into MetaMask or geth. The key
+file is encrypted with the export password, using the scrypt or pbkdf2 key derivation function.
+By default, the key file is written to the current directory, named as by geth.`,
+	Example: "thetacli key export 26d813157F7503a9057FB2DB6Eb2f83a35c4FdD7 --kdf=pbkdf2",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			utils.Error("Usage: thetacli key export <address>\n")
+		}
+		address := common.HexToAddress(args[0])
+		if kdfFlag != ks.KDFScrypt && kdfFlag != ks.KDFPBKDF2 {
+			utils.Error("Unsupported key derivation function %v, should be %v or %v\n", kdfFlag, ks.KDFScrypt, ks.KDFPBKDF2)
+		}
+
+		output := outputFlag
+		if output == "" {
+			output = ks.KeyFileNameV3(address, time.Now())
+		}
+		if _, err := os.Stat(output); err == nil {
+			utils.Error("%v already exists\n", output)
+		}
+
+		cfgPath := cmd.Flag("config").Value.String()
+		wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
+		if err != nil {
+			utils.Error("Failed to open wallet: %v\n", err)
+		}
+
+		password, err := utils.GetPassword("Please enter password: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+
+		auth, err := utils.GetPassword("Please enter the password of the key file: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+		auth2, err := utils.GetPassword("Please enter the password of the key file again: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+		if auth != auth2 {
+			utils.Error("Passwords do not match, abort\n")
+		}
+
+		keyjson, err := wallet.(*sw.SoftWallet).ExportKey(address, password, auth, kdfFlag)
+		if err != nil {
+			utils.Error("Failed to export key for address %v: %v\n", address.Hex(), err)
+		}
+
+		if err := ioutil.WriteFile(output, keyjson, 0600); err != nil {
+			utils.Error("Failed to write the key file: %v\n", err)
+		}
+
+		fmt.Printf("Successfully exported key to %v\n", output)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVar(&kdfFlag, "kdf", ks.KDFScrypt, "Key derivation function of the key file, scrypt or pbkdf2")
+	exportCmd.Flags().StringVar(&outputFlag, "output", "", "Path of the key file")
+}
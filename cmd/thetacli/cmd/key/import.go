@@ -0,0 +1,53 @@
+package key
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/wallet"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
+	wtypes "github.com/thetatoken/theta/wallet/types"
+)
+
+// importCmd imports a key from an Ethereum V3 key file
+var importCmd = &cobra.Command{
+	Use:   "import <keyfile>",
+	Short: "Import a key from an Ethereum V3 key file",
+	Long: `Import a key from an Ethereum V3 key file, e.g. exported from MetaMask or geth, encrypted with
+either scrypt or pbkdf2. The key is stored in the keystore, encrypted with the password.`,
+	Example: "thetacli key import UTC--2016-03-22T12-57-55.920751759Z--7ef5a6135f1fd6a02593eedc869c6d41d934aef8",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) < 1 {
+			utils.Error("Usage: thetacli key import <keyfile>\n")
+		}
+		keyjson, err := ioutil.ReadFile(args[0])
+		if err != nil {
+			utils.Error("Failed to read the key file: %v\n", err)
+		}
+
+		cfgPath := cmd.Flag("config").Value.String()
+		wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
+		if err != nil {
+			utils.Error("Failed to open wallet: %v\n", err)
+		}
+
+		auth, err := utils.GetPassword("Please enter the password of the key file: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+
+		password, err := utils.GetPassword("Please enter password: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+
+		address, err := wallet.(*sw.SoftWallet).ImportKey(keyjson, auth, password)
+		if err != nil {
+			utils.Error("Failed to import key: %v\n", err)
+		}
+
+		fmt.Printf("Successfully imported key: %v\n", address.Hex())
+	},
+}
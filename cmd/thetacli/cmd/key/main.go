@@ -4,6 +4,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	mnemonicFlag   bool
+	wordsFlag      int
+	pathFlag       string
+	passphraseFlag bool
+	kdfFlag        string
+	outputFlag     string
+	fromFlag       string
+	addressFlag    string
+	messageFlag    string
+	hexFlag        bool
+	typedDataFlag  string
+	signatureFlag  string
+	walletFlag     string
+	passwordFlag   string
+)
+
 // KeyCmd represents the key command
 var KeyCmd = &cobra.Command{
 	Use:   "key",
@@ -13,6 +30,12 @@ var KeyCmd = &cobra.Command{
 
 func init() {
 	KeyCmd.AddCommand(newCmd)
+	KeyCmd.AddCommand(recoverCmd)
+	KeyCmd.AddCommand(deriveCmd)
+	KeyCmd.AddCommand(importCmd)
+	KeyCmd.AddCommand(exportCmd)
+	KeyCmd.AddCommand(signMessageCmd)
+	KeyCmd.AddCommand(verifyMessageCmd)
 	KeyCmd.AddCommand(listCmd)
 	KeyCmd.AddCommand(deleteCmd)
 	KeyCmd.AddCommand(passwordCmd)
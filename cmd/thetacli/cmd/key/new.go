@@ -6,15 +6,19 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
 	"github.com/thetatoken/theta/wallet"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
 	wtypes "github.com/thetatoken/theta/wallet/types"
 )
 
 // newCmd generates a new key
 var newCmd = &cobra.Command{
-	Use:     "new",
-	Short:   "Generates a new private key",
-	Long:    `Generates a new private key.`,
-	Example: "thetacli key new",
+	Use:   "new",
+	Short: "Generates a new private key",
+	Long: `Generates a new private key. With --mnemonic, the key is derived from a new BIP-39 mnemonic
+along the derivation path, and can be recovered from the mnemonic with "thetacli key recover". The
+seed of the mnemonic is stored with the key, to derive further keys with "thetacli key derive".`,
+	Example: `thetacli key new
+thetacli key new --mnemonic --words=24`,
 	Run: func(cmd *cobra.Command, args []string) {
 		cfgPath := cmd.Flag("config").Value.String()
 		wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
@@ -28,11 +32,38 @@ var newCmd = &cobra.Command{
 			utils.Error("Failed to get password: %v\n", err)
 		}
 
-		address, err := wallet.NewKey(password)
+		if !mnemonicFlag {
+			address, err := wallet.NewKey(password)
+			if err != nil {
+				utils.Error("Failed to generate new key: %v\n", err)
+			}
+
+			fmt.Printf("Successfully created key: %v\n", address.Hex())
+			return
+		}
+
+		if wordsFlag%3 != 0 || wordsFlag < 12 || wordsFlag > 24 {
+			utils.Error("The number of words should be 12, 15, 18, 21 or 24\n")
+		}
+		path, err := wtypes.ParseDerivationPath(pathFlag)
+		if err != nil {
+			utils.Error("Failed to parse the derivation path: %v\n", err)
+		}
+
+		address, mnemonic, err := wallet.(*sw.SoftWallet).NewKeyWithMnemonic(password, wordsFlag*32/3, path)
 		if err != nil {
 			utils.Error("Failed to generate new key: %v\n", err)
 		}
 
 		fmt.Printf("Successfully created key: %v\n", address.Hex())
+		fmt.Printf("Derivation path: %v\n\n", path)
+		fmt.Printf("Mnemonic:\n\n%v\n\n", mnemonic)
+		fmt.Printf("Write down the mnemonic and keep it safe, it is the only way to recover the key if the keystore is lost.\n")
 	},
 }
+
+func init() {
+	newCmd.Flags().BoolVar(&mnemonicFlag, "mnemonic", false, "Derive the key from a new BIP-39 mnemonic")
+	newCmd.Flags().IntVar(&wordsFlag, "words", 12, "Number of words of the mnemonic")
+	newCmd.Flags().StringVar(&pathFlag, "path", wtypes.DefaultBaseDerivationPath.String(), "Derivation path of the key")
+}
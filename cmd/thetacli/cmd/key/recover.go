@@ -4,14 +4,66 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/wallet"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
+	"github.com/thetatoken/theta/wallet/softwallet/hd"
+	wtypes "github.com/thetatoken/theta/wallet/types"
 )
 
 // recoverCmd recovers the key from the given seed phrase
 var recoverCmd = &cobra.Command{
 	Use:   "recover",
 	Short: "Recover a key from seed phrase",
-	Long:  `Recover a key from seed phrase.`,
+	Long: `Recover a key from a BIP-39 seed phrase (mnemonic). The key is derived along the derivation
+path, m/44'/60'/0'/0/0 by default as with the Trezor wallets, or m/44'/60'/0'/0 for the keys of the
+Ledger wallets. The recovered key is stored in the keystore with the seed of the mnemonic, encrypted with
+the password.`,
+	Example: `thetacli key recover
+thetacli key recover --path="m/44'/60'/0'/0/1" --passphrase`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("key recover not supported yet")
+		path, err := wtypes.ParseDerivationPath(pathFlag)
+		if err != nil {
+			utils.Error("Failed to parse the derivation path: %v\n", err)
+		}
+
+		cfgPath := cmd.Flag("config").Value.String()
+		wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
+		if err != nil {
+			utils.Error("Failed to open wallet: %v\n", err)
+		}
+
+		mnemonic, err := utils.GetPassword("Please enter the mnemonic: ")
+		if err != nil {
+			utils.Error("Failed to get the mnemonic: %v\n", err)
+		}
+		if err := hd.ValidateMnemonic(mnemonic); err != nil {
+			utils.Error("%v\n", err)
+		}
+
+		passphrase := ""
+		if passphraseFlag {
+			passphrase, err = utils.GetPassword("Please enter the mnemonic passphrase: ")
+			if err != nil {
+				utils.Error("Failed to get the passphrase: %v\n", err)
+			}
+		}
+
+		password, err := utils.GetPassword("Please enter password: ")
+		if err != nil {
+			utils.Error("Failed to get password: %v\n", err)
+		}
+
+		address, err := wallet.(*sw.SoftWallet).RecoverKey(mnemonic, passphrase, path, password)
+		if err != nil {
+			utils.Error("Failed to recover key: %v\n", err)
+		}
+
+		fmt.Printf("Successfully recovered key: %v\n", address.Hex())
 	},
 }
+
+func init() {
+	recoverCmd.Flags().StringVar(&pathFlag, "path", wtypes.DefaultBaseDerivationPath.String(), "Derivation path of the key")
+	recoverCmd.Flags().BoolVar(&passphraseFlag, "passphrase", false, "Prompt for the BIP-39 passphrase of the mnemonic")
+}
@@ -0,0 +1,140 @@
+package key
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/crypto"
+	wtypes "github.com/thetatoken/theta/wallet/types"
+)
+
+// signMessageCmd signs a message or EIP-712 typed data with the key of the given address
+var signMessageCmd = &cobra.Command{
+	Use:   "sign-message",
+	Short: "Sign a message or EIP-712 typed data",
+	Long: `Sign a message as personal_sign (EIP-191), or EIP-712 typed data in the JSON format of
+eth_signTypedData_v4, with a key of the software wallet, or of a Ledger or Trezor wallet. The
+signature is printed in the Ethereum format r || s || v.`,
+	Example: `thetacli key sign-message --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --message="Login challenge 4f2a"
+thetacli key sign-message --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --typed_data=order.json
+thetacli key sign-message --wallet=nano --path="m/44'/60'/0'/0" --message=0x48656c6c6f --hex`,
+	Run: doSignMessageCmd,
+}
+
+// verifyMessageCmd verifies the signature of a message or EIP-712 typed data
+var verifyMessageCmd = &cobra.Command{
+	Use:   "verify-message",
+	Short: "Verify the signature of a message or EIP-712 typed data",
+	Long: `Verify that a message or EIP-712 typed data was signed by the address, with a signature
+produced by "thetacli key sign-message", personal_sign or eth_signTypedData_v4.`,
+	Example: `thetacli key verify-message --address=2E833968E5bB786Ae419c4d13189fB081Cc43bab --message="Login challenge 4f2a" --signature=0x...`,
+	Run:     doVerifyMessageCmd,
+}
+
+func doSignMessageCmd(cmd *cobra.Command, args []string) {
+	message, typedData := getMessageToSign()
+
+	wallet, address, err := utils.WalletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil || wallet == nil {
+		utils.Error("Failed to unlock the key\n")
+	}
+	defer wallet.Lock(address)
+
+	var signature *crypto.Signature
+	if typedData != nil {
+		signature, err = wallet.SignTypedData(address, typedData)
+	} else {
+		signature, err = wallet.SignMessage(address, message)
+	}
+	if err != nil {
+		utils.Error("Failed to sign the message: %v\n", err)
+	}
+
+	fmt.Printf("Signer: %v\n", address.Hex())
+	fmt.Printf("Signature: %v\n", hexutil.Encode(wtypes.EncodeEthSignature(signature)))
+}
+
+func doVerifyMessageCmd(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(addressFlag) {
+		utils.Error("Invalid address: %v\n", addressFlag)
+	}
+	address := common.HexToAddress(addressFlag)
+	message, typedData := getMessageToSign()
+
+	sigBytes, err := hexutil.Decode(signatureFlag)
+	if err != nil {
+		utils.Error("Failed to decode the signature: %v\n", err)
+	}
+	signature, err := wtypes.DecodeEthSignature(sigBytes)
+	if err != nil {
+		utils.Error("Failed to decode the signature: %v\n", err)
+	}
+
+	var signer common.Address
+	if typedData != nil {
+		signer, err = wtypes.RecoverTypedDataSigner(typedData, signature)
+	} else {
+		signer, err = wtypes.RecoverMessageSigner(message, signature)
+	}
+	if err != nil {
+		utils.Error("Failed to recover the signer: %v\n", err)
+	}
+	if signer != address {
+		utils.Error("Signature verification failed, the message was signed by %v\n", signer.Hex())
+	}
+
+	fmt.Printf("Signature verified, the message was signed by %v\n", signer.Hex())
+}
+
+// getMessageToSign returns the message given by the --message flag, or the typed data in the
+// file given by the --typed_data flag
+func getMessageToSign() (common.Bytes, *wtypes.TypedData) {
+	if typedDataFlag != "" {
+		if messageFlag != "" {
+			utils.Error("Only one of --message and --typed_data can be specified\n")
+		}
+		data, err := ioutil.ReadFile(typedDataFlag)
+		if err != nil {
+			utils.Error("Failed to read the typed data: %v\n", err)
+		}
+		typedData, err := wtypes.ParseTypedData(data)
+		if err != nil {
+			utils.Error("%v\n", err)
+		}
+		if _, err := typedData.SigningMessage(); err != nil {
+			utils.Error("Failed to encode the typed data: %v\n", err)
+		}
+		return nil, typedData
+	}
+
+	if hexFlag {
+		message, err := hexutil.Decode(messageFlag)
+		if err != nil {
+			utils.Error("Failed to decode the message: %v\n", err)
+		}
+		return message, nil
+	}
+	return common.Bytes(messageFlag), nil
+}
+
+func init() {
+	signMessageCmd.Flags().StringVar(&fromFlag, "from", "", "Address of the signer")
+	signMessageCmd.Flags().StringVar(&messageFlag, "message", "", "Message to sign")
+	signMessageCmd.Flags().BoolVar(&hexFlag, "hex", false, "Whether the message is a hex string of the bytes to sign")
+	signMessageCmd.Flags().StringVar(&typedDataFlag, "typed_data", "", "Path of the EIP-712 typed data to sign")
+	signMessageCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano|trezor)")
+	signMessageCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+	signMessageCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+	verifyMessageCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the expected signer")
+	verifyMessageCmd.Flags().StringVar(&messageFlag, "message", "", "Signed message")
+	verifyMessageCmd.Flags().BoolVar(&hexFlag, "hex", false, "Whether the message is a hex string of the signed bytes")
+	verifyMessageCmd.Flags().StringVar(&typedDataFlag, "typed_data", "", "Path of the signed EIP-712 typed data")
+	verifyMessageCmd.Flags().StringVar(&signatureFlag, "signature", "", "Signature in hex")
+	verifyMessageCmd.MarkFlagRequired("address")
+	verifyMessageCmd.MarkFlagRequired("signature")
+}
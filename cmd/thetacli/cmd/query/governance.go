@@ -0,0 +1,55 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/rpc"
+
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// governanceCmd represents the governance command.
+// Example:
+//		thetacli query governance
+//		thetacli query governance --proposal=1
+var governanceCmd = &cobra.Command{
+	Use:   "governance",
+	Short: "Get the protocol parameters set by the governance, or a governance proposal",
+	Example: `thetacli query governance
+thetacli query governance --proposal=1`,
+	Run: doGovernanceCmd,
+}
+
+func doGovernanceCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	var res *rpcc.RPCResponse
+	var err error
+	if proposalIDFlag != 0 {
+		res, err = client.Call("theta.GetGovernanceProposal", rpc.GetGovernanceProposalArgs{
+			ID: common.JSONUint64(proposalIDFlag),
+		})
+	} else {
+		res, err = client.Call("theta.GetGovernanceParams", rpc.GetGovernanceParamsArgs{})
+	}
+	if err != nil {
+		utils.Error("Failed to get governance: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get governance: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%s\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
+
+func init() {
+	governanceCmd.Flags().Uint64Var(&proposalIDFlag, "proposal", uint64(0), "ID of the proposal")
+}
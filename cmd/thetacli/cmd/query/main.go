@@ -15,6 +15,7 @@ var (
 	endFlag              uint64
 	skipEdgeNodeFlag     bool
 	includeEthTxHashFlag bool
+	proposalIDFlag       uint64
 )
 
 // QueryCmd represents the query command
@@ -35,6 +36,8 @@ func init() {
 	QueryCmd.AddCommand(eenpCmd)
 	QueryCmd.AddCommand(srdrsCmd)
 	QueryCmd.AddCommand(stakeReturnsCmd)
+	QueryCmd.AddCommand(governanceCmd)
+	QueryCmd.AddCommand(timeLocksCmd)
 	QueryCmd.AddCommand(peersCmd)
 	QueryCmd.AddCommand(versionCmd)
 }
@@ -0,0 +1,47 @@
+package query
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/rpc"
+
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// timeLocksCmd represents the timelocks command.
+// Example:
+//		thetacli query timelocks --address=2E833968E5bB786Ae419c4d13189fB081Cc43bab
+var timeLocksCmd = &cobra.Command{
+	Use:     "timelocks",
+	Short:   "Get the pending time locks of an address, as the source or the beneficiary",
+	Example: `thetacli query timelocks --address=2E833968E5bB786Ae419c4d13189fB081Cc43bab`,
+	Run:     doTimeLocksCmd,
+}
+
+func doTimeLocksCmd(cmd *cobra.Command, args []string) {
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	res, err := client.Call("theta.GetTimeLocks", rpc.GetTimeLocksArgs{
+		Address: addressFlag,
+	})
+	if err != nil {
+		utils.Error("Failed to get time locks: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Failed to get time locks: %v\n", res.Error)
+	}
+	json, err := json.MarshalIndent(res.Result, "", "    ")
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n%s\n", err, string(json))
+	}
+	fmt.Println(string(json))
+}
+
+func init() {
+	timeLocksCmd.Flags().StringVar(&addressFlag, "address", "", "Address of the source or the beneficiary")
+	timeLocksCmd.MarkFlagRequired("address")
+}
@@ -4,16 +4,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
-	"strings"
-
-	"github.com/thetatoken/theta/crypto"
-
-	"github.com/thetatoken/theta/crypto/bls"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
-	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/core"
 	"github.com/thetatoken/theta/ledger/types"
 	"github.com/thetatoken/theta/rpc"
@@ -32,7 +26,7 @@ var depositStakeCmd = &cobra.Command{
 }
 
 func doDepositStakeCmd(cmd *cobra.Command, args []string) {
-	wallet, sourceAddress, err := walletUnlockWithPath(cmd, sourceFlag, pathFlag, passwordFlag)
+	wallet, sourceAddress, err := utils.WalletUnlockWithPath(cmd, sourceFlag, pathFlag, passwordFlag)
 	if err != nil {
 		return
 	}
@@ -79,80 +73,16 @@ func doDepositStakeCmd(cmd *cobra.Command, args []string) {
 		Purpose: purposeFlag,
 	}
 
-	// Parse holder flag.
-	var holderAddress common.Address
-	if purposeFlag == core.StakeForValidator {
-		if len(holderFlag) != 40 && len(holderFlag) != 42 {
-			utils.Error("holder must be a valid address")
-		}
-		holderAddress = common.HexToAddress(holderFlag)
-	} else if purposeFlag == core.StakeForGuardian {
-		if strings.HasPrefix(holderFlag, "0x") {
-			holderFlag = holderFlag[2:]
-		}
-		if len(holderFlag) != 458 {
-			utils.Error("Holder must be a valid guardian summary")
-		}
-		guardianKeyBytes, err := hex.DecodeString(holderFlag)
-		if err != nil {
-			utils.Error("Failed to decode guardian address: %v\n", err)
-		}
-		holderAddress = common.BytesToAddress(guardianKeyBytes[:20])
-		blsPubkey, err := bls.PublicKeyFromBytes(guardianKeyBytes[20:68])
-		if err != nil {
-			utils.Error("Failed to decode bls Pubkey: %v\n", err)
-		}
-		blsPop, err := bls.SignatureFromBytes(guardianKeyBytes[68:164])
-		if err != nil {
-			utils.Error("Failed to decode bls POP: %v\n", err)
-		}
-		holderSig, err := crypto.SignatureFromBytes(guardianKeyBytes[164:])
-		if err != nil {
-			utils.Error("Failed to decode signature: %v\n", err)
-		}
-
-		depositStakeTx.BlsPubkey = blsPubkey
-		depositStakeTx.BlsPop = blsPop
-		depositStakeTx.HolderSig = holderSig
-	} else { // purposeFlag == core.StakeForEliteEdgeNode
-		if strings.HasPrefix(holderFlag, "0x") {
-			holderFlag = holderFlag[2:]
-		}
-		if len(holderFlag) != 522 {
-			utils.Error("Holder must be a valid elite edge node summary")
-		}
-		eenSummaryBytes, err := hex.DecodeString(holderFlag)
-		if err != nil {
-			utils.Error("Failed to decode elite edge node summary: %v\n", err)
-		}
-		holderAddress = common.BytesToAddress(eenSummaryBytes[:20])
-		blsPubkey, err := bls.PublicKeyFromBytes(eenSummaryBytes[20:68])
-		if err != nil {
-			utils.Error("Failed to decode bls Pubkey: %v\n", err)
-		}
-		blsPop, err := bls.SignatureFromBytes(eenSummaryBytes[68:164])
-		if err != nil {
-			utils.Error("Failed to decode bls POP: %v\n", err)
-		}
-		holderSig, err := crypto.SignatureFromBytes(eenSummaryBytes[164:229])
-		if err != nil {
-			utils.Error("Failed to decode signature: %v\n", err)
-		}
-
-		expectedSummaryHash := crypto.Keccak256Hash([]byte("0x" + holderFlag[:458])).Hex()
-		summaryHash := hex.EncodeToString(eenSummaryBytes[229:])
-		if expectedSummaryHash[2:] != summaryHash {
-			utils.Error("Failed to verify elite edge node summary: unmatched summary hash - %v vs %v\n",
-				expectedSummaryHash, summaryHash)
-		}
-
-		depositStakeTx.BlsPubkey = blsPubkey
-		depositStakeTx.BlsPop = blsPop
-		depositStakeTx.HolderSig = holderSig
+	holder, err := utils.ParseStakeHolder(holderFlag, purposeFlag)
+	if err != nil {
+		utils.Error("%v\n", err)
 	}
+	depositStakeTx.BlsPubkey = holder.BlsPubkey
+	depositStakeTx.BlsPop = holder.BlsPop
+	depositStakeTx.HolderSig = holder.HolderSig
 
 	depositStakeTx.Holder = types.TxOutput{
-		Address: holderAddress,
+		Address: holder.Address,
 	}
 
 	sig, err := wallet.Sign(sourceAddress, depositStakeTx.SignBytes(chainIDFlag))
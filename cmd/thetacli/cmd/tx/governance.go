@@ -0,0 +1,154 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// proposeCmd represents the governance proposal command
+// Example:
+//		thetacli tx propose --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --param=max_validator_count --value=31 --voting_period=14400 --seq=9
+var proposeCmd = &cobra.Command{
+	Use:   "propose",
+	Short: "Propose to change a protocol parameter",
+	Long: `Propose to change a protocol parameter. Only the validator candidates and guardians can submit
+proposals. The value is an integer in the unit of the parameter, e.g. ThetaWei for the minimum
+validator stake. The votes are counted at the first checkpoint after the voting period, and the
+proposal passes if more than 2/3 of the validator and guardian stake approves it.
+
+Parameters: ` + strings.Join(core.GovernanceParams, ", "),
+	Example: `thetacli tx propose --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --param=max_validator_count --value=31 --voting_period=14400 --seq=9`,
+	Run:     doProposeCmd,
+}
+
+func doProposeCmd(cmd *cobra.Command, args []string) {
+	wallet, proposerAddress, err := utils.WalletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(proposerAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	if !core.IsGovernanceParam(paramFlag) {
+		utils.Error("Unknown parameter %v, should be one of: %v\n", paramFlag, strings.Join(core.GovernanceParams, ", "))
+	}
+	value, ok := new(big.Int).SetString(valueFlag, 10)
+	if !ok {
+		utils.Error("Failed to parse value %v\n", valueFlag)
+	}
+
+	proposalTx := &types.GovernanceProposalTx{
+		Fee: types.Coins{
+			ThetaWei: new(big.Int).SetUint64(0),
+			TFuelWei: fee,
+		},
+		Proposer: types.TxInput{
+			Address:  proposerAddress,
+			Sequence: uint64(seqFlag),
+		},
+		Param:        paramFlag,
+		Value:        value,
+		VotingPeriod: votingPeriodFlag,
+	}
+
+	sig, err := wallet.Sign(proposerAddress, proposalTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	proposalTx.SetSignature(proposerAddress, sig)
+
+	broadcastTx(proposalTx)
+}
+
+// voteCmd represents the governance vote command
+// Example:
+//		thetacli tx vote --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --proposal=1 --approve=true --seq=10
+var voteCmd = &cobra.Command{
+	Use:   "vote",
+	Short: "Vote on a protocol parameter change proposal",
+	Long: `Vote on a protocol parameter change proposal. Only the validator candidates and guardians can
+vote, and the vote is weighted by the stake they hold when the votes are counted. Voting again
+replaces the previous vote.`,
+	Example: `thetacli tx vote --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --proposal=1 --approve=true --seq=10`,
+	Run:     doVoteCmd,
+}
+
+func doVoteCmd(cmd *cobra.Command, args []string) {
+	wallet, voterAddress, err := utils.WalletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(voterAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	voteTx := &types.GovernanceVoteTx{
+		Fee: types.Coins{
+			ThetaWei: new(big.Int).SetUint64(0),
+			TFuelWei: fee,
+		},
+		Voter: types.TxInput{
+			Address:  voterAddress,
+			Sequence: uint64(seqFlag),
+		},
+		ProposalID: proposalIDFlag,
+		Approve:    approveFlag,
+	}
+
+	sig, err := wallet.Sign(voterAddress, voteTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	voteTx.SetSignature(voterAddress, sig)
+
+	broadcastTx(voteTx)
+}
+
+func init() {
+	proposeCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+	proposeCmd.Flags().StringVar(&fromFlag, "from", "", "Address of the proposer")
+	proposeCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+	proposeCmd.Flags().StringVar(&paramFlag, "param", "", "Name of the parameter")
+	proposeCmd.Flags().StringVar(&valueFlag, "value", "", "Proposed value of the parameter")
+	proposeCmd.Flags().Uint64Var(&votingPeriodFlag, "voting_period", core.MinGovernanceVotingPeriod, "Number of blocks the proposal is open for voting")
+	proposeCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeeTFuelWeiJune2021), "Fee")
+	proposeCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+	proposeCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
+	proposeCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+	proposeCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+	proposeCmd.MarkFlagRequired("chain")
+	proposeCmd.MarkFlagRequired("from")
+	proposeCmd.MarkFlagRequired("param")
+	proposeCmd.MarkFlagRequired("value")
+	proposeCmd.MarkFlagRequired("seq")
+
+	voteCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+	voteCmd.Flags().StringVar(&fromFlag, "from", "", "Address of the voter")
+	voteCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+	voteCmd.Flags().Uint64Var(&proposalIDFlag, "proposal", 0, "ID of the proposal")
+	voteCmd.Flags().BoolVar(&approveFlag, "approve", false, "Whether to approve the proposal")
+	voteCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeeTFuelWeiJune2021), "Fee")
+	voteCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+	voteCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
+	voteCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+	voteCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+	voteCmd.MarkFlagRequired("chain")
+	voteCmd.MarkFlagRequired("from")
+	voteCmd.MarkFlagRequired("proposal")
+	voteCmd.MarkFlagRequired("seq")
+}
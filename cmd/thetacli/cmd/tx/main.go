@@ -27,15 +27,27 @@ var (
 	gasPriceFlag                 string
 	gasLimitFlag                 uint64
 	dataFlag                     string
+	abiFlag                      string
+	methodFlag                   string
+	argFlags                     []string
 	walletFlag                   string
 	stakeInThetaFlag             string
 	purposeFlag                  uint8
 	sourceFlag                   string
 	holderFlag                   string
+	fromHolderFlag               string
+	toHolderFlag                 string
+	stakeAmountFlag              string
 	asyncFlag                    bool
 	beneficiaryFlag              string
 	splitBasisPointFlag          uint64
 	passwordFlag                 string
+	paramFlag                    string
+	votingPeriodFlag             uint64
+	proposalIDFlag               uint64
+	approveFlag                  bool
+	releaseHeightFlag            uint64
+	vestingFlag                  bool
 )
 
 // TxCmd represents the Tx command
@@ -53,5 +65,9 @@ func init() {
 	TxCmd.AddCommand(smartContractCmd)
 	TxCmd.AddCommand(depositStakeCmd)
 	TxCmd.AddCommand(withdrawStakeCmd)
+	TxCmd.AddCommand(redelegateStakeCmd)
 	TxCmd.AddCommand(stakeRewardDistributionCmd)
+	TxCmd.AddCommand(proposeCmd)
+	TxCmd.AddCommand(voteCmd)
+	TxCmd.AddCommand(timeLockCmd)
 }
@@ -0,0 +1,112 @@
+package tx
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/rpc"
+
+	rpcc "github.com/ybbus/jsonrpc"
+)
+
+// redelegateStakeCmd represents the redelegate stake command
+// Example:
+//		thetacli tx redelegate --chain="privatenet" --source=2E833968E5bB786Ae419c4d13189fB081Cc43bab --from_holder=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to_holder=70f587259738cB626A1720Af7038B8DcDb6a42a0 --purpose=1 --amount=1000 --seq=9
+var redelegateStakeCmd = &cobra.Command{
+	Use:   "redelegate",
+	Short: "Move stake from one validator, guardian or elite edge node to another",
+	Long: `Move the given amount of the stake of the source from one holder to another holder of the same
+purpose, without waiting for the return locking period of a withdrawal. The amount is in Theta for
+validators and guardians, and in TFuel for elite edge nodes. A guardian or an elite edge node must
+have been staked to before stake can be redelegated to it.`,
+	Example: `thetacli tx redelegate --chain="privatenet" --source=2E833968E5bB786Ae419c4d13189fB081Cc43bab --from_holder=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to_holder=70f587259738cB626A1720Af7038B8DcDb6a42a0 --purpose=1 --amount=1000 --seq=9`,
+	Run:     doRedelegateStakeCmd,
+}
+
+func doRedelegateStakeCmd(cmd *cobra.Command, args []string) {
+	wallet, sourceAddress, err := utils.WalletUnlockWithPath(cmd, sourceFlag, pathFlag, passwordFlag)
+	if err != nil {
+		return
+	}
+	defer wallet.Lock(sourceAddress)
+
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	redelegateStakeTx := &types.RedelegateStakeTx{
+		Fee: types.Coins{
+			ThetaWei: new(big.Int).SetUint64(0),
+			TFuelWei: fee,
+		},
+		Source: types.TxInput{
+			Address:  sourceAddress,
+			Sequence: uint64(seqFlag),
+		},
+		FromHolder: types.TxOutput{
+			Address: common.HexToAddress(fromHolderFlag),
+		},
+		ToHolder: types.TxOutput{
+			Address: common.HexToAddress(toHolderFlag),
+		},
+		Purpose: purposeFlag,
+		Amount:  parseStakeAmount(stakeAmountFlag, purposeFlag),
+	}
+
+	sig, err := wallet.Sign(sourceAddress, redelegateStakeTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	redelegateStakeTx.SetSignature(sourceAddress, sig)
+
+	raw, err := types.TxToBytes(redelegateStakeTx)
+	if err != nil {
+		utils.Error("Failed to encode transaction: %v\n", err)
+	}
+	signedTx := hex.EncodeToString(raw)
+
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+
+	var res *rpcc.RPCResponse
+	if asyncFlag {
+		res, err = client.Call("theta.BroadcastRawTransactionAsync", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
+	} else {
+		res, err = client.Call("theta.BroadcastRawTransaction", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
+	}
+	if err != nil {
+		utils.Error("Failed to broadcast transaction: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Server returned error: %v\n", res.Error)
+	}
+	fmt.Printf("Successfully broadcasted transaction.\n")
+}
+
+func init() {
+	redelegateStakeCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+	redelegateStakeCmd.Flags().StringVar(&sourceFlag, "source", "", "Source of the stake")
+	redelegateStakeCmd.Flags().StringVar(&fromHolderFlag, "from_holder", "", "Current holder of the stake")
+	redelegateStakeCmd.Flags().StringVar(&toHolderFlag, "to_holder", "", "New holder of the stake")
+	redelegateStakeCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+	redelegateStakeCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeeTFuelWeiJune2021), "Fee")
+	redelegateStakeCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+	redelegateStakeCmd.Flags().Uint8Var(&purposeFlag, "purpose", 0, "Purpose of staking")
+	redelegateStakeCmd.Flags().StringVar(&stakeAmountFlag, "amount", "", "Amount of stake to redelegate")
+	redelegateStakeCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
+	redelegateStakeCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+	redelegateStakeCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+	redelegateStakeCmd.MarkFlagRequired("chain")
+	redelegateStakeCmd.MarkFlagRequired("source")
+	redelegateStakeCmd.MarkFlagRequired("from_holder")
+	redelegateStakeCmd.MarkFlagRequired("to_holder")
+	redelegateStakeCmd.MarkFlagRequired("amount")
+	redelegateStakeCmd.MarkFlagRequired("seq")
+}
@@ -25,7 +25,7 @@ var releaseFundCmd = &cobra.Command{
 }
 
 func doReleaseFundCmd(cmd *cobra.Command, args []string) {
-	wallet, fromAddress, err := walletUnlock(cmd, fromFlag, passwordFlag)
+	wallet, fromAddress, err := utils.WalletUnlock(cmd, fromFlag, passwordFlag)
 	if err != nil {
 		return
 	}
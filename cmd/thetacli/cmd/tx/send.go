@@ -31,7 +31,7 @@ var sendCmd = &cobra.Command{
 }
 
 func doSendCmd(cmd *cobra.Command, args []string) {
-	walletType := getWalletType(cmd)
+	walletType := utils.GetWalletType(cmd)
 	if walletType == wtypes.WalletTypeSoft && len(fromFlag) == 0 {
 		utils.Error("The from address cannot be empty") // we don't need to specify the "from address" for hardware wallets
 		return
@@ -46,7 +46,7 @@ func doSendCmd(cmd *cobra.Command, args []string) {
 		return
 	}
 
-	wallet, fromAddress, err := walletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	wallet, fromAddress, err := utils.WalletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
 	if err != nil || wallet == nil {
 		return
 	}
@@ -6,13 +6,16 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/thetatoken/theta/blockchain"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/ledger/vm/abi"
 	"github.com/thetatoken/theta/rpc"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils/contract"
 
 	rpcc "github.com/ybbus/jsonrpc"
 )
@@ -24,6 +27,10 @@ import (
 //		thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --value=1680 --gas_price=3 --gas_limit=50000 --data=600a600c600039600a6000f3600360135360016013f3 --seq=1
 //   * Call an API of a smart contract
 //		thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --seq=2
+//   * Call an API of a smart contract with the ABI, which decodes the return value and the events of the receipt
+//		thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --seq=3 --abi=Token.json --method=transfer --arg=0x70f587259738cb626a1720af7038b8dcdb6a42a0 --arg=1000
+//   * Deploy a smart contract from the compiled artifact with the constructor arguments
+//		thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --gas_price=3 --gas_limit=500000 --seq=4 --abi=Token.json --arg="Token" --arg=1000000
 
 var smartContractCmd = &cobra.Command{
 	Use:   "smart_contract",
@@ -33,13 +40,19 @@ var smartContractCmd = &cobra.Command{
 	thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --value=1680 --gas_price=3 --gas_limit=50000 --data=600a600c600039600a6000f3600360135360016013f3 --seq=1	
 	
 	[Call an API of a smart contract]
-	thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --seq=2`,
+	thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --seq=2
+
+	[Call an API of a smart contract with the ABI, which decodes the return value and the events of the receipt]
+	thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --to=0x7ad6cea2bc3162e30a3c98d84f821b3233c22647 --gas_price=3 --gas_limit=50000 --seq=3 --abi=Token.json --method=transfer --arg=0x70f587259738cb626a1720af7038b8dcdb6a42a0 --arg=1000
+
+	[Deploy a smart contract from the compiled artifact with the constructor arguments]
+	thetacli tx smart_contract --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --gas_price=3 --gas_limit=500000 --seq=4 --abi=Token.json --arg="Token" --arg=1000000`,
 	Long: "smartContractCmd represents the smart_contract command. It will submit a smart contract transaction to the blockchain, which will modify the global consensus state when it is included in the blockchain",
 	Run:  doSmartContractCmd,
 }
 
 func doSmartContractCmd(cmd *cobra.Command, args []string) {
-	wallet, fromAddress, err := walletUnlock(cmd, fromFlag, passwordFlag)
+	wallet, fromAddress, err := utils.WalletUnlock(cmd, fromFlag, passwordFlag)
 	if err != nil {
 		return
 	}
@@ -68,9 +81,9 @@ func doSmartContractCmd(cmd *cobra.Command, args []string) {
 		utils.Error("Failed to parse gas price")
 	}
 
-	data, err := hex.DecodeString(dataFlag)
+	data, artifact, method, err := contract.ParseSmartContractData(dataFlag, abiFlag, toFlag, methodFlag, argFlags)
 	if err != nil {
-		return
+		utils.Error("Failed to encode data: %v\n", err)
 	}
 
 	smartContractTx := &types.SmartContractTx{
@@ -117,6 +130,59 @@ func doSmartContractCmd(cmd *cobra.Command, args []string) {
 		utils.Error("Failed to parse server response: %v\n", err)
 	}
 	fmt.Printf("Successfully broadcasted transaction:\n%s\n", formatted)
+
+	if artifact != nil && !asyncFlag {
+		printDecodedReceipt(client, result.TxHash, artifact, method)
+	}
+}
+
+// decodedReceipt is the transaction receipt with the return value and the events decoded with the local ABI.
+type decodedReceipt struct {
+	ContractAddress common.Address    `json:"contract_address"`
+	GasUsed         common.JSONUint64 `json:"gas_used"`
+	EvmError        string            `json:"evm_error"`
+	DecodedReturn   []abi.Value       `json:"decoded_return,omitempty"`
+	DecodedLogs     []*abi.DecodedLog `json:"decoded_logs"`
+}
+
+func printDecodedReceipt(client *rpcc.RPCClient, txHash string, artifact *contract.ContractArtifact, method *abi.Method) {
+	res, err := client.Call("theta.GetTransaction", rpc.GetTransactionArgs{Hash: txHash})
+	if err != nil {
+		utils.Error("Failed to get transaction receipt: %v\n", err)
+	}
+	if res.Error != nil {
+		utils.Error("Server returned error: %v\n", res.Error)
+	}
+	txResult := &struct {
+		Receipt *blockchain.TxReceiptEntry `json:"receipt"`
+	}{}
+	err = res.GetObject(txResult)
+	if err != nil {
+		utils.Error("Failed to parse server response: %v\n", err)
+	}
+	receipt := txResult.Receipt
+	if receipt == nil {
+		fmt.Printf("Transaction receipt not available yet\n")
+		return
+	}
+
+	decoded := &decodedReceipt{
+		ContractAddress: receipt.ContractAddress,
+		GasUsed:         common.JSONUint64(receipt.GasUsed),
+		EvmError:        receipt.EvmErr,
+		DecodedLogs:     artifact.DecodeLogs(receipt.Logs),
+	}
+	if method != nil && receipt.EvmErr == "" {
+		decoded.DecodedReturn, err = method.Outputs.UnpackValues(receipt.EvmRet)
+		if err != nil {
+			utils.Error("Failed to decode return value of %v: %v\n", method.Sig(), err)
+		}
+	}
+	formatted, err := json.MarshalIndent(decoded, "", "    ")
+	if err != nil {
+		utils.Error("Failed to format receipt: %v\n", err)
+	}
+	fmt.Printf("Transaction receipt:\n%s\n", formatted)
 }
 
 func init() {
@@ -127,6 +193,9 @@ func init() {
 	smartContractCmd.Flags().StringVar(&gasPriceFlag, "gas_price", fmt.Sprintf("%dwei", types.MinimumGasPriceJune2021), "The gas price")
 	smartContractCmd.Flags().Uint64Var(&gasLimitFlag, "gas_limit", 0, "The gas limit")
 	smartContractCmd.Flags().StringVar(&dataFlag, "data", "", "The data for the smart contract")
+	smartContractCmd.Flags().StringVar(&abiFlag, "abi", "", "The ABI JSON file, or the compiled contract artifact with the ABI and the bytecode")
+	smartContractCmd.Flags().StringVar(&methodFlag, "method", "", "The method to call, the name or the signature if overloaded, e.g. transfer(address,uint256)")
+	smartContractCmd.Flags().StringArrayVar(&argFlags, "arg", []string{}, "The method or the constructor argument, repeated in order. Arrays and tuples are JSON arrays")
 	smartContractCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
 	smartContractCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
 	smartContractCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
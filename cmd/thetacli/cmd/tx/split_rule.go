@@ -27,7 +27,7 @@ var splitRuleCmd = &cobra.Command{
 }
 
 func doSplitRuleCmd(cmd *cobra.Command, args []string) {
-	wallet, fromAddress, err := walletUnlock(cmd, fromFlag, passwordFlag)
+	wallet, fromAddress, err := utils.WalletUnlock(cmd, fromFlag, passwordFlag)
 	if err != nil {
 		return
 	}
@@ -26,7 +26,7 @@ var stakeRewardDistributionCmd = &cobra.Command{
 }
 
 func doStakeRewardDistributionCmd(cmd *cobra.Command, args []string) {
-	wallet, holderAddress, err := walletUnlockWithPath(cmd, holderFlag, pathFlag, passwordFlag)
+	wallet, holderAddress, err := utils.WalletUnlockWithPath(cmd, holderFlag, pathFlag, passwordFlag)
 	if err != nil {
 		return
 	}
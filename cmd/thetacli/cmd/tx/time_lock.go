@@ -0,0 +1,96 @@
+package tx
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// timeLockCmd represents the time lock command
+// Example:
+//		thetacli tx timelock --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --beneficiary=9F1233798E905E173560071255140b4A8aBd3Ec6 --theta=100 --release_height=100000 --vesting --seq=11
+var timeLockCmd = &cobra.Command{
+	Use:   "timelock",
+	Short: "Lock tokens until a release height",
+	Long: `Lock tokens until a release height, when they are credited to the beneficiary, which is the
+sender if not specified. With --vesting, the tokens vest linearly from the block of the
+transaction to the release height, and the vested tokens are released at each checkpoint.`,
+	Example: `thetacli tx timelock --chain="privatenet" --from=2E833968E5bB786Ae419c4d13189fB081Cc43bab --beneficiary=9F1233798E905E173560071255140b4A8aBd3Ec6 --theta=100 --release_height=100000 --vesting --seq=11`,
+	Run:     doTimeLockCmd,
+}
+
+func doTimeLockCmd(cmd *cobra.Command, args []string) {
+	wallet, sourceAddress, err := utils.WalletUnlockWithPath(cmd, fromFlag, pathFlag, passwordFlag)
+	if err != nil || wallet == nil {
+		return
+	}
+	defer wallet.Lock(sourceAddress)
+
+	theta, ok := types.ParseCoinAmount(thetaAmountFlag)
+	if !ok {
+		utils.Error("Failed to parse theta amount")
+	}
+	tfuel, ok := types.ParseCoinAmount(tfuelAmountFlag)
+	if !ok {
+		utils.Error("Failed to parse tfuel amount")
+	}
+	fee, ok := types.ParseCoinAmount(feeFlag)
+	if !ok {
+		utils.Error("Failed to parse fee")
+	}
+
+	beneficiary := sourceAddress
+	if len(beneficiaryFlag) != 0 {
+		beneficiary = common.HexToAddress(beneficiaryFlag)
+	}
+
+	timeLockTx := &types.TimeLockTx{
+		Fee: types.Coins{
+			ThetaWei: new(big.Int).SetUint64(0),
+			TFuelWei: fee,
+		},
+		Source: types.TxInput{
+			Address: sourceAddress,
+			Coins: types.Coins{
+				ThetaWei: theta,
+				TFuelWei: tfuel,
+			},
+			Sequence: uint64(seqFlag),
+		},
+		Beneficiary:   beneficiary,
+		ReleaseHeight: releaseHeightFlag,
+		Vesting:       vestingFlag,
+	}
+
+	sig, err := wallet.Sign(sourceAddress, timeLockTx.SignBytes(chainIDFlag))
+	if err != nil {
+		utils.Error("Failed to sign transaction: %v\n", err)
+	}
+	timeLockTx.SetSignature(sourceAddress, sig)
+
+	broadcastTx(timeLockTx)
+}
+
+func init() {
+	timeLockCmd.Flags().StringVar(&chainIDFlag, "chain", "", "Chain ID")
+	timeLockCmd.Flags().StringVar(&fromFlag, "from", "", "Address to lock the tokens from")
+	timeLockCmd.Flags().StringVar(&pathFlag, "path", "", "Wallet derivation path")
+	timeLockCmd.Flags().StringVar(&beneficiaryFlag, "beneficiary", "", "Address receiving the released tokens (default is the from address)")
+	timeLockCmd.Flags().StringVar(&thetaAmountFlag, "theta", "0", "Theta amount to lock")
+	timeLockCmd.Flags().StringVar(&tfuelAmountFlag, "tfuel", "0", "TFuel amount to lock")
+	timeLockCmd.Flags().Uint64Var(&releaseHeightFlag, "release_height", 0, "Block height at which all the tokens are released")
+	timeLockCmd.Flags().BoolVar(&vestingFlag, "vesting", false, "Release the tokens linearly until the release height")
+	timeLockCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeeTFuelWeiJune2021), "Fee")
+	timeLockCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
+	timeLockCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano|trezor)")
+	timeLockCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
+	timeLockCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
+
+	timeLockCmd.MarkFlagRequired("chain")
+	timeLockCmd.MarkFlagRequired("release_height")
+	timeLockCmd.MarkFlagRequired("seq")
+}
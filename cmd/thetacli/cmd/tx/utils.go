@@ -1,164 +1,57 @@
 package tx
 
 import (
+	"encoding/hex"
 	"fmt"
-	"math"
-	"strconv"
-	"strings"
+	"math/big"
 
-	log "github.com/sirupsen/logrus"
-
-	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
-	"github.com/thetatoken/theta/common"
-	"github.com/thetatoken/theta/wallet"
-	"github.com/thetatoken/theta/wallet/types"
-	wtypes "github.com/thetatoken/theta/wallet/types"
-)
-
-const HARDENED_FLAG = 1 << 31
-
-func walletUnlock(cmd *cobra.Command, addressStr string, password string) (wtypes.Wallet, common.Address, error) {
-	return walletUnlockWithPath(cmd, addressStr, "", password)
-}
-
-func walletUnlockWithPath(cmd *cobra.Command, addressStr string, path string, password string) (wtypes.Wallet, common.Address, error) {
-	var wallet wtypes.Wallet
-	var address common.Address
-	var err error
-	walletType := getWalletType(cmd)
-	if walletType == wtypes.WalletTypeSoft {
-		cfgPath := cmd.Flag("config").Value.String()
-		wallet, address, err = SoftWalletUnlock(cfgPath, addressStr, password)
-	} else {
-		derivationPath, err := parseDerivationPath(path, walletType)
-		if err != nil {
-			return nil, common.Address{}, err
-		}
-		wallet, address, err = ColdWalletUnlock(walletType, derivationPath)
-	}
-	return wallet, address, err
-}
+	"github.com/thetatoken/theta/core"
+	ltypes "github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/rpc"
 
-func ColdWalletUnlock(walletType wtypes.WalletType, derivationPath types.DerivationPath) (wtypes.Wallet, common.Address, error) {
-	wallet, err := wallet.OpenWallet("", walletType, true)
-	if err != nil {
-		fmt.Printf("Failed to open wallet: %v\n", err)
-		return nil, common.Address{}, err
-	}
+	rpcc "github.com/ybbus/jsonrpc"
+)
 
-	err = wallet.Unlock(common.Address{}, "", derivationPath)
-	if err != nil {
-		fmt.Printf("Failed to unlock wallet: %v\n", err)
-		return nil, common.Address{}, err
+// parseStakeAmount parses the amount of a stake withdrawal or redelegation, which is in Theta
+// for validators and guardians, and in TFuel for elite edge nodes.
+func parseStakeAmount(amountStr string, purpose uint8) ltypes.Coins {
+	amount, ok := ltypes.ParseCoinAmount(amountStr)
+	if !ok {
+		utils.Error("Failed to parse amount")
 	}
-
-	addresses, err := wallet.List()
-	if err != nil {
-		fmt.Printf("Failed to list wallet addresses: %v\n", err)
-		return nil, common.Address{}, err
+	if amount.Cmp(core.Zero) <= 0 {
+		utils.Error("Invalid input: amount must be positive\n")
 	}
 
-	if len(addresses) == 0 {
-		errMsg := fmt.Sprintf("No address detected in the wallet\n")
-		fmt.Printf(errMsg)
-		return nil, common.Address{}, fmt.Errorf(errMsg)
+	if purpose == core.StakeForEliteEdgeNode {
+		return ltypes.Coins{ThetaWei: new(big.Int).SetUint64(0), TFuelWei: amount}
 	}
-	address := addresses[0]
-
-	log.Infof("Wallet address: %v", address)
-
-	return wallet, address, nil
+	return ltypes.Coins{ThetaWei: amount, TFuelWei: new(big.Int).SetUint64(0)}
 }
 
-func SoftWalletUnlock(cfgPath, addressStr string, password string) (wtypes.Wallet, common.Address, error) {
-	wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
-	if err != nil {
-		fmt.Printf("Failed to open wallet: %v\n", err)
-		return nil, common.Address{}, err
-	}
-
-	if password == "" || len(password) == 0 {
-		prompt := fmt.Sprintf("Please enter password: ")
-		password, err = utils.GetPassword(prompt)
-		if err != nil {
-			fmt.Printf("Failed to get password: %v\n", err)
-			return nil, common.Address{}, err
-		}
-	}
-
-	address := common.HexToAddress(addressStr)
-	err = wallet.Unlock(address, password, nil)
+// broadcastTx encodes the signed transaction and broadcasts it through the remote RPC endpoint.
+func broadcastTx(tx ltypes.Tx) {
+	raw, err := ltypes.TxToBytes(tx)
 	if err != nil {
-		fmt.Printf("Failed to unlock address %v: %v\n", address.Hex(), err)
-		return nil, common.Address{}, err
+		utils.Error("Failed to encode transaction: %v\n", err)
 	}
+	signedTx := hex.EncodeToString(raw)
 
-	return wallet, address, nil
-}
+	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
 
-func getWalletType(cmd *cobra.Command) (walletType wtypes.WalletType) {
-	walletTypeStr := cmd.Flag("wallet").Value.String()
-	if walletTypeStr == "nano" {
-		walletType = wtypes.WalletTypeColdNano
-	} else if walletTypeStr == "trezor" {
-		walletType = wtypes.WalletTypeColdTrezor
+	var res *rpcc.RPCResponse
+	if asyncFlag {
+		res, err = client.Call("theta.BroadcastRawTransactionAsync", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
 	} else {
-		walletType = wtypes.WalletTypeSoft
-	}
-	return walletType
-}
-
-func parseDerivationPath(nstr string, walletType wtypes.WalletType) (types.DerivationPath, error) {
-	if len(nstr) == 0 {
-		if walletType == wtypes.WalletTypeColdNano {
-			// nstr = "m/44'/60'/0'/0"
-			return types.DefaultRootDerivationPath, nil
-		} else if walletType == wtypes.WalletTypeColdTrezor {
-			// nstr = "m/44'/60'/0'/0/0"
-			return types.DefaultBaseDerivationPath, nil
-		} else {
-			return nil, fmt.Errorf("can't parse derivation path for soft wallet")
-		}
-	}
-
-	n := strings.Split(nstr, "/")
-
-	// m/a/b/c => a/b/c
-	if n[0] == "m" {
-		n = n[1:]
+		res, err = client.Call("theta.BroadcastRawTransaction", rpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
 	}
-
-	derivationPath := types.DerivationPath{}
-	for _, i := range n {
-		p, err := strToHarden(i)
-		if err != nil {
-			return derivationPath, err
-		}
-		derivationPath = append(derivationPath, uint32(p))
+	if err != nil {
+		utils.Error("Failed to broadcast transaction: %v\n", err)
 	}
-
-	return derivationPath, nil
-}
-
-func strToHarden(x string) (int, error) {
-	if strings.HasPrefix(x, "-") {
-		i, err := strconv.Atoi(x)
-		if err != nil {
-			return 0, err
-		}
-		return H(int(math.Abs(float64(i)))), nil
-	} else if strings.HasSuffix(x, "h") || strings.HasSuffix(x, "'") {
-		i, err := strconv.Atoi(x[:len(x)-1])
-		if err != nil {
-			return 0, err
-		}
-		return H(i), nil
+	if res.Error != nil {
+		utils.Error("Server returned error: %v\n", res.Error)
 	}
-	return strconv.Atoi(x)
-}
-
-// Shortcut function that "hardens" a number in a BIP44 path.
-func H(x int) int {
-	return x | HARDENED_FLAG
+	fmt.Printf("Successfully broadcasted transaction.\n")
 }
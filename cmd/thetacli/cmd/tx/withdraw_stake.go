@@ -18,15 +18,19 @@ import (
 // withdrawStakeCmd represents the withdraw stake command
 // Example:
 //		thetacli tx withdraw --chain="privatenet" --source=2E833968E5bB786Ae419c4d13189fB081Cc43bab --holder=2E833968E5bB786Ae419c4d13189fB081Cc43bab --purpose=0 --seq=8
+//		thetacli tx withdraw --chain="privatenet" --source=2E833968E5bB786Ae419c4d13189fB081Cc43bab --holder=2E833968E5bB786Ae419c4d13189fB081Cc43bab --purpose=0 --amount=1000000 --seq=8
 var withdrawStakeCmd = &cobra.Command{
-	Use:     "withdraw",
-	Short:   "withdraw stake to a validator or guardian",
+	Use:   "withdraw",
+	Short: "withdraw stake to a validator or guardian",
+	Long: `Withdraw the stake of the source from the holder. With --amount, only the given amount is
+withdrawn (in Theta for validators and guardians, in TFuel for elite edge nodes), and the rest
+remains staked.`,
 	Example: `thetacli tx withdraw --chain="privatenet" --source=2E833968E5bB786Ae419c4d13189fB081Cc43bab --holder=2E833968E5bB786Ae419c4d13189fB081Cc43bab --purpose=0 --seq=8`,
 	Run:     doWithdrawStakeCmd,
 }
 
 func doWithdrawStakeCmd(cmd *cobra.Command, args []string) {
-	wallet, sourceAddress, err := walletUnlockWithPath(cmd, sourceFlag, pathFlag, passwordFlag)
+	wallet, sourceAddress, err := utils.WalletUnlockWithPath(cmd, sourceFlag, pathFlag, passwordFlag)
 	if err != nil {
 		return
 	}
@@ -45,21 +49,40 @@ func doWithdrawStakeCmd(cmd *cobra.Command, args []string) {
 		Address: common.HexToAddress(holderFlag),
 	}
 
-	withdrawStakeTx := &types.WithdrawStakeTx{
-		Fee: types.Coins{
-			ThetaWei: new(big.Int).SetUint64(0),
-			TFuelWei: fee,
-		},
-		Source:  source,
-		Holder:  holder,
-		Purpose: purposeFlag,
+	var withdrawStakeTx types.Tx
+	if stakeAmountFlag == "" {
+		withdrawStakeTx = &types.WithdrawStakeTx{
+			Fee: types.Coins{
+				ThetaWei: new(big.Int).SetUint64(0),
+				TFuelWei: fee,
+			},
+			Source:  source,
+			Holder:  holder,
+			Purpose: purposeFlag,
+		}
+	} else {
+		withdrawStakeTx = &types.WithdrawStakeTxV2{
+			Fee: types.Coins{
+				ThetaWei: new(big.Int).SetUint64(0),
+				TFuelWei: fee,
+			},
+			Source:  source,
+			Holder:  holder,
+			Purpose: purposeFlag,
+			Amount:  parseStakeAmount(stakeAmountFlag, purposeFlag),
+		}
 	}
 
 	sig, err := wallet.Sign(sourceAddress, withdrawStakeTx.SignBytes(chainIDFlag))
 	if err != nil {
 		utils.Error("Failed to sign transaction: %v\n", err)
 	}
-	withdrawStakeTx.SetSignature(sourceAddress, sig)
+	switch tx := withdrawStakeTx.(type) {
+	case *types.WithdrawStakeTx:
+		tx.SetSignature(sourceAddress, sig)
+	case *types.WithdrawStakeTxV2:
+		tx.SetSignature(sourceAddress, sig)
+	}
 
 	raw, err := types.TxToBytes(withdrawStakeTx)
 	if err != nil {
@@ -92,6 +115,7 @@ func init() {
 	withdrawStakeCmd.Flags().StringVar(&feeFlag, "fee", fmt.Sprintf("%dwei", types.MinimumTransactionFeeTFuelWeiJune2021), "Fee")
 	withdrawStakeCmd.Flags().Uint64Var(&seqFlag, "seq", 0, "Sequence number of the transaction")
 	withdrawStakeCmd.Flags().Uint8Var(&purposeFlag, "purpose", 0, "Purpose of staking")
+	withdrawStakeCmd.Flags().StringVar(&stakeAmountFlag, "amount", "", "Amount of stake to withdraw, all of it if not set")
 	withdrawStakeCmd.Flags().StringVar(&walletFlag, "wallet", "soft", "Wallet type (soft|nano)")
 	withdrawStakeCmd.Flags().BoolVar(&asyncFlag, "async", false, "block until tx has been included in the blockchain")
 	withdrawStakeCmd.Flags().StringVar(&passwordFlag, "password", "", "password to unlock the wallet")
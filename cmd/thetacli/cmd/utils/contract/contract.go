@@ -0,0 +1,169 @@
+package contract
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/ledger/vm/abi"
+)
+
+// ContractArtifact is the ABI and the deployment bytecode of a compiled contract.
+type ContractArtifact struct {
+	ABI      *abi.ABI
+	Bytecode []byte
+}
+
+// contractArtifactJSON covers the artifact formats of the common Solidity toolchains:
+// Hardhat and Truffle ("bytecode": "0x..."), Foundry ("bytecode": {"object": "0x..."}),
+// solc --combined-json ("bin": "...") and the solc standard JSON output ("evm").
+type contractArtifactJSON struct {
+	ABI      json.RawMessage `json:"abi"`
+	Bytecode json.RawMessage `json:"bytecode"`
+	Bin      string          `json:"bin"`
+	EVM      struct {
+		Bytecode struct {
+			Object string `json:"object"`
+		} `json:"bytecode"`
+	} `json:"evm"`
+}
+
+// LoadContractArtifact loads a JSON ABI file, or a compiled contract artifact which contains
+// the ABI and the bytecode.
+func LoadContractArtifact(path string) (*ContractArtifact, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content = []byte(strings.TrimSpace(string(content)))
+	if strings.HasPrefix(string(content), "[") {
+		contractABI, err := abi.Parse(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI: %v", err)
+		}
+		return &ContractArtifact{ABI: contractABI}, nil
+	}
+
+	var artifact contractArtifactJSON
+	if err := json.Unmarshal(content, &artifact); err != nil {
+		return nil, fmt.Errorf("failed to parse contract artifact: %v", err)
+	}
+	abiJSON := artifact.ABI
+	var abiStr string
+	if json.Unmarshal(abiJSON, &abiStr) == nil { // older solc versions emit the ABI as a string
+		abiJSON = []byte(abiStr)
+	}
+	contractABI, err := abi.Parse(abiJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	bytecodeStr := artifact.Bin
+	if artifact.EVM.Bytecode.Object != "" {
+		bytecodeStr = artifact.EVM.Bytecode.Object
+	}
+	if len(artifact.Bytecode) > 0 {
+		var bytecode struct {
+			Object string `json:"object"`
+		}
+		if json.Unmarshal(artifact.Bytecode, &bytecodeStr) != nil {
+			if err := json.Unmarshal(artifact.Bytecode, &bytecode); err != nil {
+				return nil, fmt.Errorf("failed to parse bytecode: %v", err)
+			}
+			bytecodeStr = bytecode.Object
+		}
+	}
+	if strings.Contains(bytecodeStr, "__") {
+		return nil, fmt.Errorf("bytecode contains unlinked library references")
+	}
+	bytecode := common.FromHex(bytecodeStr)
+
+	return &ContractArtifact{ABI: contractABI, Bytecode: bytecode}, nil
+}
+
+// EncodeContractData encodes the call data of the method with the arguments, or the deployment
+// data, i.e. the bytecode followed by the constructor arguments, if the method is empty.
+func (artifact *ContractArtifact) EncodeContractData(method string, args []string) ([]byte, *abi.Method, error) {
+	if method == "" {
+		if len(artifact.Bytecode) == 0 {
+			return nil, nil, fmt.Errorf("no bytecode for deployment, a method must be specified to call the contract")
+		}
+		constructor := artifact.ABI.Constructor
+		if constructor == nil {
+			constructor = &abi.Method{}
+		}
+		values, err := constructor.Inputs.ParseArgs(args)
+		if err != nil {
+			return nil, nil, err
+		}
+		packed, err := constructor.Inputs.Pack(values...)
+		if err != nil {
+			return nil, nil, err
+		}
+		return append(common.CopyBytes(artifact.Bytecode), packed...), nil, nil
+	}
+
+	m, err := artifact.ABI.MethodByName(method)
+	if err != nil {
+		return nil, nil, err
+	}
+	values, err := m.Inputs.ParseArgs(args)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := m.Pack(values...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return data, m, nil
+}
+
+// DecodeLogs decodes the logs with the events of the ABI, the logs of the unknown events are
+// left undecoded.
+func (artifact *ContractArtifact) DecodeLogs(logs []*types.Log) []*abi.DecodedLog {
+	decodedLogs := []*abi.DecodedLog{}
+	for _, log := range logs {
+		decodedLogs = append(decodedLogs, abi.DecodeLog(artifact.ABI, log))
+	}
+	return decodedLogs
+}
+
+// ParseSmartContractData returns the data of a smart contract transaction, which is either the raw
+// hex data, or encoded with the ABI from the method and the arguments. Without a method and a
+// contract address, the data deploys the bytecode of the artifact with the constructor arguments.
+func ParseSmartContractData(dataHex, abiPath, to, method string, args []string) ([]byte, *ContractArtifact, *abi.Method, error) {
+	if abiPath == "" {
+		if method != "" || len(args) != 0 {
+			return nil, nil, nil, fmt.Errorf("--abi must be specified to encode the method and the arguments")
+		}
+		data, err := hex.DecodeString(strings.TrimPrefix(dataHex, "0x"))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decode data: %v, err: %v", dataHex, err)
+		}
+		return data, nil, nil, nil
+	}
+
+	if dataHex != "" {
+		return nil, nil, nil, fmt.Errorf("--data can not be used together with --abi")
+	}
+	if to != "" && method == "" {
+		return nil, nil, nil, fmt.Errorf("--method must be specified to call the contract")
+	}
+	if to == "" && method != "" {
+		return nil, nil, nil, fmt.Errorf("--to must be specified to call the method")
+	}
+	artifact, err := LoadContractArtifact(abiPath)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	data, m, err := artifact.EncodeContractData(method, args)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return data, artifact, m, nil
+}
@@ -0,0 +1,121 @@
+package contract
+
+import (
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// word returns the hex of a 32-byte ABI word holding the value.
+func word(value int) string {
+	return strings.Repeat("00", 31) + hex.EncodeToString([]byte{byte(value)})
+}
+
+func TestLoadContractArtifact(t *testing.T) {
+	tests := []struct {
+		file     string
+		bytecode string
+		err      string
+	}{
+		{file: "abi.json"},
+		{file: "hardhat.json", bytecode: "6080604052"},
+		{file: "foundry.json", bytecode: "6080604052"},
+		{file: "solc_combined.json", bytecode: "6080604052"},
+		{file: "solc_standard.json", bytecode: "6080604052"},
+		{file: "abi_string.json", bytecode: "6080604052"},
+		{file: "unlinked.json", err: "unlinked library references"},
+		{file: "missing.json", err: "no such file"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			assert := assert.New(t)
+
+			artifact, err := LoadContractArtifact(path.Join("testdata", test.file))
+			if test.err != "" {
+				assert.NotNil(err)
+				assert.Contains(err.Error(), test.err)
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(test.bytecode, hex.EncodeToString(artifact.Bytecode))
+			assert.NotNil(artifact.ABI.Constructor)
+			_, err = artifact.ABI.MethodByName("set")
+			assert.Nil(err)
+		})
+	}
+}
+
+func TestLoadContractArtifactInvalid(t *testing.T) {
+	tmpdir, err := ioutil.TempDir("", "contract-test")
+	require.Nil(t, err)
+	defer os.RemoveAll(tmpdir)
+
+	for name, content := range map[string]string{
+		"abi":      `[{"type":"function","name":"set","inputs":[{"name":"value","type":"uint1000"}]}]`,
+		"artifact": `{"abi": [], "bytecode": 1234`,
+		"bytecode": `{"abi": [], "bytecode": 1234}`,
+	} {
+		file := path.Join(tmpdir, name+".json")
+		require.Nil(t, ioutil.WriteFile(file, []byte(content), 0600))
+		_, err := LoadContractArtifact(file)
+		assert.NotNil(t, err, name)
+	}
+}
+
+func TestParseSmartContractData(t *testing.T) {
+	artifactPath := path.Join("testdata", "hardhat.json")
+	abiPath := path.Join("testdata", "abi.json")
+	to := "0x2e833968e5bb786ae419c4d13189fb081cc43bab"
+
+	tests := []struct {
+		name    string
+		dataHex string
+		abiPath string
+		to      string
+		method  string
+		args    []string
+		data    string
+		err     string
+	}{
+		{name: "raw data", dataHex: "0x60fe47b1", data: "60fe47b1"},
+		{name: "raw data without prefix", dataHex: "60fe47b1", to: to, data: "60fe47b1"},
+		{name: "invalid raw data", dataHex: "0xzz", err: "failed to decode data"},
+		{name: "method without abi", to: to, method: "set", args: []string{"5"}, err: "--abi must be specified"},
+		{name: "args without abi", args: []string{"5"}, err: "--abi must be specified"},
+		{name: "data with abi", dataHex: "0x60fe47b1", abiPath: abiPath, to: to, method: "set", err: "--data can not be used together with --abi"},
+		{name: "to without method", abiPath: abiPath, to: to, err: "--method must be specified"},
+		{name: "method without to", abiPath: abiPath, method: "set", args: []string{"5"}, err: "--to must be specified"},
+		{name: "call", abiPath: abiPath, to: to, method: "set", args: []string{"5"}, data: "60fe47b1" + word(5)},
+		{name: "call with signature", abiPath: artifactPath, to: to, method: "set(uint256)", args: []string{"5"}, data: "60fe47b1" + word(5)},
+		{name: "call with missing args", abiPath: abiPath, to: to, method: "set", err: "argument"},
+		{name: "unknown method", abiPath: abiPath, to: to, method: "get", err: "not found"},
+		{name: "deploy", abiPath: artifactPath, args: []string{"7"}, data: "6080604052" + word(7)},
+		{name: "deploy without bytecode", abiPath: abiPath, args: []string{"7"}, err: "no bytecode for deployment"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			data, artifact, method, err := ParseSmartContractData(test.dataHex, test.abiPath, test.to, test.method, test.args)
+			if test.err != "" {
+				assert.NotNil(err)
+				if err != nil {
+					assert.Contains(err.Error(), test.err)
+				}
+				return
+			}
+			require.Nil(t, err)
+			assert.Equal(test.data, hex.EncodeToString(data))
+			assert.Equal(test.abiPath != "", artifact != nil)
+			assert.Equal(test.method != "", method != nil)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/crypto/bls"
+)
+
+// StakeHolder is the holder of a stake deposit. For guardians and elite edge nodes, it includes
+// the BLS key and the proof of possession of the node summary.
+type StakeHolder struct {
+	Address   common.Address
+	BlsPubkey *bls.PublicKey
+	BlsPop    *bls.Signature
+	HolderSig *crypto.Signature
+}
+
+// ParseStakeHolder parses the holder of a stake deposit, which is the address of a validator,
+// or the summary of a guardian or an elite edge node.
+func ParseStakeHolder(holder string, purpose uint8) (*StakeHolder, error) {
+	if purpose == core.StakeForValidator {
+		if len(holder) != 40 && len(holder) != 42 {
+			return nil, fmt.Errorf("Holder must be a valid address")
+		}
+		return &StakeHolder{Address: common.HexToAddress(holder)}, nil
+	}
+
+	holder = strings.TrimPrefix(holder, "0x")
+	var summaryBytes []byte
+	var err error
+	if purpose == core.StakeForGuardian {
+		if len(holder) != 458 {
+			return nil, fmt.Errorf("Holder must be a valid guardian summary")
+		}
+		summaryBytes, err = hex.DecodeString(holder)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode guardian address: %v", err)
+		}
+	} else if purpose == core.StakeForEliteEdgeNode {
+		if len(holder) != 522 {
+			return nil, fmt.Errorf("Holder must be a valid elite edge node summary")
+		}
+		summaryBytes, err = hex.DecodeString(holder)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to decode elite edge node summary: %v", err)
+		}
+
+		expectedSummaryHash := crypto.Keccak256Hash([]byte("0x" + holder[:458])).Hex()
+		summaryHash := hex.EncodeToString(summaryBytes[229:])
+		if expectedSummaryHash[2:] != summaryHash {
+			return nil, fmt.Errorf("Failed to verify elite edge node summary: unmatched summary hash - %v vs %v",
+				expectedSummaryHash, summaryHash)
+		}
+	} else {
+		return nil, fmt.Errorf("Invalid staking purpose: %v", purpose)
+	}
+
+	blsPubkey, err := bls.PublicKeyFromBytes(summaryBytes[20:68])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode bls Pubkey: %v", err)
+	}
+	blsPop, err := bls.SignatureFromBytes(summaryBytes[68:164])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode bls POP: %v", err)
+	}
+	holderSig, err := crypto.SignatureFromBytes(summaryBytes[164:229])
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode signature: %v", err)
+	}
+
+	return &StakeHolder{
+		Address:   common.BytesToAddress(summaryBytes[:20]),
+		BlsPubkey: blsPubkey,
+		BlsPop:    blsPop,
+		HolderSig: holderSig,
+	}, nil
+}
@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/wallet"
+	wtypes "github.com/thetatoken/theta/wallet/types"
+)
+
+// WalletUnlock unlocks the key of the address in the wallet of the type given by the --wallet flag.
+func WalletUnlock(cmd *cobra.Command, addressStr string, password string) (wtypes.Wallet, common.Address, error) {
+	return WalletUnlockWithPath(cmd, addressStr, "", password)
+}
+
+// WalletUnlockWithPath unlocks the key of the address in the wallet of the type given by the
+// --wallet flag. For the cold wallets, the key is the one at the derivation path.
+func WalletUnlockWithPath(cmd *cobra.Command, addressStr string, path string, password string) (wtypes.Wallet, common.Address, error) {
+	var wallet wtypes.Wallet
+	var address common.Address
+	var err error
+	walletType := GetWalletType(cmd)
+	if walletType == wtypes.WalletTypeSoft {
+		cfgPath := cmd.Flag("config").Value.String()
+		wallet, address, err = SoftWalletUnlock(cfgPath, addressStr, password)
+	} else {
+		derivationPath, err := ParseDerivationPath(path, walletType)
+		if err != nil {
+			return nil, common.Address{}, err
+		}
+		wallet, address, err = ColdWalletUnlock(walletType, derivationPath)
+	}
+	return wallet, address, err
+}
+
+// ColdWalletUnlock unlocks the key at the derivation path of the cold wallet.
+func ColdWalletUnlock(walletType wtypes.WalletType, derivationPath wtypes.DerivationPath) (wtypes.Wallet, common.Address, error) {
+	wallet, err := wallet.OpenWallet("", walletType, true)
+	if err != nil {
+		fmt.Printf("Failed to open wallet: %v\n", err)
+		return nil, common.Address{}, err
+	}
+
+	err = wallet.Unlock(common.Address{}, "", derivationPath)
+	if err != nil {
+		fmt.Printf("Failed to unlock wallet: %v\n", err)
+		return nil, common.Address{}, err
+	}
+
+	addresses, err := wallet.List()
+	if err != nil {
+		fmt.Printf("Failed to list wallet addresses: %v\n", err)
+		return nil, common.Address{}, err
+	}
+
+	if len(addresses) == 0 {
+		errMsg := fmt.Sprintf("No address detected in the wallet\n")
+		fmt.Printf(errMsg)
+		return nil, common.Address{}, fmt.Errorf(errMsg)
+	}
+	address := addresses[0]
+
+	log.Infof("Wallet address: %v", address)
+
+	return wallet, address, nil
+}
+
+// SoftWalletUnlock unlocks the key of the address in the software wallet.
+func SoftWalletUnlock(cfgPath, addressStr string, password string) (wtypes.Wallet, common.Address, error) {
+	wallet, err := wallet.OpenWallet(cfgPath, wtypes.WalletTypeSoft, true)
+	if err != nil {
+		fmt.Printf("Failed to open wallet: %v\n", err)
+		return nil, common.Address{}, err
+	}
+
+	if password == "" || len(password) == 0 {
+		prompt := fmt.Sprintf("Please enter password: ")
+		password, err = GetPassword(prompt)
+		if err != nil {
+			fmt.Printf("Failed to get password: %v\n", err)
+			return nil, common.Address{}, err
+		}
+	}
+
+	address := common.HexToAddress(addressStr)
+	err = wallet.Unlock(address, password, nil)
+	if err != nil {
+		fmt.Printf("Failed to unlock address %v: %v\n", address.Hex(), err)
+		return nil, common.Address{}, err
+	}
+
+	return wallet, address, nil
+}
+
+// GetWalletType returns the wallet type given by the --wallet flag.
+func GetWalletType(cmd *cobra.Command) (walletType wtypes.WalletType) {
+	walletTypeStr := cmd.Flag("wallet").Value.String()
+	if walletTypeStr == "nano" {
+		walletType = wtypes.WalletTypeColdNano
+	} else if walletTypeStr == "trezor" {
+		walletType = wtypes.WalletTypeColdTrezor
+	} else {
+		walletType = wtypes.WalletTypeSoft
+	}
+	return walletType
+}
+
+// ParseDerivationPath parses the derivation path of the key in a cold wallet, or returns the
+// default path of the wallet if not specified.
+func ParseDerivationPath(nstr string, walletType wtypes.WalletType) (wtypes.DerivationPath, error) {
+	if len(nstr) == 0 {
+		if walletType == wtypes.WalletTypeColdNano {
+			// nstr = "m/44'/60'/0'/0"
+			return wtypes.DefaultRootDerivationPath, nil
+		} else if walletType == wtypes.WalletTypeColdTrezor {
+			// nstr = "m/44'/60'/0'/0/0"
+			return wtypes.DefaultBaseDerivationPath, nil
+		} else {
+			return nil, fmt.Errorf("can't parse derivation path for soft wallet")
+		}
+	}
+
+	return wtypes.ParseDerivationPath(nstr)
+}
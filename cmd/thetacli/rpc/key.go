@@ -1,7 +1,12 @@
 package rpc
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	wtypes "github.com/thetatoken/theta/wallet/types"
 )
 
 // ------------------------------- UnlockKey -----------------------------------
@@ -107,3 +112,127 @@ func (t *ThetaCliRPCService) ListKeys(args *ListKeysArgs, result *ListKeysResult
 
 	return nil
 }
+
+// ------------------------------- SignMessage -----------------------------------
+
+type SignMessageArgs struct {
+	Address string `json:"address"`
+	Message string `json:"message"`
+	IsHex   bool   `json:"is_hex"` // whether the message is a hex string of the bytes to sign
+}
+
+type SignMessageResult struct {
+	Signature string `json:"signature"`
+}
+
+func (t *ThetaCliRPCService) SignMessage(args *SignMessageArgs, result *SignMessageResult) (err error) {
+	address := common.HexToAddress(args.Address)
+	if !t.wallet.IsUnlocked(address) {
+		return fmt.Errorf("The address %v has not been unlocked yet", address.Hex())
+	}
+
+	message, err := decodeMessage(args.Message, args.IsHex)
+	if err != nil {
+		return err
+	}
+
+	signature, err := t.wallet.SignMessage(address, message)
+	if err != nil {
+		return fmt.Errorf("Failed to sign the message: %v", err)
+	}
+
+	result.Signature = hexutil.Encode(wtypes.EncodeEthSignature(signature))
+	return nil
+}
+
+// ------------------------------- SignTypedData -----------------------------------
+
+type SignTypedDataArgs struct {
+	Address   string          `json:"address"`
+	TypedData json.RawMessage `json:"typed_data"` // in the JSON format of eth_signTypedData_v4
+}
+
+type SignTypedDataResult struct {
+	Signature string `json:"signature"`
+}
+
+func (t *ThetaCliRPCService) SignTypedData(args *SignTypedDataArgs, result *SignTypedDataResult) (err error) {
+	address := common.HexToAddress(args.Address)
+	if !t.wallet.IsUnlocked(address) {
+		return fmt.Errorf("The address %v has not been unlocked yet", address.Hex())
+	}
+
+	typedData, err := wtypes.ParseTypedData(args.TypedData)
+	if err != nil {
+		return err
+	}
+
+	signature, err := t.wallet.SignTypedData(address, typedData)
+	if err != nil {
+		return fmt.Errorf("Failed to sign the typed data: %v", err)
+	}
+
+	result.Signature = hexutil.Encode(wtypes.EncodeEthSignature(signature))
+	return nil
+}
+
+// ------------------------------- VerifyMessage -----------------------------------
+
+type VerifyMessageArgs struct {
+	Address   string          `json:"address"`
+	Message   string          `json:"message"`
+	IsHex     bool            `json:"is_hex"`
+	TypedData json.RawMessage `json:"typed_data"` // verifies the typed data instead of the message if specified
+	Signature string          `json:"signature"`
+}
+
+type VerifyMessageResult struct {
+	Valid  bool   `json:"valid"`
+	Signer string `json:"signer"`
+}
+
+func (t *ThetaCliRPCService) VerifyMessage(args *VerifyMessageArgs, result *VerifyMessageResult) (err error) {
+	sigBytes, err := hexutil.Decode(args.Signature)
+	if err != nil {
+		return fmt.Errorf("Failed to decode the signature: %v", err)
+	}
+	signature, err := wtypes.DecodeEthSignature(sigBytes)
+	if err != nil {
+		return err
+	}
+
+	var signer common.Address
+	if len(args.TypedData) != 0 {
+		var typedData *wtypes.TypedData
+		typedData, err = wtypes.ParseTypedData(args.TypedData)
+		if err != nil {
+			return err
+		}
+		signer, err = wtypes.RecoverTypedDataSigner(typedData, signature)
+	} else {
+		var message common.Bytes
+		message, err = decodeMessage(args.Message, args.IsHex)
+		if err != nil {
+			return err
+		}
+		signer, err = wtypes.RecoverMessageSigner(message, signature)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to recover the signer: %v", err)
+	}
+
+	result.Signer = signer.Hex()
+	result.Valid = signer == common.HexToAddress(args.Address)
+	return nil
+}
+
+func decodeMessage(message string, isHex bool) (common.Bytes, error) {
+	if !isHex {
+		return common.Bytes(message), nil
+	}
+	decoded, err := hexutil.Decode(message)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decode the message: %v", err)
+	}
+	return decoded, nil
+}
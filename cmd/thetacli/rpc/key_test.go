@@ -0,0 +1,132 @@
+package rpc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sw "github.com/thetatoken/theta/wallet/softwallet"
+)
+
+const testTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"}
+		],
+		"Mail": [
+			{"name": "to", "type": "address"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {"name": "Theta Mail", "version": "1", "chainId": 361},
+	"message": {"to": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB", "contents": "Hello, Bob!"}
+}`
+
+func newTestCliRPCService(t *testing.T) (*ThetaCliRPCService, string, func()) {
+	tmpdir, err := ioutil.TempDir("", "theta-cli-rpc-test")
+	require.Nil(t, err)
+	wallet, err := sw.NewSoftWallet(tmpdir, sw.KeystoreTypeEncrypted)
+	require.Nil(t, err)
+
+	service := &ThetaCliRPCService{wallet: wallet, client: &testClient{}}
+	newKeyResult := &NewKeyResult{}
+	require.Nil(t, service.NewKey(&NewKeyArgs{Password: "qwertyuiop"}, newKeyResult))
+	return service, newKeyResult.Address, func() { os.RemoveAll(tmpdir) }
+}
+
+func TestSignVerifyMessage(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+
+	// The key has to be unlocked first
+	require.Nil(service.LockKey(&LockKeyArgs{Address: address}, &LockKeyResult{}))
+	err := service.SignMessage(&SignMessageArgs{Address: address, Message: "hello"}, &SignMessageResult{})
+	assert.NotNil(err)
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	for _, args := range []*SignMessageArgs{
+		{Address: address, Message: "hello"},
+		{Address: address, Message: "0x68656c6c6f", IsHex: true},
+	} {
+		signResult := &SignMessageResult{}
+		require.Nil(service.SignMessage(args, signResult))
+
+		verifyResult := &VerifyMessageResult{}
+		require.Nil(service.VerifyMessage(&VerifyMessageArgs{
+			Address:   address,
+			Message:   args.Message,
+			IsHex:     args.IsHex,
+			Signature: signResult.Signature,
+		}, verifyResult))
+		assert.True(verifyResult.Valid)
+		assert.Equal(address, verifyResult.Signer)
+
+		// The signature does not match another message
+		verifyResult = &VerifyMessageResult{}
+		require.Nil(service.VerifyMessage(&VerifyMessageArgs{
+			Address:   address,
+			Message:   "goodbye",
+			Signature: signResult.Signature,
+		}, verifyResult))
+		assert.False(verifyResult.Valid)
+	}
+
+	// The hex message is decoded before signing, so both encodings yield the same signature
+	textResult, hexResult := &SignMessageResult{}, &SignMessageResult{}
+	require.Nil(service.SignMessage(&SignMessageArgs{Address: address, Message: "hello"}, textResult))
+	require.Nil(service.SignMessage(&SignMessageArgs{Address: address, Message: "0x68656c6c6f", IsHex: true}, hexResult))
+	assert.Equal(textResult.Signature, hexResult.Signature)
+
+	err = service.SignMessage(&SignMessageArgs{Address: address, Message: "0xzz", IsHex: true}, &SignMessageResult{})
+	assert.NotNil(err)
+	err = service.VerifyMessage(&VerifyMessageArgs{Address: address, Message: "hello", Signature: "0x1234"}, &VerifyMessageResult{})
+	assert.NotNil(err)
+}
+
+func TestSignVerifyTypedData(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	signResult := &SignTypedDataResult{}
+	require.Nil(service.SignTypedData(&SignTypedDataArgs{
+		Address:   address,
+		TypedData: json.RawMessage(testTypedData),
+	}, signResult))
+
+	verifyResult := &VerifyMessageResult{}
+	require.Nil(service.VerifyMessage(&VerifyMessageArgs{
+		Address:   address,
+		TypedData: json.RawMessage(testTypedData),
+		Signature: signResult.Signature,
+	}, verifyResult))
+	assert.True(verifyResult.Valid)
+	assert.Equal(address, verifyResult.Signer)
+
+	// The typed data signature is not a valid personal_sign signature of the same content
+	verifyResult = &VerifyMessageResult{}
+	require.Nil(service.VerifyMessage(&VerifyMessageArgs{
+		Address:   address,
+		Message:   testTypedData,
+		Signature: signResult.Signature,
+	}, verifyResult))
+	assert.False(verifyResult.Valid)
+
+	err := service.SignTypedData(&SignTypedDataArgs{
+		Address:   address,
+		TypedData: json.RawMessage(`{"types": {}, "primaryType": "Mail"}`),
+	}, &SignTypedDataResult{})
+	assert.NotNil(err)
+}
@@ -13,9 +13,12 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/util"
+	trpc "github.com/thetatoken/theta/rpc"
 	"github.com/thetatoken/theta/rpc/lib/rpc-codec/jsonrpc2"
+	"github.com/thetatoken/theta/rpc/sequence"
 	wl "github.com/thetatoken/theta/wallet"
 	wt "github.com/thetatoken/theta/wallet/types"
 	"golang.org/x/net/netutil"
@@ -25,7 +28,9 @@ import (
 var logger *log.Entry
 
 type ThetaCliRPCService struct {
-	wallet wt.Wallet
+	wallet    wt.Wallet
+	client    trpc.Client // the RPC client of the node
+	sequences *sequence.Manager
 
 	// Life cycle
 	wg      *sync.WaitGroup
@@ -53,10 +58,21 @@ func NewThetaCliRPCServer(cfgPath, port string) (*ThetaCliRPCServer, error) {
 		return nil, err
 	}
 
+	logger = util.GetLoggerForModule("rpc")
+
+	client := trpc.NewClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
+	node := sequence.NewRPCNode(client)
+	sequenceConfig := sequence.DefaultConfig()
+	sequenceConfig.EventHandler = func(event sequence.Event) {
+		logger.Infof("Transaction %v", event)
+	}
+
 	t := &ThetaCliRPCServer{
 		ThetaCliRPCService: &ThetaCliRPCService{
-			wallet: wallet,
-			wg:     &sync.WaitGroup{},
+			wallet:    wallet,
+			client:    client,
+			sequences: sequence.NewManager(node, sequenceConfig),
+			wg:        &sync.WaitGroup{},
 		},
 		port: port,
 	}
@@ -76,8 +92,6 @@ func NewThetaCliRPCServer(cfgPath, port string) (*ThetaCliRPCServer, error) {
 		Handler: t.router,
 	}
 
-	logger = util.GetLoggerForModule("rpc")
-
 	return t, nil
 }
 
@@ -87,6 +101,8 @@ func (t *ThetaCliRPCServer) Start(ctx context.Context) {
 	t.ctx = c
 	t.cancel = cancel
 
+	t.sequences.Start(t.ctx)
+
 	t.wg.Add(1)
 	go t.mainLoop()
 }
@@ -124,4 +140,5 @@ func (t *ThetaCliRPCServer) Stop() {
 // Wait blocks until all goroutines stop.
 func (t *ThetaCliRPCServer) Wait() {
 	t.wg.Wait()
+	t.sequences.Wait()
 }
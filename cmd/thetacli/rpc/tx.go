@@ -12,27 +12,46 @@ import (
 	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
 	"github.com/thetatoken/theta/ledger/types"
 	trpc "github.com/thetatoken/theta/rpc"
+	"github.com/thetatoken/theta/rpc/sequence"
 )
 
+// TxArgs are the arguments common to all the transactions. The amounts are in wei.
+type TxArgs struct {
+	ChainID  string `json:"chain_id"`
+	Fee      string `json:"fee"`       // the current minimum transaction fee of the node if not specified
+	Sequence string `json:"sequence"`  // assigned and tracked by the daemon if not specified
+	Async    bool   `json:"async"`     // return once the transaction is in the mempool
+	SignOnly bool   `json:"sign_only"` // return the signed transaction without broadcasting it
+}
+
+// TxResult is the result of all the transactions. The block is returned for the transactions
+// broadcasted synchronously.
+type TxResult struct {
+	TxHash   string            `json:"hash"`
+	TxBytes  string            `json:"tx_bytes"`
+	Sequence string            `json:"sequence"`
+	Block    *core.BlockHeader `json:"block" rlp:"nil"`
+}
+
+type signableTx interface {
+	types.Tx
+	SetSignature(addr common.Address, sig *crypto.Signature) bool
+}
+
 // ------------------------------- SendTx -----------------------------------
 
 type SendArgs struct {
-	ChainID  string `json:"chain_id"`
+	TxArgs
 	From     string `json:"from"`
 	To       string `json:"to"`
 	ThetaWei string `json:"thetawei"`
 	TFuelWei string `json:"tfuelwei"`
-	Fee      string `json:"fee"`
-	Sequence string `json:"sequence"`
-	Async    bool   `json:"async"`
 }
 
-type SendResult struct {
-	TxHash string            `json:"hash"`
-	Block  *core.BlockHeader `json:"block",rlp:"nil"`
-}
+type SendResult = TxResult
 
 func (t *ThetaCliRPCService) Send(args *SendArgs, result *SendResult) (err error) {
 	if len(args.From) == 0 || len(args.To) == 0 {
@@ -44,85 +63,751 @@ func (t *ThetaCliRPCService) Send(args *SendArgs, result *SendResult) (err error
 
 	from := common.HexToAddress(args.From)
 	to := common.HexToAddress(args.To)
-	thetawei, ok := new(big.Int).SetString(args.ThetaWei, 10)
-	if !ok {
-		return fmt.Errorf("Failed to parse thetawei: %v", args.ThetaWei)
+	thetawei, err := parseWei("thetawei", args.ThetaWei)
+	if err != nil {
+		return err
 	}
-	tfuelwei, ok := new(big.Int).SetString(args.TFuelWei, 10)
-	if !ok {
-		return fmt.Errorf("Failed to parse tfuelwei: %v", args.TFuelWei)
+	tfuelwei, err := parseWei("tfuelwei", args.TFuelWei)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		inputs := []types.TxInput{{
+			Address: from,
+			Coins: types.Coins{
+				TFuelWei: new(big.Int).Add(tfuelwei, fee),
+				ThetaWei: thetawei,
+			},
+			Sequence: sequence,
+		}}
+		outputs := []types.TxOutput{{
+			Address: to,
+			Coins: types.Coins{
+				TFuelWei: tfuelwei,
+				ThetaWei: thetawei,
+			},
+		}}
+		return &types.SendTx{
+			Fee:     tfuelFee(fee),
+			Inputs:  inputs,
+			Outputs: outputs,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- DepositStake -----------------------------------
+
+type DepositStakeArgs struct {
+	TxArgs
+	Source  string `json:"source"`
+	Holder  string `json:"holder"` // the address of a validator, or the summary of a guardian or an elite edge node
+	Purpose uint8  `json:"purpose"`
+	Amount  string `json:"amount"` // in ThetaWei for validators and guardians, and in TFuelWei for elite edge nodes
+}
+
+type DepositStakeResult = TxResult
+
+func (t *ThetaCliRPCService) DepositStake(args *DepositStakeArgs, result *DepositStakeResult) (err error) {
+	source, err := parseAddress("source", args.Source)
+	if err != nil {
+		return err
+	}
+	holder, err := utils.ParseStakeHolder(args.Holder, args.Purpose)
+	if err != nil {
+		return err
+	}
+	stake, err := parseStakeAmount(args.Amount, args.Purpose)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, source, func(sequence uint64) (signableTx, error) {
+		return &types.DepositStakeTxV2{
+			Fee: tfuelFee(fee),
+			Source: types.TxInput{
+				Address:  source,
+				Coins:    stake,
+				Sequence: sequence,
+			},
+			Holder: types.TxOutput{
+				Address: holder.Address,
+			},
+			Purpose:   args.Purpose,
+			BlsPubkey: holder.BlsPubkey,
+			BlsPop:    holder.BlsPop,
+			HolderSig: holder.HolderSig,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- WithdrawStake -----------------------------------
+
+type WithdrawStakeArgs struct {
+	TxArgs
+	Source  string `json:"source"`
+	Holder  string `json:"holder"`
+	Purpose uint8  `json:"purpose"`
+	Amount  string `json:"amount"` // withdraw the whole stake if not specified
+}
+
+type WithdrawStakeResult = TxResult
+
+func (t *ThetaCliRPCService) WithdrawStake(args *WithdrawStakeArgs, result *WithdrawStakeResult) (err error) {
+	source, err := parseAddress("source", args.Source)
+	if err != nil {
+		return err
+	}
+	holder, err := parseAddress("holder", args.Holder)
+	if err != nil {
+		return err
 	}
-	fee, ok := new(big.Int).SetString(args.Fee, 10)
+	var amount types.Coins
+	if len(args.Amount) != 0 {
+		amount, err = parseStakeAmount(args.Amount, args.Purpose)
+		if err != nil {
+			return err
+		}
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, source, func(sequence uint64) (signableTx, error) {
+		if len(args.Amount) == 0 {
+			return &types.WithdrawStakeTx{
+				Fee:     tfuelFee(fee),
+				Source:  types.TxInput{Address: source, Sequence: sequence},
+				Holder:  types.TxOutput{Address: holder},
+				Purpose: args.Purpose,
+			}, nil
+		}
+		return &types.WithdrawStakeTxV2{
+			Fee:     tfuelFee(fee),
+			Source:  types.TxInput{Address: source, Sequence: sequence},
+			Holder:  types.TxOutput{Address: holder},
+			Purpose: args.Purpose,
+			Amount:  amount,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- RedelegateStake -----------------------------------
+
+type RedelegateStakeArgs struct {
+	TxArgs
+	Source     string `json:"source"`
+	FromHolder string `json:"from_holder"`
+	ToHolder   string `json:"to_holder"`
+	Purpose    uint8  `json:"purpose"`
+	Amount     string `json:"amount"`
+}
+
+type RedelegateStakeResult = TxResult
+
+func (t *ThetaCliRPCService) RedelegateStake(args *RedelegateStakeArgs, result *RedelegateStakeResult) (err error) {
+	source, err := parseAddress("source", args.Source)
+	if err != nil {
+		return err
+	}
+	fromHolder, err := parseAddress("from_holder", args.FromHolder)
+	if err != nil {
+		return err
+	}
+	toHolder, err := parseAddress("to_holder", args.ToHolder)
+	if err != nil {
+		return err
+	}
+	amount, err := parseStakeAmount(args.Amount, args.Purpose)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, source, func(sequence uint64) (signableTx, error) {
+		return &types.RedelegateStakeTx{
+			Fee:        tfuelFee(fee),
+			Source:     types.TxInput{Address: source, Sequence: sequence},
+			FromHolder: types.TxOutput{Address: fromHolder},
+			ToHolder:   types.TxOutput{Address: toHolder},
+			Purpose:    args.Purpose,
+			Amount:     amount,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- StakeRewardDistribution -----------------------------------
+
+type StakeRewardDistributionArgs struct {
+	TxArgs
+	Holder          string `json:"holder"`
+	Beneficiary     string `json:"beneficiary"`
+	SplitBasisPoint uint   `json:"split_basis_point"`
+}
+
+type StakeRewardDistributionResult = TxResult
+
+func (t *ThetaCliRPCService) StakeRewardDistribution(args *StakeRewardDistributionArgs, result *StakeRewardDistributionResult) (err error) {
+	holder, err := parseAddress("holder", args.Holder)
+	if err != nil {
+		return err
+	}
+	beneficiary, err := parseAddress("beneficiary", args.Beneficiary)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, holder, func(sequence uint64) (signableTx, error) {
+		return &types.StakeRewardDistributionTx{
+			Fee:             tfuelFee(fee),
+			Holder:          types.TxInput{Address: holder, Sequence: sequence},
+			Beneficiary:     types.TxOutput{Address: beneficiary},
+			SplitBasisPoint: args.SplitBasisPoint,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- ReserveFund -----------------------------------
+
+type ReserveFundArgs struct {
+	TxArgs
+	From        string   `json:"from"`
+	Fund        string   `json:"fund"`       // in TFuelWei
+	Collateral  string   `json:"collateral"` // in TFuelWei
+	ResourceIDs []string `json:"resource_ids"`
+	Duration    uint64   `json:"duration"`
+}
+
+type ReserveFundResult = TxResult
+
+func (t *ThetaCliRPCService) ReserveFund(args *ReserveFundArgs, result *ReserveFundResult) (err error) {
+	from, err := parseAddress("from", args.From)
+	if err != nil {
+		return err
+	}
+	fund, err := parseWei("fund", args.Fund)
+	if err != nil {
+		return err
+	}
+	collateral, err := parseWei("collateral", args.Collateral)
+	if err != nil {
+		return err
+	}
+	if collateral.Sign() <= 0 {
+		return fmt.Errorf("The collateral must be positive")
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		return &types.ReserveFundTx{
+			Fee: tfuelFee(fee),
+			Source: types.TxInput{
+				Address:  from,
+				Coins:    types.Coins{ThetaWei: big.NewInt(0), TFuelWei: fund},
+				Sequence: sequence,
+			},
+			ResourceIDs: args.ResourceIDs,
+			Collateral:  types.Coins{ThetaWei: big.NewInt(0), TFuelWei: collateral},
+			Duration:    args.Duration,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- ReleaseFund -----------------------------------
+
+type ReleaseFundArgs struct {
+	TxArgs
+	From            string `json:"from"`
+	ReserveSequence uint64 `json:"reserve_sequence"`
+}
+
+type ReleaseFundResult = TxResult
+
+func (t *ThetaCliRPCService) ReleaseFund(args *ReleaseFundArgs, result *ReleaseFundResult) (err error) {
+	from, err := parseAddress("from", args.From)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		return &types.ReleaseFundTx{
+			Fee:             tfuelFee(fee),
+			Source:          types.TxInput{Address: from, Sequence: sequence},
+			ReserveSequence: args.ReserveSequence,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- SplitRule -----------------------------------
+
+type SplitArg struct {
+	Address    string `json:"address"`
+	Percentage uint   `json:"percentage"`
+}
+
+type SplitRuleArgs struct {
+	TxArgs
+	From       string     `json:"from"`
+	ResourceID string     `json:"resource_id"`
+	Splits     []SplitArg `json:"splits"`
+	Duration   uint64     `json:"duration"`
+}
+
+type SplitRuleResult = TxResult
+
+func (t *ThetaCliRPCService) SplitRule(args *SplitRuleArgs, result *SplitRuleResult) (err error) {
+	from, err := parseAddress("from", args.From)
+	if err != nil {
+		return err
+	}
+	splits := []types.Split{}
+	for _, split := range args.Splits {
+		address, err := parseAddress("split address", split.Address)
+		if err != nil {
+			return err
+		}
+		splits = append(splits, types.Split{Address: address, Percentage: split.Percentage})
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		return &types.SplitRuleTx{
+			Fee:        tfuelFee(fee),
+			ResourceID: args.ResourceID,
+			Initiator:  types.TxInput{Address: from, Sequence: sequence},
+			Splits:     splits,
+			Duration:   args.Duration,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- SmartContract -----------------------------------
+
+// SmartContractArgs are the arguments of a smart contract transaction, which pays the gas
+// instead of the fee.
+type SmartContractArgs struct {
+	TxArgs
+	From     string `json:"from"`
+	To       string `json:"to"`    // deploys the contract in the data if not specified
+	Value    string `json:"value"` // in TFuelWei
+	GasPrice string `json:"gas_price"`
+	GasLimit uint64 `json:"gas_limit"`
+	Data     string `json:"data"`
+}
+
+type SmartContractResult = TxResult
+
+func (t *ThetaCliRPCService) SmartContract(args *SmartContractArgs, result *SmartContractResult) (err error) {
+	from, err := parseAddress("from", args.From)
+	if err != nil {
+		return err
+	}
+	var to common.Address
+	if len(args.To) != 0 {
+		to, err = parseAddress("to", args.To)
+		if err != nil {
+			return err
+		}
+	}
+	value, err := parseWei("value", args.Value)
+	if err != nil {
+		return err
+	}
+	gasPrice := new(big.Int).SetUint64(types.MinimumGasPriceJune2021)
+	if len(args.GasPrice) != 0 {
+		gasPrice, err = parseWei("gas_price", args.GasPrice)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := hex.DecodeString(trimHexPrefix(args.Data))
+	if err != nil {
+		return fmt.Errorf("Failed to decode data: %v", err)
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		return &types.SmartContractTx{
+			From: types.TxInput{
+				Address:  from,
+				Coins:    types.Coins{ThetaWei: big.NewInt(0), TFuelWei: value},
+				Sequence: sequence,
+			},
+			To:       types.TxOutput{Address: to},
+			GasLimit: args.GasLimit,
+			GasPrice: gasPrice,
+			Data:     data,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- GovernanceProposal -----------------------------------
+
+type GovernanceProposalArgs struct {
+	TxArgs
+	Proposer     string `json:"proposer"`
+	Param        string `json:"param"`
+	Value        string `json:"value"`
+	VotingPeriod uint64 `json:"voting_period"`
+}
+
+type GovernanceProposalResult = TxResult
+
+func (t *ThetaCliRPCService) GovernanceProposal(args *GovernanceProposalArgs, result *GovernanceProposalResult) (err error) {
+	proposer, err := parseAddress("proposer", args.Proposer)
+	if err != nil {
+		return err
+	}
+	if !core.IsGovernanceParam(args.Param) {
+		return fmt.Errorf("Unknown parameter %v", args.Param)
+	}
+	value, ok := new(big.Int).SetString(args.Value, 10)
 	if !ok {
-		return fmt.Errorf("Failed to parse fee: %v", args.Fee)
+		return fmt.Errorf("Failed to parse value: %v", args.Value)
 	}
-	sequence, err := strconv.ParseUint(args.Sequence, 10, 64)
+	fee, err := t.parseFee(args.Fee)
 	if err != nil {
 		return err
 	}
 
-	if !t.wallet.IsUnlocked(from) {
-		return fmt.Errorf("The from address %v has not been unlocked yet", from.Hex())
+	return t.signAndBroadcastTx(&args.TxArgs, proposer, func(sequence uint64) (signableTx, error) {
+		return &types.GovernanceProposalTx{
+			Fee:          tfuelFee(fee),
+			Proposer:     types.TxInput{Address: proposer, Sequence: sequence},
+			Param:        args.Param,
+			Value:        value,
+			VotingPeriod: args.VotingPeriod,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- GovernanceVote -----------------------------------
+
+type GovernanceVoteArgs struct {
+	TxArgs
+	Voter      string `json:"voter"`
+	ProposalID uint64 `json:"proposal_id"`
+	Approve    bool   `json:"approve"`
+}
+
+type GovernanceVoteResult = TxResult
+
+func (t *ThetaCliRPCService) GovernanceVote(args *GovernanceVoteArgs, result *GovernanceVoteResult) (err error) {
+	voter, err := parseAddress("voter", args.Voter)
+	if err != nil {
+		return err
+	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
 	}
 
-	inputs := []types.TxInput{{
-		Address: from,
-		Coins: types.Coins{
-			TFuelWei: new(big.Int).Add(tfuelwei, fee),
-			ThetaWei: thetawei,
-		},
-		Sequence: sequence,
-	}}
-	outputs := []types.TxOutput{{
-		Address: to,
-		Coins: types.Coins{
-			TFuelWei: tfuelwei,
-			ThetaWei: thetawei,
-		},
-	}}
-	sendTx := &types.SendTx{
-		Fee: types.Coins{
-			ThetaWei: new(big.Int).SetUint64(0),
-			TFuelWei: fee,
-		},
-		Inputs:  inputs,
-		Outputs: outputs,
+	return t.signAndBroadcastTx(&args.TxArgs, voter, func(sequence uint64) (signableTx, error) {
+		return &types.GovernanceVoteTx{
+			Fee:        tfuelFee(fee),
+			Voter:      types.TxInput{Address: voter, Sequence: sequence},
+			ProposalID: args.ProposalID,
+			Approve:    args.Approve,
+		}, nil
+	}, result)
+}
+
+// ------------------------------- TimeLock -----------------------------------
+
+type TimeLockArgs struct {
+	TxArgs
+	From          string `json:"from"`
+	Beneficiary   string `json:"beneficiary"` // the sender if not specified
+	ThetaWei      string `json:"thetawei"`
+	TFuelWei      string `json:"tfuelwei"`
+	ReleaseHeight uint64 `json:"release_height"`
+	Vesting       bool   `json:"vesting"`
+}
+
+type TimeLockResult = TxResult
+
+func (t *ThetaCliRPCService) TimeLock(args *TimeLockArgs, result *TimeLockResult) (err error) {
+	from, err := parseAddress("from", args.From)
+	if err != nil {
+		return err
+	}
+	beneficiary := from
+	if len(args.Beneficiary) != 0 {
+		beneficiary, err = parseAddress("beneficiary", args.Beneficiary)
+		if err != nil {
+			return err
+		}
+	}
+	thetawei, err := parseWei("thetawei", args.ThetaWei)
+	if err != nil {
+		return err
+	}
+	tfuelwei, err := parseWei("tfuelwei", args.TFuelWei)
+	if err != nil {
+		return err
 	}
+	fee, err := t.parseFee(args.Fee)
+	if err != nil {
+		return err
+	}
+
+	return t.signAndBroadcastTx(&args.TxArgs, from, func(sequence uint64) (signableTx, error) {
+		return &types.TimeLockTx{
+			Fee: tfuelFee(fee),
+			Source: types.TxInput{
+				Address:  from,
+				Coins:    types.Coins{ThetaWei: thetawei, TFuelWei: tfuelwei},
+				Sequence: sequence,
+			},
+			Beneficiary:   beneficiary,
+			ReleaseHeight: args.ReleaseHeight,
+			Vesting:       args.Vesting,
+		}, nil
+	}, result)
+}
 
-	signBytes := sendTx.SignBytes(args.ChainID)
-	sig, err := t.wallet.Sign(from, signBytes)
+// ------------------------------- ResetSequence -----------------------------------
+
+type ResetSequenceArgs struct {
+	Address string `json:"address"`
+}
+
+type ResetSequenceResult struct {
+}
+
+// ResetSequence stops tracking the transactions of the address, e.g. after a transaction
+// returned unbroadcast was discarded. The sequence is fetched from the node again for the
+// next transaction.
+func (t *ThetaCliRPCService) ResetSequence(args *ResetSequenceArgs, result *ResetSequenceResult) (err error) {
+	address, err := parseAddress("address", args.Address)
 	if err != nil {
-		utils.Error("Failed to sign transaction: %v\n", err)
+		return err
 	}
-	sendTx.SetSignature(from, sig)
+	t.sequences.Reset(address)
+	return nil
+}
 
-	raw, err := types.TxToBytes(sendTx)
+// ------------------------------- ListPendingTxs -----------------------------------
+
+type ListPendingTxsArgs struct {
+	Address string `json:"address"`
+}
+
+type PendingTx struct {
+	TxHash    string `json:"hash"`
+	Sequence  string `json:"sequence"`
+	Resubmits int    `json:"resubmits"`
+	Filler    bool   `json:"filler"`
+}
+
+type ListPendingTxsResult struct {
+	Txs []PendingTx `json:"txs"`
+}
+
+// ListPendingTxs lists the in-flight transactions of the address sent by the daemon.
+func (t *ThetaCliRPCService) ListPendingTxs(args *ListPendingTxsArgs, result *ListPendingTxsResult) (err error) {
+	address, err := parseAddress("address", args.Address)
 	if err != nil {
-		utils.Error("Failed to encode transaction: %v\n", err)
+		return err
+	}
+	result.Txs = []PendingTx{}
+	for _, tx := range t.sequences.Pending(address) {
+		result.Txs = append(result.Txs, PendingTx{
+			TxHash:    tx.TxHash.Hex(),
+			Sequence:  strconv.FormatUint(tx.Sequence, 10),
+			Resubmits: tx.Resubmits,
+			Filler:    tx.Filler,
+		})
+	}
+	return nil
+}
+
+// ------------------------------- Utils -----------------------------------
+
+// signAndBroadcastTx signs the transaction built with the sequence, and broadcasts it unless
+// only the signed transaction is requested. Unless specified, the sequence is assigned by the
+// sequence manager, so that the transactions sent concurrently from the same address get
+// consecutive sequences, and the broadcasted transactions are tracked by it.
+func (t *ThetaCliRPCService) signAndBroadcastTx(args *TxArgs, signer common.Address,
+	buildTx func(sequence uint64) (signableTx, error), result *TxResult) (err error) {
+	if !t.wallet.IsUnlocked(signer) {
+		return fmt.Errorf("The address %v has not been unlocked yet", signer.Hex())
+	}
+
+	signTx := func(sequence uint64) (common.Bytes, error) {
+		tx, err := buildTx(sequence)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := t.wallet.Sign(signer, tx.SignBytes(args.ChainID))
+		if err != nil {
+			return nil, fmt.Errorf("Failed to sign transaction: %v", err)
+		}
+		tx.SetSignature(signer, sig)
+
+		raw, err := types.TxToBytes(tx)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode transaction: %v", err)
+		}
+		return raw, nil
+	}
+
+	var block *core.BlockHeader
+	broadcast := func(txBytes common.Bytes) (err error) {
+		block, err = broadcastRawTx(hex.EncodeToString(txBytes), args.Async)
+		return err
 	}
-	signedTx := hex.EncodeToString(raw)
 
+	var seq uint64
+	var raw common.Bytes
+	if len(args.Sequence) != 0 {
+		seq, err = strconv.ParseUint(args.Sequence, 10, 64)
+		if err != nil {
+			return fmt.Errorf("Failed to parse sequence: %v", args.Sequence)
+		}
+		raw, err = signTx(seq)
+		if err != nil {
+			return err
+		}
+		if !args.SignOnly {
+			if err = broadcast(raw); err != nil {
+				return err
+			}
+		}
+	} else {
+		var pendingTx *sequence.PendingTx
+		if args.SignOnly {
+			pendingTx, err = t.sequences.Sign(signer, signTx)
+		} else {
+			pendingTx, err = t.sequences.Send(signer, signTx, broadcast)
+		}
+		if err != nil {
+			return err
+		}
+		seq, raw = pendingTx.Sequence, pendingTx.TxBytes
+	}
+
+	result.TxHash = crypto.Keccak256Hash(raw).Hex()
+	result.TxBytes = hex.EncodeToString(raw)
+	result.Sequence = strconv.FormatUint(seq, 10)
+	result.Block = block
+
+	return nil
+}
+
+func broadcastRawTx(signedTx string, async bool) (*core.BlockHeader, error) {
 	client := rpcc.NewRPCClient(viper.GetString(utils.CfgRemoteRPCEndpoint))
 
 	rpcMethod := "theta.BroadcastRawTransaction"
-	if args.Async {
+	if async {
 		rpcMethod = "theta.BroadcastRawTransactionAsync"
 	}
 	res, err := client.Call(rpcMethod, trpc.BroadcastRawTransactionArgs{TxBytes: signedTx})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if res.Error != nil {
-		return fmt.Errorf("Server returned error: %v", res.Error)
+		return nil, fmt.Errorf("Server returned error: %v", res.Error)
 	}
 	trpcResult := &trpc.BroadcastRawTransactionResult{}
 	err = res.GetObject(trpcResult)
 	if err != nil {
-		return fmt.Errorf("Failed to parse Theta node response: %v", err)
+		return nil, fmt.Errorf("Failed to parse Theta node response: %v", err)
+	}
+	return trpcResult.Block, nil
+}
+
+func parseAddress(name, addressStr string) (common.Address, error) {
+	if !common.IsHexAddress(addressStr) {
+		return common.Address{}, fmt.Errorf("Invalid %v address: %v", name, addressStr)
 	}
+	return common.HexToAddress(addressStr), nil
+}
 
-	result.TxHash = trpcResult.TxHash
-	result.Block = trpcResult.Block
+// parseWei parses an amount in wei, which is zero if not specified.
+func parseWei(name, amountStr string) (*big.Int, error) {
+	if len(amountStr) == 0 {
+		return big.NewInt(0), nil
+	}
+	amount, ok := new(big.Int).SetString(amountStr, 10)
+	if !ok || amount.Sign() < 0 {
+		return nil, fmt.Errorf("Failed to parse %v: %v", name, amountStr)
+	}
+	return amount, nil
+}
 
-	return nil
+// parseFee parses the fee of a transaction. If no fee is given, the current minimum fee is
+// queried from the node, since it depends on the chain and can be changed by the governance.
+func (t *ThetaCliRPCService) parseFee(feeStr string) (*big.Int, error) {
+	if len(feeStr) == 0 {
+		return t.getMinimumFee()
+	}
+	return parseWei("fee", feeStr)
+}
+
+func (t *ThetaCliRPCService) getMinimumFee() (*big.Int, error) {
+	result := &trpc.GetMinimumTransactionFeeResult{}
+	args := trpc.GetMinimumTransactionFeeArgs{}
+	if err := t.client.Call("theta.GetMinimumTransactionFee", []interface{}{args}, result); err != nil {
+		return nil, fmt.Errorf("Failed to get the minimum transaction fee: %v", err)
+	}
+	if result.MinimumFee == nil {
+		return nil, fmt.Errorf("Failed to get the minimum transaction fee")
+	}
+	return (*big.Int)(result.MinimumFee), nil
+}
+
+// parseStakeAmount parses the amount of a stake, which is in Theta for validators and
+// guardians, and in TFuel for elite edge nodes.
+func parseStakeAmount(amountStr string, purpose uint8) (types.Coins, error) {
+	amount, err := parseWei("amount", amountStr)
+	if err != nil {
+		return types.Coins{}, err
+	}
+	if amount.Sign() <= 0 {
+		return types.Coins{}, fmt.Errorf("The amount must be positive")
+	}
+	if purpose == core.StakeForEliteEdgeNode {
+		return types.Coins{ThetaWei: big.NewInt(0), TFuelWei: amount}, nil
+	}
+	return types.Coins{ThetaWei: amount, TFuelWei: big.NewInt(0)}, nil
+}
+
+func tfuelFee(fee *big.Int) types.Coins {
+	return types.Coins{
+		ThetaWei: new(big.Int).SetUint64(0),
+		TFuelWei: fee,
+	}
+}
+
+func trimHexPrefix(str string) string {
+	if len(str) >= 2 && (str[:2] == "0x" || str[:2] == "0X") {
+		return str[2:]
+	}
+	return str
 }
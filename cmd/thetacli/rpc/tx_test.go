@@ -0,0 +1,418 @@
+package rpc
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger/types"
+	trpc "github.com/thetatoken/theta/rpc"
+	"github.com/thetatoken/theta/rpc/sequence"
+)
+
+const testChainID = "privatenet"
+
+var (
+	testAddress1 = "0x2E833968E5bB786Ae419c4d13189fB081Cc43bab"
+	testAddress2 = "0x70f587259738cB626A1720Af7038B8DcDb6a42a0"
+)
+
+// testTxArgs returns the arguments of a transaction signed without being broadcasted, so
+// that the node is only queried for the minimum fee.
+func testTxArgs() TxArgs {
+	return TxArgs{ChainID: testChainID, Sequence: "5", SignOnly: true}
+}
+
+// decodeTestTx decodes the signed transaction of the result, and checks its signature.
+func decodeTestTx(t *testing.T, result *TxResult, signer string, getInput func(tx types.Tx) types.TxInput) types.Tx {
+	require.Equal(t, "5", result.Sequence)
+	require.Nil(t, result.Block)
+
+	raw, err := hex.DecodeString(result.TxBytes)
+	require.Nil(t, err)
+	assert.Equal(t, crypto.Keccak256Hash(raw).Hex(), result.TxHash)
+	tx, err := types.TxFromBytes(raw)
+	require.Nil(t, err)
+
+	input := getInput(tx)
+	assert.Equal(t, common.HexToAddress(signer), input.Address)
+	assert.Equal(t, uint64(5), input.Sequence)
+	require.NotNil(t, input.Signature)
+	assert.True(t, input.Signature.Verify(tx.SignBytes(testChainID), input.Address))
+	return tx
+}
+
+// testNode is a sequence.Node whose accounts are all at sequence 7.
+type testNode struct{}
+
+func (n *testNode) GetSequence(address common.Address, preview bool) (uint64, error) {
+	return 7, nil
+}
+
+func (n *testNode) GetTransactionStatus(hash common.Hash) (string, error) {
+	return "", nil
+}
+
+func (n *testNode) GetPendingTransactions() ([]common.Hash, error) {
+	return nil, nil
+}
+
+func (n *testNode) BroadcastRawTransaction(txBytes common.Bytes) error {
+	return nil
+}
+
+// testMinimumFee is the minimum transaction fee returned by testClient, different from the
+// protocol fee as if set by the governance.
+var testMinimumFee = new(big.Int).Mul(big.NewInt(4), big.NewInt(1e17))
+
+// testClient is a node RPC client which only answers the minimum transaction fee queries,
+// or fails with the error if set.
+type testClient struct {
+	err error
+}
+
+func (c *testClient) Call(name string, args []interface{}, result interface{}) error {
+	if c.err != nil {
+		return c.err
+	}
+	if name != "theta.GetMinimumTransactionFee" {
+		return fmt.Errorf("unexpected call: %v", name)
+	}
+	result.(*trpc.GetMinimumTransactionFeeResult).MinimumFee = (*common.JSONBig)(testMinimumFee)
+	return nil
+}
+
+func defaultFee() types.Coins {
+	return tfuelFee(testMinimumFee)
+}
+
+func TestSignOnlyTxs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	// Send
+	result := &TxResult{}
+	require.Nil(service.Send(&SendArgs{
+		TxArgs:   testTxArgs(),
+		From:     address,
+		To:       testAddress1,
+		ThetaWei: "100",
+		TFuelWei: "200",
+	}, result))
+	tx := decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.SendTx).Inputs[0] })
+	sendTx := tx.(*types.SendTx)
+	assert.Equal(defaultFee(), sendTx.Fee)
+	assert.Equal(int64(100), sendTx.Inputs[0].Coins.ThetaWei.Int64())
+	assert.Equal(new(big.Int).Add(big.NewInt(200), sendTx.Fee.TFuelWei), sendTx.Inputs[0].Coins.TFuelWei)
+	assert.Equal(common.HexToAddress(testAddress1), sendTx.Outputs[0].Address)
+	assert.Equal(int64(200), sendTx.Outputs[0].Coins.TFuelWei.Int64())
+
+	// DepositStake
+	result = &TxResult{}
+	require.Nil(service.DepositStake(&DepositStakeArgs{
+		TxArgs:  testTxArgs(),
+		Source:  address,
+		Holder:  testAddress1,
+		Purpose: core.StakeForValidator,
+		Amount:  "1000",
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.DepositStakeTxV2).Source })
+	depositTx := tx.(*types.DepositStakeTxV2)
+	assert.Equal(common.HexToAddress(testAddress1), depositTx.Holder.Address)
+	assert.Equal(int64(1000), depositTx.Source.Coins.ThetaWei.Int64())
+	assert.Equal(int64(0), depositTx.Source.Coins.TFuelWei.Int64())
+
+	// WithdrawStake, of the whole stake or of an amount
+	result = &TxResult{}
+	require.Nil(service.WithdrawStake(&WithdrawStakeArgs{
+		TxArgs:  testTxArgs(),
+		Source:  address,
+		Holder:  testAddress1,
+		Purpose: core.StakeForValidator,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.WithdrawStakeTx).Source })
+	assert.Equal(common.HexToAddress(testAddress1), tx.(*types.WithdrawStakeTx).Holder.Address)
+
+	result = &TxResult{}
+	require.Nil(service.WithdrawStake(&WithdrawStakeArgs{
+		TxArgs:  testTxArgs(),
+		Source:  address,
+		Holder:  testAddress1,
+		Purpose: core.StakeForEliteEdgeNode,
+		Amount:  "300",
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.WithdrawStakeTxV2).Source })
+	assert.Equal(int64(300), tx.(*types.WithdrawStakeTxV2).Amount.TFuelWei.Int64())
+	assert.Equal(int64(0), tx.(*types.WithdrawStakeTxV2).Amount.ThetaWei.Int64())
+
+	// RedelegateStake
+	result = &TxResult{}
+	require.Nil(service.RedelegateStake(&RedelegateStakeArgs{
+		TxArgs:     testTxArgs(),
+		Source:     address,
+		FromHolder: testAddress1,
+		ToHolder:   testAddress2,
+		Purpose:    core.StakeForGuardian,
+		Amount:     "400",
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.RedelegateStakeTx).Source })
+	redelegateTx := tx.(*types.RedelegateStakeTx)
+	assert.Equal(common.HexToAddress(testAddress1), redelegateTx.FromHolder.Address)
+	assert.Equal(common.HexToAddress(testAddress2), redelegateTx.ToHolder.Address)
+	assert.Equal(int64(400), redelegateTx.Amount.ThetaWei.Int64())
+
+	// StakeRewardDistribution
+	result = &TxResult{}
+	require.Nil(service.StakeRewardDistribution(&StakeRewardDistributionArgs{
+		TxArgs:          testTxArgs(),
+		Holder:          address,
+		Beneficiary:     testAddress1,
+		SplitBasisPoint: 100,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.StakeRewardDistributionTx).Holder })
+	assert.Equal(common.HexToAddress(testAddress1), tx.(*types.StakeRewardDistributionTx).Beneficiary.Address)
+	assert.Equal(uint(100), tx.(*types.StakeRewardDistributionTx).SplitBasisPoint)
+
+	// ReserveFund
+	result = &TxResult{}
+	require.Nil(service.ReserveFund(&ReserveFundArgs{
+		TxArgs:      testTxArgs(),
+		From:        address,
+		Fund:        "1000",
+		Collateral:  "1001",
+		ResourceIDs: []string{"rid1"},
+		Duration:    100,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.ReserveFundTx).Source })
+	reserveTx := tx.(*types.ReserveFundTx)
+	assert.Equal(int64(1000), reserveTx.Source.Coins.TFuelWei.Int64())
+	assert.Equal(int64(1001), reserveTx.Collateral.TFuelWei.Int64())
+	assert.Equal([]string{"rid1"}, reserveTx.ResourceIDs)
+	assert.Equal(uint64(100), reserveTx.Duration)
+
+	// ReleaseFund
+	result = &TxResult{}
+	require.Nil(service.ReleaseFund(&ReleaseFundArgs{
+		TxArgs:          testTxArgs(),
+		From:            address,
+		ReserveSequence: 3,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.ReleaseFundTx).Source })
+	assert.Equal(uint64(3), tx.(*types.ReleaseFundTx).ReserveSequence)
+
+	// SplitRule
+	result = &TxResult{}
+	require.Nil(service.SplitRule(&SplitRuleArgs{
+		TxArgs:     testTxArgs(),
+		From:       address,
+		ResourceID: "rid1",
+		Splits:     []SplitArg{{Address: testAddress1, Percentage: 30}, {Address: testAddress2, Percentage: 20}},
+		Duration:   100,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.SplitRuleTx).Initiator })
+	splitTx := tx.(*types.SplitRuleTx)
+	assert.Equal("rid1", splitTx.ResourceID)
+	require.Equal(2, len(splitTx.Splits))
+	assert.Equal(common.HexToAddress(testAddress2), splitTx.Splits[1].Address)
+	assert.Equal(uint(20), splitTx.Splits[1].Percentage)
+
+	// SmartContract, deploying a contract with the default gas price
+	result = &TxResult{}
+	require.Nil(service.SmartContract(&SmartContractArgs{
+		TxArgs:   testTxArgs(),
+		From:     address,
+		GasLimit: 100000,
+		Data:     "0x600a600c",
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.SmartContractTx).From })
+	contractTx := tx.(*types.SmartContractTx)
+	assert.Equal(common.Address{}, contractTx.To.Address)
+	assert.Equal(new(big.Int).SetUint64(types.MinimumGasPriceJune2021), contractTx.GasPrice)
+	assert.Equal(uint64(100000), contractTx.GasLimit)
+	assert.Equal(common.Bytes{0x60, 0x0a, 0x60, 0x0c}, contractTx.Data)
+
+	// GovernanceProposal
+	result = &TxResult{}
+	require.Nil(service.GovernanceProposal(&GovernanceProposalArgs{
+		TxArgs:       testTxArgs(),
+		Proposer:     address,
+		Param:        core.ParamMaxValidatorCount,
+		Value:        "40",
+		VotingPeriod: 1000,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.GovernanceProposalTx).Proposer })
+	proposalTx := tx.(*types.GovernanceProposalTx)
+	assert.Equal(core.ParamMaxValidatorCount, proposalTx.Param)
+	assert.Equal(int64(40), proposalTx.Value.Int64())
+	assert.Equal(uint64(1000), proposalTx.VotingPeriod)
+
+	// GovernanceVote
+	result = &TxResult{}
+	require.Nil(service.GovernanceVote(&GovernanceVoteArgs{
+		TxArgs:     testTxArgs(),
+		Voter:      address,
+		ProposalID: 2,
+		Approve:    true,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.GovernanceVoteTx).Voter })
+	assert.Equal(uint64(2), tx.(*types.GovernanceVoteTx).ProposalID)
+	assert.True(tx.(*types.GovernanceVoteTx).Approve)
+
+	// TimeLock, for the sender if no beneficiary is specified
+	result = &TxResult{}
+	require.Nil(service.TimeLock(&TimeLockArgs{
+		TxArgs:        testTxArgs(),
+		From:          address,
+		ThetaWei:      "500",
+		ReleaseHeight: 2000,
+		Vesting:       true,
+	}, result))
+	tx = decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.TimeLockTx).Source })
+	timeLockTx := tx.(*types.TimeLockTx)
+	assert.Equal(common.HexToAddress(address), timeLockTx.Beneficiary)
+	assert.Equal(int64(500), timeLockTx.Source.Coins.ThetaWei.Int64())
+	assert.Equal(uint64(2000), timeLockTx.ReleaseHeight)
+	assert.True(timeLockTx.Vesting)
+}
+
+func TestTxFee(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	// The fee given is used without querying the node
+	service.client = &testClient{err: fmt.Errorf("node unavailable")}
+	args := testTxArgs()
+	args.Fee = "1000"
+	result := &TxResult{}
+	require.Nil(service.Send(&SendArgs{TxArgs: args, From: address, To: testAddress1, TFuelWei: "200"}, result))
+	tx := decodeTestTx(t, result, address, func(tx types.Tx) types.TxInput { return tx.(*types.SendTx).Inputs[0] })
+	assert.Equal(tfuelFee(big.NewInt(1000)), tx.(*types.SendTx).Fee)
+
+	// The transaction fails if no fee is given and the node can not be queried
+	err := service.Send(&SendArgs{TxArgs: testTxArgs(), From: address, To: testAddress1, TFuelWei: "200"}, &TxResult{})
+	assert.NotNil(err)
+}
+
+func TestTxArgsValidation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+
+	// The signer has to be unlocked
+	require.Nil(service.LockKey(&LockKeyArgs{Address: address}, &LockKeyResult{}))
+	err := service.Send(&SendArgs{TxArgs: testTxArgs(), From: address, To: testAddress1}, &TxResult{})
+	assert.NotNil(err)
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	invalidSequence := testTxArgs()
+	invalidSequence.Sequence = "-1"
+	invalidFee := testTxArgs()
+	invalidFee.Fee = "abc"
+
+	for name, call := range map[string]func() error{
+		"send without to": func() error {
+			return service.Send(&SendArgs{TxArgs: testTxArgs(), From: address}, &TxResult{})
+		},
+		"send to itself": func() error {
+			return service.Send(&SendArgs{TxArgs: testTxArgs(), From: address, To: address}, &TxResult{})
+		},
+		"negative amount": func() error {
+			return service.Send(&SendArgs{TxArgs: testTxArgs(), From: address, To: testAddress1, ThetaWei: "-1"}, &TxResult{})
+		},
+		"invalid fee": func() error {
+			return service.Send(&SendArgs{TxArgs: invalidFee, From: address, To: testAddress1}, &TxResult{})
+		},
+		"invalid sequence": func() error {
+			return service.Send(&SendArgs{TxArgs: invalidSequence, From: address, To: testAddress1}, &TxResult{})
+		},
+		"invalid source": func() error {
+			return service.DepositStake(&DepositStakeArgs{TxArgs: testTxArgs(), Source: "0x12", Holder: testAddress1, Amount: "1"}, &TxResult{})
+		},
+		"invalid guardian summary": func() error {
+			return service.DepositStake(&DepositStakeArgs{TxArgs: testTxArgs(), Source: address, Holder: testAddress1,
+				Purpose: core.StakeForGuardian, Amount: "1"}, &TxResult{})
+		},
+		"zero stake": func() error {
+			return service.DepositStake(&DepositStakeArgs{TxArgs: testTxArgs(), Source: address, Holder: testAddress1, Amount: "0"}, &TxResult{})
+		},
+		"zero redelegated stake": func() error {
+			return service.RedelegateStake(&RedelegateStakeArgs{TxArgs: testTxArgs(), Source: address, FromHolder: testAddress1,
+				ToHolder: testAddress2}, &TxResult{})
+		},
+		"zero collateral": func() error {
+			return service.ReserveFund(&ReserveFundArgs{TxArgs: testTxArgs(), From: address, Fund: "1"}, &TxResult{})
+		},
+		"invalid split address": func() error {
+			return service.SplitRule(&SplitRuleArgs{TxArgs: testTxArgs(), From: address, Splits: []SplitArg{{Address: "abc"}}}, &TxResult{})
+		},
+		"invalid contract data": func() error {
+			return service.SmartContract(&SmartContractArgs{TxArgs: testTxArgs(), From: address, Data: "0xzz"}, &TxResult{})
+		},
+		"unknown governance param": func() error {
+			return service.GovernanceProposal(&GovernanceProposalArgs{TxArgs: testTxArgs(), Proposer: address,
+				Param: "unknown", Value: "1"}, &TxResult{})
+		},
+		"invalid governance value": func() error {
+			return service.GovernanceProposal(&GovernanceProposalArgs{TxArgs: testTxArgs(), Proposer: address,
+				Param: core.ParamMaxValidatorCount, Value: "abc"}, &TxResult{})
+		},
+		"invalid beneficiary": func() error {
+			return service.TimeLock(&TimeLockArgs{TxArgs: testTxArgs(), From: address, Beneficiary: "abc"}, &TxResult{})
+		},
+	} {
+		assert.NotNil(call(), name)
+	}
+}
+
+func TestSignOnlyTxsSequence(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	service, address, cleanup := newTestCliRPCService(t)
+	defer cleanup()
+	service.sequences = sequence.NewManager(&testNode{}, sequence.DefaultConfig())
+	require.Nil(service.UnlockKey(&UnlockKeyArgs{Address: address, Password: "qwertyuiop"}, &UnlockKeyResult{}))
+
+	// The sequences are assigned consecutively after the one of the account
+	for _, expected := range []string{"8", "9"} {
+		result := &TxResult{}
+		require.Nil(service.Send(&SendArgs{
+			TxArgs:   TxArgs{ChainID: testChainID, SignOnly: true},
+			From:     address,
+			To:       testAddress1,
+			TFuelWei: "1",
+		}, result))
+		assert.Equal(expected, result.Sequence)
+	}
+
+	// The transactions signed only are not tracked
+	pendingResult := &ListPendingTxsResult{}
+	require.Nil(service.ListPendingTxs(&ListPendingTxsArgs{Address: address}, pendingResult))
+	assert.Equal(0, len(pendingResult.Txs))
+
+	// The sequence is fetched again after a reset
+	require.Nil(service.ResetSequence(&ResetSequenceArgs{Address: address}, &ResetSequenceResult{}))
+	result := &TxResult{}
+	require.Nil(service.Send(&SendArgs{
+		TxArgs: TxArgs{ChainID: testChainID, SignOnly: true},
+		From:   address,
+		To:     testAddress1,
+	}, result))
+	assert.Equal("8", result.Sequence)
+}
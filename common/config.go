@@ -24,6 +24,12 @@ const (
 	// CfgGenesisChainID defines the chainID.
 	CfgGenesisChainID = "genesis.chainID"
 
+	// CfgForksPreset selects the fork schedule preset of the chain, "mainnet", "testnet" or "dev". It
+	// applies to the chain set by CfgGenesisChainID, or to all the chains without a registered schedule.
+	CfgForksPreset = "forks.preset"
+	// CfgForksHeights overrides the activation heights of the fork schedule, e.g. forks.heights.enableSmartContract
+	CfgForksHeights = "forks.heights"
+
 	// CfgConsensusMaxEpochLength defines the maxium length of an epoch.
 	CfgConsensusMaxEpochLength = "consensus.maxEpochLength"
 	// CfgConsensusMinBlockTime defines the minimal block interval (in seconds)
@@ -34,6 +40,8 @@ const (
 	CfgConsensusEdgeNodeVoteQueueSize = "consensus.edgeNodeVoteQueueSize"
 	// CfgConsensusPassThroughGuardianVote defines the how guardian vote is handled.
 	CfgConsensusPassThroughGuardianVote = "consensus.passThroughGuardianVote"
+	// CfgConsensusWALEnabled indicates whether the consensus engine records its inbound messages and timer events.
+	CfgConsensusWALEnabled = "consensus.walEnabled"
 
 	// CfgStorageRollingEnabled indicates whether rolling is enabled
 	CfgStorageRollingEnabled = "storage.stateRollingEnabled"
@@ -45,12 +53,18 @@ const (
 	CfgStorageStatePruningRetainedBlocks = "storage.statePruningRetainedBlocks"
 	// CfgStorageStatePruningSkipCheckpoints indicates if the checkpoint state trie should be retained
 	CfgStorageStatePruningSkipCheckpoints = "storage.statePruningSkipCheckpoints"
-	// CfgStorageLevelDBCacheSize indicates Level DB cache size
+	// CfgStorageBackend selects the storage engine of the main database, "leveldb", "pebble" or "memory"
+	CfgStorageBackend = "storage.backend"
+	// CfgStorageLevelDBCacheSize indicates Level DB cache size, also used by the Pebble backend
 	CfgStorageLevelDBCacheSize = "storage.levelDBCacheSize"
-	// CfgStorageLevelDBHandles indicates Level DB handle count
+	// CfgStorageLevelDBHandles indicates Level DB handle count, also used by the Pebble backend
 	CfgStorageLevelDBHandles = "storage.levelDBHandles"
+	// CfgStorageTrieCacheSize is the maximum number of clean trie nodes cached for the ledger state, 0 to disable
+	CfgStorageTrieCacheSize = "storage.trieCacheSize"
 	// CfgStorageRollingInterval is the block interval that we start new db layer
 	CfgStorageRollingInterval = "storage.rollingInterval"
+	// CfgStorageRewardRecordsEnabled indicates whether the reward granted for each stake is recorded for the GetRewards RPC API
+	CfgStorageRewardRecordsEnabled = "storage.rewardRecordsEnabled"
 
 	// CfgSyncMessageQueueSize defines the capacity of Sync Manager message queue.
 	CfgSyncMessageQueueSize = "sync.messageQueueSize"
@@ -59,6 +73,10 @@ const (
 	// CfgSyncDownloadByHeader indicates whether should download blocks using header.
 	CfgSyncDownloadByHeader = "sync.downloadByHeader"
 
+	// CfgMempoolReplacementFeeMargin defines the minimal percentage by which the fee of a transaction
+	// must exceed the fee of the pending transaction with the same sequence to replace it.
+	CfgMempoolReplacementFeeMargin = "mempool.replacementFeeMargin"
+
 	// CfgP2POpt sets which P2P network to use: p2p, libp2p, or both.
 	CfgP2POpt = "p2p.opt"
 	// CfgP2PReuseStream sets whether to reuse libp2p stream
@@ -165,19 +183,25 @@ func init() {
 	viper.SetDefault(CfgConsensusMessageQueueSize, 512)
 	viper.SetDefault(CfgConsensusEdgeNodeVoteQueueSize, 100000)
 	viper.SetDefault(CfgConsensusPassThroughGuardianVote, false)
+	viper.SetDefault(CfgConsensusWALEnabled, false)
 
 	viper.SetDefault(CfgSyncMessageQueueSize, 512)
 	viper.SetDefault(CfgSyncDownloadByHash, false)
 	viper.SetDefault(CfgSyncDownloadByHeader, true)
 
+	viper.SetDefault(CfgMempoolReplacementFeeMargin, 10)
+
 	viper.SetDefault(CfgStorageRollingEnabled, true)
 	viper.SetDefault(CfgStorageStatePruningEnabled, true)
 	viper.SetDefault(CfgStorageStatePruningInterval, 16)
 	viper.SetDefault(CfgStorageStatePruningRetainedBlocks, 2048)
 	viper.SetDefault(CfgStorageStatePruningSkipCheckpoints, true)
+	viper.SetDefault(CfgStorageBackend, "leveldb")
 	viper.SetDefault(CfgStorageLevelDBCacheSize, 256)
 	viper.SetDefault(CfgStorageLevelDBHandles, 16)
+	viper.SetDefault(CfgStorageTrieCacheSize, 262144)
 	viper.SetDefault(CfgStorageRollingInterval, 14400) // approximately 1 days by default
+	viper.SetDefault(CfgStorageRewardRecordsEnabled, false)
 
 	viper.SetDefault(CfgRPCEnabled, false)
 	viper.SetDefault(CfgP2PMessageQueueSize, 512)
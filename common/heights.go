@@ -1,37 +1,192 @@
 package common
 
-// HeightEnableValidatorReward specifies the minimal block height to enable the validtor TFUEL reward
-const HeightEnableValidatorReward uint64 = 4164982 // approximate time: 2pm January 14th, 2020 PST
+import (
+	"fmt"
+	"sync"
 
-// HeightEnableTheta2 specifies the minimal block height to enable the Theta2.0 feature.
-const HeightEnableTheta2 uint64 = 5877350 // approximate time: 12pm May 27th, 2020 PDT
+	"github.com/spf13/viper"
+)
 
-// HeightLowerGNStakeThresholdTo1000 specifies the minimal block height to lower the GN Stake Threshold to 1,000 THETA
-const HeightLowerGNStakeThresholdTo1000 uint64 = 8411427 // approximate time: 12pm Dec 10th, 2020 PST
+// ForkSchedule specifies the block heights at which the protocol upgrades are
+// activated on a chain. Each upgrade applies to the blocks at or above its
+// height, a height of zero activates the upgrade from the genesis block.
+type ForkSchedule struct {
+	// EnableValidatorReward specifies the minimal block height to enable the validtor TFUEL reward
+	EnableValidatorReward uint64 `json:"enableValidatorReward"`
 
-// HeightEnableSmartContract specifies the minimal block height to eanble the Turing-complete smart contract support
-const HeightEnableSmartContract uint64 = 8411427 // approximate time: 12pm Dec 10th, 2020 PST
+	// EnableTheta2 specifies the minimal block height to enable the Theta2.0 feature.
+	EnableTheta2 uint64 `json:"enableTheta2"`
 
-// HeightSampleStakingReward specifies the block heigth to enable sampling of staking reward
-const HeightSampleStakingReward uint64 = 9497418 // approximate time: 7pm Mar 10th, 2021 PST
+	// LowerGNStakeThresholdTo1000 specifies the minimal block height to lower the GN Stake Threshold to 1,000 THETA
+	LowerGNStakeThresholdTo1000 uint64 `json:"lowerGNStakeThresholdTo1000"`
 
-// HeightJune2021FeeAdjustment specifies the block heigth to enable transaction fee burning adjustment
-const HeightJune2021FeeAdjustment uint64 = 10709540 // approximate time: 12pm June 11, 2021 PT
+	// EnableSmartContract specifies the minimal block height to eanble the Turing-complete smart contract support
+	EnableSmartContract uint64 `json:"enableSmartContract"`
 
-// HeightEnableTheta3 specifies the minimal block height to enable the Theta3.0 feature.
-const HeightEnableTheta3 uint64 = 10968061 // approximate time: 12pm June 30, 2021 PT
+	// SampleStakingReward specifies the block heigth to enable sampling of staking reward
+	SampleStakingReward uint64 `json:"sampleStakingReward"`
 
-// HeightRPCCompatibility specifies the block height to enable Ethereum compatible RPC support
-const HeightRPCCompatibility uint64 = 11354820 // approximate time: 12pm July 30, 2021 PT
+	// June2021FeeAdjustment specifies the block heigth to enable transaction fee burning adjustment
+	June2021FeeAdjustment uint64 `json:"june2021FeeAdjustment"`
 
-// HeightTxWrapperExtension specifies the block height to extend the Tx Wrapper
-const HeightTxWrapperExtension uint64 = 12749952
+	// EnableTheta3 specifies the minimal block height to enable the Theta3.0 feature.
+	EnableTheta3 uint64 `json:"enableTheta3"`
 
-// HeightSupportThetaTokenInSmartContract specifies the block height to support Theta in smart contracts
-const HeightSupportThetaTokenInSmartContract uint64 = 13123789 // approximate time: 5pm Dec 4, 2021 PT
+	// RPCCompatibility specifies the block height to enable Ethereum compatible RPC support
+	RPCCompatibility uint64 `json:"rpcCompatibility"`
 
-// HeightValidatorStakeChangedTo200K specifies the block height to lower the validator stake to 200,000 Theta
-const HeightValidatorStakeChangedTo200K uint64 = 14526120 // approximate time: 12pm Mar 14, 2022 PT
+	// TxWrapperExtension specifies the block height to extend the Tx Wrapper
+	TxWrapperExtension uint64 `json:"txWrapperExtension"`
+
+	// SupportThetaTokenInSmartContract specifies the block height to support Theta in smart contracts
+	SupportThetaTokenInSmartContract uint64 `json:"supportThetaTokenInSmartContract"`
+
+	// ValidatorStakeChangedTo200K specifies the block height to lower the validator stake to 200,000 Theta
+	ValidatorStakeChangedTo200K uint64 `json:"validatorStakeChangedTo200K"`
+
+	// StakeRedelegation specifies the block height to enable the partial stake withdrawal and the stake redelegation transactions
+	StakeRedelegation uint64 `json:"stakeRedelegation"`
+
+	// Governance specifies the block height to enable the on-chain governance of the protocol parameters
+	Governance uint64 `json:"governance"`
+
+	// TimeLock specifies the block height to enable the time-locked transfers
+	TimeLock uint64 `json:"timeLock"`
+
+	// EVMIstanbul specifies the block height to enable the Istanbul EVM semantics: the EIP-1884
+	// opcode repricing, the EIP-2028 calldata cost and the EIP-2200 SSTORE metering
+	EVMIstanbul uint64 `json:"evmIstanbul"`
+
+	// EVMBerlin specifies the block height to enable the Berlin EVM semantics: the EIP-2929
+	// access-list-aware gas costs of the state access opcodes
+	EVMBerlin uint64 `json:"evmBerlin"`
+
+	// EVMLondon specifies the block height to enable the London EVM semantics: the EIP-3198
+	// BASEFEE opcode, the EIP-3529 refund reduction and the EIP-3541 rejection of 0xEF code
+	EVMLondon uint64 `json:"evmLondon"`
+
+	// EVMShanghai specifies the block height to enable the Shanghai EVM semantics: the EIP-3855
+	// PUSH0 opcode, the EIP-3860 initcode metering and the EIP-3651 warm coinbase
+	EVMShanghai uint64 `json:"evmShanghai"`
+
+	// StakingAndBLSPrecompiles specifies the block height to enable the precompiled contracts querying
+	// the guardian and elite edge node pools and verifying the BLS signatures
+	StakingAndBLSPrecompiles uint64 `json:"stakingAndBLSPrecompiles"`
+}
+
+// HeightNotScheduled is the height of the upgrades not yet scheduled on a chain.
+const HeightNotScheduled = ^uint64(0) // max uint64
+
+// MainnetForkSchedule is the fork schedule of the mainnet.
+var MainnetForkSchedule = &ForkSchedule{
+	EnableValidatorReward:            4164982,  // approximate time: 2pm January 14th, 2020 PST
+	EnableTheta2:                     5877350,  // approximate time: 12pm May 27th, 2020 PDT
+	LowerGNStakeThresholdTo1000:      8411427,  // approximate time: 12pm Dec 10th, 2020 PST
+	EnableSmartContract:              8411427,  // approximate time: 12pm Dec 10th, 2020 PST
+	SampleStakingReward:              9497418,  // approximate time: 7pm Mar 10th, 2021 PST
+	June2021FeeAdjustment:            10709540, // approximate time: 12pm June 11, 2021 PT
+	EnableTheta3:                     10968061, // approximate time: 12pm June 30, 2021 PT
+	RPCCompatibility:                 11354820, // approximate time: 12pm July 30, 2021 PT
+	TxWrapperExtension:               12749952,
+	SupportThetaTokenInSmartContract: 13123789, // approximate time: 5pm Dec 4, 2021 PT
+	ValidatorStakeChangedTo200K:      14526120, // approximate time: 12pm Mar 14, 2022 PT
+	StakeRedelegation:                HeightNotScheduled,
+	Governance:                       HeightNotScheduled,
+	TimeLock:                         HeightNotScheduled,
+	EVMIstanbul:                      HeightNotScheduled,
+	EVMBerlin:                        HeightNotScheduled,
+	EVMLondon:                        HeightNotScheduled,
+	EVMShanghai:                      HeightNotScheduled,
+	StakingAndBLSPrecompiles:         HeightNotScheduled,
+}
+
+// TestnetForkSchedule is the fork schedule of the public testnets, which have
+// been upgraded by the same releases as the mainnet.
+var TestnetForkSchedule = MainnetForkSchedule.Copy()
+
+// DevForkSchedule activates all the protocol upgrades from the genesis block,
+// for private networks and tests.
+var DevForkSchedule = &ForkSchedule{}
+
+// ForkSchedulePresets maps the preset names accepted in the config to the fork schedules.
+var ForkSchedulePresets = map[string]*ForkSchedule{
+	"mainnet": MainnetForkSchedule,
+	"testnet": TestnetForkSchedule,
+	"dev":     DevForkSchedule,
+}
+
+var (
+	forkSchedulesLock   sync.RWMutex
+	defaultForkSchedule = MainnetForkSchedule
+	forkSchedules       = map[string]*ForkSchedule{
+		"mainnet":          MainnetForkSchedule,
+		"testnet":          TestnetForkSchedule,
+		"testnet_sapphire": TestnetForkSchedule,
+		"testnet_amber":    TestnetForkSchedule,
+	}
+)
+
+// Copy returns a copy of the fork schedule.
+func (fs *ForkSchedule) Copy() *ForkSchedule {
+	fsCopy := *fs
+	return &fsCopy
+}
+
+// RegisterForkSchedule sets the fork schedule of the chain with the given ID.
+func RegisterForkSchedule(chainID string, fs *ForkSchedule) {
+	forkSchedulesLock.Lock()
+	defer forkSchedulesLock.Unlock()
+	forkSchedules[chainID] = fs
+}
+
+// SetDefaultForkSchedule sets the fork schedule of the chains without a
+// registered schedule, the mainnet schedule by default.
+func SetDefaultForkSchedule(fs *ForkSchedule) {
+	forkSchedulesLock.Lock()
+	defer forkSchedulesLock.Unlock()
+	defaultForkSchedule = fs
+}
+
+// GetForkSchedule returns the fork schedule of the chain with the given ID.
+func GetForkSchedule(chainID string) *ForkSchedule {
+	forkSchedulesLock.RLock()
+	defer forkSchedulesLock.RUnlock()
+	if fs, ok := forkSchedules[chainID]; ok {
+		return fs
+	}
+	return defaultForkSchedule
+}
+
+// LoadForkSchedule applies the fork schedule of the config to the chain with
+// the given ID: the heights set under CfgForksHeights override the heights of
+// the CfgForksPreset preset, or of the current schedule of the chain if no
+// preset is set. If chainID is empty, the schedule becomes the default one.
+// It must be called before any block of the chain is decoded, since the block
+// encoding depends on the schedule.
+func LoadForkSchedule(chainID string) (*ForkSchedule, error) {
+	if !viper.IsSet(CfgForksPreset) && !viper.IsSet(CfgForksHeights) {
+		return GetForkSchedule(chainID), nil
+	}
+
+	base := GetForkSchedule(chainID)
+	if preset := viper.GetString(CfgForksPreset); preset != "" {
+		var ok bool
+		if base, ok = ForkSchedulePresets[preset]; !ok {
+			return nil, fmt.Errorf("unknown fork schedule preset: %v", preset)
+		}
+	}
+	fs := base.Copy()
+	if err := viper.UnmarshalKey(CfgForksHeights, fs); err != nil {
+		return nil, fmt.Errorf("invalid fork heights: %v", err)
+	}
+
+	if chainID == "" {
+		SetDefaultForkSchedule(fs)
+	} else {
+		RegisterForkSchedule(chainID, fs)
+	}
+	return fs, nil
+}
 
 // CheckpointInterval defines the interval between checkpoints.
 const CheckpointInterval = int64(100)
@@ -0,0 +1,54 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetForkSchedule(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(MainnetForkSchedule, GetForkSchedule("mainnet"))
+	assert.Equal(TestnetForkSchedule, GetForkSchedule("testnet_amber"))
+	assert.Equal(MainnetForkSchedule, GetForkSchedule("unregistered_chain"))
+
+	RegisterForkSchedule("test_chain", DevForkSchedule)
+	assert.Equal(DevForkSchedule, GetForkSchedule("test_chain"))
+}
+
+func TestLoadForkSchedule(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	defer viper.Reset()
+
+	// Without fork settings the schedule of the chain is unchanged
+	fs, err := LoadForkSchedule("mainnet")
+	require.Nil(err)
+	assert.Equal(MainnetForkSchedule, fs)
+
+	viper.Set(CfgForksPreset, "dev")
+	viper.Set(CfgForksHeights, map[string]interface{}{"enableTheta3": 100, "rpcCompatibility": 200})
+	fs, err = LoadForkSchedule("custom_chain")
+	require.Nil(err)
+	assert.Equal(uint64(0), fs.EnableTheta2)
+	assert.Equal(uint64(100), fs.EnableTheta3)
+	assert.Equal(uint64(200), fs.RPCCompatibility)
+	assert.Equal(fs, GetForkSchedule("custom_chain"))
+	assert.Equal(uint64(0), DevForkSchedule.EnableTheta3, "the preset should not be modified")
+
+	viper.Set(CfgForksPreset, "unknown")
+	_, err = LoadForkSchedule("custom_chain")
+	assert.NotNil(err)
+
+	// With an empty chain ID, the schedule applies to the unregistered chains
+	viper.Set(CfgForksPreset, "dev")
+	viper.Set(CfgForksHeights, map[string]interface{}{})
+	_, err = LoadForkSchedule("")
+	require.Nil(err)
+	defer SetDefaultForkSchedule(MainnetForkSchedule)
+	assert.Equal(uint64(0), GetForkSchedule("unregistered_chain").EnableSmartContract)
+	assert.Equal(MainnetForkSchedule, GetForkSchedule("mainnet"))
+}
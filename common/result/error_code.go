@@ -41,4 +41,9 @@ const (
 	CodeInsufficientStake       ErrorCode = 106003
 	CodeNotEnoughBalanceToStake ErrorCode = 106004
 	CodeStakeExceedsCap         ErrorCode = 106005
+
+	// TimeLock Errors
+	CodeInvalidTimeLockAmount    ErrorCode = 107001
+	CodeInvalidTimeLockRelease   ErrorCode = 107002
+	CodeInvalidTimeLockRecipient ErrorCode = 107003
 )
@@ -0,0 +1,184 @@
+package timer
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time of a component. The system clock is used in
+// production, tests can substitute a SimulatedClock to control the timing.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+	NewTicker(d time.Duration) Ticker
+}
+
+// Timer mirrors time.Timer.
+type Timer interface {
+	C() <-chan time.Time
+	Stop() bool
+}
+
+// Ticker mirrors time.Ticker.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// SystemClock is the Clock backed by the time package.
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) NewTimer(d time.Duration) Timer {
+	return systemTimer{time.NewTimer(d)}
+}
+
+func (systemClock) NewTicker(d time.Duration) Ticker {
+	return systemTicker{time.NewTicker(d)}
+}
+
+type systemTimer struct {
+	*time.Timer
+}
+
+func (t systemTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+type systemTicker struct {
+	*time.Ticker
+}
+
+func (t systemTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+/*
+SimulatedClock is a Clock that only moves when Advance() is called. The timers
+and tickers fire in the order of their deadlines as the clock passes them.
+Like the time package, a firing is dropped if the previous one has not been
+received yet.
+*/
+type SimulatedClock struct {
+	mtx    sync.Mutex
+	now    time.Time
+	timers []*simulatedTimer
+}
+
+var _ Clock = (*SimulatedClock)(nil)
+
+// NewSimulatedClock creates a SimulatedClock set to the given time.
+func NewSimulatedClock(now time.Time) *SimulatedClock {
+	return &SimulatedClock{now: now}
+}
+
+// Now returns the current simulated time.
+func (c *SimulatedClock) Now() time.Time {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.now
+}
+
+// NewTimer creates a timer that fires once the clock is advanced by d.
+func (c *SimulatedClock) NewTimer(d time.Duration) Timer {
+	return c.add(d, 0)
+}
+
+// NewTicker creates a ticker that fires every time the clock is advanced by d.
+func (c *SimulatedClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	return simulatedTicker{c.add(d, d)}
+}
+
+func (c *SimulatedClock) add(d time.Duration, period time.Duration) *simulatedTimer {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	t := &simulatedTimer{
+		clock:    c,
+		ch:       make(chan time.Time, 1),
+		deadline: c.now.Add(d),
+		period:   period,
+	}
+	if d <= 0 {
+		t.ch <- c.now
+		return t
+	}
+	c.timers = append(c.timers, t)
+	return t
+}
+
+// Advance moves the clock forward by d and fires the timers and the tickers
+// that are due.
+func (c *SimulatedClock) Advance(d time.Duration) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	end := c.now.Add(d)
+	for {
+		sort.SliceStable(c.timers, func(i, j int) bool {
+			return c.timers[i].deadline.Before(c.timers[j].deadline)
+		})
+		if len(c.timers) == 0 || c.timers[0].deadline.After(end) {
+			break
+		}
+		t := c.timers[0]
+		c.now = t.deadline
+		select {
+		case t.ch <- c.now:
+		default:
+		}
+		if t.period > 0 {
+			t.deadline = t.deadline.Add(t.period)
+		} else {
+			c.timers = c.timers[1:]
+		}
+	}
+	c.now = end
+}
+
+// remove stops the given timer, it returns false if the timer has already
+// fired or been stopped.
+func (c *SimulatedClock) remove(t *simulatedTimer) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	for i, other := range c.timers {
+		if other == t {
+			c.timers = append(c.timers[:i], c.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+type simulatedTimer struct {
+	clock    *SimulatedClock
+	ch       chan time.Time
+	deadline time.Time
+	period   time.Duration
+}
+
+func (t *simulatedTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *simulatedTimer) Stop() bool {
+	return t.clock.remove(t)
+}
+
+type simulatedTicker struct {
+	*simulatedTimer
+}
+
+func (t simulatedTicker) Stop() {
+	t.simulatedTimer.Stop()
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/thetatoken/theta/blockchain"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/common/timer"
 	"github.com/thetatoken/theta/common/util"
 	"github.com/thetatoken/theta/core"
 	"github.com/thetatoken/theta/crypto"
@@ -52,13 +53,21 @@ type ConsensusEngine struct {
 	stopped bool
 
 	mu            *sync.Mutex
-	voteTimer     *time.Timer
-	epochTimer    *time.Timer
-	guardianTimer *time.Ticker
+	clock         timer.Clock
+	voteTimer     timer.Timer
+	epochTimer    timer.Timer
+	guardianTimer timer.Ticker
 
 	voteTimerReady bool
 	blockProcessed bool
 
+	// Blocks on demand (developer mode)
+	pendingTxs func() int
+	newTx      chan struct{}
+
+	// Optional record of the inbound messages and timer events
+	wal *WAL
+
 	state *State
 }
 
@@ -80,8 +89,11 @@ func NewConsensusEngine(privateKey *crypto.PrivateKey, db store.Store, chain *bl
 
 		validatorManager: validatorManager,
 
+		clock:          timer.SystemClock,
 		voteTimerReady: false,
 		blockProcessed: false,
+
+		newTx: make(chan struct{}, 1),
 	}
 
 	logger = util.GetLoggerForModule("consensus")
@@ -103,6 +115,33 @@ func (e *ConsensusEngine) SetLedger(ledger core.Ledger) {
 	e.ledger = ledger
 }
 
+// SetClock replaces the clock driving the epoch timers and the block timestamps.
+// It needs to be called before the engine starts.
+func (e *ConsensusEngine) SetClock(clock timer.Clock) {
+	e.clock = clock
+}
+
+// SetWAL makes the engine record its inbound messages and timer events in the given WAL.
+// It needs to be called before the engine starts.
+func (e *ConsensusEngine) SetWAL(wal *WAL) {
+	e.wal = wal
+}
+
+// EnableBlocksOnDemand makes the engine only produce blocks when there are transactions
+// to include. pendingTxs reports the number of transactions waiting in the mempool.
+// It is intended for single validator development chains.
+func (e *ConsensusEngine) EnableBlocksOnDemand(pendingTxs func() int) {
+	e.pendingTxs = pendingTxs
+}
+
+// NotifyNewTx wakes up an idle engine when blocks are produced on demand.
+func (e *ConsensusEngine) NotifyNewTx() {
+	select {
+	case e.newTx <- struct{}{}:
+	default:
+	}
+}
+
 // GetLedger returns the ledger instance attached to the consensus engine
 func (e *ConsensusEngine) GetLedger() core.Ledger {
 	return e.ledger
@@ -151,6 +190,7 @@ func (e *ConsensusEngine) Start(ctx context.Context) {
 	lastCC := e.autoRewind(e.state.GetHighestCCBlock())
 	//e.ledger.ResetState(lastCC.Height, lastCC.StateHash)
 	e.ledger.ResetState(lastCC.Block)
+	e.recordStart()
 
 	e.resetGuardianTimer()
 	e.guardian.Start(e.ctx)
@@ -260,6 +300,9 @@ func (e *ConsensusEngine) mainLoop() {
 	defer e.wg.Done()
 
 	for {
+		if !e.waitForTxs() {
+			return
+		}
 		e.enterEpoch()
 		e.propose()
 	Epoch:
@@ -269,38 +312,137 @@ func (e *ConsensusEngine) mainLoop() {
 				e.stopped = true
 				return
 			case msg := <-e.incoming:
+				e.recordMessage(msg)
 				endEpoch := e.processMessage(msg)
 				if endEpoch {
 					break Epoch
 				}
-			case <-e.voteTimer.C:
-				e.voteTimerReady = true
-				if e.blockProcessed {
-					e.vote()
-				}
-			case <-e.epochTimer.C:
-				e.logger.WithFields(log.Fields{"e.epoch": e.GetEpoch()}).Debug("Epoch timeout. Repeating epoch")
-				e.vote()
+			case <-e.voteTimer.C():
+				e.recordEvent(WALEntryVoteTimeout)
+				e.handleVoteTimeout()
+			case <-e.epochTimer.C():
+				e.recordEvent(WALEntryEpochTimeout)
+				e.handleEpochTimeout()
 				break Epoch
-			case <-e.guardianTimer.C:
-				v := e.guardian.GetVoteToBroadcast()
+			case <-e.guardianTimer.C():
+				e.recordEvent(WALEntryGuardianTimeout)
+				e.handleGuardianTimeout()
+			}
+		}
+	}
+}
 
-				if v != nil {
-					e.guardian.logger.WithFields(log.Fields{"vote": v}).Debug("Broadcasting guardian vote")
-					e.broadcastGuardianVote(v)
-				}
-				e.guardian.StartNewRound()
+func (e *ConsensusEngine) handleVoteTimeout() {
+	e.voteTimerReady = true
+	if e.blockProcessed {
+		e.vote()
+	}
+}
 
-				eenv := e.eliteEdgeNode.GetVoteToBroadcast()
+func (e *ConsensusEngine) handleEpochTimeout() {
+	e.logger.WithFields(log.Fields{"e.epoch": e.GetEpoch()}).Debug("Epoch timeout. Repeating epoch")
+	e.vote()
+}
 
-				if eenv != nil {
-					e.eliteEdgeNode.logger.WithFields(log.Fields{"vote": eenv}).Debug("Broadcasting aggregated elite edge node vote")
-					e.broadcastAggregatedEliteEdgeNodeVotes(eenv)
-				}
-				e.eliteEdgeNode.StartNewRound()
-			}
+func (e *ConsensusEngine) handleGuardianTimeout() {
+	v := e.guardian.GetVoteToBroadcast()
+
+	if v != nil {
+		e.guardian.logger.WithFields(log.Fields{"vote": v}).Debug("Broadcasting guardian vote")
+		e.broadcastGuardianVote(v)
+	}
+	e.guardian.StartNewRound()
+
+	eenv := e.eliteEdgeNode.GetVoteToBroadcast()
+
+	if eenv != nil {
+		e.eliteEdgeNode.logger.WithFields(log.Fields{"vote": eenv}).Debug("Broadcasting aggregated elite edge node vote")
+		e.broadcastAggregatedEliteEdgeNodeVotes(eenv)
+	}
+	e.eliteEdgeNode.StartNewRound()
+}
+
+// recordStart writes the starting state of the engine to the WAL.
+func (e *ConsensusEngine) recordStart() {
+	if e.wal == nil {
+		return
+	}
+	data, err := rlp.EncodeToBytes(WALStart{
+		Epoch:              e.GetEpoch(),
+		HighestCCBlock:     e.state.GetHighestCCBlock().Hash(),
+		LastFinalizedBlock: e.GetLastFinalizedBlock().Hash(),
+	})
+	if err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Warn("Failed to encode the WAL start entry")
+		return
+	}
+	if err := e.wal.Write(WALEntryStart, data, e.clock.Now()); err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Warn("Failed to write to the WAL")
+	}
+}
+
+// recordMessage writes an inbound message to the WAL.
+func (e *ConsensusEngine) recordMessage(msg interface{}) {
+	if e.wal == nil {
+		return
+	}
+	if err := e.wal.WriteMessage(msg, e.clock.Now()); err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Warn("Failed to write to the WAL")
+	}
+}
+
+// recordEvent writes a timer event to the WAL.
+func (e *ConsensusEngine) recordEvent(entryType WALEntryType) {
+	if e.wal == nil {
+		return
+	}
+	if err := e.wal.Write(entryType, nil, e.clock.Now()); err != nil {
+		e.logger.WithFields(log.Fields{"error": err}).Warn("Failed to write to the WAL")
+	}
+}
+
+// waitForTxs blocks while blocks are produced on demand and there is nothing
+// to include. It returns false if the engine is stopped while waiting.
+func (e *ConsensusEngine) waitForTxs() bool {
+	for e.pendingTxs != nil && e.isIdle() {
+		select {
+		case <-e.ctx.Done():
+			e.stopped = true
+			return false
+		case msg := <-e.incoming:
+			e.recordMessage(msg)
+			e.processMessage(msg)
+		case <-e.newTx:
+		}
+	}
+	return true
+}
+
+// isIdle returns true if the mempool is empty and all blocks after the last
+// finalized block only contain the coinbase transaction. The last block with
+// user transactions needs one more block on top of it to get finalized. The
+// engine is never idle before a block past the root is finalized, so that the
+// finalized state and the votes are available to the RPC queries.
+func (e *ConsensusEngine) isIdle() bool {
+	if e.pendingTxs() > 0 {
+		return false
+	}
+	lfb := e.GetLastFinalizedBlock()
+	if lfb.Height <= e.chain.Root().Height {
+		return false
+	}
+	block := e.GetTipToExtend()
+	for block != nil && block.Height > lfb.Height {
+		if len(block.Txs) > 1 {
+			return false
+		}
+		parent, err := e.chain.FindBlock(block.Parent)
+		if err != nil {
+			return false
 		}
+		block = parent
 	}
+	return true
 }
 
 // enterEpoch is called when engine enters a new epoch.
@@ -311,12 +453,12 @@ func (e *ConsensusEngine) enterEpoch() {
 	if e.epochTimer != nil {
 		e.epochTimer.Stop()
 	}
-	e.epochTimer = time.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMaxEpochLength)) * time.Second)
+	e.epochTimer = e.clock.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMaxEpochLength)) * time.Second)
 
 	if e.voteTimer != nil {
 		e.voteTimer.Stop()
 	}
-	e.voteTimer = time.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMinBlockInterval)) * time.Second)
+	e.voteTimer = e.clock.NewTimer(time.Duration(viper.GetInt(common.CfgConsensusMinBlockInterval)) * time.Second)
 
 	e.voteTimerReady = false
 	e.blockProcessed = false
@@ -381,7 +523,7 @@ func (e *ConsensusEngine) checkSyncStatus() error {
 	// current finalized height is at most maxVoteHeight-1
 	currentHeight := uint64(maxVoteHeight - 1)
 
-	e.hasSynced = !isSyncing(e.GetLastFinalizedBlock(), currentHeight)
+	e.hasSynced = !isSyncing(e.GetLastFinalizedBlock(), currentHeight, e.clock.Now())
 
 	return nil
 }
@@ -508,7 +650,7 @@ func (e *ConsensusEngine) validateBlock(block *core.Block, parent *core.Extended
 
 	// Validate Guardian Votes.
 	// We allow checkpoint blocs to have nil guardian votes.
-	if block.GuardianVotes != nil && block.Height >= common.HeightEnableTheta2 && common.IsCheckPointHeight(block.Height) {
+	if block.GuardianVotes != nil && block.Height >= common.GetForkSchedule(e.chain.ChainID).EnableTheta2 && common.IsCheckPointHeight(block.Height) {
 		// Voted block must exist.
 		padding := uint64(20)
 		if e.chain.Root().Height+padding*uint64(common.CheckpointInterval) < block.Height {
@@ -579,7 +721,7 @@ func (e *ConsensusEngine) validateBlock(block *core.Block, parent *core.Extended
 
 	// Validate Elite Edge Node Votes.
 	// We allow checkpoint blocks to have nil elite edge node votes.
-	if block.EliteEdgeNodeVotes != nil && block.Height >= common.HeightEnableTheta3 && common.IsCheckPointHeight(block.Height) {
+	if block.EliteEdgeNodeVotes != nil && block.Height >= common.GetForkSchedule(e.chain.ChainID).EnableTheta3 && common.IsCheckPointHeight(block.Height) {
 		// Voted block must exist.
 		padding := uint64(20)
 		if e.chain.Root().Height+padding*uint64(common.CheckpointInterval) < block.Height {
@@ -1255,18 +1397,18 @@ func (e *ConsensusEngine) createProposal(shouldIncludeValidatorUpdateTxs bool) (
 	block.Parent = tip.Hash()
 	block.Height = tip.Height + 1
 	block.Proposer = e.privateKey.PublicKey().Address()
-	block.Timestamp = big.NewInt(time.Now().Unix())
+	block.Timestamp = big.NewInt(e.clock.Now().Unix())
 	block.HCC.BlockHash = e.state.GetHighestCCBlock().Hash()
 	hccValidators := e.validatorManager.GetValidatorSet(block.HCC.BlockHash)
 	block.HCC.Votes = e.chain.FindVotesByHash(block.HCC.BlockHash).UniqueVoter().FilterByValidators(hccValidators)
 
 	// Add guardian votes.
-	if block.Height >= common.HeightEnableTheta2 && common.IsCheckPointHeight(block.Height) {
+	if block.Height >= common.GetForkSchedule(e.chain.ChainID).EnableTheta2 && common.IsCheckPointHeight(block.Height) {
 		block.GuardianVotes = e.guardian.GetBestVote()
 	}
 
 	// Add elite edge node votes.
-	if block.Height >= common.HeightEnableTheta3 && common.IsCheckPointHeight(block.Height) {
+	if block.Height >= common.GetForkSchedule(e.chain.ChainID).EnableTheta3 && common.IsCheckPointHeight(block.Height) {
 		block.EliteEdgeNodeVotes = e.eliteEdgeNode.GetBestVote()
 	}
 
@@ -1383,14 +1525,14 @@ func (e *ConsensusEngine) resetGuardianTimer() {
 	if e.guardianTimer != nil {
 		e.guardianTimer.Stop()
 	}
-	e.guardianTimer = time.NewTicker(time.Duration(viper.GetInt(common.CfgGuardianRoundLength)) * time.Second)
+	e.guardianTimer = e.clock.NewTicker(time.Duration(viper.GetInt(common.CfgGuardianRoundLength)) * time.Second)
 }
 
-func isSyncing(lastestFinalizedBlock *core.ExtendedBlock, currentHeight uint64) bool {
+func isSyncing(lastestFinalizedBlock *core.ExtendedBlock, currentHeight uint64, now time.Time) bool {
 	if lastestFinalizedBlock == nil {
 		return true
 	}
-	currentTime := big.NewInt(time.Now().Unix())
+	currentTime := big.NewInt(now.Unix())
 	maxDiff := new(big.Int).SetUint64(30) // thirty seconds, about 5 blocks
 	threshold := new(big.Int).Sub(currentTime, maxDiff)
 	isSyncing := lastestFinalizedBlock.Timestamp.Cmp(threshold) < 0
@@ -1,7 +1,9 @@
 package consensus
 
 import (
+	"context"
 	"math/big"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -742,3 +744,93 @@ func TestTipSelection(t *testing.T) {
 	tip = ce.GetTipToExtend()
 	assert.Equal(a2.Hash(), tip.Hash(), "should not select blocks with validator update that are higher than local HCC")
 }
+
+func TestBlocksOnDemand(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	privKey, _, _ := crypto.GenerateKeyPair()
+	validatorManager := MockValidatorManager{PrivKey: privKey}
+
+	core.ResetTestBlocks()
+
+	store := kvstore.NewKVStore(backend.NewMemDatabase())
+	root := core.CreateTestBlock("root", "")
+	chain := blockchain.NewChain("testchain", store, root)
+
+	ce := NewConsensusEngine(privKey, store, chain, nil, validatorManager)
+	ce.ctx, ce.cancel = context.WithCancel(context.Background())
+	defer ce.cancel()
+
+	// Blocks are produced continuously unless enabled
+	assert.True(ce.waitForTxs())
+
+	var pendingTxs int32
+	ce.EnableBlocksOnDemand(func() int { return int(atomic.LoadInt32(&pendingTxs)) })
+
+	// Never idle before a block past the root is finalized
+	assert.False(ce.isIdle())
+
+	addBlock := func(name, parent string, numTxs int) *core.ExtendedBlock {
+		block := core.CreateTestBlock(name, parent)
+		block.Txs = make([]common.Bytes, numTxs)
+		_, err := chain.AddBlock(block)
+		require.Nil(err)
+		eb := chain.MarkBlockValid(block.Hash())
+		require.Nil(ce.state.SetHighestCCBlock(eb))
+		return eb
+	}
+	finalize := func(eb *core.ExtendedBlock) {
+		require.Nil(ce.state.SetLastFinalizedBlock(eb))
+	}
+
+	// Blocks with the coinbase transaction only
+	a1 := addBlock("a1", "root", 1)
+	finalize(a1)
+	assert.True(ce.isIdle())
+	addBlock("a2", "a1", 1)
+	assert.True(ce.isIdle())
+
+	// The block with user transactions needs a block on top of it to get finalized
+	a3 := addBlock("a3", "a2", 3)
+	assert.False(ce.isIdle())
+	finalize(a3)
+	assert.True(ce.isIdle())
+
+	// New transactions in the mempool
+	atomic.StoreInt32(&pendingTxs, 2)
+	assert.False(ce.isIdle())
+	atomic.StoreInt32(&pendingTxs, 0)
+
+	// The idle engine waits until a new transaction arrives
+	done := make(chan bool)
+	go func() {
+		done <- ce.waitForTxs()
+	}()
+	select {
+	case <-done:
+		assert.Fail("should wait for transactions")
+	case <-time.After(100 * time.Millisecond):
+	}
+	atomic.StoreInt32(&pendingTxs, 1)
+	ce.NotifyNewTx()
+	select {
+	case ok := <-done:
+		assert.True(ok)
+	case <-time.After(5 * time.Second):
+		assert.Fail("should stop waiting on new transactions")
+	}
+
+	// A stopped engine stops waiting
+	atomic.StoreInt32(&pendingTxs, 0)
+	go func() {
+		done <- ce.waitForTxs()
+	}()
+	ce.cancel()
+	select {
+	case ok := <-done:
+		assert.False(ok)
+	case <-time.After(5 * time.Second):
+		assert.Fail("should stop waiting when stopped")
+	}
+}
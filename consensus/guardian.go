@@ -217,7 +217,7 @@ func (g *GuardianEngine) HandleVote(vote *core.AggregatedVotes) {
 	case g.incoming <- vote:
 		return
 	default:
-		g.logger.Debug("GuardianEngine queue is full, discarding vote: %v", vote)
+		g.logger.Debugf("GuardianEngine queue is full, discarding vote: %v", vote)
 	}
 }
 
@@ -0,0 +1,137 @@
+package consensus
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/thetatoken/theta/common/timer"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/rlp"
+)
+
+// Replay feeds the entries of a WAL to the engine in place of the network and the
+// timers, on a simulated clock following the recorded timestamps, so that the decisions
+// of the recorded run can be reproduced step by step. step is called after each entry.
+//
+// The engine must not be started, and its database should be a copy of the node database
+// as of the first start entry of the WAL. The engine does not make proposals of its own
+// during a replay, the blocks it proposed in the recorded run are replayed from the WAL
+// like the other inbound blocks.
+func (e *ConsensusEngine) Replay(ctx context.Context, reader *WALReader, step func(entry *WALEntry)) error {
+	first, err := reader.Next()
+	if err == io.EOF {
+		return fmt.Errorf("the WAL is empty")
+	}
+	if err != nil {
+		return err
+	}
+	if first.Type != WALEntryStart {
+		return fmt.Errorf("the WAL begins with a %v entry instead of a start entry", first.Type)
+	}
+
+	clock := timer.NewSimulatedClock(first.Timestamp())
+	e.clock = clock
+	c, cancel := context.WithCancel(ctx)
+	e.ctx = c
+	e.cancel = cancel
+	defer cancel()
+
+	if err := e.replayStart(first); err != nil {
+		return err
+	}
+	e.guardian.Start(e.ctx)
+	e.eliteEdgeNode.Start(e.ctx)
+	step(first)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		entry, err := reader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read the WAL: %v", err)
+		}
+		if d := entry.Timestamp().Sub(clock.Now()); d > 0 {
+			clock.Advance(d)
+		}
+
+		switch entry.Type {
+		case WALEntryStart:
+			// The node was restarted
+			if err := e.replayStart(entry); err != nil {
+				return err
+			}
+		case WALEntryVoteTimeout:
+			e.handleVoteTimeout()
+		case WALEntryEpochTimeout:
+			e.handleEpochTimeout()
+			e.enterEpoch()
+		case WALEntryGuardianTimeout:
+			e.handleGuardianTimeout()
+		default:
+			msg, err := entry.Message()
+			if err != nil {
+				return fmt.Errorf("failed to decode the %v entry: %v", entry.Type, err)
+			}
+			if block, ok := msg.(*core.Block); ok {
+				// The sync manager adds the blocks to the chain before passing them on
+				if _, err := e.chain.FindBlock(block.Hash()); err != nil {
+					if _, err := e.chain.AddBlock(block); err != nil {
+						return fmt.Errorf("failed to add block %v to the chain: %v", block.Hash().Hex(), err)
+					}
+				}
+			}
+			if endEpoch := e.processMessage(msg); endEpoch {
+				e.enterEpoch()
+			}
+		}
+
+		e.discardIncoming()
+		step(entry)
+	}
+}
+
+// replayStart brings the engine to the state it was in when the recorded run started.
+func (e *ConsensusEngine) replayStart(entry *WALEntry) error {
+	start := WALStart{}
+	if err := rlp.DecodeBytes(entry.Data, &start); err != nil {
+		return fmt.Errorf("failed to decode the start entry: %v", err)
+	}
+
+	lastCC := e.autoRewind(e.state.GetHighestCCBlock())
+	e.ledger.ResetState(lastCC.Block)
+	if e.GetLastFinalizedBlock().Hash() != start.LastFinalizedBlock ||
+		e.state.GetHighestCCBlock().Hash() != start.HighestCCBlock {
+		e.logger.WithFields(log.Fields{
+			"recorded.lastFinalizedBlock": start.LastFinalizedBlock.Hex(),
+			"recorded.highestCCBlock":     start.HighestCCBlock.Hex(),
+			"lastFinalizedBlock":          e.GetLastFinalizedBlock().Hash().Hex(),
+			"highestCCBlock":              e.state.GetHighestCCBlock().Hash().Hex(),
+		}).Warn("The database does not match the state recorded in the WAL, the replay may diverge")
+	}
+
+	e.resetGuardianTimer()
+	e.checkSyncStatus()
+	e.enterEpoch()
+	return nil
+}
+
+// discardIncoming drops the votes the engine sent to itself during a replay. The WAL
+// has the ones of the recorded run.
+func (e *ConsensusEngine) discardIncoming() {
+	for {
+		select {
+		case <-e.incoming:
+		default:
+			return
+		}
+	}
+}
@@ -141,6 +141,9 @@ func (m *RotatingValidatorManager) GetNextValidatorSet(blockHash common.Hash) *c
 
 func SelectTopStakeHoldersAsValidators(vcp *core.ValidatorCandidatePool) *core.ValidatorSet {
 	maxNumValidators := MaxValidatorCount
+	if count, ok := vcp.GetMaxValidatorCount(); ok {
+		maxNumValidators = count
+	}
 	topStakeHolders := vcp.GetTopStakeHolders(maxNumValidators)
 
 	valSet := core.NewValidatorSet()
@@ -0,0 +1,226 @@
+package consensus
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/rlp"
+)
+
+// WALEntryType is the type of an event recorded in the consensus WAL.
+type WALEntryType byte
+
+const (
+	// WALEntryStart records the state of the engine when it starts.
+	WALEntryStart WALEntryType = iota
+	WALEntryVote
+	WALEntryBlock
+	WALEntryGuardianVote
+	WALEntryEENVote
+	WALEntryAggregatedEENVotes
+	WALEntryVoteTimeout
+	WALEntryEpochTimeout
+	WALEntryGuardianTimeout
+)
+
+func (t WALEntryType) String() string {
+	switch t {
+	case WALEntryStart:
+		return "start"
+	case WALEntryVote:
+		return "vote"
+	case WALEntryBlock:
+		return "block"
+	case WALEntryGuardianVote:
+		return "guardian vote"
+	case WALEntryEENVote:
+		return "elite edge node vote"
+	case WALEntryAggregatedEENVotes:
+		return "aggregated elite edge node votes"
+	case WALEntryVoteTimeout:
+		return "vote timeout"
+	case WALEntryEpochTimeout:
+		return "epoch timeout"
+	case WALEntryGuardianTimeout:
+		return "guardian timeout"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(t))
+	}
+}
+
+// WALEntry is an event processed by the consensus engine main loop. Data holds the
+// RLP encoded message for the message entries.
+type WALEntry struct {
+	Time uint64 // Unix time in nanoseconds
+	Type WALEntryType
+	Data common.Bytes
+}
+
+// Timestamp returns the time the entry was recorded.
+func (entry *WALEntry) Timestamp() time.Time {
+	return time.Unix(0, int64(entry.Time))
+}
+
+// Message decodes the message of a message entry.
+func (entry *WALEntry) Message() (interface{}, error) {
+	var err error
+	switch entry.Type {
+	case WALEntryVote:
+		vote := core.Vote{}
+		err = rlp.DecodeBytes(entry.Data, &vote)
+		return vote, err
+	case WALEntryBlock:
+		block := &core.Block{}
+		err = rlp.DecodeBytes(entry.Data, block)
+		return block, err
+	case WALEntryGuardianVote:
+		vote := &core.AggregatedVotes{}
+		err = rlp.DecodeBytes(entry.Data, vote)
+		return vote, err
+	case WALEntryEENVote:
+		vote := &core.EENVote{}
+		err = rlp.DecodeBytes(entry.Data, vote)
+		return vote, err
+	case WALEntryAggregatedEENVotes:
+		vote := &core.AggregatedEENVotes{}
+		err = rlp.DecodeBytes(entry.Data, vote)
+		return vote, err
+	default:
+		return nil, fmt.Errorf("WAL entry of type %v has no message", entry.Type)
+	}
+}
+
+// WALStart is the data of the start entry.
+type WALStart struct {
+	Epoch              uint64
+	HighestCCBlock     common.Hash
+	LastFinalizedBlock common.Hash
+}
+
+// WALPath returns the path of the consensus WAL of the node with the given data path.
+func WALPath(dataPath string) string {
+	return path.Join(dataPath, "wal", "consensus")
+}
+
+// WAL records the inbound messages and the timer events of the consensus engine,
+// so that a stalled epoch can be diagnosed by replaying them.
+type WAL struct {
+	mu     *sync.Mutex
+	file   *os.File
+	writer *bufio.Writer
+}
+
+// OpenWAL creates a new WAL file at the given path. The WAL of the previous run is kept
+// with the ".1" suffix, the older ones are discarded.
+func OpenWAL(filePath string) (*WAL, error) {
+	if err := os.MkdirAll(path.Dir(filePath), 0700); err != nil {
+		return nil, err
+	}
+	if _, err := os.Stat(filePath); err == nil {
+		if err := os.Rename(filePath, filePath+".1"); err != nil {
+			return nil, err
+		}
+	}
+	file, err := os.OpenFile(filePath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &WAL{
+		mu:     &sync.Mutex{},
+		file:   file,
+		writer: bufio.NewWriter(file),
+	}, nil
+}
+
+// Write appends an entry to the WAL. The entry is flushed to the file right away, so
+// that it survives a crash of the node.
+func (w *WAL) Write(entryType WALEntryType, data common.Bytes, now time.Time) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	entry := &WALEntry{
+		Time: uint64(now.UnixNano()),
+		Type: entryType,
+		Data: data,
+	}
+	if err := rlp.Encode(w.writer, entry); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// WriteMessage appends an inbound message of the engine to the WAL.
+func (w *WAL) WriteMessage(msg interface{}, now time.Time) error {
+	var entryType WALEntryType
+	switch msg.(type) {
+	case core.Vote:
+		entryType = WALEntryVote
+	case *core.Block:
+		entryType = WALEntryBlock
+	case *core.AggregatedVotes:
+		entryType = WALEntryGuardianVote
+	case *core.EENVote:
+		entryType = WALEntryEENVote
+	case *core.AggregatedEENVotes:
+		entryType = WALEntryAggregatedEENVotes
+	default:
+		return fmt.Errorf("unknown message type: %T", msg)
+	}
+	data, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return err
+	}
+	return w.Write(entryType, data, now)
+}
+
+// Close flushes and closes the WAL file.
+func (w *WAL) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if err := w.writer.Flush(); err != nil {
+		w.file.Close()
+		return err
+	}
+	return w.file.Close()
+}
+
+// WALReader reads the entries of a WAL file in order.
+type WALReader struct {
+	file   *os.File
+	stream *rlp.Stream
+}
+
+// NewWALReader opens the WAL file at the given path for reading.
+func NewWALReader(filePath string) (*WALReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return &WALReader{
+		file:   file,
+		stream: rlp.NewStream(bufio.NewReader(file), 0),
+	}, nil
+}
+
+// Next returns the next entry, or io.EOF at the end of the WAL. The last entry might be
+// cut short if the node crashed while writing it, which is reported as
+// io.ErrUnexpectedEOF.
+func (r *WALReader) Next() (*WALEntry, error) {
+	entry := &WALEntry{}
+	if err := r.stream.Decode(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// Close closes the WAL file.
+func (r *WALReader) Close() error {
+	return r.file.Close()
+}
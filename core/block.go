@@ -146,7 +146,8 @@ func (h *BlockHeader) EncodeRLP(w io.Writer) error {
 	if h == nil {
 		return rlp.Encode(w, &BlockHeader{})
 	}
-	if h.Height < common.HeightEnableTheta2 {
+	forks := common.GetForkSchedule(h.ChainID)
+	if h.Height < forks.EnableTheta2 {
 		return rlp.Encode(w, []interface{}{
 			h.ChainID,
 			h.Epoch,
@@ -164,7 +165,7 @@ func (h *BlockHeader) EncodeRLP(w io.Writer) error {
 	}
 
 	// Theta2.0 fork
-	if h.Height >= common.HeightEnableTheta2 && h.Height < common.HeightEnableTheta3 {
+	if h.Height >= forks.EnableTheta2 && h.Height < forks.EnableTheta3 {
 		return rlp.Encode(w, []interface{}{
 			h.ChainID,
 			h.Epoch,
@@ -270,8 +271,10 @@ func (h *BlockHeader) DecodeRLP(stream *rlp.Stream) error {
 		return err
 	}
 
+	forks := common.GetForkSchedule(h.ChainID)
+
 	// Theta2.0 fork
-	if h.Height >= common.HeightEnableTheta2 {
+	if h.Height >= forks.EnableTheta2 {
 		raw, err := stream.Raw()
 		if err != nil {
 			return err
@@ -290,7 +293,7 @@ func (h *BlockHeader) DecodeRLP(stream *rlp.Stream) error {
 	}
 
 	// Theta3.0 fork
-	if h.Height >= common.HeightEnableTheta3 {
+	if h.Height >= forks.EnableTheta3 {
 		raw, err := stream.Raw()
 		if err != nil {
 			return err
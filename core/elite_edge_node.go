@@ -292,6 +292,15 @@ func (een *EliteEdgeNode) ReturnStake(source common.Address, currentHeight uint6
 	return een.StakeHolder.returnStake(source, currentHeight)
 }
 
+func (een *EliteEdgeNode) WithdrawPartialStake(source common.Address, amount *big.Int, currentHeight uint64) (*Stake, error) {
+	return een.StakeHolder.withdrawPartialStake(source, amount, currentHeight)
+}
+
+// TakeStake removes the given amount from the stake of the source to redelegate it.
+func (een *EliteEdgeNode) TakeStake(source common.Address, amount *big.Int) error {
+	return een.StakeHolder.takeStake(source, amount)
+}
+
 //
 // ------- EliteEdgeNodePool ------- //
 //
@@ -304,5 +313,7 @@ type EliteEdgeNodePool interface {
 	GetAll(withstake bool) []*EliteEdgeNode
 	DepositStake(source common.Address, holder common.Address, amount *big.Int, pubkey *bls.PublicKey, blockHeight uint64) (err error)
 	WithdrawStake(source common.Address, holder common.Address, currentHeight uint64) (*Stake, error)
+	WithdrawPartialStake(source common.Address, holder common.Address, amount *big.Int, currentHeight uint64) (*Stake, error)
+	RedelegateStake(source common.Address, fromHolder common.Address, toHolder common.Address, amount *big.Int, chainID string, blockHeight uint64) error
 	RandomRewardWeight(block common.Hash, eenAddr common.Address) int
 }
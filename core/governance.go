@@ -0,0 +1,146 @@
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+)
+
+//
+// ------- Governance Parameters ------- //
+//
+
+// The protocol parameters that can be changed by the on-chain governance. Until a proposal
+// to set a parameter passes, the parameter keeps the value defined by the protocol for the
+// block height. The transaction fees are not credited to any account, they are burned in full,
+// so the fee burn rules under governance are the minimum fee and the minimum gas price, which
+// set the least amount burned by each transaction. The burned share itself is not governed.
+const (
+	ParamMaxValidatorCount     = "max_validator_count"       // maximum number of validators
+	ParamMinValidatorStake     = "min_validator_stake"       // minimum validator stake deposit in ThetaWei
+	ParamMinGuardianStake      = "min_guardian_stake"        // minimum guardian stake deposit in ThetaWei
+	ParamMinEliteEdgeNodeStake = "min_elite_edge_node_stake" // minimum elite edge node stake deposit in TFuelWei
+	ParamMaxEliteEdgeNodeStake = "max_elite_edge_node_stake" // maximum stake of an elite edge node in TFuelWei
+	ParamMaxTxGasLimit         = "max_tx_gas_limit"          // maximum gas limit of a smart contract transaction
+	ParamMinGasPrice           = "min_gas_price"             // minimum gas price in TFuelWei, the fee is burned
+	ParamMinTxFee              = "min_tx_fee"                // minimum fee of a regular transaction in TFuelWei, the fee is burned
+)
+
+// GovernanceParams lists the parameters that can be changed by the on-chain governance.
+var GovernanceParams = []string{
+	ParamMaxValidatorCount,
+	ParamMinValidatorStake,
+	ParamMinGuardianStake,
+	ParamMinEliteEdgeNodeStake,
+	ParamMaxEliteEdgeNodeStake,
+	ParamMaxTxGasLimit,
+	ParamMinGasPrice,
+	ParamMinTxFee,
+}
+
+const (
+	// MaxGovernedValidatorCount is the upper bound of the maximum number of validators
+	MaxGovernedValidatorCount uint64 = 1000
+
+	// MinGovernanceVotingPeriod is the minimum voting period of a proposal, in number of blocks
+	MinGovernanceVotingPeriod uint64 = uint64(common.CheckpointInterval)
+
+	// MaxGovernanceVotingPeriod is the maximum voting period of a proposal, in number of blocks
+	MaxGovernanceVotingPeriod uint64 = 201600 // approximately 2 weeks with 6 second block time
+
+	// MaxGovernedTxGasLimit is the upper bound of the maximum gas limit of a smart contract transaction
+	MaxGovernedTxGasLimit uint64 = 100000000
+)
+
+// IsGovernanceParam returns whether the parameter can be changed by the on-chain governance.
+func IsGovernanceParam(param string) bool {
+	for _, p := range GovernanceParams {
+		if p == param {
+			return true
+		}
+	}
+	return false
+}
+
+//
+// ------- Governance Proposal ------- //
+//
+
+type ProposalStatus uint8
+
+const (
+	ProposalStatusVoting ProposalStatus = iota
+	ProposalStatusPassed
+	ProposalStatusRejected
+)
+
+func (s ProposalStatus) String() string {
+	switch s {
+	case ProposalStatusVoting:
+		return "voting"
+	case ProposalStatusPassed:
+		return "passed"
+	case ProposalStatusRejected:
+		return "rejected"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+// GovernanceVote is the vote of a validator or guardian on a proposal. The vote is weighted
+// by the stake held by the voter when the proposal is tallied.
+type GovernanceVote struct {
+	Voter   common.Address
+	Approve bool
+}
+
+// GovernanceProposal is a proposal to set a protocol parameter to a new value. The votes are
+// tallied at the first checkpoint at or after VotingEndHeight. The proposal passes if the
+// voters approving it hold more than 2/3 of the total stake of the validator candidates and
+// guardians, and the new value takes effect from the next block.
+type GovernanceProposal struct {
+	ID              uint64
+	Proposer        common.Address
+	Param           string
+	Value           *big.Int
+	SubmitHeight    uint64
+	VotingEndHeight uint64
+	Status          ProposalStatus
+	Votes           []*GovernanceVote
+	ApprovingStake  *big.Int // stake of the approving voters, set by the tally
+	TotalStake      *big.Int // total stake of the validator candidates and guardians, set by the tally
+}
+
+// NewGovernanceProposal creates a proposal open for voting.
+func NewGovernanceProposal(id uint64, proposer common.Address, param string, value *big.Int,
+	submitHeight uint64, votingEndHeight uint64) *GovernanceProposal {
+	return &GovernanceProposal{
+		ID:              id,
+		Proposer:        proposer,
+		Param:           param,
+		Value:           value,
+		SubmitHeight:    submitHeight,
+		VotingEndHeight: votingEndHeight,
+		Status:          ProposalStatusVoting,
+		Votes:           []*GovernanceVote{},
+		ApprovingStake:  big.NewInt(0),
+		TotalStake:      big.NewInt(0),
+	}
+}
+
+// Vote records the vote of the voter, replacing its previous vote if any.
+func (p *GovernanceProposal) Vote(voter common.Address, approve bool) {
+	for _, v := range p.Votes {
+		if v.Voter == voter {
+			v.Approve = approve
+			return
+		}
+	}
+	p.Votes = append(p.Votes, &GovernanceVote{Voter: voter, Approve: approve})
+}
+
+func (p *GovernanceProposal) String() string {
+	return fmt.Sprintf("GovernanceProposal{ID: %v, Proposer: %v, Param: %v, Value: %v, VotingEndHeight: %v, Status: %v}",
+		p.ID, p.Proposer, p.Param, p.Value, p.VotingEndHeight, p.Status)
+}
@@ -297,9 +297,9 @@ func (gcp *GuardianCandidatePool) Hash() common.Hash {
 	return crypto.Keccak256Hash(raw)
 }
 
-func (gcp *GuardianCandidatePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, pubkey *bls.PublicKey, blockHeight uint64) (err error) {
+func (gcp *GuardianCandidatePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, pubkey *bls.PublicKey, chainID string, blockHeight uint64) (err error) {
 	minGuardianStake := MinGuardianStakeDeposit
-	if blockHeight >= common.HeightLowerGNStakeThresholdTo1000 {
+	if blockHeight >= common.GetForkSchedule(chainID).LowerGNStakeThresholdTo1000 {
 		minGuardianStake = MinGuardianStakeDeposit1000
 	}
 	if amount.Cmp(minGuardianStake) < 0 {
@@ -347,6 +347,45 @@ func (gcp *GuardianCandidatePool) WithdrawStake(source common.Address, holder co
 	return nil
 }
 
+// WithdrawPartialStake withdraws the given amount of the stake of the source, the rest remains
+// staked. The withdrawn amount is returned after the locking period.
+func (gcp *GuardianCandidatePool) WithdrawPartialStake(source common.Address, holder common.Address, amount *big.Int, currentHeight uint64) error {
+	g := gcp.GetWithHolderAddress(holder)
+	if g == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", holder)
+	}
+
+	_, err := g.withdrawPartialStake(source, amount, currentHeight)
+	return err
+}
+
+// RedelegateStake moves the given amount of the stake of the source from one guardian to
+// another, without going through the return locking period. The target guardian must be in
+// the pool already, since a guardian joins the pool with its BLS key through a deposit.
+func (gcp *GuardianCandidatePool) RedelegateStake(source common.Address, fromHolder common.Address, toHolder common.Address, amount *big.Int, chainID string, blockHeight uint64) error {
+	if fromHolder == toHolder {
+		return fmt.Errorf("Cannot redelegate stake to the same holder: %v", toHolder)
+	}
+	from := gcp.GetWithHolderAddress(fromHolder)
+	if from == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", fromHolder)
+	}
+	to := gcp.GetWithHolderAddress(toHolder)
+	if to == nil {
+		return fmt.Errorf("Guardian %v not found, the stake can only be redelegated to an existing guardian", toHolder)
+	}
+
+	err := from.takeStake(source, amount)
+	if err != nil {
+		return err
+	}
+	if len(from.Stakes) == 0 {
+		gcp.Remove(fromHolder)
+	}
+
+	return gcp.DepositStake(source, toHolder, amount, to.Pubkey, chainID, blockHeight)
+}
+
 func (gcp *GuardianCandidatePool) ReturnStakes(currentHeight uint64) []*Stake {
 	returnedStakes := []*Stake{}
 
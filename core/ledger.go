@@ -31,6 +31,8 @@ type Ledger interface {
 	GetCurrentBlock() *Block
 	ScreenTxUnsafe(rawTx common.Bytes) result.Result
 	ScreenTx(rawTx common.Bytes) (priority *TxInfo, res result.Result)
+	ScreenTxReplacement(rawTx common.Bytes, precedingRawTxs []common.Bytes) (priority *TxInfo, res result.Result)
+	GetTxInfo(rawTx common.Bytes) (priority *TxInfo, res result.Result)
 	ProposeBlockTxs(block *Block, shouldIncludeValidatorUpdateTxs bool) (stateRootHash common.Hash, blockRawTxs []common.Bytes, res result.Result)
 	ApplyBlockTxs(block *Block) result.Result
 	ApplyBlockTxsForChainCorrection(block *Block) (common.Hash, result.Result)
@@ -121,15 +121,12 @@ func (sh *StakeHolder) depositStake(source common.Address, amount *big.Int) erro
 		return fmt.Errorf("Invalid stake: %v", amount)
 	}
 
-	for _, stake := range sh.Stakes {
-		if stake.Source == source {
-			if stake.Withdrawn {
-				return fmt.Errorf("Cannot deposit during the withdrawal locking period for: %v", source)
-			}
-
-			stake.Amount = new(big.Int).Add(stake.Amount, amount)
-			return nil
-		}
+	if stake := sh.activeStake(source); stake != nil {
+		stake.Amount = new(big.Int).Add(stake.Amount, amount)
+		return nil
+	}
+	if sh.hasStake(source) {
+		return fmt.Errorf("Cannot deposit during the withdrawal locking period for: %v", source)
 	}
 
 	newStake := NewStake(source, amount)
@@ -139,36 +136,112 @@ func (sh *StakeHolder) depositStake(source common.Address, amount *big.Int) erro
 }
 
 func (sh *StakeHolder) withdrawStake(source common.Address, currentHeight uint64) (*Stake, error) {
-	for _, stake := range sh.Stakes {
-		if stake.Source == source {
-			if stake.Withdrawn {
-				return nil, fmt.Errorf("Already withdrawn, cannot withdraw again for source: %v", source)
+	if stake := sh.activeStake(source); stake != nil {
+		stake.Withdrawn = true
+		stake.ReturnHeight = currentHeight + ReturnLockingPeriod
+		return stake, nil
+	}
+	if sh.hasStake(source) {
+		return nil, fmt.Errorf("Already withdrawn, cannot withdraw again for source: %v", source)
+	}
+
+	return nil, fmt.Errorf("Cannot withdraw, no matched stake source address found: %v", source)
+}
+
+// withdrawPartialStake withdraws the given amount from the stake of the source. The withdrawn
+// amount is split off into a stake of its own, which is returned after the locking period like
+// a full withdrawal, while the rest of the stake remains in place.
+func (sh *StakeHolder) withdrawPartialStake(source common.Address, amount *big.Int, currentHeight uint64) (*Stake, error) {
+	stake, err := sh.checkPartialStake(source, amount)
+	if err != nil {
+		return nil, err
+	}
+	if stake.Amount.Cmp(amount) == 0 {
+		return sh.withdrawStake(source, currentHeight)
+	}
+
+	stake.Amount = new(big.Int).Sub(stake.Amount, amount)
+	withdrawnStake := &Stake{
+		Holder:       stake.Holder,
+		Source:       source,
+		Amount:       new(big.Int).Set(amount),
+		Withdrawn:    true,
+		ReturnHeight: currentHeight + ReturnLockingPeriod,
+	}
+	sh.Stakes = append(sh.Stakes, withdrawnStake)
+	return withdrawnStake, nil
+}
+
+// takeStake removes the given amount from the stake of the source without locking it, so
+// that it can be deposited to another holder right away. The stake is removed if nothing is
+// left of it.
+func (sh *StakeHolder) takeStake(source common.Address, amount *big.Int) error {
+	stake, err := sh.checkPartialStake(source, amount)
+	if err != nil {
+		return err
+	}
+
+	stake.Amount = new(big.Int).Sub(stake.Amount, amount)
+	if stake.Amount.Cmp(Zero) == 0 {
+		for idx := range sh.Stakes {
+			if sh.Stakes[idx] == stake {
+				sh.Stakes = append(sh.Stakes[:idx], sh.Stakes[idx+1:]...)
+				break
 			}
-			stake.Withdrawn = true
-			stake.ReturnHeight = currentHeight + ReturnLockingPeriod
-			return stake, nil
 		}
 	}
+	return nil
+}
 
-	return nil, fmt.Errorf("Cannot withdraw, no matched stake source address found: %v", source)
+func (sh *StakeHolder) checkPartialStake(source common.Address, amount *big.Int) (*Stake, error) {
+	if amount.Cmp(Zero) <= 0 {
+		return nil, fmt.Errorf("Invalid amount: %v", amount)
+	}
+	stake := sh.activeStake(source)
+	if stake == nil {
+		return nil, fmt.Errorf("No stake that is not withdrawn found for source: %v", source)
+	}
+	if stake.Amount.Cmp(amount) < 0 {
+		return nil, fmt.Errorf("Insufficient stake, source %v has %v staked, requested: %v", source, stake.Amount, amount)
+	}
+	return stake, nil
 }
 
+// returnStake removes a withdrawn stake of the source whose return height has been reached.
+// A source can have several withdrawn stakes after partial withdrawals, the last one is
+// returned first.
 func (sh *StakeHolder) returnStake(source common.Address, currentHeight uint64) (*Stake, error) {
-	for idx, stake := range sh.Stakes {
-		if stake.Source == source {
-			if !stake.Withdrawn {
-				return nil, fmt.Errorf("Cannot return, stake not withdrawn yet")
-			}
-			if stake.ReturnHeight > currentHeight {
-				return nil, fmt.Errorf("Cannot return, current height: %v, return height: %v",
-					currentHeight, stake.ReturnHeight)
-			}
+	for idx := len(sh.Stakes) - 1; idx >= 0; idx-- {
+		stake := sh.Stakes[idx]
+		if stake.Source == source && stake.Withdrawn && stake.ReturnHeight <= currentHeight {
 			sh.Stakes = append(sh.Stakes[:idx], sh.Stakes[idx+1:]...)
 			return stake, nil
 		}
 	}
 
-	return nil, fmt.Errorf("Cannot return, no matched stake source address found: %v", source)
+	if !sh.hasStake(source) {
+		return nil, fmt.Errorf("Cannot return, no matched stake source address found: %v", source)
+	}
+	return nil, fmt.Errorf("Cannot return, no withdrawn stake of %v due at height %v", source, currentHeight)
+}
+
+// activeStake returns the stake of the source that is not withdrawn, nil if there is none.
+func (sh *StakeHolder) activeStake(source common.Address) *Stake {
+	for _, stake := range sh.Stakes {
+		if stake.Source == source && !stake.Withdrawn {
+			return stake
+		}
+	}
+	return nil
+}
+
+func (sh *StakeHolder) hasStake(source common.Address) bool {
+	for _, stake := range sh.Stakes {
+		if stake.Source == source {
+			return true
+		}
+	}
+	return false
 }
 
 func (sh *StakeHolder) String() string {
@@ -183,6 +183,25 @@ func init() {
 
 type ValidatorCandidatePool struct {
 	SortedCandidates []*StakeHolder
+
+	// MaxValidators holds the maximum number of validators once it is set by the on-chain
+	// governance, and is empty before, which keeps the encoding of the existing pools unchanged.
+	// It is kept with the pool so that the validator set can be derived from the pool alone.
+	MaxValidators []uint64 `rlp:"tail" json:",omitempty"`
+}
+
+// GetMaxValidatorCount returns the maximum number of validators set by the on-chain
+// governance, or false if it has not been set.
+func (vcp *ValidatorCandidatePool) GetMaxValidatorCount() (int, bool) {
+	if len(vcp.MaxValidators) == 0 {
+		return 0, false
+	}
+	return int(vcp.MaxValidators[0]), true
+}
+
+// SetMaxValidatorCount sets the maximum number of validators.
+func (vcp *ValidatorCandidatePool) SetMaxValidatorCount(count uint64) {
+	vcp.MaxValidators = []uint64{count}
 }
 
 func (vcp *ValidatorCandidatePool) FindStakeDelegate(delegateAddr common.Address) *StakeHolder {
@@ -202,9 +221,9 @@ func (vcp *ValidatorCandidatePool) GetTopStakeHolders(maxNumStakeHolders int) []
 	return vcp.SortedCandidates[:n]
 }
 
-func (vcp *ValidatorCandidatePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, blockHeight uint64) (err error) {
+func (vcp *ValidatorCandidatePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, chainID string, blockHeight uint64) (err error) {
 	minValidatorStake := MinValidatorStakeDeposit
-	if blockHeight >= common.HeightValidatorStakeChangedTo200K {
+	if blockHeight >= common.GetForkSchedule(chainID).ValidatorStakeChangedTo200K {
 		minValidatorStake = MinValidatorStakeDeposit200K
 	}
 	if amount.Cmp(minValidatorStake) < 0 {
@@ -255,6 +274,51 @@ func (vcp *ValidatorCandidatePool) WithdrawStake(source common.Address, holder c
 	return nil
 }
 
+// WithdrawPartialStake withdraws the given amount of the stake of the source, the rest remains
+// staked. The withdrawn amount is returned after the locking period.
+func (vcp *ValidatorCandidatePool) WithdrawPartialStake(source common.Address, holder common.Address, amount *big.Int, currentHeight uint64) error {
+	candidate := vcp.FindStakeDelegate(holder)
+	if candidate == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", holder)
+	}
+
+	_, err := candidate.withdrawPartialStake(source, amount, currentHeight)
+	if err != nil {
+		return err
+	}
+
+	vcp.sortCandidates()
+
+	return nil
+}
+
+// RedelegateStake moves the given amount of the stake of the source from one holder to
+// another, without going through the return locking period.
+func (vcp *ValidatorCandidatePool) RedelegateStake(source common.Address, fromHolder common.Address, toHolder common.Address, amount *big.Int, chainID string, blockHeight uint64) error {
+	if fromHolder == toHolder {
+		return fmt.Errorf("Cannot redelegate stake to the same holder: %v", toHolder)
+	}
+	candidate := vcp.FindStakeDelegate(fromHolder)
+	if candidate == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", fromHolder)
+	}
+
+	err := candidate.takeStake(source, amount)
+	if err != nil {
+		return err
+	}
+	if len(candidate.Stakes) == 0 {
+		for cidx := range vcp.SortedCandidates {
+			if vcp.SortedCandidates[cidx] == candidate {
+				vcp.SortedCandidates = append(vcp.SortedCandidates[:cidx], vcp.SortedCandidates[cidx+1:]...)
+				break
+			}
+		}
+	}
+
+	return vcp.DepositStake(source, toHolder, amount, chainID, blockHeight)
+}
+
 func (vcp *ValidatorCandidatePool) ReturnStakes(currentHeight uint64) []*Stake {
 	returnedStakes := []*Stake{}
 
@@ -48,13 +48,13 @@ func (dp *Dispatcher) Start(ctx context.Context) error {
 	dp.cancel = cancel
 	var err error
 
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		err = dp.p2pnet.Start(c)
 		if err != nil {
 			return err
 		}
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		err = dp.p2plnet.Start(c)
 	}
 	return err
@@ -67,10 +67,10 @@ func (dp *Dispatcher) Stop() {
 
 // Wait suspends the caller goroutine
 func (dp *Dispatcher) Wait() {
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		dp.p2pnet.Wait()
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		dp.p2plnet.Wait()
 	}
 	dp.wg.Wait()
@@ -126,10 +126,10 @@ func (dp *Dispatcher) SendData(peerIDs []string, datarsp DataResponse) {
 
 // ID returns the ID of the node
 func (dp Dispatcher) ID() string {
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		return dp.p2pnet.ID()
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		return dp.p2plnet.ID()
 	}
 	return ""
@@ -138,10 +138,10 @@ func (dp Dispatcher) ID() string {
 // TODO: for 1.3.0 upgrade only, delete it after the upgrade completed
 // ID returns the ID of the node
 func (dp Dispatcher) LibP2PID() string {
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		return dp.p2plnet.ID()
 	}
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		return dp.p2pnet.ID()
 	}
 	return ""
@@ -149,10 +149,10 @@ func (dp Dispatcher) LibP2PID() string {
 
 // Peers returns the IDs of all peers
 func (dp *Dispatcher) Peers(skipEdgeNode bool) []string {
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		return dp.p2pnet.Peers(skipEdgeNode)
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		return dp.p2plnet.Peers(skipEdgeNode)
 	}
 	return []string{}
@@ -160,10 +160,10 @@ func (dp *Dispatcher) Peers(skipEdgeNode bool) []string {
 
 // Peers returns the IDs of all peers
 func (dp *Dispatcher) PeerURLs(skipEdgeNode bool) []string {
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		return dp.p2pnet.PeerURLs(skipEdgeNode)
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		return dp.p2plnet.PeerURLs(skipEdgeNode)
 	}
 	return []string{}
@@ -171,10 +171,10 @@ func (dp *Dispatcher) PeerURLs(skipEdgeNode bool) []string {
 
 // PeerExists indicates if the given peerID is a neighboring peer
 func (dp *Dispatcher) PeerExists(peerID string) bool {
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		return dp.p2pnet.PeerExists(peerID)
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		return dp.p2plnet.PeerExists(peerID)
 	}
 	return false
@@ -193,13 +193,13 @@ func (dp *Dispatcher) send(peerIDs []string, channelID common.ChannelIDEnum, con
 
 	for _, peerID := range peerIDs {
 		go func(peerID string) {
-			if !reflect.ValueOf(dp.p2pnet).IsNil() {
+			if !isNil(dp.p2pnet) {
 				ok := dp.p2pnet.Send(peerID, messageOld)
 				if !ok {
 					logger.Debugf("Failed to send message to [%v]: %v, %v", peerID, channelID, content)
 				}
 			}
-			if !reflect.ValueOf(dp.p2plnet).IsNil() {
+			if !isNil(dp.p2plnet) {
 				dp.p2plnet.Send(peerID, message)
 			}
 		}(peerID)
@@ -217,10 +217,10 @@ func (dp *Dispatcher) broadcastToAll(channelID common.ChannelIDEnum, content int
 		ChannelID: channelID,
 		Content:   content,
 	}
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		dp.p2pnet.Broadcast(messageOld, skipEdgeNode)
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		dp.p2plnet.Broadcast(message, skipEdgeNode)
 	}
 }
@@ -236,11 +236,16 @@ func (dp *Dispatcher) broadcastToNeighbors(channelID common.ChannelIDEnum, conte
 		Content:   content,
 	}
 	maxNumPeersToBroadcast := viper.GetInt(common.CfgP2PMaxNumPeersToBroadcast)
-	if !reflect.ValueOf(dp.p2pnet).IsNil() {
+	if !isNil(dp.p2pnet) {
 		//dp.p2pnet.Broadcast(messageOld)
 		dp.p2pnet.BroadcastToNeighbors(messageOld, maxNumPeersToBroadcast, skipEdgeNode)
 	}
-	if !reflect.ValueOf(dp.p2plnet).IsNil() {
+	if !isNil(dp.p2plnet) {
 		dp.p2plnet.BroadcastToNeighbors(message, maxNumPeersToBroadcast, skipEdgeNode)
 	}
 }
+
+// isNil returns whether the network is not set, either as a nil interface or a nil pointer.
+func isNil(network interface{}) bool {
+	return network == nil || reflect.ValueOf(network).IsNil()
+}
@@ -0,0 +1,473 @@
+// Package harness runs a network of full nodes in a single process for tests. The
+// nodes talk through a simulated network and share a simulated clock, so that the
+// tests decide when the epochs and block intervals elapse.
+package harness
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/timer"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/node"
+	"github.com/thetatoken/theta/p2p/simulation"
+	msgl "github.com/thetatoken/theta/p2pl/messenger"
+	"github.com/thetatoken/theta/snapshot"
+	"github.com/thetatoken/theta/store/database"
+	"github.com/thetatoken/theta/store/database/backend"
+	"github.com/thetatoken/theta/store/rollingdb"
+)
+
+const (
+	// clockStep is how far the simulated clock moves at a time
+	clockStep = 100 * time.Millisecond
+	// settleTime is the wall clock time given to the nodes to react after each clock step
+	settleTime = 5 * time.Millisecond
+)
+
+// Spec describes the genesis state and the consensus settings of a test network.
+type Spec struct {
+	ChainID          string
+	NumValidators    int
+	ValidatorStake   *big.Int                       // Stake of each validator, at least core.MinValidatorStakeDeposit
+	ValidatorBalance types.Coins                    // Liquid balance of each validator
+	Accounts         map[common.Address]types.Coins // Additional prefunded accounts
+	Forks            *common.ForkSchedule           // Defaults to common.DevForkSchedule
+	MinBlockInterval int                            // In seconds of the simulated clock
+	MaxEpochLength   int                            // In seconds of the simulated clock
+}
+
+// DefaultSpec returns the spec of a network with the given number of validators.
+func DefaultSpec(numValidators int) Spec {
+	ten18 := new(big.Int).SetUint64(1000000000000000000)
+	balance := new(big.Int).Mul(new(big.Int).SetUint64(1000000), ten18)
+	return Spec{
+		ChainID:          "harness",
+		NumValidators:    numValidators,
+		ValidatorStake:   new(big.Int).Set(core.MinValidatorStakeDeposit),
+		ValidatorBalance: types.Coins{ThetaWei: balance, TFuelWei: new(big.Int).Set(balance)},
+		Accounts:         make(map[common.Address]types.Coins),
+		Forks:            common.DevForkSchedule,
+		MinBlockInterval: 1,
+		MaxEpochLength:   5,
+	}
+}
+
+// Network is a set of nodes connected through a simulated network.
+type Network struct {
+	Spec   Spec
+	Clock  *timer.SimulatedClock
+	Simnet *simulation.Simnet
+	Nodes  []*Node
+
+	dir     string
+	genesis *core.BlockHeader
+	ctx     context.Context
+	cancel  context.CancelFunc
+}
+
+// Node is a validator of the test network. Its database survives restarts.
+type Node struct {
+	*node.Node
+
+	Index      int
+	PrivateKey *crypto.PrivateKey
+
+	network  *Network
+	db       database.Database
+	endpoint *simulation.SimnetEndpoint
+	wal      *consensus.WAL
+	cancel   context.CancelFunc
+	running  bool
+}
+
+// NewNetwork writes the genesis snapshot for the spec and creates its nodes. The nodes
+// are not started. The consensus settings are process wide, so only one network should
+// be running at a time.
+func NewNetwork(spec Spec) (*Network, error) {
+	if spec.NumValidators <= 0 {
+		return nil, fmt.Errorf("the network needs at least one validator")
+	}
+	if spec.MaxEpochLength <= spec.MinBlockInterval {
+		return nil, fmt.Errorf("max epoch length must be larger than the min block interval")
+	}
+	forks := spec.Forks
+	if forks == nil {
+		forks = common.DevForkSchedule
+	}
+	// The block headers are encoded according to the fork schedule, register it first
+	common.RegisterForkSchedule(spec.ChainID, forks)
+
+	dir, err := ioutil.TempDir("", "theta-harness")
+	if err != nil {
+		return nil, err
+	}
+
+	nw := &Network{
+		Spec:   spec,
+		Simnet: simulation.NewSimnet(),
+		dir:    dir,
+	}
+
+	balances := make(map[common.Address]types.Coins)
+	for address, coins := range spec.Accounts {
+		balances[address] = coins.NoNil()
+	}
+	stakes := []snapshot.GenesisStake{}
+	for i := 0; i < spec.NumValidators; i++ {
+		privKey, err := validatorKey(i)
+		if err != nil {
+			nw.removeDir()
+			return nil, err
+		}
+		address := privKey.PublicKey().Address()
+		stake := types.Coins{ThetaWei: spec.ValidatorStake, TFuelWei: big.NewInt(0)}
+		balances[address] = spec.ValidatorBalance.NoNil().Plus(stake)
+		stakes = append(stakes, snapshot.GenesisStake{Source: address, Holder: address, Amount: spec.ValidatorStake})
+		nw.Nodes = append(nw.Nodes, &Node{Index: i, PrivateKey: privKey, network: nw})
+	}
+
+	sv, metadata, err := snapshot.GenerateGenesisSnapshot(spec.ChainID, balances, stakes)
+	if err != nil {
+		nw.removeDir()
+		return nil, err
+	}
+	if err := snapshot.WriteGenesisSnapshot(sv, metadata, nw.genesisPath()); err != nil {
+		nw.removeDir()
+		return nil, err
+	}
+	nw.genesis = metadata.TailTrio.Second.Header
+	nw.Clock = timer.NewSimulatedClock(time.Unix(nw.genesis.Timestamp.Int64(), 0))
+	nw.Simnet.SetClock(nw.Clock)
+
+	viper.Set(common.CfgGenesisChainID, spec.ChainID)
+	viper.Set(common.CfgGenesisHash, nw.genesis.Hash().Hex())
+	viper.Set(common.CfgConsensusMinBlockInterval, spec.MinBlockInterval)
+	viper.Set(common.CfgConsensusMaxEpochLength, spec.MaxEpochLength)
+	viper.Set(common.CfgP2POpt, common.P2POptOld)
+	viper.Set(common.CfgRPCEnabled, false)
+	viper.Set(common.CfgStorageRollingEnabled, false)
+
+	return nw, nil
+}
+
+// validatorKey derives the key of the i-th validator, so that the addresses are the
+// same across runs.
+func validatorKey(i int) (*crypto.PrivateKey, error) {
+	seed := crypto.Keccak256([]byte("theta harness validator " + strconv.Itoa(i)))
+	return crypto.PrivateKeyFromBytes(seed)
+}
+
+func (nw *Network) genesisPath() string {
+	return path.Join(nw.dir, "genesis")
+}
+
+func (nw *Network) removeDir() {
+	os.RemoveAll(nw.dir)
+}
+
+// Start starts the simulated network and all the nodes.
+func (nw *Network) Start() {
+	nw.ctx, nw.cancel = context.WithCancel(context.Background())
+	nw.Simnet.Start(nw.ctx)
+	for _, n := range nw.Nodes {
+		n.Start()
+	}
+}
+
+// Stop stops all the nodes and the simulated network, and removes the genesis snapshot.
+func (nw *Network) Stop() {
+	for _, n := range nw.Nodes {
+		n.Stop()
+	}
+	nw.cancel()
+	nw.removeDir()
+}
+
+// Advance moves the simulated clock forward by d, giving the nodes time to react
+// after every step.
+func (nw *Network) Advance(d time.Duration) {
+	for d > 0 {
+		step := clockStep
+		if d < step {
+			step = d
+		}
+		nw.Clock.Advance(step)
+		d -= step
+		time.Sleep(settleTime)
+	}
+}
+
+// WaitForHeight advances the simulated clock until all the running nodes have finalized
+// the block at the given height. It gives up after the wall clock timeout.
+func (nw *Network) WaitForHeight(height uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		reached := true
+		for _, n := range nw.Nodes {
+			if n.running && n.FinalizedHeight() < height {
+				reached = false
+				break
+			}
+		}
+		if reached {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for height %v, finalized heights: %v", height, nw.FinalizedHeights())
+		}
+		nw.Advance(clockStep)
+	}
+}
+
+// Partition splits the nodes, given by their indices, into groups that can not reach
+// each other until the partition with the given name is healed.
+func (nw *Network) Partition(name string, groups ...[]int) {
+	idGroups := [][]string{}
+	for _, group := range groups {
+		ids := []string{}
+		for _, i := range group {
+			ids = append(ids, nw.Nodes[i].id())
+		}
+		idGroups = append(idGroups, ids)
+	}
+	nw.Simnet.Partition(name, idGroups...)
+}
+
+// Heal removes the partition with the given name.
+func (nw *Network) Heal(name string) {
+	nw.Simnet.Heal(name)
+}
+
+// SetLink sets the faults of the messages sent from one node to another.
+func (nw *Network) SetLink(from, to int, config simulation.LinkConfig) {
+	nw.Simnet.SetLink(nw.Nodes[from].id(), nw.Nodes[to].id(), config)
+}
+
+// FinalizedHeights returns the last finalized height of each node.
+func (nw *Network) FinalizedHeights() []uint64 {
+	heights := []uint64{}
+	for _, n := range nw.Nodes {
+		heights = append(heights, n.FinalizedHeight())
+	}
+	return heights
+}
+
+// SubmitTx adds the signed transaction to the mempool of the given node, which gossips
+// it to the other nodes.
+func (nw *Network) SubmitTx(i int, tx types.Tx) error {
+	raw, err := types.TxToBytes(tx)
+	if err != nil {
+		return err
+	}
+	n := nw.Nodes[i]
+	if !n.running {
+		return fmt.Errorf("node %v is not running", i)
+	}
+	if err := n.Mempool.InsertTransaction(raw); err != nil {
+		return err
+	}
+	n.Mempool.BroadcastTx(raw)
+	return nil
+}
+
+// ReplayWAL replays the WAL recorded by the given node in a fresh engine that starts
+// from the genesis, so the WAL must have been recorded from the first start of the
+// node. It returns the node of the replaying engine, which is not connected to the
+// network.
+func (nw *Network) ReplayWAL(i int, step func(entry *consensus.WALEntry)) (*node.Node, error) {
+	n := nw.Nodes[i]
+	reader, err := consensus.NewWALReader(n.walPath())
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	simnet := simulation.NewSimnet()
+	endpoint := simnet.AddEndpoint(n.id())
+	simnet.Start(nw.ctx)
+
+	var network *msgl.Messenger
+	header := *nw.genesis
+	db := backend.NewMemDatabase()
+	replay := node.NewNode(&node.Params{
+		ChainID:      nw.Spec.ChainID,
+		PrivateKey:   n.PrivateKey,
+		Root:         &core.Block{BlockHeader: &header},
+		NetworkOld:   endpoint,
+		Network:      network,
+		DB:           db,
+		RollingDB:    rollingdb.NewRollingDB(path.Join(nw.dir, "replay-"+n.id()), db),
+		SnapshotPath: nw.genesisPath(),
+	})
+	if err := replay.Consensus.Replay(nw.ctx, reader, step); err != nil {
+		return nil, err
+	}
+	return replay, nil
+}
+
+// NewSendTx returns a signed transaction that sends TFuel from the key holder to the
+// given address, paying the minimum fee.
+func (nw *Network) NewSendTx(from *crypto.PrivateKey, to common.Address, tfuelWei *big.Int, sequence uint64) (types.Tx, error) {
+	fee := types.GetSendTxMinimumTransactionFeeTFuelWei(2, nw.Spec.ChainID, nw.Nodes[0].FinalizedHeight()+1)
+	address := from.PublicKey().Address()
+	tx := &types.SendTx{
+		Fee: types.Coins{ThetaWei: big.NewInt(0), TFuelWei: fee},
+		Inputs: []types.TxInput{{
+			Address:  address,
+			Coins:    types.Coins{ThetaWei: big.NewInt(0), TFuelWei: new(big.Int).Add(tfuelWei, fee)},
+			Sequence: sequence,
+		}},
+		Outputs: []types.TxOutput{{
+			Address: to,
+			Coins:   types.Coins{ThetaWei: big.NewInt(0), TFuelWei: tfuelWei},
+		}},
+	}
+	sig, err := from.Sign(tx.SignBytes(nw.Spec.ChainID))
+	if err != nil {
+		return nil, err
+	}
+	tx.SetSignature(address, sig)
+	return tx, nil
+}
+
+// Start starts the node, reusing its database if it ran before.
+func (n *Node) Start() {
+	if n.running {
+		return
+	}
+	nw := n.network
+	id := n.id()
+	dataDir := path.Join(nw.dir, id)
+	if n.db == nil {
+		n.db = backend.NewMemDatabase()
+	}
+	n.endpoint = nw.Simnet.AddEndpoint(id)
+
+	var network *msgl.Messenger
+	header := *nw.genesis
+	params := &node.Params{
+		ChainID:      nw.Spec.ChainID,
+		PrivateKey:   n.PrivateKey,
+		Root:         &core.Block{BlockHeader: &header},
+		NetworkOld:   n.endpoint,
+		Network:      network,
+		DB:           n.db,
+		RollingDB:    rollingdb.NewRollingDB(dataDir, n.db),
+		SnapshotPath: nw.genesisPath(),
+	}
+	n.Node = node.NewNode(params)
+	n.Consensus.SetClock(nw.Clock)
+	if n.wal != nil {
+		n.Consensus.SetWAL(n.wal)
+	}
+
+	var ctx context.Context
+	ctx, n.cancel = context.WithCancel(nw.ctx)
+	n.Node.Start(ctx)
+	n.running = true
+}
+
+// Stop stops the node and disconnects it from the network.
+func (n *Node) Stop() {
+	if !n.running {
+		return
+	}
+	n.network.Simnet.RemoveEndpoint(n.endpoint.ID())
+	n.Node.Stop()
+	n.cancel()
+	n.Node.Wait()
+	n.running = false
+	if n.wal != nil {
+		n.wal.Close()
+		n.wal = nil
+	}
+}
+
+// EnableWAL makes the node record a consensus WAL until it is stopped. It needs to be
+// called before the node starts.
+func (n *Node) EnableWAL() error {
+	wal, err := consensus.OpenWAL(n.walPath())
+	if err != nil {
+		return err
+	}
+	n.wal = wal
+	return nil
+}
+
+func (n *Node) walPath() string {
+	return consensus.WALPath(path.Join(n.network.dir, n.id()))
+}
+
+// id returns the ID of the node in the simulated network.
+func (n *Node) id() string {
+	return n.Address().Hex()
+}
+
+// Running returns whether the node is running.
+func (n *Node) Running() bool {
+	return n.running
+}
+
+// Address returns the address of the validator.
+func (n *Node) Address() common.Address {
+	return n.PrivateKey.PublicKey().Address()
+}
+
+// FinalizedHeight returns the height of the last finalized block of the node.
+func (n *Node) FinalizedHeight() uint64 {
+	if n.Node == nil {
+		return 0
+	}
+	return n.Consensus.GetLastFinalizedBlock().Height
+}
+
+// FinalizedBlock returns the block finalized by the node at the given height, or nil.
+func (n *Node) FinalizedBlock(height uint64) *core.ExtendedBlock {
+	if n.Node == nil {
+		return nil
+	}
+	for _, block := range n.Chain.FindBlocksByHeight(height) {
+		if block.Status.IsFinalized() {
+			return block
+		}
+	}
+	return nil
+}
+
+// Account returns the account in the finalized state of the node, or nil.
+func (n *Node) Account(address common.Address) *types.Account {
+	if n.Node == nil {
+		return nil
+	}
+	view, err := n.Ledger.(*ledger.Ledger).GetFinalizedSnapshot()
+	if err != nil {
+		return nil
+	}
+	return view.GetAccount(address)
+}
+
+// ValidatorCandidatePool returns the validator candidate pool in the finalized state of
+// the node, or nil.
+func (n *Node) ValidatorCandidatePool() *core.ValidatorCandidatePool {
+	if n.Node == nil {
+		return nil
+	}
+	view, err := n.Ledger.(*ledger.Ledger).GetFinalizedSnapshot()
+	if err != nil {
+		return nil
+	}
+	return view.GetValidatorCandidatePool()
+}
@@ -0,0 +1,270 @@
+package harness
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/blockchain"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/consensus"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/p2p/simulation"
+)
+
+func TestNetworkFinalizesBlocks(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	nw.Start()
+	defer nw.Stop()
+
+	require.Nil(nw.WaitForHeight(3, 60*time.Second))
+
+	// Submit a transfer and wait for it to be finalized everywhere
+	to := common.HexToAddress("0x2E833968E5bB786Ae419c4d13189fB081Cc43bab")
+	amount := big.NewInt(1000)
+	tx, err := nw.NewSendTx(nw.Nodes[0].PrivateKey, to, amount, 1)
+	require.Nil(err)
+	require.Nil(nw.SubmitTx(1, tx))
+
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		done := true
+		for _, n := range nw.Nodes {
+			if acc := n.Account(to); acc == nil || acc.Balance.TFuelWei.Cmp(amount) != 0 {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+		require.True(time.Now().Before(deadline), "transfer not finalized")
+		nw.Advance(time.Second)
+	}
+
+	// The network keeps going without one of the validators, and the validator
+	// catches up after restarting
+	nw.Nodes[3].Stop()
+	stoppedHeight := nw.Nodes[3].FinalizedHeight()
+	height := nw.Nodes[0].FinalizedHeight() + 2
+	require.Nil(nw.WaitForHeight(height, 60*time.Second))
+	assert.Equal(stoppedHeight, nw.Nodes[3].FinalizedHeight())
+
+	nw.Nodes[3].Start()
+	height += 2
+	require.Nil(nw.WaitForHeight(height, 60*time.Second))
+
+	for _, n := range nw.Nodes[1:] {
+		assert.Equal(nw.Nodes[0].FinalizedBlock(height).StateHash, n.FinalizedBlock(height).StateHash)
+	}
+}
+
+func TestNetworkRecoversFromPartition(t *testing.T) {
+	require := require.New(t)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	nw.Start()
+	defer nw.Stop()
+
+	require.Nil(nw.WaitForHeight(2, 60*time.Second))
+
+	// Neither half has two thirds of the stake, so no block can be finalized
+	nw.Partition("split", []int{0, 1}, []int{2, 3})
+	nw.Advance(2 * time.Second)
+	heights := nw.FinalizedHeights()
+	nw.Advance(30 * time.Second)
+	require.Equal(heights, nw.FinalizedHeights())
+
+	nw.Heal("split")
+	height := heights[0]
+	for _, h := range heights {
+		if h > height {
+			height = h
+		}
+	}
+	require.Nil(nw.WaitForHeight(height+2, 60*time.Second))
+}
+
+func TestNetworkMakesProgressOverLossyLinks(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	nw.Simnet.SetSeed(1)
+	nw.Simnet.SetDefaultLink(simulation.LinkConfig{
+		Latency:       100 * time.Millisecond,
+		Jitter:        200 * time.Millisecond,
+		DropRate:      0.05,
+		DuplicateRate: 0.05,
+		ReorderRate:   0.1,
+	})
+	nw.Start()
+	defer nw.Stop()
+
+	require.Nil(nw.WaitForHeight(5, 120*time.Second))
+	for _, n := range nw.Nodes[1:] {
+		assert.Equal(nw.Nodes[0].FinalizedBlock(5).Hash(), n.FinalizedBlock(5).Hash())
+	}
+}
+
+func TestConsensusReplay(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	require.Nil(nw.Nodes[0].EnableWAL())
+	nw.Start()
+	defer nw.Stop()
+
+	require.Nil(nw.WaitForHeight(5, 60*time.Second))
+	nw.Nodes[0].Stop()
+	recorded := nw.Nodes[0].FinalizedBlock(nw.Nodes[0].FinalizedHeight())
+
+	entries := 0
+	replay, err := nw.ReplayWAL(0, func(entry *consensus.WALEntry) { entries++ })
+	require.Nil(err)
+	assert.True(entries > 0)
+
+	// The replayed engine reaches the same decisions
+	lfb := replay.Consensus.GetLastFinalizedBlock()
+	assert.Equal(recorded.Height, lfb.Height)
+	assert.Equal(recorded.Hash(), lfb.Hash())
+	assert.Equal(recorded.StateHash, lfb.StateHash)
+}
+
+func TestStakeRedelegationAndPartialWithdrawal(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	nw.Start()
+	defer nw.Stop()
+
+	require.Nil(nw.WaitForHeight(2, 60*time.Second))
+
+	key := nw.Nodes[0].PrivateKey
+	source := key.PublicKey().Address()
+	from := nw.Nodes[0].Address()
+	to := nw.Nodes[1].Address()
+	theta := func(n int64) *big.Int { return new(big.Int).Mul(big.NewInt(n), big.NewInt(1e18)) }
+	fee := types.Coins{ThetaWei: big.NewInt(0), TFuelWei: types.GetMinimumTransactionFeeTFuelWei(nw.Spec.ChainID, 1)}
+
+	redelegateTx := &types.RedelegateStakeTx{
+		Fee:        fee,
+		Source:     types.TxInput{Address: source, Sequence: 1},
+		FromHolder: types.TxOutput{Address: from},
+		ToHolder:   types.TxOutput{Address: to},
+		Purpose:    core.StakeForValidator,
+		Amount:     types.Coins{ThetaWei: theta(500000), TFuelWei: big.NewInt(0)},
+	}
+	sig, err := key.Sign(redelegateTx.SignBytes(nw.Spec.ChainID))
+	require.Nil(err)
+	redelegateTx.SetSignature(source, sig)
+	require.Nil(nw.SubmitTx(0, redelegateTx))
+
+	withdrawTx := &types.WithdrawStakeTxV2{
+		Fee:     fee,
+		Source:  types.TxInput{Address: source, Sequence: 2},
+		Holder:  types.TxOutput{Address: from},
+		Purpose: core.StakeForValidator,
+		Amount:  types.Coins{ThetaWei: theta(300000), TFuelWei: big.NewInt(0)},
+	}
+	sig, err = key.Sign(withdrawTx.SignBytes(nw.Spec.ChainID))
+	require.Nil(err)
+	withdrawTx.SetSignature(source, sig)
+	require.Nil(nw.SubmitTx(0, withdrawTx))
+
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		done := true
+		for _, n := range nw.Nodes {
+			if acc := n.Account(source); acc == nil || acc.Sequence != 2 {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+		require.True(time.Now().Before(deadline), "transactions not finalized")
+		nw.Advance(time.Second)
+	}
+
+	// The stake moved to the other validator right away, the withdrawn part is locked
+	stake := nw.Spec.ValidatorStake
+	for _, n := range nw.Nodes {
+		vcp := n.ValidatorCandidatePool()
+		require.NotNil(vcp)
+		fromHolder := vcp.FindStakeDelegate(from)
+		require.NotNil(fromHolder)
+		assert.Equal(new(big.Int).Sub(stake, theta(800000)), fromHolder.TotalStake())
+		require.Equal(2, len(fromHolder.Stakes))
+		assert.True(fromHolder.Stakes[1].Withdrawn)
+		assert.Equal(theta(300000), fromHolder.Stakes[1].Amount)
+		toHolder := vcp.FindStakeDelegate(to)
+		require.NotNil(toHolder)
+		assert.Equal(new(big.Int).Add(stake, theta(500000)), toHolder.TotalStake())
+	}
+
+	// The network keeps finalizing blocks with the updated stakes
+	require.Nil(nw.WaitForHeight(nw.Nodes[0].FinalizedHeight()+2, 60*time.Second))
+}
+
+func TestRewardRecords(t *testing.T) {
+	require := require.New(t)
+	assert := assert.New(t)
+
+	viper.Set(common.CfgStorageRewardRecordsEnabled, true)
+	defer viper.Set(common.CfgStorageRewardRecordsEnabled, false)
+
+	nw, err := NewNetwork(DefaultSpec(4))
+	require.Nil(err)
+	nw.Start()
+	defer nw.Stop()
+
+	// Rewards are granted at the checkpoints
+	checkpoint := uint64(common.CheckpointInterval) + 1
+	require.True(common.IsCheckPointHeight(checkpoint))
+	require.Nil(nw.WaitForHeight(checkpoint+1, 120*time.Second))
+
+	// The validators have equal stakes, so they split the reward evenly
+	tfuelRewardPerBlock := new(big.Int).Mul(big.NewInt(48), big.NewInt(1e18))
+	totalReward := new(big.Int).Mul(tfuelRewardPerBlock, big.NewInt(common.CheckpointInterval))
+	expected := new(big.Int).Div(totalReward, big.NewInt(int64(len(nw.Nodes))))
+	for _, n := range nw.Nodes {
+		block := n.FinalizedBlock(checkpoint)
+		require.NotNil(block)
+		records, found := n.Chain.FindRewardRecords(block)
+		require.True(found)
+		require.Equal(len(nw.Nodes), len(records))
+
+		for _, v := range nw.Nodes {
+			var record *blockchain.RewardRecord
+			for _, r := range records {
+				if r.Staker == v.Address() {
+					record = r
+				}
+			}
+			require.NotNil(record)
+			assert.Equal(blockchain.RewardTypeBlock, record.Type)
+			assert.Equal(0, expected.Cmp(record.Amount))
+			assert.True(record.Beneficiary.IsEmpty())
+		}
+	}
+
+	// No rewards in between the checkpoints
+	block := nw.Nodes[0].FinalizedBlock(checkpoint + 1)
+	require.NotNil(block)
+	_, found := nw.Nodes[0].Chain.FindRewardRecords(block)
+	assert.False(found)
+}
@@ -40,6 +40,9 @@ type StakeDeposit struct {
 // pushd $THETA_HOME/integration/privatenet/node
 // generate_genesis -chainID=privatenet -erc20snapshot=./data/genesis_theta_erc20_snapshot.json -stake_deposit=./data/genesis_stake_deposit.json -genesis=./genesis
 //
+// For chains with guardians, elite edge nodes, contracts or a custom fork schedule at genesis,
+// use "theta genesis build" instead.
+//
 func main() {
 	chainID, erc20SnapshotJSONFilePath, stakeDepositFilePath, genesisSnapshotFilePath := parseArguments()
 
@@ -91,7 +94,7 @@ func generateGenesisSnapshot(chainID, erc20SnapshotJSONFilePath, stakeDepositFil
 	genesisHeight := core.GenesisBlockHeight
 
 	sv := loadInitialBalances(erc20SnapshotJSONFilePath)
-	performInitialStakeDeposit(stakeDepositFilePath, genesisHeight, sv)
+	performInitialStakeDeposit(chainID, stakeDepositFilePath, genesisHeight, sv)
 
 	stateHash := sv.Hash()
 
@@ -156,7 +159,7 @@ func loadInitialBalances(erc20SnapshotJSONFilePath string) *state.StoreView {
 	return sv
 }
 
-func performInitialStakeDeposit(stakeDepositFilePath string, genesisHeight uint64, sv *state.StoreView) *core.ValidatorCandidatePool {
+func performInitialStakeDeposit(chainID, stakeDepositFilePath string, genesisHeight uint64, sv *state.StoreView) *core.ValidatorCandidatePool {
 	var stakeDeposits []StakeDeposit
 	stakeDepositFile, err := os.Open(stakeDepositFilePath)
 	stakeDepositByteValue, err := ioutil.ReadAll(stakeDepositFile)
@@ -188,7 +191,7 @@ func performInitialStakeDeposit(stakeDepositFilePath string, genesisHeight uint6
 			panic(fmt.Sprintf("The source account %v does NOT have sufficient balance for stake deposit. ThetaWeiBalance = %v, StakeAmount = %v",
 				sourceAddress, sourceAccount.Balance.ThetaWei, stakeDeposit.Amount))
 		}
-		err := vcp.DepositStake(sourceAddress, holderAddress, stakeAmount, genesisHeight)
+		err := vcp.DepositStake(sourceAddress, holderAddress, stakeAmount, chainID, genesisHeight)
 		if err != nil {
 			panic(fmt.Sprintf("Failed to deposit stake, err: %v", err))
 		}
@@ -162,14 +162,14 @@ func validateInputsBasic(ins []types.TxInput) result.Result {
 }
 
 // Validate inputs and compute total amount of coins
-func validateInputsAdvanced(accounts map[string]*types.Account, signBytes []byte, ins []types.TxInput, blockHeight uint64) (total types.Coins, res result.Result) {
+func validateInputsAdvanced(accounts map[string]*types.Account, signBytes []byte, ins []types.TxInput, chainID string, blockHeight uint64) (total types.Coins, res result.Result) {
 	total = types.NewCoins(0, 0)
 	for _, in := range ins {
 		acc := accounts[string(in.Address[:])]
 		if acc == nil {
 			panic("validateInputsAdvanced() expects account in accounts")
 		}
-		res = validateInputAdvanced(acc, signBytes, in, blockHeight)
+		res = validateInputAdvanced(acc, signBytes, in, chainID, blockHeight)
 		if res.IsError() {
 			return
 		}
@@ -179,7 +179,7 @@ func validateInputsAdvanced(accounts map[string]*types.Account, signBytes []byte
 	return total, result.OK
 }
 
-func validateInputAdvanced(acc *types.Account, signBytes []byte, in types.TxInput, blockHeight uint64) result.Result {
+func validateInputAdvanced(acc *types.Account, signBytes []byte, in types.TxInput, chainID string, blockHeight uint64) result.Result {
 	// Check sequence/coins
 	seq, balance := acc.Sequence, acc.Balance
 	if seq+1 != in.Sequence {
@@ -195,7 +195,7 @@ func validateInputAdvanced(acc *types.Account, signBytes []byte, in types.TxInpu
 
 	// Check signatures
 	signatureValid := in.Signature.Verify(signBytes, acc.Address)
-	if blockHeight >= common.HeightTxWrapperExtension {
+	if blockHeight >= common.GetForkSchedule(chainID).TxWrapperExtension {
 		signBytesV2 := types.ChangeEthereumTxWrapper(signBytes, 2)
 		signatureValid = signatureValid || in.Signature.Verify(signBytesV2, acc.Address)
 	}
@@ -254,12 +254,12 @@ func adjustByOutputs(view *state.StoreView, accounts map[string]*types.Account,
 	}
 }
 
-func sanityCheckForGasPrice(gasPrice *big.Int, blockHeight uint64) bool {
+func sanityCheckForGasPrice(view *state.StoreView, gasPrice *big.Int, chainID string, blockHeight uint64) bool {
 	if gasPrice == nil {
 		return false
 	}
 
-	minimumGasPrice := types.GetMinimumGasPrice(blockHeight)
+	minimumGasPrice := getMinimumGasPrice(view, chainID, blockHeight)
 	if gasPrice.Cmp(minimumGasPrice) < 0 {
 		return false
 	}
@@ -267,22 +267,55 @@ func sanityCheckForGasPrice(gasPrice *big.Int, blockHeight uint64) bool {
 	return true
 }
 
-func sanityCheckForFee(fee types.Coins, blockHeight uint64) (minimumFee *big.Int, success bool) {
+func sanityCheckForFee(view *state.StoreView, fee types.Coins, chainID string, blockHeight uint64) (minimumFee *big.Int, success bool) {
 	fee = fee.NoNil()
-	minimumFee = types.GetMinimumTransactionFeeTFuelWei(blockHeight)
+	minimumFee = getMinimumTransactionFee(view, chainID, blockHeight)
 	success = (fee.ThetaWei.Cmp(types.Zero) == 0 && fee.TFuelWei.Cmp(minimumFee) >= 0)
 
 	return minimumFee, success
 }
 
-func sanityCheckForSendTxFee(fee types.Coins, numAccountsAffected uint64, blockHeight uint64) (minimumFee *big.Int, success bool) {
+func sanityCheckForSendTxFee(view *state.StoreView, fee types.Coins, numAccountsAffected uint64, chainID string, blockHeight uint64) (minimumFee *big.Int, success bool) {
 	fee = fee.NoNil()
-	minimumFee = types.GetSendTxMinimumTransactionFeeTFuelWei(numAccountsAffected, blockHeight)
+	minimumFee = getSendTxMinimumTransactionFee(view, numAccountsAffected, chainID, blockHeight)
 	success = (fee.ThetaWei.Cmp(types.Zero) == 0 && fee.TFuelWei.Cmp(minimumFee) >= 0)
 
 	return minimumFee, success
 }
 
+// getMinimumGasPrice returns the minimum gas price, which can be changed by the on-chain governance.
+func getMinimumGasPrice(view *state.StoreView, chainID string, blockHeight uint64) *big.Int {
+	return state.NewGovernance(view).GetParamOrDefault(core.ParamMinGasPrice, types.GetMinimumGasPrice(chainID, blockHeight))
+}
+
+// getMaxGasLimit returns the maximum gas limit of a smart contract transaction, which can be
+// changed by the on-chain governance.
+func getMaxGasLimit(view *state.StoreView, chainID string, blockHeight uint64) *big.Int {
+	return state.NewGovernance(view).GetParamOrDefault(core.ParamMaxTxGasLimit, types.GetMaxGasLimit(chainID, blockHeight))
+}
+
+// getMinimumTransactionFee returns the minimum fee of a regular transaction, which can be
+// changed by the on-chain governance.
+func getMinimumTransactionFee(view *state.StoreView, chainID string, blockHeight uint64) *big.Int {
+	return state.NewGovernance(view).GetParamOrDefault(core.ParamMinTxFee, types.GetMinimumTransactionFeeTFuelWei(chainID, blockHeight))
+}
+
+// getSendTxMinimumTransactionFee returns the minimum fee of a send transaction. Once the fee is
+// set by the on-chain governance, it is charged for every two accounts affected, same as the
+// protocol fee after the June 2021 fee adjustment.
+func getSendTxMinimumTransactionFee(view *state.StoreView, numAccountsAffected uint64, chainID string, blockHeight uint64) *big.Int {
+	minTxFee := state.NewGovernance(view).GetParam(core.ParamMinTxFee)
+	if minTxFee == nil {
+		return types.GetSendTxMinimumTransactionFeeTFuelWei(numAccountsAffected, chainID, blockHeight)
+	}
+
+	if numAccountsAffected < 2 {
+		numAccountsAffected = 2
+	}
+	minSendTxFee := new(big.Int).Mul(new(big.Int).SetUint64(numAccountsAffected), minTxFee)
+	return minSendTxFee.Div(minSendTxFee, big.NewInt(2))
+}
+
 func chargeFee(account *types.Account, fee types.Coins) bool {
 	if !account.Balance.IsGTE(fee) {
 		return false
@@ -299,8 +332,119 @@ func getBlockHeight(ledgerState *state.LedgerState) uint64 {
 
 func getRegularTxGas(ledgerState *state.LedgerState) uint64 {
 	blockHeight := getBlockHeight(ledgerState)
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+	if blockHeight < common.GetForkSchedule(ledgerState.GetChainID()).June2021FeeAdjustment {
 		return types.GasRegularTx
 	}
 	return types.GasRegularTxJune2021
 }
+
+// sanityCheckForStakeAmount checks the amount of a partial stake withdrawal or a stake
+// redelegation, and returns it in the currency staked for the purpose: Theta for validators
+// and guardians, TFuel for elite edge nodes.
+func sanityCheckForStakeAmount(purpose uint8, amount types.Coins) (*big.Int, result.Result) {
+	if !(purpose == core.StakeForValidator || purpose == core.StakeForGuardian || purpose == core.StakeForEliteEdgeNode) {
+		return nil, result.Error("Invalid stake purpose!").
+			WithErrorCode(result.CodeInvalidStakePurpose)
+	}
+
+	amount = amount.NoNil()
+	if !amount.IsValid() || !amount.IsPositive() {
+		return nil, result.Error("Invalid stake amount!").
+			WithErrorCode(result.CodeInvalidStake)
+	}
+
+	if purpose == core.StakeForEliteEdgeNode {
+		if amount.ThetaWei.Cmp(types.Zero) != 0 {
+			return nil, result.Error("Theta has to be zero for elite edge node stake!").
+				WithErrorCode(result.CodeInvalidStake)
+		}
+		return amount.TFuelWei, result.OK
+	}
+
+	if amount.TFuelWei.Cmp(types.Zero) != 0 {
+		return nil, result.Error("TFuel has to be zero for validator or guardian stake!").
+			WithErrorCode(result.CodeInvalidStake)
+	}
+	return amount.ThetaWei, result.OK
+}
+
+// getMinStakeDeposit returns the minimal stake deposit for the purpose, which can be raised
+// by the on-chain governance.
+func getMinStakeDeposit(view *state.StoreView, purpose uint8, chainID string, blockHeight uint64) *big.Int {
+	protocolMinStake := getProtocolMinStakeDeposit(purpose, chainID, blockHeight)
+	gov := state.NewGovernance(view)
+	switch purpose {
+	case core.StakeForValidator:
+		return gov.GetParamOrDefault(core.ParamMinValidatorStake, protocolMinStake)
+	case core.StakeForGuardian:
+		return gov.GetParamOrDefault(core.ParamMinGuardianStake, protocolMinStake)
+	default:
+		return gov.GetParamOrDefault(core.ParamMinEliteEdgeNodeStake, protocolMinStake)
+	}
+}
+
+// getProtocolMinStakeDeposit returns the minimal stake deposit for the purpose defined by the protocol.
+func getProtocolMinStakeDeposit(purpose uint8, chainID string, blockHeight uint64) *big.Int {
+	forks := common.GetForkSchedule(chainID)
+	switch purpose {
+	case core.StakeForValidator:
+		if blockHeight >= forks.ValidatorStakeChangedTo200K {
+			return core.MinValidatorStakeDeposit200K
+		}
+		return core.MinValidatorStakeDeposit
+	case core.StakeForGuardian:
+		if blockHeight >= forks.LowerGNStakeThresholdTo1000 {
+			return core.MinGuardianStakeDeposit1000
+		}
+		return core.MinGuardianStakeDeposit
+	default:
+		return core.MinEliteEdgeNodeStakeDeposit
+	}
+}
+
+// getActiveStake returns the amount the source has staked to the holder for the purpose,
+// excluding the withdrawn stakes.
+func getActiveStake(view *state.StoreView, purpose uint8, source common.Address, holder common.Address) *big.Int {
+	var holderStakes []*core.Stake
+	switch purpose {
+	case core.StakeForValidator:
+		if candidate := view.GetValidatorCandidatePool().FindStakeDelegate(holder); candidate != nil {
+			holderStakes = candidate.Stakes
+		}
+	case core.StakeForGuardian:
+		if g := view.GetGuardianCandidatePool().GetWithHolderAddress(holder); g != nil {
+			holderStakes = g.Stakes
+		}
+	case core.StakeForEliteEdgeNode:
+		if een := state.NewEliteEdgeNodePool(view, true).Get(holder); een != nil {
+			holderStakes = een.Stakes
+		}
+	}
+
+	activeStake := big.NewInt(0)
+	for _, stake := range holderStakes {
+		if stake.Source == source && !stake.Withdrawn {
+			activeStake.Add(activeStake, stake.Amount)
+		}
+	}
+	return activeStake
+}
+
+// sanityCheckForRemainingStake makes sure that a partial stake withdrawal or redelegation
+// does not leave less than the minimal stake deposit behind, unless it takes all the stake.
+func sanityCheckForRemainingStake(view *state.StoreView, purpose uint8, source common.Address, holder common.Address,
+	amount *big.Int, chainID string, blockHeight uint64) result.Result {
+	activeStake := getActiveStake(view, purpose, source, holder)
+	if activeStake.Cmp(amount) < 0 {
+		return result.Error("Insufficient stake, %v has %v staked to %v", source, activeStake, holder).
+			WithErrorCode(result.CodeInsufficientStake)
+	}
+
+	remainingStake := new(big.Int).Sub(activeStake, amount)
+	minStake := getMinStakeDeposit(view, purpose, chainID, blockHeight)
+	if remainingStake.Sign() > 0 && remainingStake.Cmp(minStake) < 0 {
+		return result.Error("The remaining stake must be either zero or at least %v, but would be %v", minStake, remainingStake).
+			WithErrorCode(result.CodeInsufficientStake)
+	}
+	return result.OK
+}
@@ -44,12 +44,18 @@ type Executor struct {
 	depositStakeTxExec            *DepositStakeExecutor
 	withdrawStakeTxExec           *WithdrawStakeExecutor
 	stakeRewardDistributionTxExec *StakeRewardDistributionTxExecutor
+	withdrawStakeV2TxExec         *WithdrawStakeV2Executor
+	redelegateStakeTxExec         *RedelegateStakeExecutor
+	governanceProposalTxExec      *GovernanceProposalTxExecutor
+	governanceVoteTxExec          *GovernanceVoteTxExecutor
+	timeLockTxExec                *TimeLockTxExecutor
 
 	skipSanityCheck bool
 }
 
 // NewExecutor creates a new instance of Executor
 func NewExecutor(db database.Database, chain *blockchain.Chain, state *st.LedgerState, consensus core.ConsensusEngine, valMgr core.ValidatorManager) *Executor {
+	votingStakes := newVotingStakeCache()
 	executor := &Executor{
 		db:             db,
 		chain:          chain,
@@ -67,6 +73,11 @@ func NewExecutor(db database.Database, chain *blockchain.Chain, state *st.Ledger
 		depositStakeTxExec:            NewDepositStakeExecutor(state),
 		withdrawStakeTxExec:           NewWithdrawStakeExecutor(state),
 		stakeRewardDistributionTxExec: NewStakeRewardDistributionTxExecutor(state),
+		withdrawStakeV2TxExec:         NewWithdrawStakeV2Executor(state),
+		redelegateStakeTxExec:         NewRedelegateStakeExecutor(state),
+		governanceProposalTxExec:      NewGovernanceProposalTxExecutor(state, votingStakes),
+		governanceVoteTxExec:          NewGovernanceVoteTxExecutor(state, votingStakes),
+		timeLockTxExec:                NewTimeLockTxExecutor(state),
 		skipSanityCheck:               false,
 	}
 
@@ -94,6 +105,12 @@ func (exec *Executor) ScreenTx(tx types.Tx) (common.Hash, result.Result) {
 	return exec.processTx(tx, core.ScreenedView)
 }
 
+// ScreenTxOnView checks the validity of the given transaction against the given view instead of
+// the screened view, e.g. a view without some of the screened transactions
+func (exec *Executor) ScreenTxOnView(tx types.Tx, view *st.StoreView) (common.Hash, result.Result) {
+	return exec.processTxOnView(tx, view)
+}
+
 // GetTxInfo extracts tx information used by mempool to sort Txs.
 func (exec *Executor) GetTxInfo(tx types.Tx) (*core.TxInfo, result.Result) {
 	txExecutor := exec.getTxExecutor(tx)
@@ -107,7 +124,6 @@ func (exec *Executor) GetTxInfo(tx types.Tx) (*core.TxInfo, result.Result) {
 
 // processTx contains the main logic to process the transaction. If the tx is invalid, a TMSP error will be returned.
 func (exec *Executor) processTx(tx types.Tx, viewSel core.ViewSelector) (common.Hash, result.Result) {
-	chainID := exec.state.GetChainID()
 	var view *st.StoreView
 	switch viewSel {
 	case core.DeliveredView:
@@ -118,6 +134,11 @@ func (exec *Executor) processTx(tx types.Tx, viewSel core.ViewSelector) (common.
 		view = exec.state.Screened()
 	}
 
+	return exec.processTxOnView(tx, view)
+}
+
+func (exec *Executor) processTxOnView(tx types.Tx, view *st.StoreView) (common.Hash, result.Result) {
+	chainID := exec.state.GetChainID()
 	sanityCheckResult := exec.sanityCheck(chainID, view, tx)
 	if sanityCheckResult.IsError() {
 		return common.Hash{}, sanityCheckResult
@@ -132,7 +153,7 @@ func (exec *Executor) sanityCheck(chainID string, view *st.StoreView, tx types.T
 		return result.OK
 	}
 
-	if !exec.isTxTypeSupported(view, tx) {
+	if !exec.isTxTypeSupported(chainID, view, tx) {
 		return result.Error("tx type not supported yet")
 	}
 
@@ -151,7 +172,7 @@ func (exec *Executor) process(chainID string, view *st.StoreView, tx types.Tx) (
 	var processResult result.Result
 	var txHash common.Hash
 
-	if !exec.isTxTypeSupported(view, tx) {
+	if !exec.isTxTypeSupported(chainID, view, tx) {
 		return txHash, result.Error("tx type not supported yet")
 	}
 
@@ -168,16 +189,29 @@ func (exec *Executor) process(chainID string, view *st.StoreView, tx types.Tx) (
 	return txHash, processResult
 }
 
-func (exec *Executor) isTxTypeSupported(view *st.StoreView, tx types.Tx) bool {
+func (exec *Executor) isTxTypeSupported(chainID string, view *st.StoreView, tx types.Tx) bool {
 	blockHeight := view.Height() + 1
+	forks := common.GetForkSchedule(chainID)
 
 	switch tx.(type) {
 	case *types.SmartContractTx:
-		if blockHeight < common.HeightEnableSmartContract {
+		if blockHeight < forks.EnableSmartContract {
 			return false
 		}
 	case *types.StakeRewardDistributionTx:
-		if blockHeight < common.HeightEnableTheta3 {
+		if blockHeight < forks.EnableTheta3 {
+			return false
+		}
+	case *types.WithdrawStakeTxV2, *types.RedelegateStakeTx:
+		if blockHeight < forks.StakeRedelegation {
+			return false
+		}
+	case *types.GovernanceProposalTx, *types.GovernanceVoteTx:
+		if blockHeight < forks.Governance {
+			return false
+		}
+	case *types.TimeLockTx:
+		if blockHeight < forks.TimeLock {
 			return false
 		}
 	default:
@@ -214,6 +248,16 @@ func (exec *Executor) getTxExecutor(tx types.Tx) TxExecutor {
 		txExecutor = exec.depositStakeTxExec
 	case *types.StakeRewardDistributionTx:
 		txExecutor = exec.stakeRewardDistributionTxExec
+	case *types.WithdrawStakeTxV2:
+		txExecutor = exec.withdrawStakeV2TxExec
+	case *types.RedelegateStakeTx:
+		txExecutor = exec.redelegateStakeTxExec
+	case *types.GovernanceProposalTx:
+		txExecutor = exec.governanceProposalTxExec
+	case *types.GovernanceVoteTx:
+		txExecutor = exec.governanceVoteTxExec
+	case *types.TimeLockTx:
+		txExecutor = exec.timeLockTxExec
 	default:
 		txExecutor = nil
 	}
@@ -0,0 +1,207 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
+	st "github.com/thetatoken/theta/ledger/state"
+)
+
+// HandleGovernanceTally counts the votes of the proposals whose voting period has ended. It
+// runs at the checkpoints, after the transactions of the block have been processed, so the
+// parameters of the passed proposals take effect from the next block. It returns true if a
+// passed proposal changed the validator selection.
+func HandleGovernanceTally(chainID string, view *st.StoreView) bool {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	if blockHeight < common.GetForkSchedule(chainID).Governance || !common.IsCheckPointHeight(blockHeight) {
+		return false
+	}
+
+	gov := st.NewGovernance(view)
+	activeIDs := gov.GetActiveProposalIDs()
+	if len(activeIDs) == 0 {
+		return false
+	}
+
+	stakes, totalStake := getGovernanceVotingStakes(view)
+
+	hasValidatorUpdate := false
+	remainingIDs := []uint64{}
+	for _, id := range activeIDs {
+		proposal := gov.GetProposal(id)
+		if proposal == nil {
+			continue
+		}
+		if blockHeight < proposal.VotingEndHeight {
+			remainingIDs = append(remainingIDs, id)
+			continue
+		}
+
+		approvingStake := big.NewInt(0)
+		for _, vote := range proposal.Votes {
+			if stake, ok := stakes[vote.Voter]; ok && vote.Approve {
+				approvingStake.Add(approvingStake, stake)
+			}
+		}
+		proposal.ApprovingStake = approvingStake
+		proposal.TotalStake = new(big.Int).Set(totalStake)
+
+		// The proposal passes with the approval of more than 2/3 of the total stake
+		passed := totalStake.Sign() > 0 &&
+			new(big.Int).Mul(approvingStake, big.NewInt(3)).Cmp(new(big.Int).Mul(totalStake, big.NewInt(2))) > 0
+		if passed {
+			// The other parameters might have changed since the submission
+			if err := validateGovernanceParam(view, chainID, blockHeight, proposal.Param, proposal.Value); err != nil {
+				logger.Infof("Governance proposal %v is rejected: %v", proposal.ID, err)
+				passed = false
+			}
+		}
+
+		if passed {
+			gov.SetParam(proposal.Param, proposal.Value)
+			if proposal.Param == core.ParamMaxValidatorCount {
+				vcp := view.GetValidatorCandidatePool()
+				vcp.SetMaxValidatorCount(proposal.Value.Uint64())
+				view.UpdateValidatorCandidatePool(vcp)
+				hasValidatorUpdate = true
+			}
+			proposal.Status = core.ProposalStatusPassed
+		} else {
+			proposal.Status = core.ProposalStatusRejected
+		}
+		gov.UpsertProposal(proposal)
+
+		logger.Infof("Governance proposal tallied, height: %v, proposal: %v, approving stake: %v, total stake: %v",
+			blockHeight, proposal, approvingStake, totalStake)
+	}
+	gov.SetActiveProposalIDs(remainingIDs)
+
+	return hasValidatorUpdate
+}
+
+// getGovernanceVotingStakes returns the voting weight of each validator candidate and
+// guardian, which is the active stake it holds, and the total voting weight.
+func getGovernanceVotingStakes(view *st.StoreView) (map[common.Address]*big.Int, *big.Int) {
+	stakes := make(map[common.Address]*big.Int)
+	totalStake := big.NewInt(0)
+	addStake := func(holder *core.StakeHolder) {
+		stake := holder.TotalStake()
+		if stake.Sign() <= 0 {
+			return
+		}
+		if _, ok := stakes[holder.Holder]; !ok {
+			stakes[holder.Holder] = big.NewInt(0)
+		}
+		stakes[holder.Holder].Add(stakes[holder.Holder], stake)
+		totalStake.Add(totalStake, stake)
+	}
+
+	if vcp := view.GetValidatorCandidatePool(); vcp != nil {
+		for _, candidate := range vcp.SortedCandidates {
+			addStake(candidate)
+		}
+	}
+	if gcp := view.GetGuardianCandidatePool(); gcp != nil {
+		for _, g := range gcp.SortedGuardians {
+			addStake(g.StakeHolder)
+		}
+	}
+
+	return stakes, totalStake
+}
+
+// votingStakeCache caches the voting weights computed from the candidate pools, keyed by the
+// hash of the encoded pools. The pools only change with the stake transactions, so checking the
+// proposals and votes of a block does not decode and scan them every time. Each Executor has
+// its own cache, shared by its governance transaction executors.
+type votingStakeCache struct {
+	mu       sync.Mutex
+	poolHash common.Hash
+	stakes   map[common.Address]*big.Int
+}
+
+func newVotingStakeCache() *votingStakeCache {
+	return &votingStakeCache{}
+}
+
+// get returns the voting weights of the candidate pools of the view.
+func (c *votingStakeCache) get(view *st.StoreView) map[common.Address]*big.Int {
+	poolHash := crypto.Keccak256Hash(view.Get(st.ValidatorCandidatePoolKey()), view.Get(st.GuardianCandidatePoolKey()))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stakes == nil || c.poolHash != poolHash {
+		c.stakes, _ = getGovernanceVotingStakes(view)
+		c.poolHash = poolHash
+	}
+	return c.stakes
+}
+
+// getStake returns the voting weight of the address, zero if it holds no stake as a validator
+// candidate or guardian.
+func (c *votingStakeCache) getStake(view *st.StoreView, addr common.Address) *big.Int {
+	if stake, ok := c.get(view)[addr]; ok {
+		return new(big.Int).Set(stake)
+	}
+	return big.NewInt(0)
+}
+
+// validateGovernanceParam checks the proposed value of a parameter against the bounds set by
+// the protocol. It is checked when the proposal is submitted, and again when it passes.
+func validateGovernanceParam(view *st.StoreView, chainID string, blockHeight uint64, param string, value *big.Int) error {
+	if value == nil || value.Sign() <= 0 {
+		return fmt.Errorf("the value of %v must be positive", param)
+	}
+
+	gov := st.NewGovernance(view)
+	switch param {
+	case core.ParamMaxValidatorCount:
+		if value.Cmp(new(big.Int).SetUint64(core.MaxGovernedValidatorCount)) > 0 {
+			return fmt.Errorf("the maximum number of validators cannot exceed %v", core.MaxGovernedValidatorCount)
+		}
+	case core.ParamMinValidatorStake:
+		minStake := getProtocolMinStakeDeposit(core.StakeForValidator, chainID, blockHeight)
+		if value.Cmp(minStake) < 0 {
+			return fmt.Errorf("the minimum validator stake cannot be lower than %v ThetaWei", minStake)
+		}
+	case core.ParamMinGuardianStake:
+		minStake := getProtocolMinStakeDeposit(core.StakeForGuardian, chainID, blockHeight)
+		if value.Cmp(minStake) < 0 {
+			return fmt.Errorf("the minimum guardian stake cannot be lower than %v ThetaWei", minStake)
+		}
+	case core.ParamMinEliteEdgeNodeStake:
+		minStake := getProtocolMinStakeDeposit(core.StakeForEliteEdgeNode, chainID, blockHeight)
+		if value.Cmp(minStake) < 0 {
+			return fmt.Errorf("the minimum elite edge node stake cannot be lower than %v TFuelWei", minStake)
+		}
+		maxStake := gov.GetParamOrDefault(core.ParamMaxEliteEdgeNodeStake, core.MaxEliteEdgeNodeStakeDeposit)
+		if value.Cmp(maxStake) > 0 {
+			return fmt.Errorf("the minimum elite edge node stake cannot be higher than the maximum %v TFuelWei", maxStake)
+		}
+	case core.ParamMaxEliteEdgeNodeStake:
+		if value.Cmp(core.MaxEliteEdgeNodeStakeDeposit) > 0 {
+			return fmt.Errorf("the maximum elite edge node stake cannot be higher than %v TFuelWei", core.MaxEliteEdgeNodeStakeDeposit)
+		}
+		minStake := getMinStakeDeposit(view, core.StakeForEliteEdgeNode, chainID, blockHeight)
+		if value.Cmp(minStake) < 0 {
+			return fmt.Errorf("the maximum elite edge node stake cannot be lower than the minimum %v TFuelWei", minStake)
+		}
+	case core.ParamMaxTxGasLimit:
+		if value.Cmp(new(big.Int).SetUint64(core.MaxGovernedTxGasLimit)) > 0 {
+			return fmt.Errorf("the maximum gas limit cannot exceed %v", core.MaxGovernedTxGasLimit)
+		}
+	case core.ParamMinGasPrice, core.ParamMinTxFee:
+		if !value.IsUint64() {
+			return fmt.Errorf("the value of %v is too large", param)
+		}
+	default:
+		return fmt.Errorf("%v cannot be changed by governance", param)
+	}
+
+	return nil
+}
@@ -0,0 +1,241 @@
+package execution
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto/bls"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// setupGovernanceTest makes accIn a validator candidate holding 3/4 of the stake, and accOut a
+// guardian holding the remaining 1/4.
+func setupGovernanceTest(t *testing.T) *execTest {
+	et := NewExecTest()
+	common.RegisterForkSchedule(et.chainID, common.DevForkSchedule)
+
+	validatorStake := new(big.Int).Mul(big.NewInt(3), core.MinValidatorStakeDeposit)
+	guardianStake := new(big.Int).Set(core.MinValidatorStakeDeposit)
+
+	tfuelBalance := new(big.Int).Mul(big.NewInt(50), big.NewInt(getMinimumTxFeeJune2021()))
+	et.accIn.Balance = types.Coins{
+		ThetaWei: new(big.Int).Mul(big.NewInt(10), core.MinValidatorStakeDeposit),
+		TFuelWei: tfuelBalance,
+	}
+	et.accOut.Balance = types.Coins{
+		ThetaWei: big.NewInt(0),
+		TFuelWei: tfuelBalance,
+	}
+	et.acc2State(et.accIn, et.accOut)
+
+	view := et.state().Delivered()
+	vcp := &core.ValidatorCandidatePool{}
+	vcp.SortedCandidates = []*core.StakeHolder{
+		core.NewStakeHolder(et.accIn.Address, []*core.Stake{core.NewStake(et.accIn.Address, validatorStake)}),
+	}
+	view.UpdateValidatorCandidatePool(vcp)
+
+	blsKey, err := bls.RandKey()
+	assert.Nil(t, err)
+	gcp := core.NewGuardianCandidatePool()
+	gcp.Add(&core.Guardian{
+		StakeHolder: core.NewStakeHolder(et.accOut.Address, []*core.Stake{core.NewStake(et.accOut.Address, guardianStake)}),
+		Pubkey:      blsKey.PublicKey(),
+	})
+	view.UpdateGuardianCandidatePool(gcp)
+	et.state().Commit()
+
+	return et
+}
+
+func teardownGovernanceTest(et *execTest) {
+	common.RegisterForkSchedule(et.chainID, common.MainnetForkSchedule)
+}
+
+func (et *execTest) makeProposalTx(proposer types.PrivAccount, seq uint64, param string, value *big.Int, votingPeriod uint64) *types.GovernanceProposalTx {
+	tx := &types.GovernanceProposalTx{
+		Fee:          types.NewCoins(0, getMinimumTxFeeJune2021()),
+		Proposer:     types.TxInput{Address: proposer.Address, Sequence: seq},
+		Param:        param,
+		Value:        value,
+		VotingPeriod: votingPeriod,
+	}
+	tx.Proposer.Signature = proposer.Sign(tx.SignBytes(et.chainID))
+	return tx
+}
+
+func (et *execTest) makeVoteTx(voter types.PrivAccount, seq uint64, proposalID uint64, approve bool) *types.GovernanceVoteTx {
+	tx := &types.GovernanceVoteTx{
+		Fee:        types.NewCoins(0, getMinimumTxFeeJune2021()),
+		Voter:      types.TxInput{Address: voter.Address, Sequence: seq},
+		ProposalID: proposalID,
+		Approve:    approve,
+	}
+	tx.Voter.Signature = voter.Sign(tx.SignBytes(et.chainID))
+	return tx
+}
+
+func TestGovernanceProposalPasses(t *testing.T) {
+	assert := assert.New(t)
+	et := setupGovernanceTest(t)
+	defer teardownGovernanceTest(et)
+
+	minStake := new(big.Int).Mul(big.NewInt(3), core.MinValidatorStakeDeposit200K)
+
+	// Invalid proposals
+	_, res := et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, "block_reward", big.NewInt(1), core.MinGovernanceVotingPeriod))
+	assert.True(res.IsError())
+	_, res = et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, core.ParamMinValidatorStake, big.NewInt(1), core.MinGovernanceVotingPeriod))
+	assert.True(res.IsError(), "the minimum stake cannot be lowered below the protocol minimum")
+	_, res = et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, core.ParamMinValidatorStake, minStake, 1))
+	assert.True(res.IsError(), "the voting period is too short")
+
+	submitHeight := et.state().Delivered().Height() + 1
+	_, res = et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, core.ParamMinValidatorStake, minStake, core.MinGovernanceVotingPeriod))
+	assert.True(res.IsOK(), res.Message)
+
+	gov := st.NewGovernance(et.state().Delivered())
+	proposal := gov.GetProposal(1)
+	assert.NotNil(proposal)
+	assert.Equal(core.ProposalStatusVoting, proposal.Status)
+	assert.Equal(submitHeight+core.MinGovernanceVotingPeriod, proposal.VotingEndHeight)
+	assert.Equal([]uint64{1}, gov.GetActiveProposalIDs())
+
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accIn, 2, 2, true))
+	assert.True(res.IsError(), "the proposal does not exist")
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accIn, 2, 1, true))
+	assert.True(res.IsOK(), res.Message)
+
+	// The voting period has not ended at the first checkpoint
+	et.fastforwardTo(100)
+	assert.False(HandleGovernanceTally(et.chainID, et.state().Delivered()))
+	assert.Equal(core.ProposalStatusVoting, gov.GetProposal(1).Status)
+	assert.Nil(gov.GetParam(core.ParamMinValidatorStake))
+
+	// Voting is closed after the voting period
+	et.fastforwardTo(150)
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accOut, 1, 1, false))
+	assert.True(res.IsError())
+
+	et.fastforwardTo(200)
+	view := et.state().Delivered()
+	assert.False(HandleGovernanceTally(et.chainID, view))
+
+	gov = st.NewGovernance(view)
+	proposal = gov.GetProposal(1)
+	assert.Equal(core.ProposalStatusPassed, proposal.Status)
+	assert.Equal(new(big.Int).Mul(big.NewInt(3), core.MinValidatorStakeDeposit), proposal.ApprovingStake)
+	assert.Equal(new(big.Int).Mul(big.NewInt(4), core.MinValidatorStakeDeposit), proposal.TotalStake)
+	assert.Equal(minStake, gov.GetParam(core.ParamMinValidatorStake))
+	assert.Equal(0, len(gov.GetActiveProposalIDs()))
+
+	// The executors read the new minimum stake
+	depositTx := &types.DepositStakeTx{
+		Fee: types.NewCoins(0, getMinimumTxFeeJune2021()),
+		Source: types.TxInput{
+			Address:  et.accIn.Address,
+			Coins:    types.Coins{ThetaWei: new(big.Int).Sub(minStake, big.NewInt(1)), TFuelWei: big.NewInt(0)},
+			Sequence: 3,
+		},
+		Holder:  types.TxOutput{Address: et.accIn.Address},
+		Purpose: core.StakeForValidator,
+	}
+	depositTx.Source.Signature = et.accIn.Sign(depositTx.SignBytes(et.chainID))
+	_, res = et.executor.ExecuteTx(depositTx)
+	assert.Equal(result.CodeInsufficientStake, res.Code)
+
+	depositTx.Source.Coins.ThetaWei = minStake
+	depositTx.Source.Signature = et.accIn.Sign(depositTx.SignBytes(et.chainID))
+	_, res = et.executor.ExecuteTx(depositTx)
+	assert.True(res.IsOK(), res.Message)
+}
+
+func TestGovernanceProposalRejected(t *testing.T) {
+	assert := assert.New(t)
+	et := setupGovernanceTest(t)
+	defer teardownGovernanceTest(et)
+
+	// The guardians alone cannot pass a proposal
+	_, res := et.executor.ExecuteTx(et.makeProposalTx(et.accOut, 1, core.ParamMaxValidatorCount, big.NewInt(1), core.MinGovernanceVotingPeriod))
+	assert.True(res.IsOK(), res.Message)
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accOut, 2, 1, true))
+	assert.True(res.IsOK(), res.Message)
+
+	// A later vote replaces the earlier one
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accIn, 1, 1, true))
+	assert.True(res.IsOK(), res.Message)
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accIn, 2, 1, false))
+	assert.True(res.IsOK(), res.Message)
+
+	et.fastforwardTo(200)
+	view := et.state().Delivered()
+	assert.False(HandleGovernanceTally(et.chainID, view))
+
+	gov := st.NewGovernance(view)
+	proposal := gov.GetProposal(1)
+	assert.Equal(core.ProposalStatusRejected, proposal.Status)
+	assert.Equal(2, len(proposal.Votes))
+	assert.Equal(core.MinValidatorStakeDeposit, proposal.ApprovingStake)
+	assert.Nil(gov.GetParam(core.ParamMaxValidatorCount))
+
+	_, ok := view.GetValidatorCandidatePool().GetMaxValidatorCount()
+	assert.False(ok)
+}
+
+func TestGovernanceMaxValidatorCount(t *testing.T) {
+	assert := assert.New(t)
+	et := setupGovernanceTest(t)
+	defer teardownGovernanceTest(et)
+
+	_, res := et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, core.ParamMaxValidatorCount, new(big.Int).SetUint64(core.MaxGovernedValidatorCount+1), core.MinGovernanceVotingPeriod))
+	assert.True(res.IsError())
+
+	_, res = et.executor.ExecuteTx(et.makeProposalTx(et.accIn, 1, core.ParamMaxValidatorCount, big.NewInt(7), core.MinGovernanceVotingPeriod))
+	assert.True(res.IsOK(), res.Message)
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accIn, 2, 1, true))
+	assert.True(res.IsOK(), res.Message)
+	_, res = et.executor.ExecuteTx(et.makeVoteTx(et.accOut, 1, 1, true))
+	assert.True(res.IsOK(), res.Message)
+
+	et.fastforwardTo(200)
+	view := et.state().Delivered()
+	assert.True(HandleGovernanceTally(et.chainID, view))
+
+	count, ok := view.GetValidatorCandidatePool().GetMaxValidatorCount()
+	assert.True(ok)
+	assert.Equal(7, count)
+	assert.Equal(big.NewInt(7), st.NewGovernance(view).GetParam(core.ParamMaxValidatorCount))
+}
+
+func TestGovernanceVotingStakeCache(t *testing.T) {
+	assert := assert.New(t)
+	et := setupGovernanceTest(t)
+	defer teardownGovernanceTest(et)
+
+	view := et.state().Delivered()
+	votingStakes := et.executor.governanceVoteTxExec.votingStakes
+	assert.True(votingStakes == et.executor.governanceProposalTxExec.votingStakes)
+	validatorStake := new(big.Int).Mul(big.NewInt(3), core.MinValidatorStakeDeposit)
+	assert.Equal(validatorStake, votingStakes.getStake(view, et.accIn.Address))
+	assert.Equal(core.MinValidatorStakeDeposit, votingStakes.getStake(view, et.accOut.Address))
+
+	// The pools are not scanned again until they change
+	stakes := votingStakes.get(view)
+	stakes[et.accProposer.Address] = big.NewInt(1)
+	assert.Equal(big.NewInt(1), votingStakes.getStake(view, et.accProposer.Address))
+
+	vcp := view.GetValidatorCandidatePool()
+	vcp.SortedCandidates = append(vcp.SortedCandidates, core.NewStakeHolder(et.accVal2.Address,
+		[]*core.Stake{core.NewStake(et.accVal2.Address, core.MinValidatorStakeDeposit)}))
+	view.UpdateValidatorCandidatePool(vcp)
+	assert.Equal(big.NewInt(0), votingStakes.getStake(view, et.accProposer.Address))
+	assert.Equal(core.MinValidatorStakeDeposit, votingStakes.getStake(view, et.accVal2.Address))
+
+	// Another executor does not share the cache
+	assert.False(votingStakes == NewExecutor(nil, nil, et.state(), nil, nil).governanceVoteTxExec.votingStakes)
+}
@@ -274,7 +274,7 @@ func deploySmartContract(et *execTest, deployerPrivAcc *types.PrivAccount,
 	parentBlock := &core.Block{
 		BlockHeader: &core.BlockHeader{
 			Height:    1,
-			Timestamp: 1601599331,
+			Timestamp: big.NewInt(1601599331),
 		},
 	}
 	stateCopy, err := et.state().Delivered().Copy()
@@ -340,10 +340,10 @@ func callSmartContract(et *execTest, contractAddr common.Address, callerPrivAcc
 	parentBlock := &core.Block{
 		BlockHeader: &core.BlockHeader{
 			Height:    1,
-			Timestamp: 1601599331,
+			Timestamp: big.NewInt(1601599331),
 		},
 	}
-	vmRet, execContractAddr, gasUsed, vmErr := vm.Execute(callSCTX, stateCopy)
+	vmRet, execContractAddr, gasUsed, vmErr := vm.Execute(parentBlock, callSCTX, stateCopy)
 	assert.Equal(contractAddr, execContractAddr)
 	log.Infof("[Call      ] gas used: %v", gasUsed)
 
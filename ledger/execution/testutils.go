@@ -93,8 +93,8 @@ func NewExecTest() *execTest {
 
 //reset everything. state is empty
 func (et *execTest) reset() {
-	et.accIn = types.MakeAccWithInitBalance("foo", types.NewCoins(700000, 50*getMinimumTxFee()))
-	et.accOut = types.MakeAccWithInitBalance("bar", types.NewCoins(700000, 50*getMinimumTxFee()))
+	et.accIn = types.MakeAccWithInitBalance("foo", types.NewCoins(700000, 20*getMinimumTxFeeJune2021()))
+	et.accOut = types.MakeAccWithInitBalance("bar", types.NewCoins(700000, 20*getMinimumTxFeeJune2021()))
 	et.accProposer = types.MakeAcc("proposer")
 	et.accVal2 = types.MakeAcc("val2")
 
@@ -208,7 +208,16 @@ func (et *execTest) SetAcc(accs ...types.PrivAccount) {
 	et.acc2State(accs...)
 }
 
+// getMinimumTxFee returns the minimum transaction fee before the June 2021 fee adjustment,
+// which applies to the test chain following the mainnet fork schedule.
 func getMinimumTxFee() int64 {
+	return int64(types.MinimumTransactionFeeTFuelWei)
+}
+
+// getMinimumTxFeeJune2021 returns the minimum transaction fee after the June 2021 fee
+// adjustment, which applies to the send transactions built by the types test utilities, and
+// to the tests following the dev fork schedule.
+func getMinimumTxFeeJune2021() int64 {
 	return int64(types.MinimumTransactionFeeTFuelWeiJune2021)
 }
 
@@ -330,8 +339,8 @@ func setupForSmartContract(ast *assert.Assertions, numAccounts int) (et *execTes
 		secret := "acc_secret_" + strconv.FormatInt(int64(i), 16)
 		privAccount := types.MakeAccWithInitBalance(secret,
 			types.Coins{
-				big.NewInt(0),
-				big.NewInt(1).Mul(big.NewInt(9000000), big.NewInt(int64(types.MinimumGasPriceJune2021))),
+				ThetaWei: big.NewInt(0),
+				TFuelWei: big.NewInt(1).Mul(big.NewInt(9000000), big.NewInt(int64(types.MinimumGasPriceJune2021))),
 			})
 		privAccounts = append(privAccounts, privAccount)
 		et.acc2State(privAccount)
@@ -0,0 +1,55 @@
+package execution
+
+import (
+	"log"
+
+	"github.com/thetatoken/theta/common"
+	st "github.com/thetatoken/theta/ledger/state"
+)
+
+// HandleTimeLockReleases credits the beneficiaries of the time locks scheduled for release at
+// the current block with the coins vested so far, and removes the fully released locks. The
+// locks with linear vesting are scheduled again at the next checkpoint or at their release
+// height.
+func HandleTimeLockReleases(chainID string, view *st.StoreView) {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	if blockHeight < common.GetForkSchedule(chainID).TimeLock {
+		return
+	}
+
+	timeLocks := st.NewTimeLocks(view)
+	ids := timeLocks.GetScheduledReleases(blockHeight)
+	if len(ids) == 0 {
+		return // no need to call timeLocks.RemoveScheduledReleases()
+	}
+
+	for _, id := range ids {
+		lock := timeLocks.Get(id)
+		if lock == nil {
+			log.Panicf("Failed to retrieve time lock %v scheduled for release at %v", id, blockHeight)
+		}
+
+		released := lock.ReleasableAmount(blockHeight)
+		if !released.IsNonnegative() {
+			log.Panicf("Invalid time lock release: lock = %v, height = %v", lock, blockHeight)
+		}
+		if !released.IsZero() {
+			beneficiaryAccount := view.GetOrCreateAccount(lock.Beneficiary)
+			beneficiaryAccount.Balance = beneficiaryAccount.Balance.Plus(released)
+			view.SetAccount(lock.Beneficiary, beneficiaryAccount)
+			lock.Released = lock.Released.Plus(released)
+		}
+
+		if blockHeight >= lock.ReleaseHeight {
+			timeLocks.Remove(lock)
+		} else {
+			timeLocks.Upsert(lock)
+			timeLocks.ScheduleRelease(lock.NextReleaseHeight(blockHeight), lock.ID)
+		}
+
+		logger.Infof("Time lock released: id = %v, beneficiary = %v, amount = %v",
+			lock.ID, lock.Beneficiary, released)
+	}
+
+	timeLocks.RemoveScheduledReleases(blockHeight)
+}
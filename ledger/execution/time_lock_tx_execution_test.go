@@ -0,0 +1,146 @@
+package execution
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var timeLockAmount = big.NewInt(3000)
+
+func setupTimeLockTest() *execTest {
+	et := NewExecTest()
+	common.RegisterForkSchedule(et.chainID, common.DevForkSchedule)
+
+	et.accIn.Balance = types.Coins{
+		ThetaWei: big.NewInt(10000),
+		TFuelWei: new(big.Int).Mul(big.NewInt(50), big.NewInt(getMinimumTxFeeJune2021())),
+	}
+	et.acc2State(et.accIn)
+	et.state().Commit()
+
+	return et
+}
+
+func teardownTimeLockTest(et *execTest) {
+	common.RegisterForkSchedule(et.chainID, common.MainnetForkSchedule)
+}
+
+func (et *execTest) makeTimeLockTx(source types.PrivAccount, seq uint64, beneficiary common.Address,
+	theta *big.Int, releaseHeight uint64, vesting bool) *types.TimeLockTx {
+	tx := &types.TimeLockTx{
+		Fee: types.NewCoins(0, getMinimumTxFeeJune2021()),
+		Source: types.TxInput{
+			Address:  source.Address,
+			Coins:    types.Coins{ThetaWei: theta, TFuelWei: big.NewInt(0)},
+			Sequence: seq,
+		},
+		Beneficiary:   beneficiary,
+		ReleaseHeight: releaseHeight,
+		Vesting:       vesting,
+	}
+	tx.Source.Signature = source.Sign(tx.SignBytes(et.chainID))
+	return tx
+}
+
+// releaseTimeLocksAt processes the time lock releases of the block at the given height.
+func (et *execTest) releaseTimeLocksAt(height uint64) *st.StoreView {
+	et.fastforwardTo(height - 1)
+	view := et.state().Delivered()
+	HandleTimeLockReleases(et.chainID, view)
+	return view
+}
+
+func TestTimeLock(t *testing.T) {
+	assert := assert.New(t)
+	et := setupTimeLockTest()
+	defer teardownTimeLockTest(et)
+
+	beneficiary := et.accOut.Address
+	blockHeight := et.state().Delivered().Height() + 1
+
+	// Invalid locks
+	_, res := et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, beneficiary, timeLockAmount, blockHeight, false))
+	assert.Equal(result.CodeInvalidTimeLockRelease, res.Code)
+	_, res = et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, beneficiary, big.NewInt(0), 50, false))
+	assert.Equal(result.CodeInvalidTimeLockAmount, res.Code)
+	_, res = et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, common.Address{}, timeLockAmount, 50, false))
+	assert.Equal(result.CodeInvalidTimeLockRecipient, res.Code)
+	_, res = et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, beneficiary, big.NewInt(10001), 50, false))
+	assert.Equal(result.CodeInsufficientFund, res.Code)
+
+	_, res = et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, beneficiary, timeLockAmount, 50, false))
+	assert.True(res.IsOK(), res.Message)
+
+	view := et.state().Delivered()
+	assert.Equal(big.NewInt(7000), view.GetAccount(et.accIn.Address).Balance.ThetaWei)
+	timeLocks := st.NewTimeLocks(view)
+	locks := timeLocks.GetByAddress(et.accIn.Address)
+	assert.Equal(1, len(locks))
+	assert.Equal(locks, timeLocks.GetByAddress(beneficiary))
+	assert.Equal(uint64(50), locks[0].ReleaseHeight)
+	assert.Equal(timeLockAmount, locks[0].Amount.ThetaWei)
+
+	// Nothing is released before the release height
+	view = et.releaseTimeLocksAt(49)
+	assert.Nil(view.GetAccount(beneficiary))
+	assert.Equal(1, len(st.NewTimeLocks(view).GetByAddress(beneficiary)))
+
+	view = et.releaseTimeLocksAt(50)
+	assert.Equal(timeLockAmount, view.GetAccount(beneficiary).Balance.ThetaWei)
+	assert.Equal(0, len(st.NewTimeLocks(view).GetByAddress(beneficiary)))
+	assert.Equal(0, len(st.NewTimeLocks(view).GetByAddress(et.accIn.Address)))
+	assert.Equal(0, len(st.NewTimeLocks(view).GetScheduledReleases(50)))
+}
+
+func TestTimeLockVesting(t *testing.T) {
+	assert := assert.New(t)
+	et := setupTimeLockTest()
+	defer teardownTimeLockTest(et)
+
+	beneficiary := et.accOut.Address
+	startHeight := et.state().Delivered().Height() + 1
+	releaseHeight := startHeight + 300
+
+	_, res := et.executor.ExecuteTx(et.makeTimeLockTx(et.accIn, 1, beneficiary, timeLockAmount, releaseHeight, true))
+	assert.True(res.IsOK(), res.Message)
+
+	// The vested coins are released at each checkpoint
+	view := et.releaseTimeLocksAt(101)
+	released := new(big.Int).Div(new(big.Int).Mul(timeLockAmount, big.NewInt(int64(101-startHeight))), big.NewInt(300))
+	assert.Equal(released, view.GetAccount(beneficiary).Balance.ThetaWei)
+	lock := st.NewTimeLocks(view).GetByAddress(beneficiary)[0]
+	assert.Equal(released, lock.Released.ThetaWei)
+
+	view = et.releaseTimeLocksAt(201)
+	released = new(big.Int).Div(new(big.Int).Mul(timeLockAmount, big.NewInt(int64(201-startHeight))), big.NewInt(300))
+	assert.Equal(released, view.GetAccount(beneficiary).Balance.ThetaWei)
+
+	et.releaseTimeLocksAt(301)
+	view = et.releaseTimeLocksAt(releaseHeight)
+	assert.Equal(timeLockAmount, view.GetAccount(beneficiary).Balance.ThetaWei)
+	assert.Equal(0, len(st.NewTimeLocks(view).GetByAddress(beneficiary)))
+}
+
+func TestTimeLockNextReleaseHeight(t *testing.T) {
+	assert := assert.New(t)
+
+	lock := types.NewTimeLock(1, common.Address{}, common.Address{}, types.NewCoins(300, 0), 10, 250, true)
+	assert.Equal(uint64(101), lock.NextReleaseHeight(10))
+	assert.Equal(uint64(101), lock.NextReleaseHeight(100))
+	assert.Equal(uint64(201), lock.NextReleaseHeight(101))
+	assert.Equal(uint64(250), lock.NextReleaseHeight(201))
+
+	assert.Equal(big.NewInt(0), lock.VestedAmount(10).ThetaWei)
+	assert.Equal(big.NewInt(120), lock.VestedAmount(106).ThetaWei)
+	assert.Equal(big.NewInt(300), lock.VestedAmount(250).ThetaWei)
+
+	lock.Vesting = false
+	assert.Equal(uint64(250), lock.NextReleaseHeight(10))
+	assert.Equal(big.NewInt(0), lock.VestedAmount(249).ThetaWei)
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"sort"
 
+	"github.com/spf13/viper"
 	"github.com/thetatoken/theta/blockchain"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/result"
@@ -98,7 +99,15 @@ func (exec *CoinbaseTxExecutor) sanityCheck(chainID string, view *st.StoreView,
 	guardianVotes := currentBlock.GuardianVotes
 	eliteEdgeNodeVotes := currentBlock.EliteEdgeNodeVotes
 	guardianPool, eliteEdgeNodePool := RetrievePools(exec.consensus.GetLedger(), exec.chain, exec.db, tx.BlockHeight, guardianVotes, eliteEdgeNodeVotes)
-	expectedRewards = CalculateReward(exec.consensus.GetLedger(), view, validatorSet, guardianVotes, guardianPool, eliteEdgeNodeVotes, eliteEdgeNodePool)
+
+	// The reward breakdown by stake is recorded at the checkpoints for the GetRewards RPC API
+	var records *[]*blockchain.RewardRecord
+	blockHeight := view.Height() + 1 // view points to the parent block
+	if viper.GetBool(common.CfgStorageRewardRecordsEnabled) && common.IsCheckPointHeight(blockHeight) {
+		records = &[]*blockchain.RewardRecord{}
+	}
+	expectedRewards = CalculateRewardWithRecords(exec.consensus.GetLedger(), chainID, view, validatorSet, guardianVotes, guardianPool,
+		eliteEdgeNodeVotes, eliteEdgeNodePool, records)
 
 	if len(expectedRewards) != len(tx.Outputs) {
 		return result.Error("Number of rewarded account is incorrect")
@@ -110,6 +119,10 @@ func (exec *CoinbaseTxExecutor) sanityCheck(chainID string, view *st.StoreView,
 				output.Address, exp, output.Coins)
 		}
 	}
+
+	if records != nil {
+		exec.chain.AddPendingRewardRecords(tx, blockHeight, *records)
+	}
 	return result.OK
 }
 
@@ -145,10 +158,11 @@ func RetrievePools(ledger core.Ledger, chain *blockchain.Chain, db database.Data
 	guardianPool = nil
 	eliteEdgeNodePool = nil
 
-	if blockHeight < common.HeightEnableTheta2 {
+	forks := common.GetForkSchedule(chain.ChainID)
+	if blockHeight < forks.EnableTheta2 {
 		guardianPool = nil
 		eliteEdgeNodePool = nil
-	} else if blockHeight < common.HeightEnableTheta3 {
+	} else if blockHeight < forks.EnableTheta3 {
 		if guardianVotes != nil {
 			guradianVoteBlock, err := chain.FindBlock(guardianVotes.Block)
 			if err != nil {
@@ -157,7 +171,7 @@ func RetrievePools(ledger core.Ledger, chain *blockchain.Chain, db database.Data
 			storeView := st.NewStoreView(guradianVoteBlock.Height, guradianVoteBlock.StateHash, db)
 			guardianPool = storeView.GetGuardianCandidatePool()
 		}
-	} else { // blockHeight >= common.HeightEnableTheta3
+	} else { // blockHeight >= forks.EnableTheta3
 		// won't reward the elite edge nodes without the guardian votes, since we need to guardian votes to confirm that
 		// the edge nodes vote for the correct checkpoint
 		if guardianVotes != nil {
@@ -185,20 +199,31 @@ func RetrievePools(ledger core.Ledger, chain *blockchain.Chain, db database.Data
 }
 
 // CalculateReward calculates the block reward for each account
-func CalculateReward(ledger core.Ledger, view *st.StoreView, validatorSet *core.ValidatorSet,
+func CalculateReward(ledger core.Ledger, chainID string, view *st.StoreView, validatorSet *core.ValidatorSet,
 	guardianVotes *core.AggregatedVotes, guardianPool *core.GuardianCandidatePool,
 	eliteEdgeNodeVotes *core.AggregatedEENVotes, eliteEdgeNodePool core.EliteEdgeNodePool) map[string]types.Coins {
+	return CalculateRewardWithRecords(ledger, chainID, view, validatorSet, guardianVotes, guardianPool,
+		eliteEdgeNodeVotes, eliteEdgeNodePool, nil)
+}
+
+// CalculateRewardWithRecords calculates the block reward for each account, and if records is
+// not nil, appends to it the reward granted for each stake
+func CalculateRewardWithRecords(ledger core.Ledger, chainID string, view *st.StoreView, validatorSet *core.ValidatorSet,
+	guardianVotes *core.AggregatedVotes, guardianPool *core.GuardianCandidatePool,
+	eliteEdgeNodeVotes *core.AggregatedEENVotes, eliteEdgeNodePool core.EliteEdgeNodePool,
+	records *[]*blockchain.RewardRecord) map[string]types.Coins {
 	accountReward := map[string]types.Coins{}
 	blockHeight := view.Height() + 1 // view points to the parent block
-	if blockHeight < common.HeightEnableValidatorReward {
+	forks := common.GetForkSchedule(chainID)
+	if blockHeight < forks.EnableValidatorReward {
 		grantValidatorsWithZeroReward(validatorSet, &accountReward)
-	} else if blockHeight < common.HeightEnableTheta2 || guardianVotes == nil || guardianPool == nil {
-		grantValidatorReward(ledger, view, validatorSet, &accountReward, blockHeight)
-	} else if blockHeight < common.HeightEnableTheta3 {
-		grantValidatorAndGuardianReward(ledger, view, validatorSet, guardianVotes, guardianPool, &accountReward, blockHeight)
-	} else { // blockHeight >= common.HeightEnableTheta3
-		grantValidatorAndGuardianReward(ledger, view, validatorSet, guardianVotes, guardianPool, &accountReward, blockHeight)
-		grantEliteEdgeNodeReward(ledger, view, guardianVotes, eliteEdgeNodeVotes, eliteEdgeNodePool, &accountReward, blockHeight)
+	} else if blockHeight < forks.EnableTheta2 || guardianVotes == nil || guardianPool == nil {
+		grantValidatorReward(ledger, view, validatorSet, &accountReward, records, blockHeight)
+	} else if blockHeight < forks.EnableTheta3 {
+		grantValidatorAndGuardianReward(ledger, view, validatorSet, guardianVotes, guardianPool, &accountReward, records, forks, blockHeight)
+	} else { // blockHeight >= forks.EnableTheta3
+		grantValidatorAndGuardianReward(ledger, view, validatorSet, guardianVotes, guardianPool, &accountReward, records, forks, blockHeight)
+		grantEliteEdgeNodeReward(ledger, view, guardianVotes, eliteEdgeNodeVotes, eliteEdgeNodePool, &accountReward, records, forks, blockHeight)
 	}
 
 	addrs := []string{}
@@ -222,7 +247,8 @@ func grantValidatorsWithZeroReward(validatorSet *core.ValidatorSet, accountRewar
 	}
 }
 
-func grantValidatorReward(ledger core.Ledger, view *st.StoreView, validatorSet *core.ValidatorSet, accountReward *map[string]types.Coins, blockHeight uint64) {
+func grantValidatorReward(ledger core.Ledger, view *st.StoreView, validatorSet *core.ValidatorSet, accountReward *map[string]types.Coins,
+	records *[]*blockchain.RewardRecord, blockHeight uint64) {
 	if !common.IsCheckPointHeight(blockHeight) {
 		return
 	}
@@ -275,6 +301,7 @@ func grantValidatorReward(ledger core.Ledger, view *st.StoreView, validatorSet *
 			TFuelWei: rewardAmount,
 		}.NoNil()
 		(*accountReward)[string(stakeSourceAddr[:])] = reward
+		addRewardRecord(records, blockchain.NewRewardRecord(blockchain.RewardTypeBlock, stakeSourceAddr, common.Address{}, rewardAmount))
 
 		logger.Infof("Block reward for staker %v : %v", hex.EncodeToString(stakeSourceAddr[:]), reward)
 	}
@@ -282,7 +309,7 @@ func grantValidatorReward(ledger core.Ledger, view *st.StoreView, validatorSet *
 
 // grant block rewards to both the validators and active guardians (they are both theta stakers)
 func grantValidatorAndGuardianReward(ledger core.Ledger, view *st.StoreView, validatorSet *core.ValidatorSet, guardianVotes *core.AggregatedVotes,
-	guardianPool *core.GuardianCandidatePool, accountReward *map[string]types.Coins, blockHeight uint64) {
+	guardianPool *core.GuardianCandidatePool, accountReward *map[string]types.Coins, records *[]*blockchain.RewardRecord, forks *common.ForkSchedule, blockHeight uint64) {
 	if !common.IsCheckPointHeight(blockHeight) {
 		return
 	}
@@ -352,23 +379,23 @@ func grantValidatorAndGuardianReward(ledger core.Ledger, view *st.StoreView, val
 	totalReward := big.NewInt(1).Mul(tfuelRewardPerBlock, big.NewInt(common.CheckpointInterval))
 
 	var srdsr *st.StakeRewardDistributionRuleSet
-	if blockHeight >= common.HeightEnableTheta3 {
+	if blockHeight >= forks.EnableTheta3 {
 		srdsr = state.NewStakeRewardDistributionRuleSet(view)
 	}
 
-	if blockHeight < common.HeightSampleStakingReward {
+	if blockHeight < forks.SampleStakingReward {
 		// the source of the stake divides the block reward proportional to their stake
-		issueFixedReward(effectiveStakes, totalStake, accountReward, totalReward, srdsr, "Block")
+		issueFixedReward(effectiveStakes, totalStake, accountReward, records, totalReward, srdsr, blockchain.RewardTypeBlock)
 	} else {
 		// randomly select (proportional to the stake) a constant-sized set of stakers and grand the block reward
 		issueRandomizedReward(ledger, guardianVotes, view, effectiveStakes,
-			totalStake, accountReward, totalReward, srdsr, "Block")
+			totalStake, accountReward, records, totalReward, srdsr, blockchain.RewardTypeBlock)
 	}
 }
 
 // grant uptime mining rewards to active elite edge nodes (they are the tfuel stakers)
 func grantEliteEdgeNodeReward(ledger core.Ledger, view *st.StoreView, guardianVotes *core.AggregatedVotes, eliteEdgeNodeVotes *core.AggregatedEENVotes,
-	eliteEdgeNodePool core.EliteEdgeNodePool, accountReward *map[string]types.Coins, blockHeight uint64) {
+	eliteEdgeNodePool core.EliteEdgeNodePool, accountReward *map[string]types.Coins, records *[]*blockchain.RewardRecord, forks *common.ForkSchedule, blockHeight uint64) {
 	if !common.IsCheckPointHeight(blockHeight) {
 		return
 	}
@@ -434,12 +461,12 @@ func grantEliteEdgeNodeReward(ledger core.Ledger, view *st.StoreView, guardianVo
 	logger.Debugf("grantEliteEdgeNodeReward: totalEffectiveStake = %v, totalReward = %v", totalEffectiveStake, totalReward)
 
 	var srdsr *st.StakeRewardDistributionRuleSet
-	if blockHeight >= common.HeightEnableTheta3 {
+	if blockHeight >= forks.EnableTheta3 {
 		srdsr = state.NewStakeRewardDistributionRuleSet(view)
 	}
 
 	// the source of the stake divides the block reward proportional to their stake
-	issueFixedReward(effectiveStakes, totalEffectiveStake, accountReward, totalReward, srdsr, "EEN  ")
+	issueFixedReward(effectiveStakes, totalEffectiveStake, accountReward, records, totalReward, srdsr, blockchain.RewardTypeEliteEdgeNode)
 
 }
 
@@ -457,7 +484,16 @@ func addRewardToMap(receiver common.Address, amount *big.Int, accountReward *map
 	}
 }
 
-func handleSplit(stake *core.Stake, srdsr *st.StakeRewardDistributionRuleSet, reward *big.Int, accountRewardMap *map[string]types.Coins) {
+// addRewardRecord appends the record if the reward records are requested
+func addRewardRecord(records *[]*blockchain.RewardRecord, record *blockchain.RewardRecord) {
+	if records == nil {
+		return
+	}
+	*records = append(*records, record)
+}
+
+func handleSplit(stake *core.Stake, srdsr *st.StakeRewardDistributionRuleSet, reward *big.Int, accountRewardMap *map[string]types.Coins,
+	records *[]*blockchain.RewardRecord, rewardType blockchain.RewardType) {
 	if srdsr == nil {
 		// Should not happen
 		logger.Panic("srdsr is nil")
@@ -470,6 +506,7 @@ func handleSplit(stake *core.Stake, srdsr *st.StakeRewardDistributionRuleSet, re
 	rewardDistribution := srdsr.Get(stake.Holder)
 	if rewardDistribution == nil {
 		addRewardToMap(stake.Source, reward, accountRewardMap)
+		addRewardRecord(records, blockchain.NewRewardRecord(rewardType, stake.Source, stake.Holder, reward))
 		return
 	}
 
@@ -493,9 +530,15 @@ func handleSplit(stake *core.Stake, srdsr *st.StakeRewardDistributionRuleSet, re
 
 	addRewardToMap(stake.Source, sourceReward, accountRewardMap)
 	addRewardToMap(rewardDistribution.Beneficiary, splitReward, accountRewardMap)
+
+	record := blockchain.NewRewardRecord(rewardType, stake.Source, stake.Holder, sourceReward)
+	record.Beneficiary = rewardDistribution.Beneficiary
+	record.SplitAmount = splitReward
+	addRewardRecord(records, record)
 }
 
-func issueFixedReward(effectiveStakes [][]*core.Stake, totalStake *big.Int, accountReward *map[string]types.Coins, totalReward *big.Int, srdsr *st.StakeRewardDistributionRuleSet, rewardType string) {
+func issueFixedReward(effectiveStakes [][]*core.Stake, totalStake *big.Int, accountReward *map[string]types.Coins, records *[]*blockchain.RewardRecord,
+	totalReward *big.Int, srdsr *st.StakeRewardDistributionRuleSet, rewardType blockchain.RewardType) {
 	if totalStake.Cmp(big.NewInt(0)) == 0 {
 		return
 	}
@@ -510,7 +553,7 @@ func issueFixedReward(effectiveStakes [][]*core.Stake, totalStake *big.Int, acco
 				logger.Infof("%v reward for staker %v : %v  (before split)", rewardType, hex.EncodeToString(stake.Source[:]), rewardAmount)
 
 				// Calculate split
-				handleSplit(stake, srdsr, rewardAmount, accountReward)
+				handleSplit(stake, srdsr, rewardAmount, accountReward, records, rewardType)
 			}
 		}
 	} else {
@@ -527,6 +570,7 @@ func issueFixedReward(effectiveStakes [][]*core.Stake, totalStake *big.Int, acco
 			rewardAmount.Mul(totalReward, totalSourceStake)
 			rewardAmount.Div(rewardAmount, totalStake)
 			addRewardToMap(stakes[0].Source, rewardAmount, accountReward)
+			addRewardRecord(records, blockchain.NewRewardRecord(rewardType, stakes[0].Source, common.Address{}, rewardAmount))
 
 			logger.Infof("%v reward for staker %v : %v  (before split)", rewardType, hex.EncodeToString(stakes[0].Source[:]), rewardAmount)
 		}
@@ -534,7 +578,8 @@ func issueFixedReward(effectiveStakes [][]*core.Stake, totalStake *big.Int, acco
 }
 
 func issueRandomizedReward(ledger core.Ledger, guardianVotes *core.AggregatedVotes, view *st.StoreView, effectiveStakes [][]*core.Stake,
-	totalStake *big.Int, accountReward *map[string]types.Coins, totalReward *big.Int, srdsr *st.StakeRewardDistributionRuleSet, rewardType string) {
+	totalStake *big.Int, accountReward *map[string]types.Coins, records *[]*blockchain.RewardRecord, totalReward *big.Int,
+	srdsr *st.StakeRewardDistributionRuleSet, rewardType blockchain.RewardType) {
 
 	if guardianVotes == nil {
 		// Should never reach here
@@ -596,7 +641,7 @@ func issueRandomizedReward(ledger core.Ledger, guardianVotes *core.AggregatedVot
 					logger.Infof("%v reward for staker %v : %v (before split)", rewardType, hex.EncodeToString(stakeSourceAddr[:]), rewardAmount)
 
 					// Calculate split
-					handleSplit(stake, srdsr, rewardAmount, accountReward)
+					handleSplit(stake, srdsr, rewardAmount, accountReward, records, rewardType)
 				}
 			}
 		}
@@ -635,6 +680,7 @@ func issueRandomizedReward(ledger core.Ledger, guardianVotes *core.AggregatedVot
 				rewardAmount := tmp.Div(tmp, big.NewInt(int64(tfuelRewardN)))
 
 				addRewardToMap(stakeSourceAddr, rewardAmount, accountReward)
+				addRewardRecord(records, blockchain.NewRewardRecord(rewardType, stakeSourceAddr, common.Address{}, rewardAmount))
 
 				logger.Infof("%v reward for staker %v : %v (before split)", rewardType, hex.EncodeToString(stakeSourceAddr[:]), rewardAmount)
 			}
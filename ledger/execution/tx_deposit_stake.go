@@ -31,7 +31,8 @@ func NewDepositStakeExecutor(state *st.LedgerState) *DepositStakeExecutor {
 func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
 	// Feature block height check
 	blockHeight := view.Height() + 1 // the view points to the parent of the current block
-	if _, ok := transaction.(*types.DepositStakeTxV2); ok && blockHeight < common.HeightEnableTheta2 {
+	forks := common.GetForkSchedule(chainID)
+	if _, ok := transaction.(*types.DepositStakeTxV2); ok && blockHeight < forks.EnableTheta2 {
 		return result.Error("Feature guardian is not active yet")
 	}
 
@@ -48,13 +49,13 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 	}
 
 	signBytes := tx.SignBytes(chainID)
-	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, blockHeight)
+	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, chainID, blockHeight)
 	if res.IsError() {
 		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Source.Address.Hex(), res))
 		return res
 	}
 
-	if minTxFee, success := sanityCheckForFee(tx.Fee, blockHeight); !success {
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
 		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
 			minTxFee).WithErrorCode(result.CodeInvalidFee)
 	}
@@ -82,10 +83,7 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 
 	// Minimum stake deposit requirement to avoid spamming
 	if tx.Purpose == core.StakeForValidator {
-		minValidatorStake := core.MinValidatorStakeDeposit
-		if blockHeight >= common.HeightValidatorStakeChangedTo200K {
-			minValidatorStake = core.MinValidatorStakeDeposit200K
-		}
+		minValidatorStake := getMinStakeDeposit(view, tx.Purpose, chainID, blockHeight)
 		if stake.ThetaWei.Cmp(minValidatorStake) < 0 {
 			return result.Error("Insufficient amount of stake, at least %v ThetaWei is required for each validator deposit", minValidatorStake).
 				WithErrorCode(result.CodeInsufficientStake)
@@ -93,10 +91,7 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 	}
 
 	if tx.Purpose == core.StakeForGuardian {
-		minGuardianStake := core.MinGuardianStakeDeposit
-		if blockHeight >= common.HeightLowerGNStakeThresholdTo1000 {
-			minGuardianStake = core.MinGuardianStakeDeposit1000
-		}
+		minGuardianStake := getMinStakeDeposit(view, tx.Purpose, chainID, blockHeight)
 		if stake.ThetaWei.Cmp(minGuardianStake) < 0 {
 			return result.Error("Insufficient amount of stake, at least %v ThetaWei is required for each guardian deposit", minGuardianStake).
 				WithErrorCode(result.CodeInsufficientStake)
@@ -104,12 +99,12 @@ func (exec *DepositStakeExecutor) sanityCheck(chainID string, view *st.StoreView
 	}
 
 	if tx.Purpose == core.StakeForEliteEdgeNode {
-		if blockHeight < common.HeightEnableTheta3 {
-			return result.Error(fmt.Sprintf("Elite Edge Node staking not enabled yet, please wait until block height %v", common.HeightEnableTheta3)).WithErrorCode(result.CodeGenericError)
+		if blockHeight < forks.EnableTheta3 {
+			return result.Error(fmt.Sprintf("Elite Edge Node staking not enabled yet, please wait until block height %v", forks.EnableTheta3)).WithErrorCode(result.CodeGenericError)
 		}
 
-		minEliteEdgeNodeStake := core.MinEliteEdgeNodeStakeDeposit
-		maxEliteEdgeNodeStake := core.MaxEliteEdgeNodeStakeDeposit
+		minEliteEdgeNodeStake := getMinStakeDeposit(view, tx.Purpose, chainID, blockHeight)
+		maxEliteEdgeNodeStake := state.NewGovernance(view).GetParamOrDefault(core.ParamMaxEliteEdgeNodeStake, core.MaxEliteEdgeNodeStakeDeposit)
 
 		if stake.ThetaWei.Cmp(big.NewInt(0)) > 0 {
 			return result.Error("Only TFuel can be deposited for elite edge nodes").
@@ -166,7 +161,7 @@ func (exec *DepositStakeExecutor) process(chainID string, view *st.StoreView, tr
 		sourceAccount.Balance = sourceAccount.Balance.Minus(stake)
 		stakeAmount := stake.ThetaWei
 		vcp := view.GetValidatorCandidatePool()
-		err := vcp.DepositStake(sourceAddress, holderAddress, stakeAmount, blockHeight)
+		err := vcp.DepositStake(sourceAddress, holderAddress, stakeAmount, chainID, blockHeight)
 		if err != nil {
 			return common.Hash{}, result.Error("Failed to deposit stake, err: %v", err)
 		}
@@ -183,7 +178,7 @@ func (exec *DepositStakeExecutor) process(chainID string, view *st.StoreView, tr
 			}
 		}
 
-		err := gcp.DepositStake(sourceAddress, holderAddress, stakeAmount, tx.BlsPubkey, blockHeight)
+		err := gcp.DepositStake(sourceAddress, holderAddress, stakeAmount, tx.BlsPubkey, chainID, blockHeight)
 		if err != nil {
 			return common.Hash{}, result.Error("Failed to deposit stake, err: %v", err)
 		}
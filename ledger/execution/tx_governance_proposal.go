@@ -0,0 +1,129 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var _ TxExecutor = (*GovernanceProposalTxExecutor)(nil)
+
+// ------------------------------- GovernanceProposal Transaction -----------------------------------
+
+// GovernanceProposalTxExecutor implements the TxExecutor interface
+type GovernanceProposalTxExecutor struct {
+	state        *st.LedgerState
+	votingStakes *votingStakeCache
+}
+
+// NewGovernanceProposalTxExecutor creates a new instance of GovernanceProposalTxExecutor
+func NewGovernanceProposalTxExecutor(state *st.LedgerState, votingStakes *votingStakeCache) *GovernanceProposalTxExecutor {
+	return &GovernanceProposalTxExecutor{
+		state:        state,
+		votingStakes: votingStakes,
+	}
+}
+
+func (exec *GovernanceProposalTxExecutor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.GovernanceProposalTx)
+
+	res := tx.Proposer.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	proposerAccount, success := getInput(view, tx.Proposer)
+	if success.IsError() {
+		return result.Error("Failed to get the proposer account: %v", tx.Proposer.Address)
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(proposerAccount, signBytes, tx.Proposer, chainID, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Proposer.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	if !core.IsGovernanceParam(tx.Param) {
+		return result.Error("Unknown governance parameter: %v", tx.Param)
+	}
+
+	if err := validateGovernanceParam(view, chainID, blockHeight, tx.Param, tx.Value); err != nil {
+		return result.Error("Invalid proposal: %v", err)
+	}
+
+	if tx.VotingPeriod < core.MinGovernanceVotingPeriod || tx.VotingPeriod > core.MaxGovernanceVotingPeriod {
+		return result.Error("Invalid voting period, it should be between %v and %v blocks",
+			core.MinGovernanceVotingPeriod, core.MaxGovernanceVotingPeriod)
+	}
+
+	if exec.votingStakes.getStake(view, tx.Proposer.Address).Sign() <= 0 {
+		return result.Error("Only the validator candidates and guardians can submit proposals").
+			WithErrorCode(result.CodeInsufficientStake)
+	}
+
+	minimalBalance := tx.Fee
+	if !proposerAccount.Balance.IsGTE(minimalBalance) {
+		logger.Infof(fmt.Sprintf("GovernanceProposal: Proposer did not have enough balance %v", tx.Proposer.Address.Hex()))
+		return result.Error("GovernanceProposal: Proposer balance is %v, but required minimal balance is %v",
+			proposerAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *GovernanceProposalTxExecutor) process(chainID string, view *st.StoreView, transaction types.Tx) (common.Hash, result.Result) {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+
+	tx := transaction.(*types.GovernanceProposalTx)
+
+	proposerAccount, success := getInput(view, tx.Proposer)
+	if success.IsError() {
+		return common.Hash{}, result.Error("Failed to get the proposer account")
+	}
+
+	if !chargeFee(proposerAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	gov := st.NewGovernance(view)
+	id := gov.NextProposalID()
+	proposal := core.NewGovernanceProposal(id, tx.Proposer.Address, tx.Param, tx.Value,
+		blockHeight, blockHeight+tx.VotingPeriod)
+	gov.UpsertProposal(proposal)
+	gov.SetActiveProposalIDs(append(gov.GetActiveProposalIDs(), id))
+
+	proposerAccount.Sequence++
+	view.SetAccount(tx.Proposer.Address, proposerAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *GovernanceProposalTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.GovernanceProposalTx)
+	return &core.TxInfo{
+		Address:           tx.Proposer.Address,
+		Sequence:          tx.Proposer.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *GovernanceProposalTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.GovernanceProposalTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.TFuelWei, gas)
+	return effectiveGasPrice
+}
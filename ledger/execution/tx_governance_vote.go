@@ -0,0 +1,123 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var _ TxExecutor = (*GovernanceVoteTxExecutor)(nil)
+
+// ------------------------------- GovernanceVote Transaction -----------------------------------
+
+// GovernanceVoteTxExecutor implements the TxExecutor interface
+type GovernanceVoteTxExecutor struct {
+	state        *st.LedgerState
+	votingStakes *votingStakeCache
+}
+
+// NewGovernanceVoteTxExecutor creates a new instance of GovernanceVoteTxExecutor
+func NewGovernanceVoteTxExecutor(state *st.LedgerState, votingStakes *votingStakeCache) *GovernanceVoteTxExecutor {
+	return &GovernanceVoteTxExecutor{
+		state:        state,
+		votingStakes: votingStakes,
+	}
+}
+
+func (exec *GovernanceVoteTxExecutor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.GovernanceVoteTx)
+
+	res := tx.Voter.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	voterAccount, success := getInput(view, tx.Voter)
+	if success.IsError() {
+		return result.Error("Failed to get the voter account: %v", tx.Voter.Address)
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(voterAccount, signBytes, tx.Voter, chainID, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Voter.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	proposal := st.NewGovernance(view).GetProposal(tx.ProposalID)
+	if proposal == nil {
+		return result.Error("Governance proposal %v not found", tx.ProposalID)
+	}
+	if proposal.Status != core.ProposalStatusVoting || blockHeight > proposal.VotingEndHeight {
+		return result.Error("Voting on governance proposal %v has ended", tx.ProposalID)
+	}
+
+	if exec.votingStakes.getStake(view, tx.Voter.Address).Sign() <= 0 {
+		return result.Error("Only the validator candidates and guardians can vote").
+			WithErrorCode(result.CodeInsufficientStake)
+	}
+
+	minimalBalance := tx.Fee
+	if !voterAccount.Balance.IsGTE(minimalBalance) {
+		logger.Infof(fmt.Sprintf("GovernanceVote: Voter did not have enough balance %v", tx.Voter.Address.Hex()))
+		return result.Error("GovernanceVote: Voter balance is %v, but required minimal balance is %v",
+			voterAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *GovernanceVoteTxExecutor) process(chainID string, view *st.StoreView, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.GovernanceVoteTx)
+
+	voterAccount, success := getInput(view, tx.Voter)
+	if success.IsError() {
+		return common.Hash{}, result.Error("Failed to get the voter account")
+	}
+
+	if !chargeFee(voterAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	gov := st.NewGovernance(view)
+	proposal := gov.GetProposal(tx.ProposalID)
+	if proposal == nil || proposal.Status != core.ProposalStatusVoting {
+		return common.Hash{}, result.Error("Governance proposal %v is not open for voting", tx.ProposalID)
+	}
+	proposal.Vote(tx.Voter.Address, tx.Approve)
+	gov.UpsertProposal(proposal)
+
+	voterAccount.Sequence++
+	view.SetAccount(tx.Voter.Address, voterAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *GovernanceVoteTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.GovernanceVoteTx)
+	return &core.TxInfo{
+		Address:           tx.Voter.Address,
+		Sequence:          tx.Voter.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *GovernanceVoteTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.GovernanceVoteTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.TFuelWei, gas)
+	return effectiveGasPrice
+}
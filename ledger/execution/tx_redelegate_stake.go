@@ -0,0 +1,172 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/state"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var _ TxExecutor = (*RedelegateStakeExecutor)(nil)
+
+// ------------------------------- RedelegateStake Transaction -----------------------------------
+
+// RedelegateStakeExecutor implements the TxExecutor interface
+type RedelegateStakeExecutor struct {
+	state *st.LedgerState
+}
+
+// NewRedelegateStakeExecutor creates a new instance of RedelegateStakeExecutor
+func NewRedelegateStakeExecutor(state *st.LedgerState) *RedelegateStakeExecutor {
+	return &RedelegateStakeExecutor{
+		state: state,
+	}
+}
+
+func (exec *RedelegateStakeExecutor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.RedelegateStakeTx)
+
+	res := tx.Source.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	res = validateOutputsBasic([]types.TxOutput{tx.FromHolder, tx.ToHolder})
+	if res.IsError() {
+		return res
+	}
+
+	if tx.FromHolder.Address == tx.ToHolder.Address {
+		return result.Error("Cannot redelegate stake to the same holder")
+	}
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return result.Error("Failed to get the source account: %v", tx.Source.Address)
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, chainID, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Source.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	amount, res := sanityCheckForStakeAmount(tx.Purpose, tx.Amount)
+	if res.IsError() {
+		return res
+	}
+
+	// The redelegated stake is a deposit to the new holder, the minimum deposit applies
+	minStake := getMinStakeDeposit(view, tx.Purpose, chainID, blockHeight)
+	if amount.Cmp(minStake) < 0 {
+		return result.Error("Insufficient amount of stake, at least %v is required for each redelegation", minStake).
+			WithErrorCode(result.CodeInsufficientStake)
+	}
+
+	res = sanityCheckForRemainingStake(view, tx.Purpose, tx.Source.Address, tx.FromHolder.Address, amount, chainID, blockHeight)
+	if res.IsError() {
+		return res
+	}
+
+	minimalBalance := tx.Fee
+	if !sourceAccount.Balance.IsGTE(minimalBalance) {
+		logger.Infof(fmt.Sprintf("RedelegateStake: Source did not have enough balance %v", tx.Source.Address.Hex()))
+		return result.Error("RedelegateStake: Source balance is %v, but required minimal balance is %v",
+			sourceAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+func (exec *RedelegateStakeExecutor) process(chainID string, view *st.StoreView, transaction types.Tx) (common.Hash, result.Result) {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+
+	tx := transaction.(*types.RedelegateStakeTx)
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return common.Hash{}, result.Error("Failed to get the source account")
+	}
+
+	if !chargeFee(sourceAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	amount, res := sanityCheckForStakeAmount(tx.Purpose, tx.Amount)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	sourceAddress := tx.Source.Address
+	fromAddress := tx.FromHolder.Address
+	toAddress := tx.ToHolder.Address
+
+	if tx.Purpose == core.StakeForValidator {
+		vcp := view.GetValidatorCandidatePool()
+		err := vcp.RedelegateStake(sourceAddress, fromAddress, toAddress, amount, chainID, blockHeight)
+		if err != nil {
+			return common.Hash{}, result.Error("Failed to redelegate stake, err: %v", err)
+		}
+		view.UpdateValidatorCandidatePool(vcp)
+	} else if tx.Purpose == core.StakeForGuardian {
+		gcp := view.GetGuardianCandidatePool()
+		err := gcp.RedelegateStake(sourceAddress, fromAddress, toAddress, amount, chainID, blockHeight)
+		if err != nil {
+			return common.Hash{}, result.Error("Failed to redelegate stake, err: %v", err)
+		}
+		view.UpdateGuardianCandidatePool(gcp)
+	} else if tx.Purpose == core.StakeForEliteEdgeNode {
+		eenp := state.NewEliteEdgeNodePool(view, false)
+		err := eenp.RedelegateStake(sourceAddress, fromAddress, toAddress, amount, chainID, blockHeight)
+		if err != nil {
+			return common.Hash{}, result.Error("Failed to redelegate stake, err: %v", err)
+		}
+	} else {
+		return common.Hash{}, result.Error("Invalid staking purpose").WithErrorCode(result.CodeInvalidStakePurpose)
+	}
+
+	// Only update stake transaction height list for validator stake tx.
+	if tx.Purpose == core.StakeForValidator {
+		hl := view.GetStakeTransactionHeightList()
+		if hl == nil {
+			hl = &types.HeightList{}
+		}
+		hl.Append(blockHeight)
+		view.UpdateStakeTransactionHeightList(hl)
+	}
+
+	sourceAccount.Sequence++
+	view.SetAccount(sourceAddress, sourceAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *RedelegateStakeExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.RedelegateStakeTx)
+	return &core.TxInfo{
+		Address:           tx.Source.Address,
+		Sequence:          tx.Source.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *RedelegateStakeExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.RedelegateStakeTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.TFuelWei, gas)
+	return effectiveGasPrice
+}
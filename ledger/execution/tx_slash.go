@@ -170,7 +170,7 @@ func (exec *SlashTxExecutor) verifySlashProof(chainID string, slashedAccount *ty
 				return false // servicePaymentTx not signed by the slashed account
 			}
 
-			paymentKey := string(servicePaymentTx.Target.Address[:]) + "." + string(servicePaymentTx.PaymentSequence)
+			paymentKey := string(servicePaymentTx.Target.Address[:]) + "." + string(rune(servicePaymentTx.PaymentSequence))
 			_, targetExists := settledPaymentLookup[paymentKey]
 			if targetExists {
 				return false // to prevent using partial payments as proof
@@ -46,13 +46,13 @@ func (exec *SmartContractTxExecutor) sanityCheck(chainID string, view *st.StoreV
 	// Check signatures
 	signBytes := tx.SignBytes(chainID)
 	nativeSignatureValid := tx.From.Signature.Verify(signBytes, tx.From.Address)
-	if blockHeight >= common.HeightTxWrapperExtension {
+	if blockHeight >= common.GetForkSchedule(chainID).TxWrapperExtension {
 		signBytesV2 := types.ChangeEthereumTxWrapper(signBytes, 2)
 		nativeSignatureValid = nativeSignatureValid || tx.From.Signature.Verify(signBytesV2, tx.From.Address)
 	}
 
 	if !nativeSignatureValid {
-		if blockHeight < common.HeightRPCCompatibility {
+		if blockHeight < common.GetForkSchedule(chainID).RPCCompatibility {
 			return result.Error("Signature verification failed, SignBytes: %v",
 				hex.EncodeToString(signBytes)).WithErrorCode(result.CodeInvalidSignature)
 		}
@@ -97,13 +97,13 @@ func (exec *SmartContractTxExecutor) sanityCheck(chainID string, view *st.StoreV
 			WithErrorCode(result.CodeInvalidValueToTransfer)
 	}
 
-	if !sanityCheckForGasPrice(tx.GasPrice, blockHeight) {
-		minimumGasPrice := types.GetMinimumGasPrice(blockHeight)
+	if !sanityCheckForGasPrice(view, tx.GasPrice, chainID, blockHeight) {
+		minimumGasPrice := getMinimumGasPrice(view, chainID, blockHeight)
 		return result.Error("Insufficient gas price. Gas price needs to be at least %v TFuelWei", minimumGasPrice).
 			WithErrorCode(result.CodeInvalidGasPrice)
 	}
 
-	maxGasLimit := types.GetMaxGasLimit(blockHeight)
+	maxGasLimit := getMaxGasLimit(view, chainID, blockHeight)
 	if new(big.Int).SetUint64(tx.GasLimit).Cmp(maxGasLimit) > 0 {
 		return result.Error("Invalid gas limit. Gas limit needs to be at most %v", maxGasLimit).
 			WithErrorCode(result.CodeInvalidGasLimit)
@@ -121,7 +121,7 @@ func (exec *SmartContractTxExecutor) sanityCheck(chainID string, view *st.StoreV
 	var minimalBalance types.Coins
 	value := coins.TFuelWei      // NoNil() already guarantees value is NOT nil
 	thetaValue := coins.ThetaWei // NoNil() already guarantees value is NOT nil
-	if !vm.SupportThetaTransferInEVM(blockHeight) {
+	if !vm.SupportThetaTransferInEVM(common.GetForkSchedule(chainID), blockHeight) {
 		minimalBalance = types.Coins{
 			ThetaWei: zero,
 			TFuelWei: feeLimit.Add(feeLimit, value),
@@ -0,0 +1,129 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var _ TxExecutor = (*TimeLockTxExecutor)(nil)
+
+// ------------------------------- TimeLock Transaction -----------------------------------
+
+// TimeLockTxExecutor implements the TxExecutor interface
+type TimeLockTxExecutor struct {
+	state *st.LedgerState
+}
+
+// NewTimeLockTxExecutor creates a new instance of TimeLockTxExecutor
+func NewTimeLockTxExecutor(state *st.LedgerState) *TimeLockTxExecutor {
+	return &TimeLockTxExecutor{
+		state: state,
+	}
+}
+
+func (exec *TimeLockTxExecutor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.TimeLockTx)
+
+	res := tx.Source.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return result.Error("Failed to get the source account: %v", tx.Source.Address)
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, chainID, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Source.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	coins := tx.Source.Coins.NoNil()
+	if !coins.IsPositive() {
+		return result.Error("Amount to lock not specified").
+			WithErrorCode(result.CodeInvalidTimeLockAmount)
+	}
+
+	if tx.Beneficiary == (common.Address{}) {
+		return result.Error("Beneficiary not specified").
+			WithErrorCode(result.CodeInvalidTimeLockRecipient)
+	}
+
+	if tx.ReleaseHeight <= blockHeight {
+		return result.Error("Release height %v should be higher than the current height %v",
+			tx.ReleaseHeight, blockHeight).WithErrorCode(result.CodeInvalidTimeLockRelease)
+	}
+
+	minimalBalance := coins.Plus(tx.Fee)
+	if !sourceAccount.Balance.IsGTE(minimalBalance) {
+		logger.Infof(fmt.Sprintf("TimeLock: Source did not have enough balance %v", tx.Source.Address.Hex()))
+		return result.Error("Insufficient fund: Source balance is %v, but required minimal balance is %v",
+			sourceAccount.Balance, minimalBalance).WithErrorCode(result.CodeInsufficientFund)
+	}
+
+	return result.OK
+}
+
+func (exec *TimeLockTxExecutor) process(chainID string, view *st.StoreView, transaction types.Tx) (common.Hash, result.Result) {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+
+	tx := transaction.(*types.TimeLockTx)
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return common.Hash{}, result.Error("Failed to get the source account")
+	}
+
+	if !chargeFee(sourceAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	coins := tx.Source.Coins.NoNil()
+	if !sourceAccount.Balance.IsGTE(coins) {
+		return common.Hash{}, result.Error("Insufficient fund to lock").WithErrorCode(result.CodeInsufficientFund)
+	}
+	sourceAccount.Balance = sourceAccount.Balance.Minus(coins)
+
+	timeLocks := st.NewTimeLocks(view)
+	lock := types.NewTimeLock(timeLocks.NextID(), tx.Source.Address, tx.Beneficiary, coins,
+		blockHeight, tx.ReleaseHeight, tx.Vesting)
+	timeLocks.Add(lock, blockHeight)
+
+	sourceAccount.Sequence++
+	view.SetAccount(tx.Source.Address, sourceAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *TimeLockTxExecutor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.TimeLockTx)
+	return &core.TxInfo{
+		Address:           tx.Source.Address,
+		Sequence:          tx.Source.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *TimeLockTxExecutor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.TimeLockTx)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.TFuelWei, gas)
+	return effectiveGasPrice
+}
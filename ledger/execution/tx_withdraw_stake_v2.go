@@ -0,0 +1,159 @@
+package execution
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/result"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/state"
+	st "github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+var _ TxExecutor = (*WithdrawStakeV2Executor)(nil)
+
+// ------------------------------- WithdrawStakeV2 Transaction -----------------------------------
+
+// WithdrawStakeV2Executor implements the TxExecutor interface
+type WithdrawStakeV2Executor struct {
+	state *st.LedgerState
+}
+
+// NewWithdrawStakeV2Executor creates a new instance of WithdrawStakeV2Executor
+func NewWithdrawStakeV2Executor(state *st.LedgerState) *WithdrawStakeV2Executor {
+	return &WithdrawStakeV2Executor{
+		state: state,
+	}
+}
+
+func (exec *WithdrawStakeV2Executor) sanityCheck(chainID string, view *st.StoreView, transaction types.Tx) result.Result {
+	blockHeight := view.Height() + 1 // the view points to the parent of the current block
+	tx := transaction.(*types.WithdrawStakeTxV2)
+
+	res := tx.Source.ValidateBasic()
+	if res.IsError() {
+		return res
+	}
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return result.Error("Failed to get the source account: %v", tx.Source.Address)
+	}
+
+	signBytes := tx.SignBytes(chainID)
+	res = validateInputAdvanced(sourceAccount, signBytes, tx.Source, chainID, blockHeight)
+	if res.IsError() {
+		logger.Debugf(fmt.Sprintf("validateSourceAdvanced failed on %v: %v", tx.Source.Address.Hex(), res))
+		return res
+	}
+
+	if minTxFee, success := sanityCheckForFee(view, tx.Fee, chainID, blockHeight); !success {
+		return result.Error("Insufficient fee. Transaction fee needs to be at least %v TFuelWei",
+			minTxFee).WithErrorCode(result.CodeInvalidFee)
+	}
+
+	amount, res := sanityCheckForStakeAmount(tx.Purpose, tx.Amount)
+	if res.IsError() {
+		return res
+	}
+
+	res = sanityCheckForRemainingStake(view, tx.Purpose, tx.Source.Address, tx.Holder.Address, amount, chainID, blockHeight)
+	if res.IsError() {
+		return res
+	}
+
+	minimalBalance := tx.Fee
+	if !sourceAccount.Balance.IsGTE(minimalBalance) {
+		logger.Infof(fmt.Sprintf("WithdrawStakeV2: Source did not have enough balance %v", tx.Source.Address.Hex()))
+		return result.Error("WithdrawStakeV2: Source balance is %v, but required minimal balance is %v",
+			sourceAccount.Balance, minimalBalance)
+	}
+
+	return result.OK
+}
+
+// NOTE: like WithdrawStakeExecutor.process(), WithdrawStakeV2Executor.process() does NOT return the
+// withdrawn amount to the source. It is split off into a withdrawn stake which is returned to the
+// source when the block height reaches its ReturnHeight.
+func (exec *WithdrawStakeV2Executor) process(chainID string, view *st.StoreView, transaction types.Tx) (common.Hash, result.Result) {
+	tx := transaction.(*types.WithdrawStakeTxV2)
+
+	sourceAccount, success := getInput(view, tx.Source)
+	if success.IsError() {
+		return common.Hash{}, result.Error("Failed to get the source account")
+	}
+
+	if !chargeFee(sourceAccount, tx.Fee) {
+		return common.Hash{}, result.Error("Failed to charge transaction fee")
+	}
+
+	amount, res := sanityCheckForStakeAmount(tx.Purpose, tx.Amount)
+	if res.IsError() {
+		return common.Hash{}, res
+	}
+
+	sourceAddress := tx.Source.Address
+	holderAddress := tx.Holder.Address
+	currentHeight := exec.state.Height()
+
+	if tx.Purpose == core.StakeForValidator {
+		vcp := view.GetValidatorCandidatePool()
+		err := vcp.WithdrawPartialStake(sourceAddress, holderAddress, amount, currentHeight)
+		if err != nil {
+			return common.Hash{}, result.Error("Failed to withdraw stake, err: %v", err)
+		}
+		view.UpdateValidatorCandidatePool(vcp)
+	} else if tx.Purpose == core.StakeForGuardian {
+		gcp := view.GetGuardianCandidatePool()
+		err := gcp.WithdrawPartialStake(sourceAddress, holderAddress, amount, currentHeight)
+		if err != nil {
+			return common.Hash{}, result.Error("Failed to withdraw stake, err: %v", err)
+		}
+		view.UpdateGuardianCandidatePool(gcp)
+	} else if tx.Purpose == core.StakeForEliteEdgeNode {
+		eenp := state.NewEliteEdgeNodePool(view, false)
+		withdrawnStake, err := eenp.WithdrawPartialStake(sourceAddress, holderAddress, amount, currentHeight)
+		if err != nil || withdrawnStake == nil {
+			return common.Hash{}, result.Error("Failed to withdraw stake, err: %v", err)
+		}
+		updateEliteEdgeNodeStakeReturns(view, holderAddress, *withdrawnStake)
+	} else {
+		return common.Hash{}, result.Error("Invalid staking purpose").WithErrorCode(result.CodeInvalidStakePurpose)
+	}
+
+	// Only update stake transaction height list for validator stake tx.
+	if tx.Purpose == core.StakeForValidator {
+		hl := view.GetStakeTransactionHeightList()
+		if hl == nil {
+			hl = &types.HeightList{}
+		}
+		blockHeight := view.Height() + 1 // the view points to the parent of the current block
+		hl.Append(blockHeight)
+		view.UpdateStakeTransactionHeightList(hl)
+	}
+
+	sourceAccount.Sequence++
+	view.SetAccount(sourceAddress, sourceAccount)
+
+	txHash := types.TxID(chainID, tx)
+	return txHash, result.OK
+}
+
+func (exec *WithdrawStakeV2Executor) getTxInfo(transaction types.Tx) *core.TxInfo {
+	tx := transaction.(*types.WithdrawStakeTxV2)
+	return &core.TxInfo{
+		Address:           tx.Source.Address,
+		Sequence:          tx.Source.Sequence,
+		EffectiveGasPrice: exec.calculateEffectiveGasPrice(transaction),
+	}
+}
+
+func (exec *WithdrawStakeV2Executor) calculateEffectiveGasPrice(transaction types.Tx) *big.Int {
+	tx := transaction.(*types.WithdrawStakeTxV2)
+	fee := tx.Fee
+	gas := new(big.Int).SetUint64(getRegularTxGas(exec.state))
+	effectiveGasPrice := new(big.Int).Div(fee.TFuelWei, gas)
+	return effectiveGasPrice
+}
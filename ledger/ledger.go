@@ -246,6 +246,58 @@ func (ledger *Ledger) ScreenTx(rawTx common.Bytes) (txInfo *core.TxInfo, res res
 	return txInfo, res
 }
 
+// GetTxInfo returns the information of the given transaction used by the mempool, without screening it
+func (ledger *Ledger) GetTxInfo(rawTx common.Bytes) (txInfo *core.TxInfo, res result.Result) {
+	var tx types.Tx
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, result.Error("Error decoding tx: %v", err)
+	}
+
+	return ledger.executor.GetTxInfo(tx)
+}
+
+// ScreenTxReplacement screens the given transaction which replaces a pending transaction with the
+// same sequence. Since the screened state already includes the replaced transaction, the given
+// transaction is screened against a copy of the delivered state, after the pending transactions
+// of the same account preceding it. The screened state is left as is, and reconciled with the
+// mempool when the next block is applied.
+func (ledger *Ledger) ScreenTxReplacement(rawTx common.Bytes, precedingRawTxs []common.Bytes) (txInfo *core.TxInfo, res result.Result) {
+	var tx types.Tx
+	tx, err := types.TxFromBytes(rawTx)
+	if err != nil {
+		return nil, result.Error("Error decoding tx: %v", err)
+	}
+
+	if ledger.shouldSkipCheckTx(tx) {
+		return nil, result.Error("Unauthorized transaction, should skip").
+			WithErrorCode(result.CodeUnauthorizedTx)
+	}
+
+	ledger.mu.Lock()
+	defer ledger.mu.Unlock()
+
+	view, err := ledger.state.Delivered().Copy()
+	if err != nil {
+		return nil, result.Error("Failed to copy the delivered view: %v", err)
+	}
+
+	for _, precedingRawTx := range precedingRawTxs {
+		precedingTx, err := types.TxFromBytes(precedingRawTx)
+		if err != nil {
+			continue
+		}
+		ledger.executor.ScreenTxOnView(precedingTx, view)
+	}
+
+	_, res = ledger.executor.ScreenTxOnView(tx, view)
+	if res.IsError() {
+		return nil, res
+	}
+
+	return ledger.executor.GetTxInfo(tx)
+}
+
 // ProposeBlockTxs collects and executes a list of transactions, which will be used to assemble the next blockl
 // It also clears these transactions from the mempool.
 func (ledger *Ledger) ProposeBlockTxs(block *core.Block, shouldIncludeValidatorUpdateTxs bool) (stateRootHash common.Hash, blockRawTxs []common.Bytes, res result.Result) {
@@ -298,6 +350,12 @@ func (ledger *Ledger) ProposeBlockTxs(block *core.Block, shouldIncludeValidatorU
 			if _, ok := tx.(*types.WithdrawStakeTx); ok {
 				continue
 			}
+			if _, ok := tx.(*types.WithdrawStakeTxV2); ok {
+				continue
+			}
+			if _, ok := tx.(*types.RedelegateStakeTx); ok {
+				continue
+			}
 		}
 
 		_, res := ledger.executor.CheckTx(tx)
@@ -367,6 +425,10 @@ func (ledger *Ledger) ApplyBlockTxs(block *core.Block) result.Result {
 			hasValidatorUpdate = true
 		} else if wtx, ok := tx.(*types.WithdrawStakeTx); ok && wtx.Purpose == core.StakeForValidator {
 			hasValidatorUpdate = true
+		} else if wtx, ok := tx.(*types.WithdrawStakeTxV2); ok && wtx.Purpose == core.StakeForValidator {
+			hasValidatorUpdate = true
+		} else if rtx, ok := tx.(*types.RedelegateStakeTx); ok && rtx.Purpose == core.StakeForValidator {
+			hasValidatorUpdate = true
 		}
 		_, res := ledger.executor.ExecuteTx(tx)
 		if res.IsError() {
@@ -380,7 +442,9 @@ func (ledger *Ledger) ApplyBlockTxs(block *core.Block) result.Result {
 	logger.Debugf("ApplyBlockTxs: Finish applying block transactions, block.height=%v, txProcessTime=%v", block.Height, txProcessTime)
 
 	start := time.Now()
-	ledger.handleDelayedStateUpdates(view)
+	if ledger.handleDelayedStateUpdates(view) {
+		hasValidatorUpdate = true
+	}
 	handleDelayedUpdateTime := time.Since(start)
 
 	newStateRoot := view.Hash()
@@ -448,6 +512,10 @@ func (ledger *Ledger) ApplyBlockTxsForChainCorrection(block *core.Block) (common
 			hasValidatorUpdate = true
 		} else if wtx, ok := tx.(*types.WithdrawStakeTx); ok && wtx.Purpose == core.StakeForValidator {
 			hasValidatorUpdate = true
+		} else if wtx, ok := tx.(*types.WithdrawStakeTxV2); ok && wtx.Purpose == core.StakeForValidator {
+			hasValidatorUpdate = true
+		} else if rtx, ok := tx.(*types.RedelegateStakeTx); ok && rtx.Purpose == core.StakeForValidator {
+			hasValidatorUpdate = true
 		}
 		_, res := ledger.executor.ExecuteTx(tx)
 		if res.IsError() {
@@ -457,7 +525,9 @@ func (ledger *Ledger) ApplyBlockTxsForChainCorrection(block *core.Block) (common
 		}
 	}
 
-	ledger.handleDelayedStateUpdates(view)
+	if ledger.handleDelayedStateUpdates(view) {
+		hasValidatorUpdate = true
+	}
 
 	ledger.state.Commit() // commit to persistent storage
 
@@ -641,15 +711,21 @@ func (ledger *Ledger) shouldSkipCheckTx(tx types.Tx) bool {
 }
 
 // handleDelayedStateUpdates handles delayed state updates, e.g. stake return, where the stake
-// is returned only after X blocks of its corresponding StakeWithdraw transaction
-func (ledger *Ledger) handleDelayedStateUpdates(view *st.StoreView) {
+// is returned only after X blocks of its corresponding StakeWithdraw transaction, the release
+// of the time-locked coins, and the tally of the governance proposals. It returns true if the
+// updates changed the validator selection
+func (ledger *Ledger) handleDelayedStateUpdates(view *st.StoreView) bool {
 	ledger.handleValidatorStakeReturn(view)
 	ledger.handleGuardianStakeReturn(view)
 
 	blockHeight := view.Height() + 1
-	if blockHeight >= common.HeightEnableTheta3 {
+	if blockHeight >= common.GetForkSchedule(ledger.chain.ChainID).EnableTheta3 {
 		ledger.handleEliteEdgeNodeStakeReturns(view)
 	}
+
+	exec.HandleTimeLockReleases(ledger.chain.ChainID, view)
+
+	return exec.HandleGovernanceTally(ledger.chain.ChainID, view)
 }
 
 func (ledger *Ledger) handleValidatorStakeReturn(view *st.StoreView) {
@@ -783,11 +859,12 @@ func (ledger *Ledger) addCoinbaseTx(view *st.StoreView, proposer *core.Validator
 	guardianVotes := currentBlock.GuardianVotes
 	eliteEdgeNodeVotes := currentBlock.EliteEdgeNodeVotes
 
-	if guardianVotes != nil && ch >= common.HeightEnableTheta2 && common.IsCheckPointHeight(ch) {
+	forks := common.GetForkSchedule(ledger.chain.ChainID)
+	if guardianVotes != nil && ch >= forks.EnableTheta2 && common.IsCheckPointHeight(ch) {
 		guardianPool, eliteEdgeNodePool := exec.RetrievePools(ledger, ledger.chain, ledger.db, ch, guardianVotes, eliteEdgeNodeVotes)
-		accountRewardMap = exec.CalculateReward(ledger, view, validatorSet, guardianVotes, guardianPool, eliteEdgeNodeVotes, eliteEdgeNodePool)
-	} else { // for compatibility with lower versions (e.g. blockHeight < common.HeightEnableValidatorReward)
-		accountRewardMap = exec.CalculateReward(ledger, view, validatorSet, nil, nil, nil, nil)
+		accountRewardMap = exec.CalculateReward(ledger, ledger.chain.ChainID, view, validatorSet, guardianVotes, guardianPool, eliteEdgeNodeVotes, eliteEdgeNodePool)
+	} else { // for compatibility with lower versions (e.g. blockHeight < forks.EnableValidatorReward)
+		accountRewardMap = exec.CalculateReward(ledger, ledger.chain.ChainID, view, validatorSet, nil, nil, nil, nil)
 	}
 
 	coinbaseTxOutputs := []types.TxOutput{}
@@ -44,6 +44,46 @@ func TestLedgerScreenTx(t *testing.T) {
 	assert.Equal(result.CodeUnauthorizedTx, res.Code, res.Message)
 }
 
+func TestLedgerScreenTxReplacement(t *testing.T) {
+	assert := assert.New(t)
+
+	chainID, ledger, _ := newTestLedger()
+	numInAccs := 1
+	accOut, accIns := prepareInitLedgerState(ledger, numInAccs)
+
+	sendTx1Bytes := newRawSendTx(chainID, 1, true, accOut, accIns[0], false)
+	_, res := ledger.ScreenTx(sendTx1Bytes)
+	assert.True(res.IsOK(), res.Message)
+	sendTx2Bytes := newRawSendTx(chainID, 2, true, accOut, accIns[0], false)
+	_, res = ledger.ScreenTx(sendTx2Bytes)
+	assert.True(res.IsOK(), res.Message)
+
+	// The replacement fails the screening, since the screened state includes the replaced transaction
+	replacement1Bytes := newRawSendTx(chainID, 1, true, accOut, accIns[0], true)
+	_, res = ledger.ScreenTx(replacement1Bytes)
+	assert.Equal(result.CodeInvalidSequence, res.Code, res.Message)
+
+	txInfo, res := ledger.GetTxInfo(replacement1Bytes)
+	assert.True(res.IsOK(), res.Message)
+	assert.Equal(accIns[0].Address, txInfo.Address)
+	assert.Equal(uint64(1), txInfo.Sequence)
+
+	txInfo, res = ledger.ScreenTxReplacement(replacement1Bytes, []common.Bytes{})
+	assert.True(res.IsOK(), res.Message)
+	assert.Equal(uint64(1), txInfo.Sequence)
+
+	// The replacement is screened after the preceding transactions
+	replacement2Bytes := newRawSendTx(chainID, 2, true, accOut, accIns[0], true)
+	_, res = ledger.ScreenTxReplacement(replacement2Bytes, []common.Bytes{})
+	assert.Equal(result.CodeInvalidSequence, res.Code, res.Message)
+	_, res = ledger.ScreenTxReplacement(replacement2Bytes, []common.Bytes{sendTx1Bytes})
+	assert.True(res.IsOK(), res.Message)
+
+	coinbaseTxBytes := newRawCoinbaseTx(chainID, ledger, 1)
+	_, res = ledger.ScreenTxReplacement(coinbaseTxBytes, []common.Bytes{})
+	assert.Equal(result.CodeUnauthorizedTx, res.Code, res.Message)
+}
+
 func TestLedgerProposerBlockTxs(t *testing.T) {
 	assert := assert.New(t)
 
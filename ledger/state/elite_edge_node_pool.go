@@ -193,13 +193,21 @@ func (eenp *EliteEdgeNodePool) GetAll(withstake bool) []*core.EliteEdgeNode {
 	return eenList
 }
 
+// getStakeLimits returns the minimal deposit and the maximal stake of an elite edge node,
+// which can be changed by the on-chain governance.
+func (eenp *EliteEdgeNodePool) getStakeLimits() (*big.Int, *big.Int) {
+	gov := NewGovernance(eenp.sv)
+	minStake := gov.GetParamOrDefault(core.ParamMinEliteEdgeNodeStake, core.MinEliteEdgeNodeStakeDeposit)
+	maxStake := gov.GetParamOrDefault(core.ParamMaxEliteEdgeNodeStake, core.MaxEliteEdgeNodeStakeDeposit)
+	return minStake, maxStake
+}
+
 func (eenp *EliteEdgeNodePool) DepositStake(source common.Address, holder common.Address, amount *big.Int, pubkey *bls.PublicKey, blockHeight uint64) (err error) {
 	if eenp.readOnly {
 		log.Panicf("EliteEdgeNodePool.DepositStake: the pool is read-only")
 	}
 
-	minEliteEdgeNodeStake := core.MinEliteEdgeNodeStakeDeposit
-	maxEliteEdgeNodeStake := core.MaxEliteEdgeNodeStakeDeposit
+	minEliteEdgeNodeStake, maxEliteEdgeNodeStake := eenp.getStakeLimits()
 	if amount.Cmp(minEliteEdgeNodeStake) < 0 {
 		return fmt.Errorf("Elite edge node staking amount below the lower limit: %v", amount)
 	}
@@ -271,6 +279,87 @@ func (eenp *EliteEdgeNodePool) WithdrawStake(source common.Address, holder commo
 	return withdrawnStake, nil
 }
 
+// WithdrawPartialStake withdraws the given amount of the stake of the source, the rest remains
+// staked. The withdrawn amount is returned after the locking period.
+func (eenp *EliteEdgeNodePool) WithdrawPartialStake(source common.Address, holder common.Address, amount *big.Int, currentHeight uint64) (*core.Stake, error) {
+	if eenp.readOnly {
+		log.Panicf("EliteEdgeNodePool.WithdrawPartialStake: the pool is read-only")
+	}
+
+	een := eenp.Get(holder)
+	if een == nil {
+		return nil, fmt.Errorf("No matched stake holder address found: %v", holder)
+	}
+
+	withdrawnStake, err := een.WithdrawPartialStake(source, amount, currentHeight)
+	if err != nil {
+		return nil, err
+	}
+
+	eenp.Upsert(een)
+
+	// Update total eenp stake
+	totalStake := eenp.sv.GetTotalEENStake()
+	totalStake.Sub(totalStake, withdrawnStake.Amount)
+	eenp.sv.SetTotalEENStake(totalStake)
+
+	return withdrawnStake, nil
+}
+
+// RedelegateStake moves the given amount of the stake of the source from one elite edge node
+// to another, without going through the return locking period. The target elite edge node
+// must be in the pool already, since an elite edge node joins the pool with its BLS key
+// through a deposit.
+func (eenp *EliteEdgeNodePool) RedelegateStake(source common.Address, fromHolder common.Address, toHolder common.Address, amount *big.Int, chainID string, blockHeight uint64) error {
+	if eenp.readOnly {
+		log.Panicf("EliteEdgeNodePool.RedelegateStake: the pool is read-only")
+	}
+
+	if blockHeight < common.GetForkSchedule(chainID).StakeRedelegation {
+		return fmt.Errorf("Stake redelegation is not enabled at height %v", blockHeight)
+	}
+	if fromHolder == toHolder {
+		return fmt.Errorf("Cannot redelegate stake to the same holder: %v", toHolder)
+	}
+	from := eenp.Get(fromHolder)
+	if from == nil {
+		return fmt.Errorf("No matched stake holder address found: %v", fromHolder)
+	}
+	to := eenp.Get(toHolder)
+	if to == nil {
+		return fmt.Errorf("Elite edge node %v not found, the stake can only be redelegated to an existing elite edge node", toHolder)
+	}
+
+	minEliteEdgeNodeStake, maxEliteEdgeNodeStake := eenp.getStakeLimits()
+	if amount.Cmp(minEliteEdgeNodeStake) < 0 {
+		return fmt.Errorf("Elite edge node staking amount below the lower limit: %v", amount)
+	}
+	expectedStake := big.NewInt(0).Add(to.TotalStake(), amount)
+	if expectedStake.Cmp(maxEliteEdgeNodeStake) > 0 {
+		return fmt.Errorf("Elite edge node stake would exceed the cap: %v", expectedStake)
+	}
+
+	// Both nodes are updated in memory first, so that the pool is left untouched on error.
+	// The total eenp stake does not change.
+	err := from.TakeStake(source, amount)
+	if err != nil {
+		return err
+	}
+	err = to.DepositStake(source, amount)
+	if err != nil {
+		return err
+	}
+
+	if len(from.Stakes) == 0 {
+		eenp.Remove(from)
+	} else {
+		eenp.Upsert(from)
+	}
+	eenp.Upsert(to)
+
+	return nil
+}
+
 func (eenp *EliteEdgeNodePool) ReturnStake(currentHeight uint64, holder common.Address, returnedStake core.Stake) error {
 	een := eenp.Get(holder)
 	if een == nil {
@@ -284,17 +373,12 @@ func (eenp *EliteEdgeNodePool) ReturnStake(currentHeight uint64, holder common.A
 	for sidx := numStakes - 1; sidx >= 0; sidx-- {
 		stake := een.Stakes[sidx]
 
-		if stake.Source == sourceAddress {
-			if stake.Withdrawn == false || stake.ReturnHeight != currentHeight {
-				log.Panicf("Returned stake mismatch: eenAddr = %v, sourceAddr = %v, currentHeight = %v, stake.Withdrawn = %v, stake.ReturnHeight = %v",
-					holder, sourceAddress, currentHeight, stake.Withdrawn, stake.ReturnHeight)
-			}
-
+		// After partial withdrawals the source may have other stakes with the holder, the
+		// returned one is the withdrawn stake of the same amount due at the current height
+		if stake.Source == sourceAddress && stake.Withdrawn && stake.ReturnHeight == currentHeight &&
+			stake.Amount.Cmp(returnedStake.Amount) == 0 {
 			logger.Infof("Stake to be returned: source = %v, amount = %v", stake.Source, stake.Amount)
-			_, err := een.ReturnStake(sourceAddress, currentHeight)
-			if err != nil {
-				return err
-			}
+			een.Stakes = append(een.Stakes[:sidx], een.Stakes[sidx+1:]...)
 
 			if len(een.Stakes) == 0 { // the candidate's stake becomes zero, no need to keep track of the candidate anymore
 				eenp.Remove(een)
@@ -302,7 +386,14 @@ func (eenp *EliteEdgeNodePool) ReturnStake(currentHeight uint64, holder common.A
 				eenp.Upsert(een)
 			}
 
-			break // only one stake to be returned
+			return nil // only one stake to be returned
+		}
+	}
+
+	for _, stake := range een.Stakes {
+		if stake.Source == sourceAddress {
+			log.Panicf("Returned stake mismatch: eenAddr = %v, sourceAddr = %v, currentHeight = %v, stake.Withdrawn = %v, stake.ReturnHeight = %v",
+				holder, sourceAddress, currentHeight, stake.Withdrawn, stake.ReturnHeight)
 		}
 	}
 
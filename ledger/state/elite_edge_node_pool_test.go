@@ -5,7 +5,12 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto/bls"
+	"github.com/thetatoken/theta/store/database/backend"
 )
 
 func TestSampleEENWeight(t *testing.T) {
@@ -25,6 +30,36 @@ func TestSampleEENWeight(t *testing.T) {
 	}
 }
 
+func TestEENRedelegateStakeForkHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	forks := common.GetForkSchedule("").Copy()
+	forks.StakeRedelegation = 100
+	common.RegisterForkSchedule("test_een_redelegation", forks)
+
+	sv := NewStoreView(1, common.Hash{}, backend.NewMemDatabase())
+	eenp := NewEliteEdgeNodePool(sv, false)
+	source := common.HexToAddress("0x1")
+	fromHolder, toHolder := common.HexToAddress("0x2"), common.HexToAddress("0x3")
+	for _, holder := range []common.Address{fromHolder, toHolder} {
+		blsKey, err := bls.RandKey()
+		require.Nil(err)
+		require.Nil(eenp.DepositStake(source, holder, core.MinEliteEdgeNodeStakeDeposit, blsKey.PublicKey(), 1))
+	}
+
+	// The redelegation is rejected before the fork of the chain
+	err := eenp.RedelegateStake(source, fromHolder, toHolder, core.MinEliteEdgeNodeStakeDeposit, "test_een_redelegation", 99)
+	assert.NotNil(err)
+	assert.Equal(core.MinEliteEdgeNodeStakeDeposit, eenp.Get(fromHolder).TotalStake())
+
+	err = eenp.RedelegateStake(source, fromHolder, toHolder, core.MinEliteEdgeNodeStakeDeposit, "test_een_redelegation", 100)
+	require.Nil(err)
+	assert.Nil(eenp.Get(fromHolder))
+	expectedStake := new(big.Int).Mul(core.MinEliteEdgeNodeStakeDeposit, big.NewInt(2))
+	assert.Equal(expectedStake, eenp.Get(toHolder).TotalStake())
+}
+
 func BenchmarkRandInt(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		stake := new(big.Int).Mul(core.MinEliteEdgeNodeStakeDeposit, big.NewInt(5*100))
@@ -0,0 +1,137 @@
+package state
+
+import (
+	"encoding/binary"
+	"log"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// Governance stores the protocol parameters set by the on-chain governance, and the
+// proposals to set them.
+type Governance struct {
+	sv *StoreView
+}
+
+// NewGovernance creates a new instance of Governance.
+func NewGovernance(sv *StoreView) *Governance {
+	return &Governance{
+		sv: sv,
+	}
+}
+
+// GetParam returns the value of the parameter set by the governance. Returns nil if the
+// parameter has never been set.
+func (g *Governance) GetParam(param string) *big.Int {
+	data := g.sv.Get(GovernanceParamKey(param))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+	return new(big.Int).SetBytes(data)
+}
+
+// GetParamOrDefault returns the value of the parameter set by the governance, or the given
+// protocol value if the parameter has never been set.
+func (g *Governance) GetParamOrDefault(param string, protocolValue *big.Int) *big.Int {
+	if value := g.GetParam(param); value != nil {
+		return value
+	}
+	return protocolValue
+}
+
+// SetParam sets the value of the parameter.
+func (g *Governance) SetParam(param string, value *big.Int) {
+	if value.Sign() <= 0 {
+		log.Panicf("Governance.SetParam: invalid value %v for %v", value, param)
+	}
+	g.sv.Set(GovernanceParamKey(param), value.Bytes())
+}
+
+// GetAllParams returns the values of all the parameters set by the governance.
+func (g *Governance) GetAllParams() map[string]*big.Int {
+	params := make(map[string]*big.Int)
+	prefix := GovernanceParamKeyPrefix()
+	cb := func(k, v common.Bytes) bool {
+		params[string(k[len(prefix):])] = new(big.Int).SetBytes(v)
+		return true
+	}
+
+	g.sv.Traverse(prefix, cb)
+
+	return params
+}
+
+// GetProposal returns the proposal with the given ID. Returns nil if not found.
+func (g *Governance) GetProposal(id uint64) *core.GovernanceProposal {
+	data := g.sv.Get(GovernanceProposalKey(id))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+
+	proposal := &core.GovernanceProposal{}
+	err := types.FromBytes(data, proposal)
+	if err != nil {
+		log.Panicf("Governance.GetProposal: Error reading proposal %X, error: %v",
+			data, err.Error())
+	}
+	return proposal
+}
+
+// UpsertProposal updates or inserts a proposal.
+func (g *Governance) UpsertProposal(proposal *core.GovernanceProposal) {
+	data, err := types.ToBytes(proposal)
+	if err != nil {
+		log.Panicf("Governance.UpsertProposal: Error serializing proposal %v, error: %v",
+			proposal, err.Error())
+	}
+	g.sv.Set(GovernanceProposalKey(proposal.ID), data)
+}
+
+// NextProposalID allocates the ID of a new proposal. The IDs start from 1.
+func (g *Governance) NextProposalID() uint64 {
+	id := uint64(1)
+	data := g.sv.Get(GovernanceNextProposalIDKey())
+	if len(data) == 8 {
+		id = binary.BigEndian.Uint64(data)
+	}
+
+	next := make([]byte, 8)
+	binary.BigEndian.PutUint64(next, id+1)
+	g.sv.Set(GovernanceNextProposalIDKey(), next)
+
+	return id
+}
+
+// GetActiveProposalIDs returns the IDs of the proposals open for voting, in the order of submission.
+func (g *Governance) GetActiveProposalIDs() []uint64 {
+	data := g.sv.Get(GovernanceActiveProposalsKey())
+	if data == nil || len(data) == 0 {
+		return []uint64{}
+	}
+
+	ids := []uint64{}
+	err := types.FromBytes(data, &ids)
+	if err != nil {
+		log.Panicf("Governance.GetActiveProposalIDs: Error reading proposal IDs %X, error: %v",
+			data, err.Error())
+	}
+	return ids
+}
+
+// SetActiveProposalIDs sets the IDs of the proposals open for voting.
+func (g *Governance) SetActiveProposalIDs(ids []uint64) {
+	if len(ids) == 0 {
+		g.sv.Delete(GovernanceActiveProposalsKey())
+		return
+	}
+
+	data, err := types.ToBytes(ids)
+	if err != nil {
+		log.Panicf("Governance.SetActiveProposalIDs: Error serializing proposal IDs %v, error: %v",
+			ids, err.Error())
+	}
+	g.sv.Set(GovernanceActiveProposalsKey(), data)
+}
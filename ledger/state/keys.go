@@ -15,9 +15,14 @@ func ChainIDKey() common.Bytes {
 	return common.Bytes("chainid")
 }
 
+// AccountKeyPrefix returns the prefix for the account key
+func AccountKeyPrefix() common.Bytes {
+	return common.Bytes("ls/a/")
+}
+
 // AccountKey constructs the state key for the given address
 func AccountKey(addr common.Address) common.Bytes {
-	return append(common.Bytes("ls/a/"), addr[:]...)
+	return append(AccountKeyPrefix(), addr[:]...)
 }
 
 // SplitRuleKeyPrefix returns the prefix for the split rule key
@@ -98,3 +103,52 @@ func EliteEdgeNodeStakeReturnsKey(height uint64) common.Bytes {
 func EliteEdgeNodesTotalActiveStakeKey() common.Bytes {
 	return common.Bytes("ls/eentas")
 }
+
+// GovernanceParamKeyPrefix returns the prefix of the governance parameter key
+func GovernanceParamKeyPrefix() common.Bytes {
+	return common.Bytes("ls/gov/param/")
+}
+
+// GovernanceParamKey returns the key of the protocol parameter set by the on-chain governance
+func GovernanceParamKey(param string) common.Bytes {
+	return append(GovernanceParamKeyPrefix(), common.Bytes(param)...)
+}
+
+// GovernanceProposalKey returns the key of the governance proposal with the given ID
+func GovernanceProposalKey(id uint64) common.Bytes {
+	idStr := strconv.FormatUint(id, 10)
+	return common.Bytes("ls/gov/prop/" + idStr)
+}
+
+// GovernanceNextProposalIDKey returns the key of the ID of the next governance proposal
+func GovernanceNextProposalIDKey() common.Bytes {
+	return common.Bytes("ls/gov/npid")
+}
+
+// GovernanceActiveProposalsKey returns the key of the IDs of the governance proposals open for voting
+func GovernanceActiveProposalsKey() common.Bytes {
+	return common.Bytes("ls/gov/active")
+}
+
+// TimeLockKey returns the key of the time lock with the given ID
+func TimeLockKey(id uint64) common.Bytes {
+	idStr := strconv.FormatUint(id, 10)
+	return common.Bytes("ls/tl/lock/" + idStr)
+}
+
+// TimeLockNextIDKey returns the key of the ID of the next time lock
+func TimeLockNextIDKey() common.Bytes {
+	return common.Bytes("ls/tl/nid")
+}
+
+// TimeLockAddressKey returns the key of the IDs of the pending time locks of the address,
+// as the source or the beneficiary
+func TimeLockAddressKey(addr common.Address) common.Bytes {
+	return append(common.Bytes("ls/tl/addr/"), addr[:]...)
+}
+
+// TimeLockReleasesKey returns the key of the IDs of the time locks to release at the given height
+func TimeLockReleasesKey(height uint64) common.Bytes {
+	heightStr := strconv.FormatUint(height, 10)
+	return common.Bytes("ls/tl/rel/" + heightStr)
+}
@@ -0,0 +1,164 @@
+package state
+
+import (
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/store"
+	"github.com/thetatoken/theta/store/database"
+)
+
+// StatePruner deletes the trie nodes of pruned states that are not reachable
+// from any retained state. Retained states are marked first, then each pruned
+// state is swept: nodes still referenced by other states are dereferenced,
+// the others are deleted. The marking makes pruning safe even if the
+// reference counts of the database are incomplete, e.g. for imported
+// snapshots.
+type StatePruner struct {
+	db     database.Database // database counting the references
+	source database.Database // database the states are read from and deleted from
+	dryRun bool
+
+	retained map[common.Hash]struct{}
+	deleted  map[common.Hash]struct{}
+	derefs   map[common.Hash]int // dereferences pending in the batch
+
+	batch     database.Batch
+	batchSize int
+
+	RetainedNodes     uint64
+	DeletedNodes      uint64
+	DeletedBytes      uint64
+	DereferencedNodes uint64
+}
+
+// NewStatePruner creates a pruner for the states stored in source, which is
+// either db or a database layered on top of it, e.g. a rolling database. The
+// reference counts are maintained in db, since the upper layers do not count
+// references. If dryRun is set, the pruner only counts the nodes that would
+// be deleted.
+func NewStatePruner(db database.Database, source database.Database, dryRun bool) *StatePruner {
+	return &StatePruner{
+		db:        db,
+		source:    source,
+		dryRun:    dryRun,
+		retained:  make(map[common.Hash]struct{}),
+		deleted:   make(map[common.Hash]struct{}),
+		derefs:    make(map[common.Hash]int),
+		batch:     db.NewBatch(),
+		batchSize: database.IdealBatchSize,
+	}
+}
+
+// Retain marks all the nodes of the state with the given root as retained.
+// It must be called for all the retained states before any call to Prune.
+// progress, if not nil, is called with the total number of retained nodes.
+func (sp *StatePruner) Retain(root common.Hash, progress func(nodes uint64)) error {
+	return WalkStateNodes(root, sp.source, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			return false
+		}
+		if _, ok := sp.retained[hash]; ok {
+			return false
+		}
+		sp.retained[hash] = struct{}{}
+		sp.RetainedNodes++
+		if progress != nil {
+			progress(sp.RetainedNodes)
+		}
+		return true
+	})
+}
+
+// Prune deletes the nodes of the state with the given root that are not
+// retained. progress, if not nil, is called with the total number of deleted
+// nodes.
+func (sp *StatePruner) Prune(root common.Hash, progress func(nodes uint64)) error {
+	var err error
+	walkErr := WalkStateNodes(root, sp.source, func(hash common.Hash, blob []byte) bool {
+		if blob == nil || err != nil {
+			return false
+		}
+		if _, ok := sp.deleted[hash]; ok {
+			return false
+		}
+
+		_, retained := sp.retained[hash]
+		inDB, e := sp.db.Has(hash[:])
+		if e != nil {
+			err = e
+			return false
+		}
+		if inDB {
+			ref, e := sp.db.CountReference(hash[:])
+			if e != nil && e != store.ErrKeyNotFound {
+				err = e
+				return false
+			}
+			ref -= sp.derefs[hash]
+			if ref > 1 {
+				// Still referenced by another state
+				err = sp.dereference(hash)
+				return false
+			}
+		}
+		if retained {
+			return false
+		}
+
+		sp.deleted[hash] = struct{}{}
+		sp.DeletedNodes++
+		sp.DeletedBytes += uint64(len(blob))
+		if !sp.dryRun {
+			// Deletes the node and its reference count from all the layers
+			if e := sp.source.Delete(hash[:]); e != nil && e != store.ErrKeyNotFound {
+				err = e
+			}
+		}
+		if progress != nil {
+			progress(sp.DeletedNodes)
+		}
+		return err == nil
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	return err
+}
+
+// Commit writes the pending dereferences to the database.
+func (sp *StatePruner) Commit() error {
+	if sp.dryRun {
+		return nil
+	}
+	return sp.flush()
+}
+
+func (sp *StatePruner) dereference(hash common.Hash) error {
+	sp.derefs[hash]++
+	sp.DereferencedNodes++
+	if sp.dryRun {
+		return nil
+	}
+	return sp.write(func() error { return sp.batch.Dereference(hash[:]) })
+}
+
+func (sp *StatePruner) write(op func() error) error {
+	if err := op(); err != nil {
+		return err
+	}
+	if sp.batch.ValueSize() < sp.batchSize {
+		return nil
+	}
+	return sp.flush()
+}
+
+// flush writes the batch. The written dereferences are reflected in the
+// reference counts of the database from then on, so they are no longer
+// subtracted from the counts.
+func (sp *StatePruner) flush() error {
+	if err := sp.batch.Write(); err != nil {
+		return err
+	}
+	sp.batch.Reset()
+	sp.derefs = make(map[common.Hash]int)
+	return nil
+}
@@ -0,0 +1,71 @@
+package state
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/store/database/backend"
+)
+
+// createPrunedStates saves three consecutive states sharing most of their
+// trie nodes, and returns the database and the state roots.
+func createPrunedStates() (*backend.MemDatabase, []common.Hash) {
+	db := backend.NewMemDatabase()
+	sv := NewStoreView(uint64(1), common.Hash{}, db)
+	for i := 0; i < 256; i++ {
+		sv.Set(common.Bytes(fmt.Sprintf("key%v", i)), common.Bytes(fmt.Sprintf("value%v", i)))
+	}
+	roots := []common.Hash{sv.Save()}
+	sv.Set(common.Bytes("key0"), common.Bytes("updated0"))
+	roots = append(roots, sv.Save())
+	sv.Set(common.Bytes("key1"), common.Bytes("updated1"))
+	roots = append(roots, sv.Save())
+	return db, roots
+}
+
+func pruneStates(db *backend.MemDatabase, roots []common.Hash, batchSize int) (*StatePruner, error) {
+	pruner := NewStatePruner(db, db, false)
+	pruner.batchSize = batchSize
+	for _, root := range roots {
+		if err := pruner.Prune(root, nil); err != nil {
+			return nil, err
+		}
+	}
+	return pruner, pruner.Commit()
+}
+
+func TestStatePrunerBatchFlush(t *testing.T) {
+	assert := assert.New(t)
+
+	// Prunes the first two states, the last one is kept by its references only
+	db, roots := createPrunedStates()
+	expected, err := pruneStates(db, roots[:2], 1<<20)
+	assert.Nil(err)
+	assert.True(expected.DeletedNodes > 0)
+	assert.True(expected.DereferencedNodes > 0)
+
+	// Flushes the batch after each dereference
+	db, roots = createPrunedStates()
+	pruner, err := pruneStates(db, roots[:2], 1)
+	assert.Nil(err)
+	assert.Equal(expected.DeletedNodes, pruner.DeletedNodes)
+	assert.Equal(expected.DereferencedNodes, pruner.DereferencedNodes)
+
+	missing := 0
+	err = WalkStateNodes(roots[2], db, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			missing++
+		}
+		return blob != nil
+	})
+	assert.Nil(err)
+	assert.Equal(0, missing)
+
+	sv := NewStoreView(uint64(3), roots[2], db)
+	for i := 2; i < 256; i++ {
+		assert.Equal(common.Bytes(fmt.Sprintf("value%v", i)), sv.Get(common.Bytes(fmt.Sprintf("key%v", i))))
+	}
+	assert.Equal(common.Bytes("updated1"), sv.Get(common.Bytes("key1")))
+}
@@ -14,6 +14,7 @@ import (
 // ------------------------- State -------------------------
 //
 
+// Tagger tags the state root committed at each height, e.g. to roll the database layers.
 type Tagger interface {
 	Tag(height uint64, root common.Hash)
 }
@@ -31,7 +32,8 @@ type LedgerState struct {
 	screened  *StoreView // for mempool screening
 }
 
-// NewLedgerState creates a new Leger State with given store.
+// NewLedgerState creates a new Leger State with given store. The tagger is optional, the
+// ledger and state test harnesses commit without one.
 // NOTE: before using the LedgerState, we need to call LedgerState.ResetState() to set
 //       the proper height and stateRootHash
 func NewLedgerState(chainID string, db database.Database, tagger Tagger) *LedgerState {
@@ -137,7 +139,9 @@ func (s *LedgerState) Finalized() *StoreView {
 func (s *LedgerState) Commit() common.Hash {
 	hash := s.delivered.Save()
 	s.delivered.IncrementHeight()
-	s.dbTagger.Tag(s.delivered.height, hash)
+	if s.dbTagger != nil {
+		s.dbTagger.Tag(s.delivered.height, hash)
+	}
 
 	var err error
 	s.checked, err = s.delivered.Copy()
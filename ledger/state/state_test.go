@@ -19,7 +19,7 @@ func TestLedgerStateBasics(t *testing.T) {
 
 	chainID := "testchain"
 	db := backend.NewMemDatabase()
-	ls := NewLedgerState(chainID, db)
+	ls := NewLedgerState(chainID, db, nil)
 
 	initHeight := uint64(127)
 	initRootHash := common.Hash{}
@@ -66,7 +66,7 @@ func TestLedgerStateAccountCommit(t *testing.T) {
 
 	chainID := "testchain"
 	db := backend.NewMemDatabase()
-	ls := NewLedgerState(chainID, db)
+	ls := NewLedgerState(chainID, db, nil)
 
 	initHeight := uint64(127)
 	initRootHash := common.Hash{}
@@ -130,7 +130,7 @@ func TestLedgerStateSplitRuleCommit(t *testing.T) {
 
 	chainID := "testchain"
 	db := backend.NewMemDatabase()
-	ls := NewLedgerState(chainID, db)
+	ls := NewLedgerState(chainID, db, nil)
 
 	initHeight := uint64(127)
 	initRootHash := common.Hash{}
@@ -545,6 +545,22 @@ func (sv *StoreView) GetThetaStake(addr common.Address) *big.Int {
 	return totalStake
 }
 
+// GetGuardian returns the guardian of the holder address, nil if the address is not in the guardian candidate pool
+func (sv *StoreView) GetGuardian(holder common.Address) *core.Guardian {
+	return sv.GetGuardianCandidatePool().GetWithHolderAddress(holder)
+}
+
+// GetGuardianCandidatePoolSize returns the size in bytes of the encoded guardian candidate pool,
+// which is loaded as a whole to retrieve a guardian
+func (sv *StoreView) GetGuardianCandidatePoolSize() int {
+	return len(sv.Get(GuardianCandidatePoolKey()))
+}
+
+// GetEliteEdgeNode returns the elite edge node of the holder address, nil if the address is not in the elite edge node pool
+func (sv *StoreView) GetEliteEdgeNode(holder common.Address) *core.EliteEdgeNode {
+	return NewEliteEdgeNodePool(sv, true).Get(holder)
+}
+
 func (sv *StoreView) GetNonce(addr common.Address) uint64 {
 	return sv.GetOrCreateAccount(addr).Sequence
 }
@@ -280,18 +280,18 @@ func TestGetAndUpdateValidatorCandidatePool(t *testing.T) {
 
 	vcp := &core.ValidatorCandidatePool{}
 
-	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr1, stake1Amount1))
-	assert.Nil(vcp.DepositStake(sourceAddr2, holderAddr1, stake2Amount1))
-	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr1, stake3Amount2))
+	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr1, stake1Amount1, "testchain", 0))
+	assert.Nil(vcp.DepositStake(sourceAddr2, holderAddr1, stake2Amount1, "testchain", 0))
+	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr1, stake3Amount2, "testchain", 0))
 
-	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr2, stake1Amount2))
-	assert.Nil(vcp.DepositStake(sourceAddr2, holderAddr2, stake2Amount2))
-	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr2, stake3Amount2))
+	assert.Nil(vcp.DepositStake(sourceAddr1, holderAddr2, stake1Amount2, "testchain", 0))
+	assert.Nil(vcp.DepositStake(sourceAddr2, holderAddr2, stake2Amount2, "testchain", 0))
+	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr2, stake3Amount2, "testchain", 0))
 
-	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr3, stake3Amount1))
+	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr3, stake3Amount1, "testchain", 0))
 
-	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr4, stake3Amount3))
-	assert.Nil(vcp.DepositStake(sourceAddr4, holderAddr4, stake4Amount1))
+	assert.Nil(vcp.DepositStake(sourceAddr3, holderAddr4, stake3Amount3, "testchain", 0))
+	assert.Nil(vcp.DepositStake(sourceAddr4, holderAddr4, stake4Amount1, "testchain", 0))
 
 	db := backend.NewMemDatabase()
 	sv := NewStoreView(uint64(1), common.Hash{}, db)
@@ -0,0 +1,153 @@
+package state
+
+import (
+	"encoding/binary"
+	"log"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// TimeLocks stores the time locks, indexed by the addresses of their sources and beneficiaries,
+// and by the heights at which they release coins.
+type TimeLocks struct {
+	sv *StoreView
+}
+
+// NewTimeLocks creates a new instance of TimeLocks.
+func NewTimeLocks(sv *StoreView) *TimeLocks {
+	return &TimeLocks{
+		sv: sv,
+	}
+}
+
+// Get returns the time lock with the given ID. Returns nil if not found.
+func (tl *TimeLocks) Get(id uint64) *types.TimeLock {
+	data := tl.sv.Get(TimeLockKey(id))
+	if data == nil || len(data) == 0 {
+		return nil
+	}
+
+	lock := &types.TimeLock{}
+	err := types.FromBytes(data, lock)
+	if err != nil {
+		log.Panicf("TimeLocks.Get: Error reading time lock %X, error: %v",
+			data, err.Error())
+	}
+	return lock
+}
+
+// Upsert updates or inserts a time lock.
+func (tl *TimeLocks) Upsert(lock *types.TimeLock) {
+	data, err := types.ToBytes(lock)
+	if err != nil {
+		log.Panicf("TimeLocks.Upsert: Error serializing time lock %v, error: %v",
+			lock, err.Error())
+	}
+	tl.sv.Set(TimeLockKey(lock.ID), data)
+}
+
+// NextID allocates the ID of a new time lock. The IDs start from 1.
+func (tl *TimeLocks) NextID() uint64 {
+	id := uint64(1)
+	data := tl.sv.Get(TimeLockNextIDKey())
+	if len(data) == 8 {
+		id = binary.BigEndian.Uint64(data)
+	}
+
+	next := make([]byte, 8)
+	binary.BigEndian.PutUint64(next, id+1)
+	tl.sv.Set(TimeLockNextIDKey(), next)
+
+	return id
+}
+
+// Add stores a new time lock, and schedules its first release after the given height.
+func (tl *TimeLocks) Add(lock *types.TimeLock, height uint64) {
+	tl.Upsert(lock)
+	tl.addToAddress(lock.Source, lock.ID)
+	if lock.Beneficiary != lock.Source {
+		tl.addToAddress(lock.Beneficiary, lock.ID)
+	}
+	tl.ScheduleRelease(lock.NextReleaseHeight(height), lock.ID)
+}
+
+// Remove deletes a fully released time lock.
+func (tl *TimeLocks) Remove(lock *types.TimeLock) {
+	tl.removeFromAddress(lock.Source, lock.ID)
+	tl.removeFromAddress(lock.Beneficiary, lock.ID)
+	tl.sv.Delete(TimeLockKey(lock.ID))
+}
+
+// GetByAddress returns the pending time locks whose source or beneficiary is the address.
+func (tl *TimeLocks) GetByAddress(addr common.Address) []*types.TimeLock {
+	locks := []*types.TimeLock{}
+	for _, id := range tl.getIDs(TimeLockAddressKey(addr)) {
+		if lock := tl.Get(id); lock != nil {
+			locks = append(locks, lock)
+		}
+	}
+	return locks
+}
+
+// ScheduleRelease schedules the release of the coins of the time lock at the given height.
+func (tl *TimeLocks) ScheduleRelease(height uint64, id uint64) {
+	key := TimeLockReleasesKey(height)
+	tl.setIDs(key, append(tl.getIDs(key), id))
+}
+
+// GetScheduledReleases returns the IDs of the time locks to release at the given height.
+func (tl *TimeLocks) GetScheduledReleases(height uint64) []uint64 {
+	return tl.getIDs(TimeLockReleasesKey(height))
+}
+
+// RemoveScheduledReleases removes the releases scheduled at the given height.
+func (tl *TimeLocks) RemoveScheduledReleases(height uint64) {
+	tl.sv.Delete(TimeLockReleasesKey(height))
+}
+
+func (tl *TimeLocks) addToAddress(addr common.Address, id uint64) {
+	key := TimeLockAddressKey(addr)
+	tl.setIDs(key, append(tl.getIDs(key), id))
+}
+
+func (tl *TimeLocks) removeFromAddress(addr common.Address, id uint64) {
+	key := TimeLockAddressKey(addr)
+	ids := tl.getIDs(key)
+	remaining := []uint64{}
+	for _, lockID := range ids {
+		if lockID != id {
+			remaining = append(remaining, lockID)
+		}
+	}
+	tl.setIDs(key, remaining)
+}
+
+func (tl *TimeLocks) getIDs(key common.Bytes) []uint64 {
+	data := tl.sv.Get(key)
+	if data == nil || len(data) == 0 {
+		return []uint64{}
+	}
+
+	ids := []uint64{}
+	err := types.FromBytes(data, &ids)
+	if err != nil {
+		log.Panicf("TimeLocks.getIDs: Error reading time lock IDs %X, error: %v",
+			data, err.Error())
+	}
+	return ids
+}
+
+func (tl *TimeLocks) setIDs(key common.Bytes, ids []uint64) {
+	if len(ids) == 0 {
+		tl.sv.Delete(key)
+		return
+	}
+
+	data, err := types.ToBytes(ids)
+	if err != nil {
+		log.Panicf("TimeLocks.setIDs: Error serializing time lock IDs %v, error: %v",
+			ids, err.Error())
+	}
+	tl.sv.Set(key, data)
+}
@@ -0,0 +1,45 @@
+package state
+
+import (
+	"bytes"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/store/database"
+	"github.com/thetatoken/theta/store/trie"
+)
+
+// WalkStateNodes walks all the trie nodes of the state with the given root
+// directly on the disk database, including the storage tries of the accounts.
+// Storage tries shared by several accounts are only walked once. See
+// trie.WalkNodes for the semantics of visit.
+func WalkStateNodes(root common.Hash, db database.Database, visit trie.NodeVisitor) error {
+	storageRoots := []common.Hash{}
+	visited := make(map[common.Hash]bool)
+
+	err := trie.WalkNodes(root, db, visit, func(key, value []byte) {
+		if !bytes.HasPrefix(key, AccountKeyPrefix()) {
+			return
+		}
+		account := &types.Account{}
+		if err := types.FromBytes(value, account); err != nil {
+			return
+		}
+		if account.Root == (common.Hash{}) || account.Root == core.EmptyRootHash || visited[account.Root] {
+			return
+		}
+		visited[account.Root] = true
+		storageRoots = append(storageRoots, account.Root)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, storageRoot := range storageRoots {
+		if err := trie.WalkNodes(storageRoot, db, visit, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
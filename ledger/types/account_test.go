@@ -75,7 +75,7 @@ func TestNilAccount(t *testing.T) {
 
 	//test sending nils for panic
 	var nilAcc *Account
-	nilAcc.String()
+	_ = nilAcc.String()
 	nilAcc.Copy()
 }
 
@@ -92,24 +92,24 @@ const (
 	ReservedFundFreezePeriodDuration uint64 = 5
 )
 
-func GetMinimumGasPrice(blockHeight uint64) *big.Int {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func GetMinimumGasPrice(chainID string, blockHeight uint64) *big.Int {
+	if blockHeight < common.GetForkSchedule(chainID).June2021FeeAdjustment {
 		return new(big.Int).SetUint64(MinimumGasPrice)
 	}
 
 	return new(big.Int).SetUint64(MinimumGasPriceJune2021)
 }
 
-func GetMaxGasLimit(blockHeight uint64) *big.Int {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func GetMaxGasLimit(chainID string, blockHeight uint64) *big.Int {
+	if blockHeight < common.GetForkSchedule(chainID).June2021FeeAdjustment {
 		return new(big.Int).SetUint64(MaximumTxGasLimit)
 	}
 
 	return new(big.Int).SetUint64(MaximumTxGasLimitJune2021)
 }
 
-func GetMinimumTransactionFeeTFuelWei(blockHeight uint64) *big.Int {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func GetMinimumTransactionFeeTFuelWei(chainID string, blockHeight uint64) *big.Int {
+	if blockHeight < common.GetForkSchedule(chainID).June2021FeeAdjustment {
 		return new(big.Int).SetUint64(MinimumTransactionFeeTFuelWei)
 	}
 
@@ -117,8 +117,8 @@ func GetMinimumTransactionFeeTFuelWei(blockHeight uint64) *big.Int {
 }
 
 // Special handling for many-to-many SendTx
-func GetSendTxMinimumTransactionFeeTFuelWei(numAccountsAffected uint64, blockHeight uint64) *big.Int {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func GetSendTxMinimumTransactionFeeTFuelWei(numAccountsAffected uint64, chainID string, blockHeight uint64) *big.Int {
+	if blockHeight < common.GetForkSchedule(chainID).June2021FeeAdjustment {
 		return new(big.Int).SetUint64(MinimumTransactionFeeTFuelWei) // backward compatiblity
 	}
 
@@ -37,6 +37,11 @@ const (
 	TxWithdrawStake
 	TxDepositStakeV2
 	TxStakeRewardDistribution
+	TxWithdrawStakeV2
+	TxRedelegateStake
+	TxGovernanceProposal
+	TxGovernanceVote
+	TxTimeLock
 )
 
 func Fuzz(data []byte) int {
@@ -114,6 +119,26 @@ func TxFromBytes(raw []byte) (Tx, error) {
 		data := &StakeRewardDistributionTx{}
 		err = s.Decode(data)
 		return data, err
+	} else if txType == TxWithdrawStakeV2 {
+		data := &WithdrawStakeTxV2{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxRedelegateStake {
+		data := &RedelegateStakeTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxGovernanceProposal {
+		data := &GovernanceProposalTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxGovernanceVote {
+		data := &GovernanceVoteTx{}
+		err = s.Decode(data)
+		return data, err
+	} else if txType == TxTimeLock {
+		data := &TimeLockTx{}
+		err = s.Decode(data)
+		return data, err
 	} else {
 		return nil, fmt.Errorf("Unknown TX type: %v", txType)
 	}
@@ -147,6 +172,16 @@ func TxToBytes(t Tx) ([]byte, error) {
 		txType = TxDepositStakeV2
 	case *StakeRewardDistributionTx:
 		txType = TxStakeRewardDistribution
+	case *WithdrawStakeTxV2:
+		txType = TxWithdrawStakeV2
+	case *RedelegateStakeTx:
+		txType = TxRedelegateStake
+	case *GovernanceProposalTx:
+		txType = TxGovernanceProposal
+	case *GovernanceVoteTx:
+		txType = TxGovernanceVote
+	case *TimeLockTx:
+		txType = TxTimeLock
 	default:
 		return nil, errors.New("Unsupported message type")
 	}
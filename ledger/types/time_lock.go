@@ -0,0 +1,88 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+)
+
+// TimeLock escrows coins of the source account until the release height, when they are
+// credited to the beneficiary. With linear vesting, the coins vest in proportion to the
+// number of blocks elapsed since the lock was created, and the vested coins are released
+// at each checkpoint until the release height.
+type TimeLock struct {
+	ID            uint64         `json:"id"`
+	Source        common.Address `json:"source"`
+	Beneficiary   common.Address `json:"beneficiary"`
+	Amount        Coins          `json:"amount"`         // total locked coins
+	Released      Coins          `json:"released"`       // coins already released to the beneficiary
+	StartHeight   uint64         `json:"start_height"`   // height of the block that created the lock
+	ReleaseHeight uint64         `json:"release_height"` // height at which all the coins are released
+	Vesting       bool           `json:"vesting"`        // whether the coins vest linearly
+}
+
+// NewTimeLock creates a new time lock.
+func NewTimeLock(id uint64, source, beneficiary common.Address, amount Coins,
+	startHeight, releaseHeight uint64, vesting bool) *TimeLock {
+	return &TimeLock{
+		ID:            id,
+		Source:        source,
+		Beneficiary:   beneficiary,
+		Amount:        amount.NoNil(),
+		Released:      NewCoins(0, 0),
+		StartHeight:   startHeight,
+		ReleaseHeight: releaseHeight,
+		Vesting:       vesting,
+	}
+}
+
+// VestedAmount returns the coins unlocked at the given height, including the coins already
+// released.
+func (lock *TimeLock) VestedAmount(height uint64) Coins {
+	if height >= lock.ReleaseHeight {
+		return lock.Amount.NoNil()
+	}
+	if !lock.Vesting || height <= lock.StartHeight {
+		return NewCoins(0, 0)
+	}
+
+	elapsed := new(big.Int).SetUint64(height - lock.StartHeight)
+	duration := new(big.Int).SetUint64(lock.ReleaseHeight - lock.StartHeight)
+	amount := lock.Amount.NoNil()
+	theta := new(big.Int).Mul(amount.ThetaWei, elapsed)
+	theta.Div(theta, duration)
+	tfuel := new(big.Int).Mul(amount.TFuelWei, elapsed)
+	tfuel.Div(tfuel, duration)
+
+	return Coins{
+		ThetaWei: theta,
+		TFuelWei: tfuel,
+	}
+}
+
+// ReleasableAmount returns the coins vested at the given height but not released yet.
+func (lock *TimeLock) ReleasableAmount(height uint64) Coins {
+	return lock.VestedAmount(height).Minus(lock.Released)
+}
+
+// NextReleaseHeight returns the height after the given one at which coins of the lock are
+// released: the release height, or with linear vesting, the next checkpoint if it is earlier.
+func (lock *TimeLock) NextReleaseHeight(height uint64) uint64 {
+	if !lock.Vesting {
+		return lock.ReleaseHeight
+	}
+	next := common.LastCheckPointHeight(height)
+	if next <= height {
+		next += uint64(common.CheckpointInterval)
+	}
+	if next > lock.ReleaseHeight {
+		return lock.ReleaseHeight
+	}
+	return next
+}
+
+func (lock *TimeLock) String() string {
+	return fmt.Sprintf("TimeLock{ID: %v, Source: %v, Beneficiary: %v, Amount: %v, Released: %v, StartHeight: %v, ReleaseHeight: %v, Vesting: %v}",
+		lock.ID, lock.Source, lock.Beneficiary, lock.Amount, lock.Released, lock.StartHeight, lock.ReleaseHeight, lock.Vesting)
+}
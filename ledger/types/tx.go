@@ -35,6 +35,11 @@ Transaction Types:
  - WithdrawStakeTx         Withdraw stake from a target address (e.g. a validator)
  - SmartContractTx         Execute smart contract
  - StakeRewardDistribution Defines how stake reward is distributed
+ - WithdrawStakeTxV2       Withdraw a specified amount of stake from a target address
+ - RedelegateStakeTx       Move stake from one target address to another
+ - GovernanceProposalTx    Propose to change a protocol parameter
+ - GovernanceVoteTx        Vote on a protocol parameter change proposal
+ - TimeLockTx              Lock coins until a release height, optionally with linear vesting
 */
 
 // Gas of regular transactions
@@ -1018,6 +1023,206 @@ func (tx *StakeRewardDistributionTx) String() string {
 		tx.Holder.Address, tx.Beneficiary.Address, tx.SplitBasisPoint)
 }
 
+//--------------------------------------------------------------------------------
+
+// WithdrawStakeTxV2 withdraws the specified amount of the stake of the source, the rest of
+// the stake remains with the holder. The amount is in Theta for validators and guardians,
+// and in TFuel for elite edge nodes.
+type WithdrawStakeTxV2 struct {
+	Fee     Coins    `json:"fee"`     // Fee
+	Source  TxInput  `json:"source"`  // source staker account
+	Holder  TxOutput `json:"holder"`  // stake holder account
+	Purpose uint8    `json:"purpose"` // purpose e.g. stake for validator/guardian/elite edge node
+	Amount  Coins    `json:"amount"`  // amount of stake to withdraw
+}
+
+func (_ *WithdrawStakeTxV2) AssertIsTx() {}
+
+func (tx *WithdrawStakeTxV2) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Source.Signature
+	tx.Source.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Source.Signature = sig
+	return signBytes
+}
+
+func (tx *WithdrawStakeTxV2) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Source.Address == addr {
+		tx.Source.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *WithdrawStakeTxV2) String() string {
+	return fmt.Sprintf("WithdrawStakeTxV2{%v <- %v, amount: %v, purpose: %v}",
+		tx.Source.Address, tx.Holder.Address, tx.Amount, tx.Purpose)
+}
+
+//--------------------------------------------------------------------------------
+
+// RedelegateStakeTx moves the specified amount of the stake of the source from one holder
+// to another holder of the same purpose at once, instead of withdrawing the stake and waiting
+// for it to be returned before depositing it again. The amount is in Theta for validators and
+// guardians, and in TFuel for elite edge nodes.
+type RedelegateStakeTx struct {
+	Fee        Coins    `json:"fee"`         // Fee
+	Source     TxInput  `json:"source"`      // source staker account
+	FromHolder TxOutput `json:"from_holder"` // current stake holder account
+	ToHolder   TxOutput `json:"to_holder"`   // new stake holder account
+	Purpose    uint8    `json:"purpose"`     // purpose e.g. stake for validator/guardian/elite edge node
+	Amount     Coins    `json:"amount"`      // amount of stake to move
+}
+
+func (_ *RedelegateStakeTx) AssertIsTx() {}
+
+func (tx *RedelegateStakeTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Source.Signature
+	tx.Source.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Source.Signature = sig
+	return signBytes
+}
+
+func (tx *RedelegateStakeTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Source.Address == addr {
+		tx.Source.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *RedelegateStakeTx) String() string {
+	return fmt.Sprintf("RedelegateStakeTx{%v: %v -> %v, amount: %v, purpose: %v}",
+		tx.Source.Address, tx.FromHolder.Address, tx.ToHolder.Address, tx.Amount, tx.Purpose)
+}
+
+//--------------------------------------------------------------------------------
+
+// GovernanceProposalTx proposes to set a protocol parameter to a new value. The proposer has
+// to hold stake as a validator candidate or a guardian. The votes are counted at the first
+// checkpoint after VotingPeriod blocks.
+type GovernanceProposalTx struct {
+	Fee          Coins    `json:"fee"`           // Fee
+	Proposer     TxInput  `json:"proposer"`      // proposer account
+	Param        string   `json:"param"`         // name of the protocol parameter
+	Value        *big.Int `json:"value"`         // proposed value of the parameter
+	VotingPeriod uint64   `json:"voting_period"` // number of blocks the proposal is open for voting
+}
+
+func (_ *GovernanceProposalTx) AssertIsTx() {}
+
+func (tx *GovernanceProposalTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Proposer.Signature
+	tx.Proposer.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Proposer.Signature = sig
+	return signBytes
+}
+
+func (tx *GovernanceProposalTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Proposer.Address == addr {
+		tx.Proposer.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *GovernanceProposalTx) String() string {
+	return fmt.Sprintf("GovernanceProposalTx{%v: %v = %v, voting period: %v}",
+		tx.Proposer.Address, tx.Param, tx.Value, tx.VotingPeriod)
+}
+
+//--------------------------------------------------------------------------------
+
+// GovernanceVoteTx casts the vote of a validator candidate or guardian stake holder on a
+// proposal. A later vote of the same voter replaces the earlier one.
+type GovernanceVoteTx struct {
+	Fee        Coins   `json:"fee"`         // Fee
+	Voter      TxInput `json:"voter"`       // voter account
+	ProposalID uint64  `json:"proposal_id"` // ID of the proposal
+	Approve    bool    `json:"approve"`     // whether the voter approves the proposal
+}
+
+func (_ *GovernanceVoteTx) AssertIsTx() {}
+
+func (tx *GovernanceVoteTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Voter.Signature
+	tx.Voter.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Voter.Signature = sig
+	return signBytes
+}
+
+func (tx *GovernanceVoteTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Voter.Address == addr {
+		tx.Voter.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *GovernanceVoteTx) String() string {
+	return fmt.Sprintf("GovernanceVoteTx{%v: proposal %v, approve: %v}",
+		tx.Voter.Address, tx.ProposalID, tx.Approve)
+}
+
+//--------------------------------------------------------------------------------
+
+// TimeLockTx locks the coins of the source input until ReleaseHeight, when they are credited
+// to the beneficiary. With Vesting, the coins vest linearly from the block of the transaction
+// to ReleaseHeight, and the vested coins are released at each checkpoint.
+type TimeLockTx struct {
+	Fee           Coins          `json:"fee"`            // Fee
+	Source        TxInput        `json:"source"`         // source account, the coins of the input are locked
+	Beneficiary   common.Address `json:"beneficiary"`    // account receiving the released coins
+	ReleaseHeight uint64         `json:"release_height"` // height at which all the coins are released
+	Vesting       bool           `json:"vesting"`        // whether the coins vest linearly
+}
+
+func (_ *TimeLockTx) AssertIsTx() {}
+
+func (tx *TimeLockTx) SignBytes(chainID string) []byte {
+	signBytes := encodeToBytes(chainID)
+	sig := tx.Source.Signature
+	tx.Source.Signature = nil
+	txBytes, _ := TxToBytes(tx)
+	signBytes = append(signBytes, txBytes...)
+	signBytes = addPrefixForSignBytes(signBytes)
+
+	tx.Source.Signature = sig
+	return signBytes
+}
+
+func (tx *TimeLockTx) SetSignature(addr common.Address, sig *crypto.Signature) bool {
+	if tx.Source.Address == addr {
+		tx.Source.Signature = sig
+		return true
+	}
+	return false
+}
+
+func (tx *TimeLockTx) String() string {
+	return fmt.Sprintf("TimeLockTx{%v -> %v, amount: %v, release height: %v, vesting: %v}",
+		tx.Source.Address, tx.Beneficiary, tx.Source.Coins, tx.ReleaseHeight, tx.Vesting)
+}
+
 // --------------- Utils --------------- //
 
 type EthereumTxWrapper struct {
@@ -1095,7 +1300,7 @@ const CHAIN_ID_OFFSET int64 = 360
 
 func MapChainID(chainIDStr string, blockHeight uint64) *big.Int {
 	chainIDWithoutOffset := mapChainIDWithoutOffset(chainIDStr)
-	if blockHeight < common.HeightRPCCompatibility {
+	if blockHeight < common.GetForkSchedule(chainIDStr).RPCCompatibility {
 		return chainIDWithoutOffset
 	}
 
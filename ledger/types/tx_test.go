@@ -10,7 +10,6 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"github.com/thetatoken/theta/cmd/thetacli/cmd/utils"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/crypto"
 	"github.com/thetatoken/theta/rlp"
@@ -325,7 +324,7 @@ func TestSendTxSignable2(t *testing.T) {
 
 	raw, err := TxToBytes(sendTx)
 	if err != nil {
-		utils.Error("Failed to encode transaction: %v\n", err)
+		t.Fatalf("Failed to encode transaction: %v", err)
 	}
 	t.Logf("sendTx.Inputs[0].Signature : %v", hex.EncodeToString(senderSignature.ToBytes()))
 
@@ -0,0 +1,195 @@
+package abi
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+)
+
+// ABI is the parsed Solidity JSON ABI of a contract.
+type ABI struct {
+	Constructor *Method
+	Methods     []*Method
+	Events      []*Event
+}
+
+// Argument is an input or output argument of a method or an event.
+type Argument struct {
+	Name    string
+	Type    Type
+	Indexed bool
+}
+
+// Arguments is the list of arguments of a method or an event.
+type Arguments []Argument
+
+// ArgumentMarshaling is the JSON representation of an argument.
+type ArgumentMarshaling struct {
+	Name       string               `json:"name"`
+	Type       string               `json:"type"`
+	Components []ArgumentMarshaling `json:"components,omitempty"`
+	Indexed    bool                 `json:"indexed,omitempty"`
+}
+
+func (am ArgumentMarshaling) toArgument() (Argument, error) {
+	t, err := NewType(am.Type, am.Components)
+	if err != nil {
+		return Argument{}, err
+	}
+	return Argument{Name: am.Name, Type: t, Indexed: am.Indexed}, nil
+}
+
+// Method is a contract function or the constructor.
+type Method struct {
+	Name            string
+	StateMutability string
+	Inputs          Arguments
+	Outputs         Arguments
+}
+
+// Sig returns the signature of the method, e.g. "transfer(address,uint256)".
+func (m *Method) Sig() string {
+	return m.Name + m.Inputs.sig()
+}
+
+// ID returns the 4 byte selector of the method.
+func (m *Method) ID() []byte {
+	return crypto.Keccak256([]byte(m.Sig()))[:4]
+}
+
+// IsConstant returns true if calling the method does not modify the state.
+func (m *Method) IsConstant() bool {
+	return m.StateMutability == "view" || m.StateMutability == "pure"
+}
+
+// Event is a contract event.
+type Event struct {
+	Name      string
+	Anonymous bool
+	Inputs    Arguments
+}
+
+// Sig returns the signature of the event, e.g. "Transfer(address,address,uint256)".
+func (e *Event) Sig() string {
+	return e.Name + e.Inputs.sig()
+}
+
+// ID returns the topic which identifies the event in the logs.
+func (e *Event) ID() common.Hash {
+	return crypto.Keccak256Hash([]byte(e.Sig()))
+}
+
+func (args Arguments) sig() string {
+	types := make([]string, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type.String()
+	}
+	return "(" + strings.Join(types, ",") + ")"
+}
+
+type abiEntry struct {
+	Type            string               `json:"type"`
+	Name            string               `json:"name"`
+	Inputs          []ArgumentMarshaling `json:"inputs"`
+	Outputs         []ArgumentMarshaling `json:"outputs"`
+	StateMutability string               `json:"stateMutability"`
+	Constant        bool                 `json:"constant"`
+	Payable         bool                 `json:"payable"`
+	Anonymous       bool                 `json:"anonymous"`
+}
+
+// Parse parses the JSON ABI of a contract. Fallback, receive and error
+// entries are skipped since they do not need decoding.
+func Parse(data []byte) (*ABI, error) {
+	var entries []abiEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	abi := &ABI{}
+	for _, entry := range entries {
+		inputs, err := toArguments(entry.Inputs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inputs of %v: %v", entry.Name, err)
+		}
+		outputs, err := toArguments(entry.Outputs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid outputs of %v: %v", entry.Name, err)
+		}
+
+		switch entry.Type {
+		case "function", "":
+			abi.Methods = append(abi.Methods, &Method{
+				Name:            entry.Name,
+				StateMutability: stateMutability(entry),
+				Inputs:          inputs,
+				Outputs:         outputs,
+			})
+		case "constructor":
+			abi.Constructor = &Method{
+				StateMutability: stateMutability(entry),
+				Inputs:          inputs,
+			}
+		case "event":
+			abi.Events = append(abi.Events, &Event{
+				Name:      entry.Name,
+				Anonymous: entry.Anonymous,
+				Inputs:    inputs,
+			})
+		}
+	}
+	return abi, nil
+}
+
+// stateMutability handles the ABIs generated by the compilers before
+// Solidity 0.4.16, which use the constant and payable flags instead.
+func stateMutability(entry abiEntry) string {
+	if entry.StateMutability != "" {
+		return entry.StateMutability
+	}
+	if entry.Constant {
+		return "view"
+	}
+	if entry.Payable {
+		return "payable"
+	}
+	return "nonpayable"
+}
+
+func toArguments(ams []ArgumentMarshaling) (Arguments, error) {
+	args := make(Arguments, len(ams))
+	for i, am := range ams {
+		arg, err := am.toArgument()
+		if err != nil {
+			return nil, err
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// MethodByID returns the method with the given 4 byte selector.
+func (abi *ABI) MethodByID(id []byte) (*Method, bool) {
+	if len(id) < 4 {
+		return nil, false
+	}
+	for _, method := range abi.Methods {
+		if string(method.ID()) == string(id[:4]) {
+			return method, true
+		}
+	}
+	return nil, false
+}
+
+// EventByID returns the non-anonymous event identified by the topic.
+func (abi *ABI) EventByID(topic common.Hash) (*Event, bool) {
+	for _, event := range abi.Events {
+		if !event.Anonymous && event.ID() == topic {
+			return event, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,139 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+const testABI = `[
+	{"type":"function","name":"transfer","stateMutability":"nonpayable",
+	 "inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],
+	 "outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"info","stateMutability":"view","inputs":[],
+	 "outputs":[{"name":"a","type":"uint256"},{"name":"s","type":"string"},{"name":"arr","type":"uint256[]"},{"name":"n","type":"int8"}]},
+	{"type":"function","name":"pair","constant":true,"inputs":[],
+	 "outputs":[{"name":"p","type":"tuple","components":[{"name":"x","type":"uint256"},{"name":"ok","type":"bool"}]},{"name":"b","type":"bytes2[2]"}]},
+	{"type":"event","name":"Transfer","anonymous":false,
+	 "inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]},
+	{"type":"constructor","inputs":[{"name":"supply","type":"uint256"}]},
+	{"type":"fallback"}
+]`
+
+func words(ws ...string) []byte {
+	data, _ := hex.DecodeString(strings.Join(ws, ""))
+	return data
+}
+
+func word(v int64) string {
+	return hex.EncodeToString(common.LeftPadBytes(big.NewInt(v).Bytes(), 32))
+}
+
+func TestParse(t *testing.T) {
+	assert := assert.New(t)
+
+	abi, err := Parse([]byte(testABI))
+	assert.Nil(err)
+	assert.Equal(3, len(abi.Methods))
+	assert.Equal(1, len(abi.Events))
+	assert.NotNil(abi.Constructor)
+
+	transfer, ok := abi.MethodByID(common.FromHex("0xa9059cbb0000"))
+	assert.True(ok)
+	assert.Equal("transfer(address,uint256)", transfer.Sig())
+	assert.False(transfer.IsConstant())
+
+	pair := abi.Methods[2]
+	assert.Equal("pair()", pair.Sig())
+	assert.True(pair.IsConstant())
+	assert.Equal("(uint256,bool)", pair.Outputs[0].Type.String())
+	assert.Equal("bytes2[2]", pair.Outputs[1].Type.String())
+
+	transferEvent, ok := abi.EventByID(common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"))
+	assert.True(ok)
+	assert.Equal("Transfer(address,address,uint256)", transferEvent.Sig())
+
+	_, err = Parse([]byte(`[{"type":"function","name":"f","inputs":[{"name":"x","type":"uint7"}]}]`))
+	assert.NotNil(err)
+	_, err = Parse([]byte(`[{"type":"function","name":"f","inputs":[{"name":"x","type":"fixed128x18"}]}]`))
+	assert.NotNil(err)
+}
+
+func TestUnpack(t *testing.T) {
+	assert := assert.New(t)
+
+	abi, err := Parse([]byte(testABI))
+	assert.Nil(err)
+
+	// (1, "hi", [2, 3], -1)
+	data := words(
+		word(1), word(0x80), word(0xc0), strings.Repeat("ff", 32),
+		word(2), "6869"+strings.Repeat("00", 30),
+		word(2), word(2), word(3),
+	)
+	values, err := abi.Methods[1].Outputs.UnpackValues(data)
+	assert.Nil(err)
+	assert.Equal([]Value{
+		{Name: "a", Type: "uint256", Value: "1"},
+		{Name: "s", Type: "string", Value: "hi"},
+		{Name: "arr", Type: "uint256[]", Value: []interface{}{"2", "3"}},
+		{Name: "n", Type: "int8", Value: "-1"},
+	}, values)
+
+	// ((7, true), [0x1234, 0x5678])
+	data = words(word(7), word(1), "1234"+strings.Repeat("00", 30), "5678"+strings.Repeat("00", 30))
+	values, err = abi.Methods[2].Outputs.UnpackValues(data)
+	assert.Nil(err)
+	assert.Equal([]Value{
+		{Name: "p", Type: "(uint256,bool)", Value: []Value{
+			{Name: "x", Type: "uint256", Value: "7"},
+			{Name: "ok", Type: "bool", Value: true},
+		}},
+		{Name: "b", Type: "bytes2[2]", Value: []interface{}{"0x1234", "0x5678"}},
+	}, values)
+
+	// Truncated data and out of range offsets
+	_, err = abi.Methods[2].Outputs.UnpackValues(data[:96])
+	assert.NotNil(err)
+	_, err = abi.Methods[1].Outputs.UnpackValues(words(word(1), word(0x1000), word(0xc0), word(0)))
+	assert.NotNil(err)
+	_, err = abi.Methods[1].Outputs.UnpackValues(words(word(1), word(0x80), word(0x80), word(0), strings.Repeat("ff", 32)))
+	assert.NotNil(err)
+}
+
+func TestUnpackLog(t *testing.T) {
+	assert := assert.New(t)
+
+	abi, err := Parse([]byte(testABI))
+	assert.Nil(err)
+	event := abi.Events[0]
+
+	from := common.HexToAddress("0x2e833968e5bb786ae419c4d13189fb081cc43bab")
+	to := common.HexToAddress("0x70f587259738cb626a1720af7038b8dcdb6a42a0")
+	topics := []common.Hash{event.ID(), common.BytesToHash(from[:]), common.BytesToHash(to[:])}
+	values, err := event.UnpackLog(topics, words(word(1000)))
+	assert.Nil(err)
+	assert.Equal([]Value{
+		{Name: "from", Type: "address", Value: from.Hex()},
+		{Name: "to", Type: "address", Value: to.Hex()},
+		{Name: "value", Type: "uint256", Value: "1000"},
+	}, values)
+
+	log := &types.Log{Address: to, Topics: topics, Data: words(word(1000))}
+	decodedLog := DecodeLog(abi, log)
+	assert.Equal("Transfer(address,address,uint256)", decodedLog.Event)
+	assert.Equal(values, decodedLog.Args)
+	decodedLog = DecodeLog(nil, log)
+	assert.Equal("", decodedLog.Event)
+	assert.Equal("0x"+word(1000), decodedLog.Data)
+
+	_, err = event.UnpackLog(topics[:2], words(word(1000)))
+	assert.NotNil(err)
+	_, err = event.UnpackLog(topics[1:], words(word(1000)))
+	assert.NotNil(err)
+}
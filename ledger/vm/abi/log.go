@@ -0,0 +1,36 @@
+package abi
+
+import (
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// DecodedLog is a log with the event and the arguments decoded, if known.
+type DecodedLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    string         `json:"data"`
+	Event   string         `json:"event,omitempty"`
+	Args    []Value        `json:"args,omitempty"`
+}
+
+// DecodeLog decodes the log with the events of the ABI. The log is left undecoded if
+// the ABI is nil, or the event is unknown.
+func DecodeLog(abi *ABI, log *types.Log) *DecodedLog {
+	decodedLog := &DecodedLog{
+		Address: log.Address,
+		Topics:  log.Topics,
+		Data:    hexutil.Encode(log.Data),
+	}
+	if abi == nil || len(log.Topics) == 0 {
+		return decodedLog
+	}
+	if event, ok := abi.EventByID(log.Topics[0]); ok {
+		if args, err := event.UnpackLog(log.Topics, log.Data); err == nil {
+			decodedLog.Event = event.Sig()
+			decodedLog.Args = args
+		}
+	}
+	return decodedLog
+}
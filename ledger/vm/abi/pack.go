@@ -0,0 +1,271 @@
+package abi
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+)
+
+// Pack encodes the arguments with the same value representation as Unpack.
+func (args Arguments) Pack(values ...interface{}) ([]byte, error) {
+	if len(values) != len(args) {
+		return nil, fmt.Errorf("abi: expected %v arguments, got %v", len(args), len(values))
+	}
+	types := make([]Type, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type
+	}
+	return packTuple(types, values)
+}
+
+// Pack encodes the call data of the method, i.e. the selector followed by the arguments.
+func (m *Method) Pack(values ...interface{}) ([]byte, error) {
+	data, err := m.Inputs.Pack(values...)
+	if err != nil {
+		return nil, err
+	}
+	return append(m.ID(), data...), nil
+}
+
+// MethodByName returns the method with the given name, or with the given
+// signature, e.g. "transfer(address,uint256)", if the name is overloaded.
+func (abi *ABI) MethodByName(name string) (*Method, error) {
+	var found []*Method
+	for _, method := range abi.Methods {
+		if method.Name == name || method.Sig() == name {
+			found = append(found, method)
+		}
+	}
+	if len(found) == 0 {
+		return nil, fmt.Errorf("abi: method %v not found", name)
+	}
+	if len(found) > 1 {
+		sigs := make([]string, len(found))
+		for i, method := range found {
+			sigs[i] = method.Sig()
+		}
+		return nil, fmt.Errorf("abi: method %v is overloaded, use one of the signatures: %v", name, strings.Join(sigs, ", "))
+	}
+	return found[0], nil
+}
+
+func packTuple(types []Type, values []interface{}) ([]byte, error) {
+	headSize := 0
+	for _, t := range types {
+		headSize += t.headSize()
+	}
+
+	var head, tail []byte
+	for i, t := range types {
+		packed, err := packValue(t, values[i])
+		if err != nil {
+			return nil, err
+		}
+		if t.isDynamic() {
+			head = append(head, packUint(big.NewInt(int64(headSize+len(tail))))...)
+			tail = append(tail, packed...)
+		} else {
+			head = append(head, packed...)
+		}
+	}
+	return append(head, tail...), nil
+}
+
+func packValue(t Type, value interface{}) ([]byte, error) {
+	switch t.Kind {
+	case UintKind, IntKind:
+		v, ok := value.(*big.Int)
+		if !ok {
+			return nil, typeError(t, value)
+		}
+		if v.Sign() < 0 {
+			return packUint(new(big.Int).Add(tt256, v)), nil
+		}
+		return packUint(v), nil
+	case AddressKind:
+		v, ok := value.(common.Address)
+		if !ok {
+			return nil, typeError(t, value)
+		}
+		return common.LeftPadBytes(v[:], 32), nil
+	case BoolKind:
+		v, ok := value.(bool)
+		if !ok {
+			return nil, typeError(t, value)
+		}
+		if v {
+			return packUint(big.NewInt(1)), nil
+		}
+		return packUint(big.NewInt(0)), nil
+	case FixedBytesKind:
+		v, ok := value.([]byte)
+		if !ok || len(v) != t.Size {
+			return nil, typeError(t, value)
+		}
+		return common.RightPadBytes(v, 32), nil
+	case BytesKind, StringKind:
+		var v []byte
+		if s, ok := value.(string); ok && t.Kind == StringKind {
+			v = []byte(s)
+		} else if b, ok := value.([]byte); ok && t.Kind == BytesKind {
+			v = b
+		} else {
+			return nil, typeError(t, value)
+		}
+		paddedLength := (len(v) + 31) / 32 * 32
+		return append(packUint(big.NewInt(int64(len(v)))), common.RightPadBytes(v, paddedLength)...), nil
+	case SliceKind, ArrayKind, TupleKind:
+		v, ok := value.([]interface{})
+		if !ok {
+			return nil, typeError(t, value)
+		}
+		switch t.Kind {
+		case SliceKind:
+			packed, err := packTuple(repeat(*t.Elem, len(v)), v)
+			if err != nil {
+				return nil, err
+			}
+			return append(packUint(big.NewInt(int64(len(v)))), packed...), nil
+		case ArrayKind:
+			if len(v) != t.Size {
+				return nil, typeError(t, value)
+			}
+			return packTuple(repeat(*t.Elem, t.Size), v)
+		default:
+			if len(v) != len(t.Components) {
+				return nil, typeError(t, value)
+			}
+			return t.Components.Pack(v...)
+		}
+	}
+	return nil, fmt.Errorf("abi: unsupported type kind %v", t.Kind)
+}
+
+func packUint(v *big.Int) []byte {
+	return common.LeftPadBytes(v.Bytes(), 32)
+}
+
+func typeError(t Type, value interface{}) error {
+	return fmt.Errorf("abi: cannot use %v as type %v", value, t.String())
+}
+
+// ParseArgs parses the arguments from their string forms, e.g. the command
+// line. The integers are decimal or 0x prefixed hex, the bytes are hex, and
+// the arrays and tuples are JSON arrays, e.g. `["0x2e83...", 100]`.
+func (args Arguments) ParseArgs(strs []string) ([]interface{}, error) {
+	if len(strs) != len(args) {
+		return nil, fmt.Errorf("abi: expected %v arguments, got %v", len(args), len(strs))
+	}
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		value, err := ParseValue(arg.Type, strs[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument %v: %v", arg.Name, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// ParseValue parses a value of the type from its string form.
+func ParseValue(t Type, s string) (interface{}, error) {
+	switch t.Kind {
+	case SliceKind, ArrayKind, TupleKind:
+		decoder := json.NewDecoder(bytes.NewReader([]byte(s)))
+		decoder.UseNumber()
+		var v interface{}
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("%v must be a JSON array: %v", t.String(), err)
+		}
+		return parseJSONValue(t, v)
+	case UintKind, IntKind:
+		v, ok := new(big.Int).SetString(s, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid integer: %v", s)
+		}
+		min, max := big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), uint(t.Size))
+		if t.Kind == IntKind {
+			max.Rsh(max, 1)
+			min.Neg(max)
+		}
+		if v.Cmp(min) < 0 || v.Cmp(max) >= 0 {
+			return nil, fmt.Errorf("%v out of the range of %v", s, t.String())
+		}
+		return v, nil
+	case AddressKind:
+		if !common.IsHexAddress(s) {
+			return nil, fmt.Errorf("invalid address: %v", s)
+		}
+		return common.HexToAddress(s), nil
+	case BoolKind:
+		return strconv.ParseBool(s)
+	case FixedBytesKind, BytesKind:
+		if !strings.HasPrefix(s, "0x") && !strings.HasPrefix(s, "0X") {
+			return nil, fmt.Errorf("bytes must be 0x prefixed hex: %v", s)
+		}
+		v, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, err
+		}
+		if t.Kind == FixedBytesKind && len(v) != t.Size {
+			return nil, fmt.Errorf("expected %v bytes, got %v", t.Size, len(v))
+		}
+		return v, nil
+	case StringKind:
+		return s, nil
+	}
+	return nil, fmt.Errorf("abi: unsupported type kind %v", t.Kind)
+}
+
+func parseJSONValue(t Type, v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case string:
+		if t.Kind == SliceKind || t.Kind == ArrayKind || t.Kind == TupleKind {
+			return nil, fmt.Errorf("%v must be a JSON array", t.String())
+		}
+		return ParseValue(t, v)
+	case json.Number:
+		if t.Kind != UintKind && t.Kind != IntKind {
+			return nil, fmt.Errorf("cannot use number %v as type %v", v, t.String())
+		}
+		return ParseValue(t, v.String())
+	case bool:
+		if t.Kind != BoolKind {
+			return nil, fmt.Errorf("cannot use bool as type %v", t.String())
+		}
+		return v, nil
+	case []interface{}:
+		var types []Type
+		switch t.Kind {
+		case SliceKind:
+			types = repeat(*t.Elem, len(v))
+		case ArrayKind:
+			types = repeat(*t.Elem, t.Size)
+		case TupleKind:
+			for _, c := range t.Components {
+				types = append(types, c.Type)
+			}
+		default:
+			return nil, fmt.Errorf("cannot use array as type %v", t.String())
+		}
+		if len(v) != len(types) {
+			return nil, fmt.Errorf("expected %v elements for %v, got %v", len(types), t.String(), len(v))
+		}
+		values := make([]interface{}, len(v))
+		for i, elem := range v {
+			value, err := parseJSONValue(types[i], elem)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = value
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("cannot use %v as type %v", v, t.String())
+}
@@ -0,0 +1,90 @@
+package abi
+
+import (
+	"encoding/hex"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+)
+
+func TestPack(t *testing.T) {
+	assert := assert.New(t)
+
+	abi, err := Parse([]byte(testABI))
+	assert.Nil(err)
+
+	transfer, err := abi.MethodByName("transfer")
+	assert.Nil(err)
+	to := common.HexToAddress("0x2e833968e5bb786ae419c4d13189fb081cc43bab")
+	data, err := transfer.Pack(to, big.NewInt(1000))
+	assert.Nil(err)
+	assert.Equal("a9059cbb"+"0000000000000000000000002e833968e5bb786ae419c4d13189fb081cc43bab"+word(1000),
+		hex.EncodeToString(data))
+
+	_, err = transfer.Pack(to)
+	assert.NotNil(err)
+	_, err = transfer.Pack(to, "1000")
+	assert.NotNil(err)
+
+	// Round trip of the dynamic types
+	info, err := abi.MethodByName("info()")
+	assert.Nil(err)
+	values := []interface{}{big.NewInt(1), "hi", []interface{}{big.NewInt(2), big.NewInt(3)}, big.NewInt(-1)}
+	data, err = info.Outputs.Pack(values...)
+	assert.Nil(err)
+	assert.Equal(words(
+		word(1), word(0x80), word(0xc0), strings.Repeat("ff", 32),
+		word(2), "6869"+strings.Repeat("00", 30),
+		word(2), word(2), word(3),
+	), data)
+	unpacked, err := info.Outputs.Unpack(data)
+	assert.Nil(err)
+	assert.Equal(values, unpacked)
+
+	_, err = abi.MethodByName("approve")
+	assert.NotNil(err)
+}
+
+func TestParseArgs(t *testing.T) {
+	assert := assert.New(t)
+
+	abi, err := Parse([]byte(testABI))
+	assert.Nil(err)
+	transfer, _ := abi.MethodByName("transfer")
+	info, _ := abi.MethodByName("info")
+	pair, _ := abi.MethodByName("pair")
+
+	values, err := transfer.Inputs.ParseArgs([]string{"0x2e833968e5bb786ae419c4d13189fb081cc43bab", "0x3e8"})
+	assert.Nil(err)
+	assert.Equal([]interface{}{common.HexToAddress("0x2e833968e5bb786ae419c4d13189fb081cc43bab"), big.NewInt(1000)}, values)
+
+	values, err = info.Outputs.ParseArgs([]string{"1", "hello, world", `[2, "3"]`, "-128"})
+	assert.Nil(err)
+	assert.Equal([]interface{}{big.NewInt(1), "hello, world", []interface{}{big.NewInt(2), big.NewInt(3)}, big.NewInt(-128)}, values)
+
+	values, err = pair.Outputs.ParseArgs([]string{`[7, true]`, `["0x1234", "0x5678"]`})
+	assert.Nil(err)
+	assert.Equal([]interface{}{
+		[]interface{}{big.NewInt(7), true},
+		[]interface{}{[]byte{0x12, 0x34}, []byte{0x56, 0x78}},
+	}, values)
+
+	for _, args := range [][]string{
+		{"0x2e833968e5bb786ae419c4d13189fb081cc43bab"},        // missing argument
+		{"0x2e833968e5bb786ae419c4d13189fb081cc43b", "1000"},  // invalid address
+		{"0x2e833968e5bb786ae419c4d13189fb081cc43bab", "-1"},  // negative uint
+		{"0x2e833968e5bb786ae419c4d13189fb081cc43bab", "abc"}, // invalid integer
+	} {
+		_, err = transfer.Inputs.ParseArgs(args)
+		assert.NotNil(err, "%v", args)
+	}
+	_, err = info.Outputs.ParseArgs([]string{"1", "hi", "[2, 3]", "128"}) // int8 overflow
+	assert.NotNil(err)
+	_, err = pair.Outputs.ParseArgs([]string{"[7, true]", `["0x12", "0x5678"]`}) // bytes2 length
+	assert.NotNil(err)
+	_, err = pair.Outputs.ParseArgs([]string{"[7]", `["0x1234", "0x5678"]`}) // tuple length
+	assert.NotNil(err)
+}
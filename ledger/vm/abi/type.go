@@ -0,0 +1,183 @@
+package abi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of an ABI type.
+type Kind int
+
+const (
+	UintKind Kind = iota
+	IntKind
+	AddressKind
+	BoolKind
+	FixedBytesKind
+	BytesKind
+	StringKind
+	SliceKind
+	ArrayKind
+	TupleKind
+)
+
+// Type is a parsed Solidity ABI type.
+type Type struct {
+	Kind Kind
+
+	// Size is the bit size of the integer types, the byte size of the fixed
+	// size bytes types, or the length of the fixed size array types.
+	Size int
+
+	// Elem is the element type of the array types.
+	Elem *Type
+
+	// Components are the fields of the tuple types.
+	Components Arguments
+}
+
+// NewType parses the type string of an ABI argument, e.g. "uint256",
+// "address[]" or "tuple[2]". The components are only used by the tuple types.
+func NewType(t string, components []ArgumentMarshaling) (Type, error) {
+	if strings.HasSuffix(t, "]") {
+		idx := strings.LastIndex(t, "[")
+		if idx < 0 {
+			return Type{}, fmt.Errorf("invalid array type: %v", t)
+		}
+		elem, err := NewType(t[:idx], components)
+		if err != nil {
+			return Type{}, err
+		}
+		lengthStr := t[idx+1 : len(t)-1]
+		if lengthStr == "" {
+			return Type{Kind: SliceKind, Elem: &elem}, nil
+		}
+		length, err := strconv.Atoi(lengthStr)
+		if err != nil || length <= 0 {
+			return Type{}, fmt.Errorf("invalid array length: %v", t)
+		}
+		return Type{Kind: ArrayKind, Size: length, Elem: &elem}, nil
+	}
+
+	switch {
+	case t == "address":
+		return Type{Kind: AddressKind, Size: 160}, nil
+	case t == "bool":
+		return Type{Kind: BoolKind}, nil
+	case t == "string":
+		return Type{Kind: StringKind}, nil
+	case t == "bytes":
+		return Type{Kind: BytesKind}, nil
+	case t == "function":
+		return Type{Kind: FixedBytesKind, Size: 24}, nil
+	case t == "tuple":
+		args := make(Arguments, len(components))
+		for i, c := range components {
+			arg, err := c.toArgument()
+			if err != nil {
+				return Type{}, err
+			}
+			args[i] = arg
+		}
+		return Type{Kind: TupleKind, Components: args}, nil
+	case strings.HasPrefix(t, "uint"):
+		size, err := parseIntSize(t, "uint")
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: UintKind, Size: size}, nil
+	case strings.HasPrefix(t, "int"):
+		size, err := parseIntSize(t, "int")
+		if err != nil {
+			return Type{}, err
+		}
+		return Type{Kind: IntKind, Size: size}, nil
+	case strings.HasPrefix(t, "bytes"):
+		size, err := strconv.Atoi(t[len("bytes"):])
+		if err != nil || size <= 0 || size > 32 {
+			return Type{}, fmt.Errorf("invalid fixed size bytes type: %v", t)
+		}
+		return Type{Kind: FixedBytesKind, Size: size}, nil
+	}
+	return Type{}, fmt.Errorf("unsupported ABI type: %v", t)
+}
+
+func parseIntSize(t, prefix string) (int, error) {
+	sizeStr := t[len(prefix):]
+	if sizeStr == "" {
+		return 256, nil
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size <= 0 || size > 256 || size%8 != 0 {
+		return 0, fmt.Errorf("invalid integer type: %v", t)
+	}
+	return size, nil
+}
+
+// String returns the canonical type string used in the signatures.
+func (t Type) String() string {
+	switch t.Kind {
+	case UintKind:
+		return fmt.Sprintf("uint%d", t.Size)
+	case IntKind:
+		return fmt.Sprintf("int%d", t.Size)
+	case AddressKind:
+		return "address"
+	case BoolKind:
+		return "bool"
+	case FixedBytesKind:
+		return fmt.Sprintf("bytes%d", t.Size)
+	case BytesKind:
+		return "bytes"
+	case StringKind:
+		return "string"
+	case SliceKind:
+		return t.Elem.String() + "[]"
+	case ArrayKind:
+		return fmt.Sprintf("%v[%d]", t.Elem.String(), t.Size)
+	case TupleKind:
+		types := make([]string, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type.String()
+		}
+		return "(" + strings.Join(types, ",") + ")"
+	}
+	return ""
+}
+
+// isDynamic returns true if the encoding of the type is referenced by an
+// offset in the head of the enclosing tuple.
+func (t Type) isDynamic() bool {
+	switch t.Kind {
+	case BytesKind, StringKind, SliceKind:
+		return true
+	case ArrayKind:
+		return t.Elem.isDynamic()
+	case TupleKind:
+		for _, c := range t.Components {
+			if c.Type.isDynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize returns the size the type occupies in the head of the enclosing tuple.
+func (t Type) headSize() int {
+	if t.isDynamic() {
+		return 32
+	}
+	switch t.Kind {
+	case ArrayKind:
+		return t.Size * t.Elem.headSize()
+	case TupleKind:
+		size := 0
+		for _, c := range t.Components {
+			size += c.Type.headSize()
+		}
+		return size
+	}
+	return 32
+}
@@ -0,0 +1,244 @@
+package abi
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+)
+
+var (
+	errInsufficientData = errors.New("abi: insufficient data")
+
+	tt256 = new(big.Int).Lsh(big.NewInt(1), 256)
+)
+
+// Value is a decoded argument in a JSON friendly form. The integers are
+// represented as decimal strings, the bytes as hex strings, the arrays as
+// lists and the tuples as lists of Values.
+type Value struct {
+	Name  string      `json:"name"`
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// Unpack decodes the ABI encoded arguments. The integers are decoded as
+// *big.Int, the addresses as common.Address, the bytes as []byte, and the
+// arrays and tuples as []interface{}.
+func (args Arguments) Unpack(data []byte) ([]interface{}, error) {
+	types := make([]Type, len(args))
+	for i, arg := range args {
+		types[i] = arg.Type
+	}
+	return unpackTuple(types, data)
+}
+
+// UnpackValues decodes the ABI encoded arguments into Values.
+func (args Arguments) UnpackValues(data []byte) ([]Value, error) {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return nil, err
+	}
+	return toValues(args, values), nil
+}
+
+// UnpackLog decodes the topics and the data of a log emitted by the event.
+// The indexed arguments of dynamic types are only available as their hashes.
+func (e *Event) UnpackLog(topics []common.Hash, data []byte) ([]Value, error) {
+	if !e.Anonymous {
+		if len(topics) == 0 || topics[0] != e.ID() {
+			return nil, fmt.Errorf("abi: log is not emitted by event %v", e.Sig())
+		}
+		topics = topics[1:]
+	}
+
+	var indexed, nonIndexed Arguments
+	for _, arg := range e.Inputs {
+		if arg.Indexed {
+			indexed = append(indexed, arg)
+		} else {
+			nonIndexed = append(nonIndexed, arg)
+		}
+	}
+	if len(topics) != len(indexed) {
+		return nil, fmt.Errorf("abi: expected %v indexed topics, got %v", len(indexed), len(topics))
+	}
+
+	indexedValues := make([]Value, len(indexed))
+	for i, arg := range indexed {
+		if arg.Type.isDynamic() || arg.Type.Kind == ArrayKind || arg.Type.Kind == TupleKind {
+			indexedValues[i] = Value{Name: arg.Name, Type: arg.Type.String(), Value: topics[i].Hex()}
+			continue
+		}
+		value, err := unpackValue(arg.Type, topics[i][:])
+		if err != nil {
+			return nil, err
+		}
+		indexedValues[i] = toValue(arg, value)
+	}
+	nonIndexedValues, err := nonIndexed.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// Restore the declaration order of the arguments
+	values := make([]Value, 0, len(e.Inputs))
+	for _, arg := range e.Inputs {
+		if arg.Indexed {
+			values = append(values, indexedValues[0])
+			indexedValues = indexedValues[1:]
+		} else {
+			values = append(values, nonIndexedValues[0])
+			nonIndexedValues = nonIndexedValues[1:]
+		}
+	}
+	return values, nil
+}
+
+func unpackTuple(types []Type, data []byte) ([]interface{}, error) {
+	values := make([]interface{}, len(types))
+	head := 0
+	for i, t := range types {
+		start := head
+		if t.isDynamic() {
+			word, err := readWord(data, head)
+			if err != nil {
+				return nil, err
+			}
+			if start, err = toOffset(word, len(data)); err != nil {
+				return nil, err
+			}
+		} else if head > len(data) {
+			return nil, errInsufficientData
+		}
+		value, err := unpackValue(t, data[start:])
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+		head += t.headSize()
+	}
+	return values, nil
+}
+
+// unpackValue decodes a value of the type from the beginning of the data.
+func unpackValue(t Type, data []byte) (interface{}, error) {
+	switch t.Kind {
+	case SliceKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		length, err := toOffset(word, len(data))
+		if err != nil {
+			return nil, err
+		}
+		return unpackTuple(repeat(*t.Elem, length), data[32:])
+	case ArrayKind:
+		return unpackTuple(repeat(*t.Elem, t.Size), data)
+	case TupleKind:
+		types := make([]Type, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+		return unpackTuple(types, data)
+	case BytesKind, StringKind:
+		word, err := readWord(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		length, err := toOffset(word, len(data))
+		if err != nil {
+			return nil, err
+		}
+		if 32+length > len(data) {
+			return nil, errInsufficientData
+		}
+		content := common.CopyBytes(data[32 : 32+length])
+		if t.Kind == StringKind {
+			return string(content), nil
+		}
+		return content, nil
+	}
+
+	word, err := readWord(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch t.Kind {
+	case UintKind:
+		return new(big.Int).SetBytes(word), nil
+	case IntKind:
+		value := new(big.Int).SetBytes(word)
+		if word[0]&0x80 != 0 {
+			value.Sub(value, tt256)
+		}
+		return value, nil
+	case AddressKind:
+		return common.BytesToAddress(word[12:]), nil
+	case BoolKind:
+		return word[31] != 0, nil
+	case FixedBytesKind:
+		return common.CopyBytes(word[:t.Size]), nil
+	}
+	return nil, fmt.Errorf("abi: unsupported type kind %v", t.Kind)
+}
+
+func readWord(data []byte, offset int) ([]byte, error) {
+	if offset < 0 || offset+32 > len(data) {
+		return nil, errInsufficientData
+	}
+	return data[offset : offset+32], nil
+}
+
+// toOffset converts a word to an offset or a length, which must not exceed the data length.
+func toOffset(word []byte, limit int) (int, error) {
+	value := new(big.Int).SetBytes(word)
+	if !value.IsInt64() || value.Int64() > int64(limit) {
+		return 0, errInsufficientData
+	}
+	return int(value.Int64()), nil
+}
+
+func repeat(t Type, n int) []Type {
+	types := make([]Type, n)
+	for i := range types {
+		types[i] = t
+	}
+	return types
+}
+
+func toValues(args Arguments, values []interface{}) []Value {
+	result := make([]Value, len(args))
+	for i, arg := range args {
+		result[i] = toValue(arg, values[i])
+	}
+	return result
+}
+
+func toValue(arg Argument, value interface{}) Value {
+	return Value{Name: arg.Name, Type: arg.Type.String(), Value: toJSONValue(arg.Type, value)}
+}
+
+func toJSONValue(t Type, value interface{}) interface{} {
+	switch t.Kind {
+	case UintKind, IntKind:
+		return value.(*big.Int).String()
+	case AddressKind:
+		return value.(common.Address).Hex()
+	case FixedBytesKind, BytesKind:
+		return hexutil.Encode(value.([]byte))
+	case SliceKind, ArrayKind:
+		elems := value.([]interface{})
+		result := make([]interface{}, len(elems))
+		for i, elem := range elems {
+			result[i] = toJSONValue(*t.Elem, elem)
+		}
+		return result
+	case TupleKind:
+		return toValues(t.Components, value.([]interface{}))
+	}
+	return value
+}
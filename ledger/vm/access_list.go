@@ -0,0 +1,94 @@
+package vm
+
+import (
+	"github.com/thetatoken/theta/common"
+)
+
+// accessList tracks the addresses and the storage slots accessed by a
+// transaction, which are charged the warm instead of the cold access cost
+// after the EIP-2929 upgrade. The additions are journaled so that the access
+// list reverts together with the state when a call frame fails.
+type accessList struct {
+	addresses map[common.Address]struct{}
+	slots     map[common.Address]map[common.Hash]struct{}
+	journal   []accessListChange
+}
+
+// accessListChange records an addition to the access list, slot is nil if
+// the change added an address.
+type accessListChange struct {
+	address common.Address
+	slot    *common.Hash
+}
+
+func newAccessList() *accessList {
+	return &accessList{
+		addresses: make(map[common.Address]struct{}),
+		slots:     make(map[common.Address]map[common.Hash]struct{}),
+	}
+}
+
+// containsAddress returns true if the address is in the access list.
+func (al *accessList) containsAddress(addr common.Address) bool {
+	_, ok := al.addresses[addr]
+	return ok
+}
+
+// containsSlot returns true if the storage slot of the address is in the access list.
+func (al *accessList) containsSlot(addr common.Address, slot common.Hash) bool {
+	slots, ok := al.slots[addr]
+	if !ok {
+		return false
+	}
+	_, ok = slots[slot]
+	return ok
+}
+
+// addAddress adds the address to the access list, and returns false if it
+// was already present.
+func (al *accessList) addAddress(addr common.Address) bool {
+	if al.containsAddress(addr) {
+		return false
+	}
+	al.addresses[addr] = struct{}{}
+	al.journal = append(al.journal, accessListChange{address: addr})
+	return true
+}
+
+// addSlot adds the storage slot of the address to the access list, and
+// returns false if it was already present.
+func (al *accessList) addSlot(addr common.Address, slot common.Hash) bool {
+	if al.containsSlot(addr, slot) {
+		return false
+	}
+	slots, ok := al.slots[addr]
+	if !ok {
+		slots = make(map[common.Hash]struct{})
+		al.slots[addr] = slots
+	}
+	slots[slot] = struct{}{}
+	al.journal = append(al.journal, accessListChange{address: addr, slot: &slot})
+	return true
+}
+
+// snapshot returns an identifier of the current access list.
+func (al *accessList) snapshot() int {
+	return len(al.journal)
+}
+
+// revertToSnapshot removes the additions made after the given snapshot.
+func (al *accessList) revertToSnapshot(id int) {
+	for i := len(al.journal) - 1; i >= id; i-- {
+		change := al.journal[i]
+		if change.slot == nil {
+			delete(al.addresses, change.address)
+			continue
+		}
+		slots := al.slots[change.address]
+		delete(slots, *change.slot)
+		if len(slots) == 0 {
+			delete(al.slots, change.address)
+		}
+	}
+	al.journal = al.journal[:id]
+}
@@ -0,0 +1,36 @@
+package vm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+)
+
+func TestAccessListRevert(t *testing.T) {
+	assert := assert.New(t)
+
+	addr1 := common.HexToAddress("0x1")
+	addr2 := common.HexToAddress("0x2")
+	slot := common.HexToHash("0x3")
+
+	al := newAccessList()
+	assert.True(al.addAddress(addr1))
+	assert.False(al.addAddress(addr1))
+
+	snapshot := al.snapshot()
+	assert.True(al.addAddress(addr2))
+	assert.True(al.addSlot(addr1, slot))
+	assert.False(al.addSlot(addr1, slot))
+	assert.True(al.containsAddress(addr2))
+	assert.True(al.containsSlot(addr1, slot))
+	assert.False(al.containsSlot(addr2, slot))
+
+	al.revertToSnapshot(snapshot)
+	assert.True(al.containsAddress(addr1))
+	assert.False(al.containsAddress(addr2))
+	assert.False(al.containsSlot(addr1, slot))
+
+	// The reverted entries are cold again
+	assert.True(al.addSlot(addr1, slot))
+}
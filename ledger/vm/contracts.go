@@ -23,7 +23,9 @@ import (
 
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/math"
+	"github.com/thetatoken/theta/core"
 	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/crypto/bls"
 	"github.com/thetatoken/theta/crypto/bn256"
 	"github.com/thetatoken/theta/ledger/vm/params"
 	"golang.org/x/crypto/ripemd160"
@@ -33,8 +35,8 @@ import (
 // requires a deterministic gas count based on the input size of the Run method of the
 // contract.
 type PrecompiledContract interface {
-	RequiredGas(input []byte, blockHeight uint64) uint64                   // RequiredPrice calculates the contract gas use
-	Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) // Run runs the precompiled contract
+	RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 // RequiredPrice calculates the contract gas use
+	Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error)           // Run runs the precompiled contract
 }
 
 // PrecompiledContractsHomestead contains the default set of pre-compiled Ethereum
@@ -77,10 +79,47 @@ var PrecompiledContractsThetaSupport = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{203}): &transferTheta{},
 }
 
+// PrecompiledContractsStakingSupport adds the queries of the guardian and elite edge
+// node pools and the BLS signature verifications to PrecompiledContractsThetaSupport.
+var PrecompiledContractsStakingSupport = map[common.Address]PrecompiledContract{
+	common.BytesToAddress([]byte{1}): &ecrecover{},
+	common.BytesToAddress([]byte{2}): &sha256hash{},
+	common.BytesToAddress([]byte{3}): &ripemd160hash{},
+	common.BytesToAddress([]byte{4}): &dataCopy{},
+	common.BytesToAddress([]byte{5}): &bigModExp{},
+	common.BytesToAddress([]byte{6}): &bn256Add{},
+	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
+	common.BytesToAddress([]byte{8}): &bn256Pairing{},
+
+	common.BytesToAddress([]byte{201}): &thetaBalance{},
+	common.BytesToAddress([]byte{202}): &thetaStake{},
+	common.BytesToAddress([]byte{203}): &transferTheta{},
+	common.BytesToAddress([]byte{204}): &guardianInfo{},
+	common.BytesToAddress([]byte{205}): &guardianStake{},
+	common.BytesToAddress([]byte{206}): &eliteEdgeNodeInfo{},
+	common.BytesToAddress([]byte{207}): &eliteEdgeNodeStake{},
+	common.BytesToAddress([]byte{208}): &blsVerify{},
+	common.BytesToAddress([]byte{209}): &blsAggregateVerify{},
+	common.BytesToAddress([]byte{210}): &blsPopVerify{},
+}
+
+// statefulPrecompiledContract is a pre-compiled contract whose cost also depends on the state,
+// e.g. on the size of the data loaded. RequiredStateGas is charged in addition to RequiredGas.
+type statefulPrecompiledContract interface {
+	PrecompiledContract
+	RequiredStateGas(evm *EVM, input []byte) uint64
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 func RunPrecompiledContract(evm *EVM, p PrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
 	blockHeight := evm.StateDB.GetBlockHeight()
-	gas := p.RequiredGas(input, blockHeight)
+	gas := p.RequiredGas(input, evm.Forks(), blockHeight)
+	if sp, ok := p.(statefulPrecompiledContract); ok {
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, sp.RequiredStateGas(evm, input)); overflow {
+			return nil, ErrOutOfGas
+		}
+	}
 	if contract.UseGas(gas) {
 		callerAddr := contract.CallerAddress
 		return p.Run(evm, input, callerAddr)
@@ -91,7 +130,7 @@ func RunPrecompiledContract(evm *EVM, p PrecompiledContract, input []byte, contr
 // ECRECOVER implemented as a native contract.
 type ecrecover struct{}
 
-func (c *ecrecover) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *ecrecover) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return params.EcrecoverGas
 }
 
@@ -128,7 +167,7 @@ type sha256hash struct{}
 //
 // This method does not require any overflow checking as the input size gas costs
 // required for anything significant is so high it's impossible to pay for.
-func (c *sha256hash) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *sha256hash) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return uint64(len(input)+31)/32*params.Sha256PerWordGas + params.Sha256BaseGas
 }
 func (c *sha256hash) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
@@ -143,7 +182,7 @@ type ripemd160hash struct{}
 //
 // This method does not require any overflow checking as the input size gas costs
 // required for anything significant is so high it's impossible to pay for.
-func (c *ripemd160hash) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *ripemd160hash) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return uint64(len(input)+31)/32*params.Ripemd160PerWordGas + params.Ripemd160BaseGas
 }
 func (c *ripemd160hash) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
@@ -159,7 +198,7 @@ type dataCopy struct{}
 //
 // This method does not require any overflow checking as the input size gas costs
 // required for anything significant is so high it's impossible to pay for.
-func (c *dataCopy) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *dataCopy) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return uint64(len(input)+31)/32*params.IdentityPerWordGas + params.IdentityBaseGas
 }
 func (c *dataCopy) Run(evm *EVM, in []byte, callerAddr common.Address) ([]byte, error) {
@@ -184,7 +223,7 @@ var (
 )
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *bigModExp) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *bigModExp) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	var (
 		baseLen = new(big.Int).SetBytes(getData(input, 0, 32))
 		expLen  = new(big.Int).SetBytes(getData(input, 32, 32))
@@ -295,8 +334,8 @@ func newTwistPoint(blob []byte) (*bn256.G2, error) {
 type bn256Add struct{}
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *bn256Add) RequiredGas(input []byte, blockHeight uint64) uint64 {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func (c *bn256Add) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	if blockHeight < forks.June2021FeeAdjustment {
 		return params.Bn256AddGas
 	}
 	return params.Bn256AddGasIstanbul
@@ -320,8 +359,8 @@ func (c *bn256Add) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byt
 type bn256ScalarMul struct{}
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *bn256ScalarMul) RequiredGas(input []byte, blockHeight uint64) uint64 {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func (c *bn256ScalarMul) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	if blockHeight < forks.June2021FeeAdjustment {
 		return params.Bn256ScalarMulGas
 	}
 
@@ -353,8 +392,8 @@ var (
 type bn256Pairing struct{}
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *bn256Pairing) RequiredGas(input []byte, blockHeight uint64) uint64 {
-	if blockHeight < common.HeightJune2021FeeAdjustment {
+func (c *bn256Pairing) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	if blockHeight < forks.June2021FeeAdjustment {
 		return params.Bn256PairingBaseGas + uint64(len(input)/192)*params.Bn256PairingPerPointGas
 	}
 
@@ -395,7 +434,7 @@ type thetaBalance struct {
 }
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *thetaBalance) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *thetaBalance) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return params.ThetaBalanceGas
 }
 
@@ -412,7 +451,7 @@ type thetaStake struct {
 }
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *thetaStake) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *thetaStake) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return params.ThetaStakeGas
 }
 
@@ -429,7 +468,7 @@ type transferTheta struct {
 }
 
 // RequiredGas returns the gas required to execute the pre-compiled contract.
-func (c *transferTheta) RequiredGas(input []byte, blockHeight uint64) uint64 {
+func (c *transferTheta) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
 	return params.ThetaTransferGas
 }
 
@@ -445,3 +484,251 @@ func (c *transferTheta) Run(evm *EVM, input []byte, callerAddr common.Address) (
 
 	return common.Bytes{}, nil
 }
+
+// stakeHolderInfoLength is the length of the output of guardianInfo and eliteEdgeNodeInfo:
+// the membership flag, the total stake, and the BLS public key padded to 64 bytes.
+const stakeHolderInfoLength = 128
+
+// stakeHolderInfo encodes the membership, the total amount of ThetaWei (guardian) or
+// TFuelWei (elite edge node) staked, and the BLS public key of a stake holder, in the
+// layout of abi.encode(bool, uint256, bytes32, bytes16).
+func stakeHolderInfo(holder *core.StakeHolder, pubkey *bls.PublicKey) []byte {
+	info := make([]byte, stakeHolderInfoLength)
+	if holder == nil {
+		return info
+	}
+	copy(info[0:32], true32Byte)
+	copy(info[32:64], common.LeftPadBytes(holder.TotalStake().Bytes(), 32))
+	if !pubkey.IsEmpty() {
+		copy(info[64:], pubkey.ToBytes())
+	}
+	return info
+}
+
+// stakeOf returns the amount staked by the source to the holder, excluding the withdrawn stakes.
+func stakeOf(holder *core.StakeHolder, source common.Address) []byte {
+	amount := new(big.Int)
+	if holder != nil {
+		for _, stake := range holder.Stakes {
+			if stake.Source == source && !stake.Withdrawn {
+				amount.Add(amount, stake.Amount)
+			}
+		}
+	}
+	return common.LeftPadBytes(amount.Bytes(), 32)
+}
+
+// guardianPoolGas returns the gas required to load the guardian candidate pool, which is
+// stored as a whole, to retrieve a guardian.
+func guardianPoolGas(evm *EVM) uint64 {
+	return toWordSize(uint64(evm.StateDB.GetGuardianCandidatePoolSize())) * params.GuardianPoolWordGas
+}
+
+// guardianInfo retrieves whether the given address is in the guardian candidate pool,
+// the total amount of ThetaWei staked to the guardian and its BLS public key
+type guardianInfo struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *guardianInfo) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.ThetaStakeHolderGas
+}
+
+// RequiredStateGas returns the gas required to load the guardian candidate pool.
+func (c *guardianInfo) RequiredStateGas(evm *EVM, input []byte) uint64 {
+	return guardianPoolGas(evm)
+}
+
+func (c *guardianInfo) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	holder := common.BytesToAddress(getData(input, 0, 32))
+	if g := evm.StateDB.GetGuardian(holder); g != nil {
+		return stakeHolderInfo(g.StakeHolder, g.Pubkey), nil
+	}
+	return stakeHolderInfo(nil, nil), nil
+}
+
+// guardianStake retrieves the amount of ThetaWei the source address staked to the guardian
+type guardianStake struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *guardianStake) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.ThetaStakeHolderGas
+}
+
+// RequiredStateGas returns the gas required to load the guardian candidate pool.
+func (c *guardianStake) RequiredStateGas(evm *EVM, input []byte) uint64 {
+	return guardianPoolGas(evm)
+}
+
+func (c *guardianStake) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	holder := common.BytesToAddress(getData(input, 0, 32))
+	source := common.BytesToAddress(getData(input, 32, 32))
+	if g := evm.StateDB.GetGuardian(holder); g != nil {
+		return stakeOf(g.StakeHolder, source), nil
+	}
+	return stakeOf(nil, source), nil
+}
+
+// eliteEdgeNodeInfo retrieves whether the given address is in the elite edge node pool,
+// the total amount of TFuelWei staked to the elite edge node and its BLS public key
+type eliteEdgeNodeInfo struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *eliteEdgeNodeInfo) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.ThetaStakeHolderGas
+}
+
+func (c *eliteEdgeNodeInfo) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	holder := common.BytesToAddress(getData(input, 0, 32))
+	if een := evm.StateDB.GetEliteEdgeNode(holder); een != nil {
+		return stakeHolderInfo(een.StakeHolder, een.Pubkey), nil
+	}
+	return stakeHolderInfo(nil, nil), nil
+}
+
+// eliteEdgeNodeStake retrieves the amount of TFuelWei the source address staked to the elite edge node
+type eliteEdgeNodeStake struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *eliteEdgeNodeStake) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.ThetaStakeHolderGas
+}
+
+func (c *eliteEdgeNodeStake) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	holder := common.BytesToAddress(getData(input, 0, 32))
+	source := common.BytesToAddress(getData(input, 32, 32))
+	if een := evm.StateDB.GetEliteEdgeNode(holder); een != nil {
+		return stakeOf(een.StakeHolder, source), nil
+	}
+	return stakeOf(nil, source), nil
+}
+
+const (
+	blsPublicKeyLength = 48 // length of a compressed BLS public key
+	blsSignatureLength = 96 // length of a compressed BLS signature
+)
+
+var (
+	errBadBLSInput = errors.New("bad BLS input size")
+)
+
+// blsVerify verifies a BLS signature. The input is the public key (48 bytes), the
+// signature (96 bytes) and the message (the remaining bytes).
+type blsVerify struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blsVerify) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.BLSVerifyGas
+}
+
+func (c *blsVerify) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	if len(input) < blsPublicKeyLength+blsSignatureLength {
+		return nil, errBadBLSInput
+	}
+	pubkey, ok := blsPublicKeyFromBytes(input[:blsPublicKeyLength])
+	if !ok {
+		return false32Byte, nil
+	}
+	return verifyBLSSignature(pubkey, input[blsPublicKeyLength:]), nil
+}
+
+// blsAggregateVerify verifies a BLS signature aggregated from the signatures of the
+// same message. The input is the number of public keys (32 bytes), the public keys
+// (48 bytes each), the aggregate signature (96 bytes) and the message (the remaining
+// bytes). The public keys are not checked against the rogue key attack, the caller
+// must only aggregate the keys whose proof of possession has been verified, e.g. the
+// keys of the guardians and elite edge nodes, or the keys checked by blsPopVerify.
+type blsAggregateVerify struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blsAggregateVerify) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	numKeys := new(big.Int).SetBytes(getData(input, 0, 32))
+	// the keys beyond the input length are rejected by Run, but not charged
+	maxKeys := uint64(len(input)) / blsPublicKeyLength
+	if !numKeys.IsUint64() || numKeys.Uint64() > maxKeys {
+		return params.BLSVerifyGas + maxKeys*params.BLSAggregatePerKeyGas
+	}
+	return params.BLSVerifyGas + numKeys.Uint64()*params.BLSAggregatePerKeyGas
+}
+
+func (c *blsAggregateVerify) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	if len(input) < 32+blsSignatureLength {
+		return nil, errBadBLSInput
+	}
+	numKeys := new(big.Int).SetBytes(input[:32])
+	maxKeys := uint64(len(input)-32-blsSignatureLength) / blsPublicKeyLength
+	if numKeys.Sign() == 0 || !numKeys.IsUint64() || numKeys.Uint64() > maxKeys {
+		return nil, errBadBLSInput
+	}
+	input = input[32:]
+	pubkeys := make([]*bls.PublicKey, numKeys.Uint64())
+	for i := range pubkeys {
+		pubkey, ok := blsPublicKeyFromBytes(input[:blsPublicKeyLength])
+		if !ok {
+			return false32Byte, nil
+		}
+		pubkeys[i] = pubkey
+		input = input[blsPublicKeyLength:]
+	}
+	aggregatedPubkey := bls.AggregatePublicKeys(pubkeys)
+	if aggregatedPubkey.Equals(bls.NewAggregatePubkey()) {
+		return false32Byte, nil
+	}
+	return verifyBLSSignature(aggregatedPubkey, input), nil
+}
+
+// blsPopVerify verifies the proof of possession of a BLS public key. The input is
+// the public key (48 bytes) and the proof of possession (96 bytes).
+type blsPopVerify struct {
+}
+
+// RequiredGas returns the gas required to execute the pre-compiled contract.
+func (c *blsPopVerify) RequiredGas(input []byte, forks *common.ForkSchedule, blockHeight uint64) uint64 {
+	return params.BLSVerifyGas
+}
+
+func (c *blsPopVerify) Run(evm *EVM, input []byte, callerAddr common.Address) ([]byte, error) {
+	if len(input) != blsPublicKeyLength+blsSignatureLength {
+		return nil, errBadBLSInput
+	}
+	pubkey, ok := blsPublicKeyFromBytes(input[:blsPublicKeyLength])
+	if !ok {
+		return false32Byte, nil
+	}
+	pop, err := bls.SignatureFromBytes(input[blsPublicKeyLength:])
+	if err != nil {
+		return false32Byte, nil
+	}
+	if pop.PopVerify(pubkey) {
+		return true32Byte, nil
+	}
+	return false32Byte, nil
+}
+
+// blsPublicKeyFromBytes parses a BLS public key, rejecting the point at infinity
+// which would verify the infinity signature of any message.
+func blsPublicKeyFromBytes(input []byte) (*bls.PublicKey, bool) {
+	pubkey, err := bls.PublicKeyFromBytes(input)
+	if err != nil || pubkey.Equals(bls.NewAggregatePubkey()) {
+		return nil, false
+	}
+	return pubkey, true
+}
+
+// verifyBLSSignature verifies the signature (96 bytes) followed by the message in
+// input against the public key.
+func verifyBLSSignature(pubkey *bls.PublicKey, input []byte) []byte {
+	sig, err := bls.SignatureFromBytes(input[:blsSignatureLength])
+	if err != nil {
+		return false32Byte
+	}
+	if sig.Verify(input[blsSignatureLength:], pubkey) {
+		return true32Byte
+	}
+	return false32Byte
+}
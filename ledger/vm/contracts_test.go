@@ -21,7 +21,13 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto/bls"
+	"github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/vm/params"
+	"github.com/thetatoken/theta/store/database/backend"
 )
 
 // precompiledTest defines the input/output pairs for precompiled contract tests.
@@ -340,9 +346,9 @@ func testPrecompiled(addr string, test precompiledTest, t *testing.T) {
 	p := PrecompiledContractsByzantium[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
-		nil, new(big.Int), p.RequiredGas(in))
+		nil, new(big.Int), p.RequiredGas(in, common.MainnetForkSchedule, 0))
 	t.Run(fmt.Sprintf("%s-Gas=%d", test.name, contract.Gas), func(t *testing.T) {
-		if res, err := RunPrecompiledContract(nil, p, in, contract); err != nil {
+		if res, err := p.Run(nil, in, contract.CallerAddress); err != nil {
 			t.Error(err)
 		} else if common.Bytes2Hex(res) != test.expected {
 			t.Errorf("Expected %v, got %v", test.expected, common.Bytes2Hex(res))
@@ -356,7 +362,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 	}
 	p := PrecompiledContractsByzantium[common.HexToAddress(addr)]
 	in := common.Hex2Bytes(test.input)
-	reqGas := p.RequiredGas(in)
+	reqGas := p.RequiredGas(in, common.MainnetForkSchedule, 0)
 	contract := NewContract(AccountRef(common.HexToAddress("1337")),
 		nil, new(big.Int), reqGas)
 
@@ -371,7 +377,7 @@ func benchmarkPrecompiled(addr string, test precompiledTest, bench *testing.B) {
 		for i := 0; i < bench.N; i++ {
 			contract.Gas = reqGas
 			copy(data, in)
-			res, err = RunPrecompiledContract(nil, p, data, contract)
+			res, err = p.Run(nil, data, contract.CallerAddress)
 		}
 		bench.StopTimer()
 		//Check if it is correct
@@ -481,3 +487,170 @@ func BenchmarkPrecompiledBn256Pairing(bench *testing.B) {
 		benchmarkPrecompiled("08", test, bench)
 	}
 }
+
+func TestPrecompiledStakeHolders(t *testing.T) {
+	assert := assert.New(t)
+
+	storeView := state.NewStoreView(0, common.Hash{}, backend.NewMemDatabase())
+	guardianAddr := common.HexToAddress("0x2e833968e5bb786ae419c4d13189fb081cc43bab")
+	eenAddr := common.HexToAddress("0x3e833968e5bb786ae419c4d13189fb081cc43bab")
+	source1 := common.HexToAddress("0x4e833968e5bb786ae419c4d13189fb081cc43bab")
+	source2 := common.HexToAddress("0x5e833968e5bb786ae419c4d13189fb081cc43bab")
+	guardianKey, _ := bls.RandKey()
+	eenKey, _ := bls.RandKey()
+
+	gcp := core.NewGuardianCandidatePool()
+	gcp.Add(&core.Guardian{
+		StakeHolder: core.NewStakeHolder(guardianAddr, []*core.Stake{
+			core.NewStake(source1, big.NewInt(1000)),
+			{Source: source2, Amount: big.NewInt(500), Withdrawn: true},
+		}),
+		Pubkey: guardianKey.PublicKey(),
+	})
+	storeView.UpdateGuardianCandidatePool(gcp)
+	state.NewEliteEdgeNodePool(storeView, false).Upsert(core.NewEliteEdgeNode(
+		core.NewStakeHolder(eenAddr, []*core.Stake{core.NewStake(source2, big.NewInt(2000))}), eenKey.PublicKey()))
+
+	evm := NewEVM(Context{}, storeView, nil, Config{})
+	word := func(addr common.Address) []byte { return common.LeftPadBytes(addr.Bytes(), 32) }
+	stake := func(amount int64) []byte { return common.LeftPadBytes(big.NewInt(amount).Bytes(), 32) }
+	info := func(amount int64, pubkey *bls.PublicKey) []byte {
+		return append(append(append(true32Byte, stake(amount)...), pubkey.ToBytes()...), make([]byte, 16)...)
+	}
+
+	ret, err := (&guardianInfo{}).Run(evm, word(guardianAddr), common.Address{})
+	assert.Nil(err)
+	assert.Equal(info(1000, guardianKey.PublicKey()), ret)
+	ret, err = (&guardianInfo{}).Run(evm, word(eenAddr), common.Address{})
+	assert.Nil(err)
+	assert.Equal(make([]byte, stakeHolderInfoLength), ret)
+	ret, err = (&eliteEdgeNodeInfo{}).Run(evm, word(eenAddr), common.Address{})
+	assert.Nil(err)
+	assert.Equal(info(2000, eenKey.PublicKey()), ret)
+
+	// The withdrawn stakes are excluded
+	ret, err = (&guardianStake{}).Run(evm, append(word(guardianAddr), word(source1)...), common.Address{})
+	assert.Nil(err)
+	assert.Equal(stake(1000), ret)
+	ret, err = (&guardianStake{}).Run(evm, append(word(guardianAddr), word(source2)...), common.Address{})
+	assert.Nil(err)
+	assert.Equal(stake(0), ret)
+	ret, err = (&eliteEdgeNodeStake{}).Run(evm, append(word(eenAddr), word(source2)...), common.Address{})
+	assert.Nil(err)
+	assert.Equal(stake(2000), ret)
+}
+
+func TestPrecompiledGuardianPoolGas(t *testing.T) {
+	assert := assert.New(t)
+
+	storeView := state.NewStoreView(0, common.Hash{}, backend.NewMemDatabase())
+	evm := NewEVM(Context{}, storeView, nil, Config{})
+	holder := common.HexToAddress("0x2e833968e5bb786ae419c4d13189fb081cc43bab")
+	input := common.LeftPadBytes(holder.Bytes(), 32)
+	assert.Equal(uint64(0), (&guardianInfo{}).RequiredStateGas(evm, input))
+
+	// The gas grows with the size of the pool loaded as a whole
+	numGuardians := 1000
+	guardianKey, _ := bls.RandKey()
+	gcp := core.NewGuardianCandidatePool()
+	for i := 0; i < numGuardians; i++ {
+		gcp.Add(&core.Guardian{
+			StakeHolder: core.NewStakeHolder(common.BigToAddress(big.NewInt(int64(i+1))), []*core.Stake{
+				core.NewStake(holder, big.NewInt(1000)),
+			}),
+			Pubkey: guardianKey.PublicKey(),
+		})
+	}
+	storeView.UpdateGuardianCandidatePool(gcp)
+
+	stateGas := (&guardianInfo{}).RequiredStateGas(evm, input)
+	assert.Equal(stateGas, (&guardianStake{}).RequiredStateGas(evm, input))
+	assert.Equal(toWordSize(uint64(storeView.GetGuardianCandidatePoolSize()))*params.GuardianPoolWordGas, stateGas)
+	assert.True(stateGas >= uint64(numGuardians)*3*params.GuardianPoolWordGas)
+
+	// Both the base and the state gas are charged
+	for _, p := range []PrecompiledContract{&guardianInfo{}, &guardianStake{}} {
+		gas := params.ThetaStakeHolderGas + stateGas
+		contract := NewContract(AccountRef(holder), AccountRef(holder), new(big.Int), gas-1)
+		_, err := RunPrecompiledContract(evm, p, input, contract)
+		assert.Equal(ErrOutOfGas, err)
+
+		contract = NewContract(AccountRef(holder), AccountRef(holder), new(big.Int), gas)
+		_, err = RunPrecompiledContract(evm, p, append(input, input...), contract)
+		assert.Nil(err)
+		assert.Equal(uint64(0), contract.Gas)
+	}
+}
+
+func TestPrecompiledBLSVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	message := []byte("attestation")
+	keys := make([]*bls.SecretKey, 3)
+	sigs := make([]*bls.Signature, 3)
+	for i := range keys {
+		keys[i], _ = bls.RandKey()
+		sigs[i] = keys[i].Sign(message)
+	}
+
+	// Single signature
+	in := append(append(keys[0].PublicKey().ToBytes(), sigs[0].ToBytes()...), message...)
+	ret, err := (&blsVerify{}).Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(true32Byte, ret)
+	in = append(append(keys[1].PublicKey().ToBytes(), sigs[0].ToBytes()...), message...)
+	ret, err = (&blsVerify{}).Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(false32Byte, ret)
+	_, err = (&blsVerify{}).Run(nil, keys[0].PublicKey().ToBytes(), common.Address{})
+	assert.Equal(errBadBLSInput, err)
+
+	// Aggregate signature
+	in = common.LeftPadBytes([]byte{3}, 32)
+	for _, key := range keys {
+		in = append(in, key.PublicKey().ToBytes()...)
+	}
+	in = append(append(in, bls.AggregateSignatures(sigs).ToBytes()...), message...)
+	p := &blsAggregateVerify{}
+	assert.Equal(params.BLSVerifyGas+3*params.BLSAggregatePerKeyGas, p.RequiredGas(in, common.MainnetForkSchedule, 0))
+	ret, err = p.Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(true32Byte, ret)
+	in = append(in[:len(in)-len(message)], []byte("forged")...)
+	ret, err = p.Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(false32Byte, ret)
+	in[31] = 4 // more keys than the input holds
+	_, err = p.Run(nil, in, common.Address{})
+	assert.Equal(errBadBLSInput, err)
+
+	// The public key at infinity is rejected
+	infinityPubkey := append([]byte{0xc0}, make([]byte, blsPublicKeyLength-1)...)
+	infinitySig := append([]byte{0xc0}, make([]byte, blsSignatureLength-1)...)
+	in = append(append(infinityPubkey, infinitySig...), message...)
+	ret, err = (&blsVerify{}).Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(false32Byte, ret)
+
+	// Proof of possession
+	in = append(keys[0].PublicKey().ToBytes(), keys[0].PopProve().ToBytes()...)
+	ret, err = (&blsPopVerify{}).Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(true32Byte, ret)
+	in = append(keys[1].PublicKey().ToBytes(), keys[0].PopProve().ToBytes()...)
+	ret, err = (&blsPopVerify{}).Run(nil, in, common.Address{})
+	assert.Nil(err)
+	assert.Equal(false32Byte, ret)
+}
+
+func TestPrecompiledContractsActivation(t *testing.T) {
+	assert := assert.New(t)
+
+	forks := common.DevForkSchedule.Copy()
+	forks.StakingAndBLSPrecompiles = 100
+	for addr := byte(204); addr <= 210; addr++ {
+		assert.Nil(getPrecompiledContracts(forks, 99)[common.BytesToAddress([]byte{addr})])
+		assert.NotNil(getPrecompiledContracts(forks, 100)[common.BytesToAddress([]byte{addr})])
+	}
+	assert.NotNil(getPrecompiledContracts(forks, 99)[common.BytesToAddress([]byte{203})])
+}
@@ -29,4 +29,7 @@ var (
 	ErrNoCompatibleInterpreter  = errors.New("no compatible interpreter")
 	ErrInvalidGasLimit          = errors.New("invalid gas limit")
 	ErrInsufficientThetaBlance  = errors.New("insufficient Theta balance for transfer")
+	ErrSstoreSentryGas          = errors.New("not enough gas for reentrancy sentry")
+	ErrInvalidCode              = errors.New("invalid code: must not begin with 0xef")
+	ErrMaxInitCodeSizeExceeded  = errors.New("max initcode size exceeded")
 )
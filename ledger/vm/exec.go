@@ -14,6 +14,7 @@ import (
 // Execute executes the given smart contract
 func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *state.StoreView) (evmRet common.Bytes,
 	contractAddr common.Address, gasUsed uint64, evmErr error) {
+	blockHeight := storeView.Height() + 1
 	context := Context{
 		CanTransfer: CanTransfer,
 		Transfer:    Transfer,
@@ -23,10 +24,14 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 		BlockNumber: new(big.Int).SetUint64(parentBlock.Height + 1),
 		Time:        parentBlock.Timestamp,
 		Difficulty:  new(big.Int).SetInt64(0),
+		// Theta has no fee market, BASEFEE reports the minimum gas price instead
+		BaseFee: state.NewGovernance(storeView).GetParamOrDefault(core.ParamMinGasPrice,
+			types.GetMinimumGasPrice(parentBlock.ChainID, blockHeight)),
 	}
 	chainIDBigInt := types.MapChainID(parentBlock.ChainID, context.BlockNumber.Uint64())
 	chainConfig := &params.ChainConfig{
 		ChainID: chainIDBigInt,
+		Forks:   common.GetForkSchedule(parentBlock.ChainID),
 	}
 	config := Config{}
 	evm := NewEVM(context, storeView, chainConfig, config)
@@ -49,13 +54,17 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 	// if gasLimit > maxGasLimit {
 	// 	return common.Bytes{}, common.Address{}, 0, ErrInvalidGasLimit
 	// }
-	blockHeight := storeView.Height() + 1
-	maxGasLimit := types.GetMaxGasLimit(blockHeight)
+	maxGasLimit := state.NewGovernance(storeView).GetParamOrDefault(core.ParamMaxTxGasLimit,
+		types.GetMaxGasLimit(parentBlock.ChainID, blockHeight))
 	if new(big.Int).SetUint64(gasLimit).Cmp(maxGasLimit) > 0 {
 		return common.Bytes{}, common.Address{}, 0, ErrInvalidGasLimit
 	}
 
-	intrinsicGas, err := calculateIntrinsicGas(tx.Data, createContract)
+	rules := evm.ChainRules()
+	if rules.IsShanghai && createContract && len(tx.Data) > params.MaxInitCodeSize {
+		return common.Bytes{}, common.Address{}, 0, ErrMaxInitCodeSizeExceeded
+	}
+	intrinsicGas, err := calculateIntrinsicGas(tx.Data, createContract, rules)
 	if err != nil {
 		return common.Bytes{}, common.Address{}, 0, err
 	}
@@ -63,6 +72,10 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 		return common.Bytes{}, common.Address{}, 0, ErrOutOfGas
 	}
 
+	if rules.IsBerlin {
+		prepareAccessList(evm, fromAddr, contractAddr, createContract)
+	}
+
 	var leftOverGas uint64
 	remainingGas := gasLimit - intrinsicGas
 	if createContract {
@@ -82,8 +95,24 @@ func Execute(parentBlock *core.Block, tx *types.SmartContractTx, storeView *stat
 	return evmRet, contractAddr, gasUsed, evmErr
 }
 
+// prepareAccessList warms up the addresses accessed by any transaction as
+// required by EIP-2929: the sender, the recipient and the precompiled contracts,
+// and the coinbase after EIP-3651.
+func prepareAccessList(evm *EVM, fromAddr, toAddr common.Address, createContract bool) {
+	evm.accessList.addAddress(fromAddr)
+	if !createContract {
+		evm.accessList.addAddress(toAddr)
+	}
+	for addr := range getPrecompiledContracts(evm.Forks(), evm.StateDB.GetBlockHeight()) {
+		evm.accessList.addAddress(addr)
+	}
+	if evm.chainRules.IsShanghai {
+		evm.accessList.addAddress(evm.Coinbase)
+	}
+}
+
 // calculateIntrinsicGas computes the 'intrinsic gas' for a message with the given data.
-func calculateIntrinsicGas(data []byte, createContract bool) (uint64, error) {
+func calculateIntrinsicGas(data []byte, createContract bool, rules params.Rules) (uint64, error) {
 	// Set the starting gas for the raw transaction
 	var gas uint64
 	if createContract {
@@ -101,16 +130,29 @@ func calculateIntrinsicGas(data []byte, createContract bool) (uint64, error) {
 			}
 		}
 		// Make sure we don't exceed uint64 for all data combinations
-		if (math.MaxUint64-gas)/params.TxDataNonZeroGas < nz {
+		nonZeroGas := params.TxDataNonZeroGas
+		if rules.IsIstanbul {
+			nonZeroGas = params.TxDataNonZeroGasEIP2028
+		}
+		if (math.MaxUint64-gas)/nonZeroGas < nz {
 			return 0, ErrOutOfGas
 		}
-		gas += nz * params.TxDataNonZeroGas
+		gas += nz * nonZeroGas
 
 		z := uint64(len(data)) - nz
 		if (math.MaxUint64-gas)/params.TxDataZeroGas < z {
 			return 0, ErrOutOfGas
 		}
 		gas += z * params.TxDataZeroGas
+
+		// Charge the words of the initcode (EIP-3860)
+		if createContract && rules.IsShanghai {
+			words := toWordSize(uint64(len(data)))
+			if (math.MaxUint64-gas)/params.InitCodeWordGas < words {
+				return 0, ErrOutOfGas
+			}
+			gas += words * params.InitCodeWordGas
+		}
 	}
 	return gas, nil
 }
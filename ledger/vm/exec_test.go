@@ -13,8 +13,11 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto"
 	"github.com/thetatoken/theta/ledger/state"
 	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/ledger/vm/params"
 	"github.com/thetatoken/theta/store/database/backend"
 )
 
@@ -63,7 +66,7 @@ func TestVMExecute(t *testing.T) {
 		GasPrice: big.NewInt(5000),
 		Data:     deployCode,
 	}
-	vmRet, contractAddr, gasUsed, vmErr := Execute(deploySCTx, storeView)
+	vmRet, contractAddr, gasUsed, vmErr := execute(deploySCTx, storeView)
 	assert.Nil(vmErr)
 	retrievedCode := storeView.GetCode(contractAddr)
 	assert.True(bytes.Equal(code, retrievedCode))
@@ -89,7 +92,7 @@ func TestVMExecute(t *testing.T) {
 		GasPrice: big.NewInt(5000),
 		Data:     nil,
 	}
-	vmRet, _, gasUsed, vmErr = Execute(callSCTX, storeView)
+	vmRet, _, gasUsed, vmErr = execute(callSCTX, storeView)
 	assert.Nil(vmErr)
 	assert.Equal(common.Bytes{0x3}, vmRet)
 
@@ -147,7 +150,7 @@ func TestVMExecutionInteractWithContract(t *testing.T) {
 		GasPrice: big.NewInt(50),
 		Data:     deploymentCode,
 	}
-	vmRet, contractAddr, gasUsed, vmErr := Execute(deploySCTx, storeView)
+	vmRet, contractAddr, gasUsed, vmErr := execute(deploySCTx, storeView)
 	assert.Nil(vmErr)
 	assert.True(bytes.Equal(code, vmRet))
 
@@ -171,7 +174,7 @@ func TestVMExecutionInteractWithContract(t *testing.T) {
 	setValueCallTx := callSCTXTmpl
 	setValueCallData, _ := hex.DecodeString("ed8b07060000000000000000000000000000000000000000000000000000000000004797") // "ed8b0706" is signature of the SetValue() interface, and 0x4797 is the hex of the value 18327
 	setValueCallTx.Data = setValueCallData
-	_, _, gasUsed, vmErr = Execute(setValueCallTx, storeView)
+	_, _, gasUsed, vmErr = execute(setValueCallTx, storeView)
 	assert.Nil(vmErr)
 	log.Infof("Call   Contract -- SetValue: %v, gasUsed: %v", value, gasUsed)
 
@@ -179,7 +182,7 @@ func TestVMExecutionInteractWithContract(t *testing.T) {
 	calculateSquareCallTx := callSCTXTmpl
 	calculateSquareCallData, _ := hex.DecodeString("b5a0241a") // signature of the CalculateSquare() interface
 	calculateSquareCallTx.Data = calculateSquareCallData
-	vmRet, _, gasUsed, vmErr = Execute(setValueCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(setValueCallTx, storeView)
 	calculatedSquare, success := new(big.Int).SetString(hex.EncodeToString(vmRet), 16)
 	assert.True(success)
 	assert.Equal(expectedSquare, calculatedSquare)
@@ -218,7 +221,7 @@ func TestVMExecutionDeployComplexContract(t *testing.T) {
 		GasPrice: big.NewInt(50),
 		Data:     deploymentCode,
 	}
-	vmRet, contractAddr, gasUsed, vmErr := Execute(deploySCTx, storeView)
+	vmRet, contractAddr, gasUsed, vmErr := execute(deploySCTx, storeView)
 	assert.Nil(vmErr)
 	assert.True(bytes.Equal(code, vmRet))
 
@@ -240,7 +243,7 @@ func TestVMExecutionDeployComplexContract(t *testing.T) {
 	monthlyWithdrawLimitInWeiCallTx := callSCTXTmpl
 	monthlyWithdrawLimitInWeiCallData, _ := hex.DecodeString("03216695") // signature of the monthlyWithdrawLimitInWei() interface
 	monthlyWithdrawLimitInWeiCallTx.Data = monthlyWithdrawLimitInWeiCallData
-	vmRet, _, gasUsed, vmErr = Execute(monthlyWithdrawLimitInWeiCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(monthlyWithdrawLimitInWeiCallTx, storeView)
 	assert.Nil(vmErr)
 	monthlyWithdrawLimitInWei, success := new(big.Int).SetString(hex.EncodeToString(vmRet), 16)
 	assert.True(success)
@@ -252,7 +255,7 @@ func TestVMExecutionDeployComplexContract(t *testing.T) {
 	lockingPeriodInMonthsCallTx := callSCTXTmpl
 	lockingPeriodInMonthsCallData, _ := hex.DecodeString("32aeaddf") // signature of the lockingPeriodInMonths() interface
 	lockingPeriodInMonthsCallTx.Data = lockingPeriodInMonthsCallData
-	vmRet, _, gasUsed, vmErr = Execute(lockingPeriodInMonthsCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(lockingPeriodInMonthsCallTx, storeView)
 	assert.Nil(vmErr)
 	lockingPeriodInMonths, success := new(big.Int).SetString(hex.EncodeToString(vmRet), 16)
 	assert.True(success)
@@ -262,7 +265,7 @@ func TestVMExecutionDeployComplexContract(t *testing.T) {
 	tokenAddressCallTx := callSCTXTmpl
 	tokenAddressCallData, _ := hex.DecodeString("fc0c546a") // signature of the token() interface
 	tokenAddressCallTx.Data = tokenAddressCallData
-	vmRet, _, gasUsed, vmErr = Execute(tokenAddressCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(tokenAddressCallTx, storeView)
 	assert.Nil(vmErr)
 	expectedTokenAddrBytes, _ := hex.DecodeString("3883f5e181fccaF8410FA61e12b59BAd963fb645")
 	expectedTokenAddr := common.BytesToAddress(expectedTokenAddrBytes)
@@ -303,7 +306,7 @@ func TestVMExecutionDeployERC20TokenContract(t *testing.T) {
 		GasPrice: big.NewInt(50),
 		Data:     deploymentCode,
 	}
-	vmRet, contractAddr, gasUsed, vmErr := Execute(deploySCTx, storeView)
+	vmRet, contractAddr, gasUsed, vmErr := execute(deploySCTx, storeView)
 	assert.Nil(vmErr)
 	assert.True(bytes.Equal(code, vmRet))
 
@@ -325,7 +328,7 @@ func TestVMExecutionDeployERC20TokenContract(t *testing.T) {
 	nameCallTx := callSCTXTmpl
 	nameCallData, _ := hex.DecodeString("06fdde03") // signature of the name() interface
 	nameCallTx.Data = nameCallData
-	vmRet, _, gasUsed, vmErr = Execute(nameCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(nameCallTx, storeView)
 	assert.Nil(vmErr)
 	name := string(vmRet[64:75])
 	assert.Equal("Theta Token", name)
@@ -334,15 +337,240 @@ func TestVMExecutionDeployERC20TokenContract(t *testing.T) {
 	symbolCallTx := callSCTXTmpl
 	symbolCallData, _ := hex.DecodeString("95d89b41") // signature of the symbol() interface
 	symbolCallTx.Data = symbolCallData
-	vmRet, _, gasUsed, vmErr = Execute(symbolCallTx, storeView)
+	vmRet, _, gasUsed, vmErr = execute(symbolCallTx, storeView)
 	assert.Nil(vmErr)
 	symbol := string(vmRet[64:69])
 	assert.Equal("THETA", symbol)
 	log.Infof("Call   Contract -- symbol: %v", symbol)
 }
 
+func TestVMExecutionEVMUpgradeOpcodes(t *testing.T) {
+	assert := assert.New(t)
+
+	// ASM:
+	// push0
+	// push0
+	// return
+	push0Code, _ := hex.DecodeString("5f5ff3")
+
+	// ASM:
+	// basefee
+	// push 0x0
+	// mstore
+	// push 0x20
+	// push 0x0
+	// return
+	baseFeeCode, _ := hex.DecodeString("4860005260206000f3")
+
+	// ASM:
+	// push 0xef
+	// push 0x0
+	// mstore8
+	// push 0x1
+	// push 0x0
+	// return
+	deployEFCode, _ := hex.DecodeString("60ef60005360016000f3")
+
+	for _, blockHeight := range []uint64{
+		upgradeTestForks.EVMLondon - 1, upgradeTestForks.EVMLondon,
+		upgradeTestForks.EVMShanghai - 1, upgradeTestForks.EVMShanghai,
+	} {
+		storeView, privAccounts := prepareUpgradeTestState(blockHeight)
+		callerAddr := privAccounts[0].Address
+		isLondon := blockHeight >= upgradeTestForks.EVMLondon
+		isShanghai := blockHeight >= upgradeTestForks.EVMShanghai
+
+		// PUSH0 is a valid opcode from the Shanghai upgrade
+		push0Addr := setTestContractCode(storeView, push0Code)
+		_, _, _, vmErr := executeOnChain(upgradeTestChainID, newTestCallTx(callerAddr, push0Addr), storeView)
+		assert.Equal(isShanghai, vmErr == nil, "PUSH0 at height %v", blockHeight)
+
+		// BASEFEE is a valid opcode from the London upgrade, which returns the minimum gas price
+		baseFeeAddr := setTestContractCode(storeView, baseFeeCode)
+		vmRet, _, _, vmErr := executeOnChain(upgradeTestChainID, newTestCallTx(callerAddr, baseFeeAddr), storeView)
+		assert.Equal(isLondon, vmErr == nil, "BASEFEE at height %v", blockHeight)
+		if isLondon {
+			minGasPrice := types.GetMinimumGasPrice(upgradeTestChainID, blockHeight)
+			assert.Equal(0, minGasPrice.Cmp(new(big.Int).SetBytes(vmRet)))
+		}
+
+		// The code starting with 0xEF is rejected from the London upgrade
+		deployTx := newTestCallTx(callerAddr, common.Address{})
+		deployTx.Data = deployEFCode
+		_, _, _, vmErr = executeOnChain(upgradeTestChainID, deployTx, storeView)
+		if isLondon {
+			assert.Equal(ErrInvalidCode, vmErr)
+		} else {
+			assert.Nil(vmErr)
+		}
+	}
+}
+
+func TestVMExecutionEVMUpgradeGas(t *testing.T) {
+	assert := assert.New(t)
+
+	// ASM:
+	// push 0x0
+	// sload
+	// pop
+	// push 0x0
+	// sload
+	// pop
+	// stop
+	sloadCode, _ := hex.DecodeString("600054506000545000")
+
+	for _, tc := range []struct {
+		blockHeight uint64
+		gasUsed     uint64
+	}{
+		{upgradeTestForks.EVMIstanbul - 1, params.TxGas + 10 + 2*200},  // flat SLOAD cost
+		{upgradeTestForks.EVMIstanbul, params.TxGas + 10 + 2*800},      // EIP-1884 repricing
+		{upgradeTestForks.EVMBerlin - 1, params.TxGas + 10 + 2*800},    // EIP-1884 repricing
+		{upgradeTestForks.EVMBerlin, params.TxGas + 10 + 2100 + 100},   // EIP-2929 cold and warm slot
+		{upgradeTestForks.EVMShanghai, params.TxGas + 10 + 2100 + 100}, // EIP-2929 cold and warm slot
+	} {
+		storeView, privAccounts := prepareUpgradeTestState(tc.blockHeight)
+		contractAddr := setTestContractCode(storeView, sloadCode)
+		_, _, gasUsed, vmErr := executeOnChain(upgradeTestChainID, newTestCallTx(privAccounts[0].Address, contractAddr), storeView)
+		assert.Nil(vmErr)
+		assert.Equal(tc.gasUsed, gasUsed, "SLOAD at height %v", tc.blockHeight)
+	}
+
+	// The initcode above the maximum size is rejected from the Shanghai upgrade
+	for _, blockHeight := range []uint64{upgradeTestForks.EVMShanghai - 1, upgradeTestForks.EVMShanghai} {
+		storeView, privAccounts := prepareUpgradeTestState(blockHeight)
+		deployTx := newTestCallTx(privAccounts[0].Address, common.Address{})
+		deployTx.GasLimit = 10000000
+		deployTx.Data = make([]byte, params.MaxInitCodeSize+1)
+		_, _, _, vmErr := executeOnChain(upgradeTestChainID, deployTx, storeView)
+		if blockHeight >= upgradeTestForks.EVMShanghai {
+			assert.Equal(ErrMaxInitCodeSizeExceeded, vmErr)
+		} else {
+			assert.Nil(vmErr)
+		}
+	}
+}
+
+func TestVMExecutionOnlyShanghaiScheduled(t *testing.T) {
+	assert := assert.New(t)
+
+	// The Shanghai upgrade implies the earlier ones
+	rules := (&params.ChainConfig{Forks: shanghaiOnlyTestForks}).Rules(new(big.Int).SetUint64(shanghaiOnlyTestForks.EVMShanghai))
+	assert.True(rules.IsIstanbul && rules.IsBerlin && rules.IsLondon && rules.IsShanghai)
+	rules = (&params.ChainConfig{Forks: shanghaiOnlyTestForks}).Rules(new(big.Int).SetUint64(shanghaiOnlyTestForks.EVMShanghai - 1))
+	assert.False(rules.IsIstanbul || rules.IsBerlin || rules.IsLondon || rules.IsShanghai)
+
+	// ASM:
+	// address
+	// balance
+	// pop
+	// caller
+	// balance
+	// pop
+	// push 0x0
+	// sload
+	// pop
+	// stop
+	code, _ := hex.DecodeString("303150333150600054500000")
+
+	// The sender and the recipient are warm, and the slot is cold
+	storeView, privAccounts := prepareUpgradeTestState(shanghaiOnlyTestForks.EVMShanghai)
+	contractAddr := setTestContractCode(storeView, code)
+	_, _, gasUsed, vmErr := executeOnChain(shanghaiOnlyTestChainID, newTestCallTx(privAccounts[0].Address, contractAddr), storeView)
+	assert.Nil(vmErr)
+	assert.Equal(params.TxGas+2+100+2+2+100+2+3+2100+2, gasUsed)
+}
+
+func TestCalculateIntrinsicGas(t *testing.T) {
+	assert := assert.New(t)
+
+	data := []byte{0x1, 0x0, 0x2, 0x0, 0x3}
+	for _, tc := range []struct {
+		rules          params.Rules
+		createContract bool
+		gas            uint64
+	}{
+		{params.Rules{}, false, params.TxGas + 3*68 + 2*4},
+		{params.Rules{}, true, params.TxGasContractCreation + 3*68 + 2*4},
+		{params.Rules{IsIstanbul: true}, false, params.TxGas + 3*16 + 2*4},
+		{params.Rules{IsIstanbul: true, IsShanghai: true}, false, params.TxGas + 3*16 + 2*4},
+		{params.Rules{IsIstanbul: true, IsShanghai: true}, true, params.TxGasContractCreation + 3*16 + 2*4 + 2},
+	} {
+		gas, err := calculateIntrinsicGas(data, tc.createContract, tc.rules)
+		assert.Nil(err)
+		assert.Equal(tc.gas, gas)
+	}
+}
+
 // ----------- Utilities ----------- //
 
+const testChainID = "privatenet"
+
+const upgradeTestChainID = "evm_upgrade_testnet"
+
+// upgradeTestForks activates the EVM upgrades at successive heights
+var upgradeTestForks = func() *common.ForkSchedule {
+	forks := common.DevForkSchedule.Copy()
+	forks.EVMIstanbul = 10
+	forks.EVMBerlin = 20
+	forks.EVMLondon = 30
+	forks.EVMShanghai = 40
+	common.RegisterForkSchedule(upgradeTestChainID, forks)
+	return forks
+}()
+
+const shanghaiOnlyTestChainID = "evm_shanghai_only_testnet"
+
+// shanghaiOnlyTestForks activates the Shanghai EVM upgrade without scheduling the earlier ones
+var shanghaiOnlyTestForks = func() *common.ForkSchedule {
+	forks := common.DevForkSchedule.Copy()
+	forks.EVMIstanbul = common.HeightNotScheduled
+	forks.EVMBerlin = common.HeightNotScheduled
+	forks.EVMLondon = common.HeightNotScheduled
+	forks.EVMShanghai = 40
+	common.RegisterForkSchedule(shanghaiOnlyTestChainID, forks)
+	return forks
+}()
+
+func execute(tx *types.SmartContractTx, storeView *state.StoreView) (evmRet common.Bytes,
+	contractAddr common.Address, gasUsed uint64, evmErr error) {
+	return executeOnChain(testChainID, tx, storeView)
+}
+
+func executeOnChain(chainID string, tx *types.SmartContractTx, storeView *state.StoreView) (evmRet common.Bytes,
+	contractAddr common.Address, gasUsed uint64, evmErr error) {
+	parentBlock := &core.Block{
+		BlockHeader: &core.BlockHeader{
+			ChainID:   chainID,
+			Height:    storeView.Height(),
+			Timestamp: big.NewInt(0),
+		},
+	}
+	return Execute(parentBlock, tx, storeView)
+}
+
+// prepareUpgradeTestState returns a store view executing the block of the given height
+func prepareUpgradeTestState(blockHeight uint64) (*state.StoreView, []types.PrivAccount) {
+	storeView := state.NewStoreView(blockHeight-2, common.Hash{}, backend.NewMemDatabase())
+	privAccounts := prepareInitState(storeView, 1)
+	return storeView, privAccounts
+}
+
+func setTestContractCode(storeView *state.StoreView, code []byte) common.Address {
+	contractAddr := common.BytesToAddress(crypto.Keccak256(code))
+	storeView.SetCode(contractAddr, code)
+	return contractAddr
+}
+
+func newTestCallTx(callerAddr, contractAddr common.Address) *types.SmartContractTx {
+	return &types.SmartContractTx{
+		From:     types.TxInput{Address: callerAddr},
+		To:       types.TxOutput{Address: contractAddr},
+		GasLimit: 100000,
+		GasPrice: big.NewInt(5000),
+	}
+}
+
 func prepareInitState(storeView *state.StoreView, numAccounts int) (privAccounts []types.PrivAccount) {
 	for i := 0; i < numAccounts; i++ {
 		secret := "acc_secret_" + strconv.FormatInt(int64(i), 16)
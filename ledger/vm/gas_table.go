@@ -516,3 +516,214 @@ func gasSwap(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem
 func gasDup(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
 	return GasFastestStep, nil
 }
+
+func gasCreateEIP3860(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := gasCreate(gt, evm, contract, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	return addInitCodeGas(gas, stack)
+}
+
+func gasCreate2EIP3860(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	gas, err := gasCreate2(gt, evm, contract, stack, mem, memorySize)
+	if err != nil {
+		return 0, err
+	}
+	return addInitCodeGas(gas, stack)
+}
+
+// addInitCodeGas adds the EIP-3860 cost of the initcode of CREATE and CREATE2
+// to gas, and rejects the initcode above the maximum size.
+func addInitCodeGas(gas uint64, stack *Stack) (uint64, error) {
+	size, overflow := bigUint64(stack.Back(2))
+	if overflow || size > params.MaxInitCodeSize {
+		return 0, ErrMaxInitCodeSizeExceeded
+	}
+	if gas, overflow = math.SafeAdd(gas, toWordSize(size)*params.InitCodeWordGas); overflow {
+		return 0, errGasUintOverflow
+	}
+	return gas, nil
+}
+
+// gasSStoreEIP2200 implements the SSTORE gas metering of EIP-2200, the net
+// metering of EIP-1283 (see gasSStore) against the value of the slot at the
+// start of the transaction, with SLOAD_GAS charged for the no-op and dirty
+// writes and a reentrancy sentry requiring more than 2300 gas left.
+func gasSStoreEIP2200(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	// If we fail the minimum gas availability invariant, fail (0)
+	if contract.Gas <= params.SstoreSentryGasEIP2200 {
+		return 0, ErrSstoreSentryGas
+	}
+	// Gas sentry honoured, do the actual gas calculation based on the stored value
+	var (
+		y, x     = stack.Back(1), stack.Back(0)
+		key      = common.BigToHash(x)
+		original = evm.originalState(contract.Address(), key)
+		current  = evm.StateDB.GetState(contract.Address(), key)
+		value    = common.BigToHash(y)
+	)
+	if current == value { // noop (1)
+		return params.SloadGasEIP2200, nil
+	}
+	if original == current {
+		if original == (common.Hash{}) { // create slot (2.1.1)
+			return params.SstoreSetGasEIP2200, nil
+		}
+		if value == (common.Hash{}) { // delete slot (2.1.2b)
+			evm.StateDB.AddRefund(params.SstoreClearsScheduleRefundEIP2200)
+		}
+		return params.SstoreResetGasEIP2200, nil // write existing slot (2.1.2)
+	}
+	if original != (common.Hash{}) {
+		if current == (common.Hash{}) { // recreate slot (2.2.1.1)
+			evm.StateDB.SubRefund(params.SstoreClearsScheduleRefundEIP2200)
+		} else if value == (common.Hash{}) { // delete slot (2.2.1.2)
+			evm.StateDB.AddRefund(params.SstoreClearsScheduleRefundEIP2200)
+		}
+	}
+	if original == value {
+		if original == (common.Hash{}) { // reset to original inexistent slot (2.2.2.1)
+			evm.StateDB.AddRefund(params.SstoreSetGasEIP2200 - params.SloadGasEIP2200)
+		} else { // reset to original existing slot (2.2.2.2)
+			evm.StateDB.AddRefund(params.SstoreResetGasEIP2200 - params.SloadGasEIP2200)
+		}
+	}
+	return params.SloadGasEIP2200, nil // dirty update (2.2)
+}
+
+// makeGasSStoreFuncEIP2929 returns the SSTORE gas metering of EIP-2200 with
+// the cold slot surcharge of EIP-2929, clearingRefund being the refund of the
+// clearing of a slot (lowered by EIP-3529).
+func makeGasSStoreFuncEIP2929(clearingRefund uint64) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		// If we fail the minimum gas availability invariant, fail (0)
+		if contract.Gas <= params.SstoreSentryGasEIP2200 {
+			return 0, ErrSstoreSentryGas
+		}
+		// Gas sentry honoured, do the actual gas calculation based on the stored value
+		var (
+			y, x = stack.Back(1), stack.Back(0)
+			key  = common.BigToHash(x)
+			cost = uint64(0)
+		)
+		// Check slot presence in the access list
+		if evm.accessList.addSlot(contract.Address(), key) {
+			cost = params.ColdSloadCostEIP2929
+		}
+		var (
+			original = evm.originalState(contract.Address(), key)
+			current  = evm.StateDB.GetState(contract.Address(), key)
+			value    = common.BigToHash(y)
+		)
+		if current == value { // noop (1)
+			return cost + params.WarmStorageReadCostEIP2929, nil // SLOAD_GAS
+		}
+		if original == current {
+			if original == (common.Hash{}) { // create slot (2.1.1)
+				return cost + params.SstoreSetGasEIP2200, nil
+			}
+			if value == (common.Hash{}) { // delete slot (2.1.2b)
+				evm.StateDB.AddRefund(clearingRefund)
+			}
+			return cost + (params.SstoreResetGasEIP2200 - params.ColdSloadCostEIP2929), nil // write existing slot (2.1.2)
+		}
+		if original != (common.Hash{}) {
+			if current == (common.Hash{}) { // recreate slot (2.2.1.1)
+				evm.StateDB.SubRefund(clearingRefund)
+			} else if value == (common.Hash{}) { // delete slot (2.2.1.2)
+				evm.StateDB.AddRefund(clearingRefund)
+			}
+		}
+		if original == value {
+			if original == (common.Hash{}) { // reset to original inexistent slot (2.2.2.1)
+				evm.StateDB.AddRefund(params.SstoreSetGasEIP2200 - params.WarmStorageReadCostEIP2929)
+			} else { // reset to original existing slot (2.2.2.2)
+				evm.StateDB.AddRefund((params.SstoreResetGasEIP2200 - params.ColdSloadCostEIP2929) - params.WarmStorageReadCostEIP2929)
+			}
+		}
+		return cost + params.WarmStorageReadCostEIP2929, nil // dirty update (2.2)
+	}
+}
+
+// gasSLoadEIP2929 calculates dynamic gas for SLOAD according to EIP-2929:
+// a cold slot is charged the cold cost and added to the access list, a warm
+// slot is charged the warm read cost.
+func gasSLoadEIP2929(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	key := common.BigToHash(stack.peek())
+	if evm.accessList.addSlot(contract.Address(), key) {
+		return params.ColdSloadCostEIP2929, nil
+	}
+	return params.WarmStorageReadCostEIP2929, nil
+}
+
+// gasAccountCheckEIP2929 calculates the gas of the opcodes that check an
+// account (BALANCE, EXTCODESIZE and EXTCODEHASH) according to EIP-2929.
+func gasAccountCheckEIP2929(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	addr := common.BigToAddress(stack.peek())
+	if evm.accessList.addAddress(addr) {
+		return params.ColdAccountAccessCostEIP2929, nil
+	}
+	return params.WarmStorageReadCostEIP2929, nil
+}
+
+// gasExtCodeCopyEIP2929 implements the EXTCODECOPY gas calculation of
+// EIP-2929, which charges the account access instead of the flat cost.
+func gasExtCodeCopyEIP2929(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+	addr := common.BigToAddress(stack.peek())
+	gt.ExtcodeCopy = params.WarmStorageReadCostEIP2929
+	if evm.accessList.addAddress(addr) {
+		gt.ExtcodeCopy = params.ColdAccountAccessCostEIP2929
+	}
+	return gasExtCodeCopy(gt, evm, contract, stack, mem, memorySize)
+}
+
+// makeCallVariantGasCallEIP2929 wraps the gas function of a call opcode to
+// charge the account access of EIP-2929 instead of the flat call cost.
+func makeCallVariantGasCallEIP2929(oldCalculator gasFunc) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		addr := common.BigToAddress(stack.Back(1))
+		gt.Calls = params.WarmStorageReadCostEIP2929
+		if !evm.accessList.addAddress(addr) {
+			return oldCalculator(gt, evm, contract, stack, mem, memorySize)
+		}
+		// The cold surcharge is charged before the 63/64 rule is applied by
+		// the old calculator, so that the callee is given the right amount
+		coldCost := params.ColdAccountAccessCostEIP2929 - params.WarmStorageReadCostEIP2929
+		if !contract.UseGas(coldCost) {
+			return 0, ErrOutOfGas
+		}
+		gas, err := oldCalculator(gt, evm, contract, stack, mem, memorySize)
+		contract.Gas += coldCost
+		if err != nil {
+			return 0, err
+		}
+		var overflow bool
+		if gas, overflow = math.SafeAdd(gas, coldCost); overflow {
+			return 0, errGasUintOverflow
+		}
+		return gas, nil
+	}
+}
+
+// makeGasSuicideFuncEIP2929 returns the SELFDESTRUCT gas function with the
+// cold beneficiary surcharge of EIP-2929, the refund being removed by EIP-3529.
+func makeGasSuicideFuncEIP2929(refundsEnabled bool) gasFunc {
+	return func(gt params.GasTable, evm *EVM, contract *Contract, stack *Stack, mem *Memory, memorySize uint64) (uint64, error) {
+		gas := gt.Suicide
+		address := common.BigToAddress(stack.Back(0))
+		if evm.accessList.addAddress(address) {
+			gas += params.ColdAccountAccessCostEIP2929
+		}
+
+		// if empty and transfers value
+		if evm.StateDB.Empty(address) && evm.StateDB.GetBalance(contract.Address()).Sign() != 0 {
+			gas += gt.CreateBySuicide
+		}
+
+		if refundsEnabled && !evm.StateDB.HasSuicided(contract.Address()) {
+			evm.StateDB.AddRefund(params.SuicideRefundGas)
+		}
+		return gas, nil
+	}
+}
@@ -594,6 +594,22 @@ func opSelfBalance(pc *uint64, interpreter *EVMInterpreter, contract *Contract,
 	return nil, nil
 }
 
+// opBaseFee implements BASEFEE opcode
+func opBaseFee(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	baseFee := interpreter.intPool.getZero()
+	if interpreter.evm.BaseFee != nil {
+		baseFee.Set(interpreter.evm.BaseFee)
+	}
+	stack.push(baseFee)
+	return nil, nil
+}
+
+// opPush0 implements PUSH0 opcode
+func opPush0(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
+	stack.push(interpreter.intPool.getZero())
+	return nil, nil
+}
+
 func opPop(pc *uint64, interpreter *EVMInterpreter, contract *Contract, memory *Memory, stack *Stack) ([]byte, error) {
 	interpreter.intPool.put(stack.pop())
 	return nil, nil
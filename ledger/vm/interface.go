@@ -20,6 +20,7 @@ import (
 	"math/big"
 
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/core"
 	"github.com/thetatoken/theta/ledger/types"
 )
 
@@ -38,6 +39,10 @@ type StateDB interface {
 	GetThetaBalance(common.Address) *big.Int // GetThetaBalance returns the ThetaWei balance of the given address
 	GetThetaStake(common.Address) *big.Int   // GetThetaStake returns the total amount of ThetaWei the address staked to validators and/or guardians
 
+	GetGuardian(holder common.Address) *core.Guardian           // GetGuardian returns the guardian of the holder address, nil if not a guardian
+	GetGuardianCandidatePoolSize() int                          // GetGuardianCandidatePoolSize returns the size in bytes of the encoded guardian candidate pool
+	GetEliteEdgeNode(holder common.Address) *core.EliteEdgeNode // GetEliteEdgeNode returns the elite edge node of the holder address, nil if not an elite edge node
+
 	GetNonce(common.Address) uint64
 	SetNonce(common.Address, uint64)
 
@@ -85,14 +85,29 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	// the jump table was initialised. If it was not
 	// we'll set the default jump table.
 	if !cfg.JumpTable[STOP].valid {
-		cfg.JumpTable = constantinopleInstructionSet
+		switch {
+		case evm.chainRules.IsShanghai:
+			cfg.JumpTable = shanghaiInstructionSet
+		case evm.chainRules.IsLondon:
+			cfg.JumpTable = londonInstructionSet
+		case evm.chainRules.IsBerlin:
+			cfg.JumpTable = berlinInstructionSet
+		case evm.chainRules.IsIstanbul:
+			cfg.JumpTable = istanbulInstructionSet
+		default:
+			cfg.JumpTable = constantinopleInstructionSet
+		}
+	}
+
+	gasTable := params.ThetaGasTable
+	if evm.chainRules.IsIstanbul {
+		gasTable = params.ThetaIstanbulGasTable
 	}
 
 	return &EVMInterpreter{
-		evm: evm,
-		cfg: cfg,
-		// gasTable: evm.ChainConfig().GasTable(evm.BlockNumber),
-		gasTable: params.ThetaGasTable,
+		evm:      evm,
+		cfg:      cfg,
+		gasTable: gasTable,
 	}
 }
 
@@ -55,8 +55,69 @@ var (
 	homesteadInstructionSet      = newHomesteadInstructionSet()
 	byzantiumInstructionSet      = newByzantiumInstructionSet()
 	constantinopleInstructionSet = newConstantinopleInstructionSet()
+	istanbulInstructionSet       = newIstanbulInstructionSet()
+	berlinInstructionSet         = newBerlinInstructionSet()
+	londonInstructionSet         = newLondonInstructionSet()
+	shanghaiInstructionSet       = newShanghaiInstructionSet()
 )
 
+// newShanghaiInstructionSet returns the instructions up to the shanghai
+// phase, which adds PUSH0 (EIP-3855) and meters the initcode (EIP-3860).
+func newShanghaiInstructionSet() [256]operation {
+	instructionSet := newLondonInstructionSet()
+	instructionSet[PUSH0] = operation{
+		execute:       opPush0,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+	instructionSet[CREATE].gasCost = gasCreateEIP3860
+	instructionSet[CREATE2].gasCost = gasCreate2EIP3860
+	return instructionSet
+}
+
+// newLondonInstructionSet returns the instructions up to the london phase,
+// which adds BASEFEE (EIP-3198) and reduces the refunds (EIP-3529).
+func newLondonInstructionSet() [256]operation {
+	instructionSet := newBerlinInstructionSet()
+	instructionSet[BASEFEE] = operation{
+		execute:       opBaseFee,
+		gasCost:       constGasFunc(GasQuickStep),
+		validateStack: makeStackFunc(0, 1),
+		valid:         true,
+	}
+	instructionSet[SSTORE].gasCost = makeGasSStoreFuncEIP2929(params.SstoreClearsScheduleRefundEIP3529)
+	instructionSet[SELFDESTRUCT].gasCost = makeGasSuicideFuncEIP2929(false)
+	return instructionSet
+}
+
+// newBerlinInstructionSet returns the instructions up to the berlin phase,
+// which charges the state access by the access list (EIP-2929).
+func newBerlinInstructionSet() [256]operation {
+	instructionSet := newIstanbulInstructionSet()
+	instructionSet[SLOAD].gasCost = gasSLoadEIP2929
+	instructionSet[SSTORE].gasCost = makeGasSStoreFuncEIP2929(params.SstoreClearsScheduleRefundEIP2200)
+	instructionSet[BALANCE].gasCost = gasAccountCheckEIP2929
+	instructionSet[EXTCODESIZE].gasCost = gasAccountCheckEIP2929
+	instructionSet[EXTCODEHASH].gasCost = gasAccountCheckEIP2929
+	instructionSet[EXTCODECOPY].gasCost = gasExtCodeCopyEIP2929
+	instructionSet[CALL].gasCost = makeCallVariantGasCallEIP2929(gasCall)
+	instructionSet[CALLCODE].gasCost = makeCallVariantGasCallEIP2929(gasCallCode)
+	instructionSet[DELEGATECALL].gasCost = makeCallVariantGasCallEIP2929(gasDelegateCall)
+	instructionSet[STATICCALL].gasCost = makeCallVariantGasCallEIP2929(gasStaticCall)
+	instructionSet[SELFDESTRUCT].gasCost = makeGasSuicideFuncEIP2929(true)
+	return instructionSet
+}
+
+// newIstanbulInstructionSet returns the instructions up to the istanbul
+// phase, which meters SSTORE by the original value (EIP-2200). The repricing
+// of EIP-1884 is carried by the gas table.
+func newIstanbulInstructionSet() [256]operation {
+	instructionSet := newConstantinopleInstructionSet()
+	instructionSet[SSTORE].gasCost = gasSStoreEIP2200
+	return instructionSet
+}
+
 // NewConstantinopleInstructionSet returns the frontier, homestead
 // byzantium and contantinople instructions.
 func newConstantinopleInstructionSet() [256]operation {
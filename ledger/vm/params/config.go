@@ -111,16 +111,16 @@ var (
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
+	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil}
 
 	// AllCliqueProtocolChanges contains every protocol change (EIPs) introduced
 	// and accepted by the Ethereum core developers into the Clique consensus.
 	//
 	// This configuration is intentionally not using keyed fields to force anyone
 	// adding flags to the config to also have to set these fields.
-	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
+	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
 
-	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, new(EthashConfig), nil}
+	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false, big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), nil, nil, nil, new(EthashConfig), nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 
@@ -160,6 +160,9 @@ type ChainConfig struct {
 	ConstantinopleBlock *big.Int `json:"constantinopleBlock,omitempty"` // Constantinople switch block (nil = no fork, 0 = already activated)
 	EWASMBlock          *big.Int `json:"ewasmBlock,omitempty"`          // EWASM switch block (nil = no fork, 0 = already activated)
 
+	// Forks is the schedule of the Theta protocol upgrades of the chain
+	Forks *common.ForkSchedule `json:"forks,omitempty"`
+
 	// Various consensus engines
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
@@ -339,16 +342,38 @@ func (err *ConfigCompatError) Error() string {
 // Rules is a one time interface meaning that it shouldn't be used in between transition
 // phases.
 type Rules struct {
-	ChainID *big.Int
+	ChainID                                    *big.Int
+	IsIstanbul, IsBerlin, IsLondon, IsShanghai bool
 }
 
-// Rules ensures c's ChainID is not nil.
+// Rules ensures c's ChainID is not nil, and evaluates the EVM upgrades of the
+// fork schedule at the given block height. A nil config, or a config without a
+// fork schedule, follows the mainnet schedule. Each upgrade builds on the earlier
+// ones, so it implies them even if they are scheduled later or not at all.
 func (c *ChainConfig) Rules(num *big.Int) Rules {
-	chainID := c.ChainID
-	if chainID == nil {
-		chainID = new(big.Int)
+	chainID := new(big.Int)
+	forks := common.MainnetForkSchedule
+	if c != nil {
+		if c.ChainID != nil {
+			chainID = c.ChainID
+		}
+		if c.Forks != nil {
+			forks = c.Forks
+		}
+	}
+	var height uint64
+	if num != nil {
+		height = num.Uint64()
 	}
+	isShanghai := height >= forks.EVMShanghai
+	isLondon := isShanghai || height >= forks.EVMLondon
+	isBerlin := isLondon || height >= forks.EVMBerlin
+	isIstanbul := isBerlin || height >= forks.EVMIstanbul
 	return Rules{
-		ChainID: new(big.Int).Set(chainID),
+		ChainID:    new(big.Int).Set(chainID),
+		IsIstanbul: isIstanbul,
+		IsBerlin:   isBerlin,
+		IsLondon:   isLondon,
+		IsShanghai: isShanghai,
 	}
 }
@@ -50,4 +50,19 @@ var (
 
 		CreateBySuicide: 25000,
 	}
+
+	// ThetaIstanbulGasTable contains the gas prices after the EIP-1884
+	// repricing of the trie-size-dependent opcodes.
+	ThetaIstanbulGasTable = GasTable{
+		ExtcodeSize: 700,
+		ExtcodeCopy: 700,
+		ExtcodeHash: 700,
+		Balance:     700,
+		SLoad:       800,
+		Calls:       700,
+		Suicide:     5000,
+		ExpByte:     50,
+
+		CreateBySuicide: 25000,
+	}
 )
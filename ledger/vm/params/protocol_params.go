@@ -52,6 +52,18 @@ const (
 	NetSstoreResetRefund      uint64 = 4800  // Once per SSTORE operation for resetting to the original non-zero value
 	NetSstoreResetClearRefund uint64 = 19800 // Once per SSTORE operation for resetting to the original zero value
 
+	SstoreSentryGasEIP2200            uint64 = 2300  // Minimum gas required to be present for an SSTORE call, not consumed
+	SstoreSetGasEIP2200               uint64 = 20000 // Once per SSTORE operation from clean zero to non-zero
+	SstoreResetGasEIP2200             uint64 = 5000  // Once per SSTORE operation from clean non-zero to something else
+	SstoreClearsScheduleRefundEIP2200 uint64 = 15000 // Once per SSTORE operation for clearing an originally existing storage slot
+	SloadGasEIP2200                   uint64 = 800   // Cost of SLOAD after EIP 2200 (part of Istanbul)
+
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // COLD_ACCOUNT_ACCESS_COST
+	ColdSloadCostEIP2929         uint64 = 2100 // COLD_SLOAD_COST
+	WarmStorageReadCostEIP2929   uint64 = 100  // WARM_STORAGE_READ_COST
+
+	SstoreClearsScheduleRefundEIP3529 uint64 = 4800 // Once per SSTORE operation for clearing an originally existing storage slot after EIP 3529 (part of London)
+
 	JumpdestGas      uint64 = 1     // Refunded gas, once per SSTORE operation if the zeroness changes to zero.
 	EpochDuration    uint64 = 30000 // Duration between proof-of-work epochs.
 	CallGas          uint64 = 40    // Once per CALL operation & message call transaction.
@@ -69,7 +81,11 @@ const (
 	MemoryGas        uint64 = 3     // Times the address of the (highest referenced byte in memory + 1). NOTE: referencing happens on read, write and in instructions such as RETURN and CALL.
 	TxDataNonZeroGas uint64 = 68    // Per byte of data attached to a transaction that is not equal to zero. NOTE: Not payable on data of calls between transactions.
 
-	MaxCodeSize = 24576 // Maximum bytecode to permit for a contract
+	TxDataNonZeroGasEIP2028 uint64 = 16 // Per byte of non zero data attached to a transaction after EIP 2028 (part of Istanbul)
+	InitCodeWordGas         uint64 = 2  // Once per word of the init code when creating a contract (part of Shanghai)
+
+	MaxCodeSize     = 24576           // Maximum bytecode to permit for a contract
+	MaxInitCodeSize = 2 * MaxCodeSize // Maximum initcode to permit in a creation transaction and create instructions (part of Shanghai)
 
 	// Precompiled contract gas prices
 
@@ -97,6 +113,11 @@ const (
 	ThetaBalanceGas  uint64 = 4     // Retrieve the Theta balance for an address
 	ThetaStakeGas    uint64 = 200   // Retrieve the total amount of staked Theta for an address
 	ThetaTransferGas uint64 = 21000 // Transfer Theta balance
+
+	ThetaStakeHolderGas   uint64 = 800    // Retrieve a guardian or an elite edge node, or the stake of an address in it
+	GuardianPoolWordGas   uint64 = 50     // Per 32-byte word price for loading the guardian candidate pool to retrieve a guardian
+	BLSVerifyGas          uint64 = 100000 // Verify a BLS signature, or a proof of possession of a BLS public key
+	BLSAggregatePerKeyGas uint64 = 500    // Per-key price for aggregating the BLS public keys of an aggregate signature
 )
 
 var (
@@ -37,8 +37,8 @@ type (
 	GetHashFunc func(uint64) common.Hash
 )
 
-func SupportThetaTransferInEVM(blockHeight uint64) bool {
-	return blockHeight >= common.HeightSupportThetaTokenInSmartContract
+func SupportThetaTransferInEVM(forks *common.ForkSchedule, blockHeight uint64) bool {
+	return blockHeight >= forks.SupportThetaTokenInSmartContract
 }
 
 // CanTransfer checks whether there are enough funds in the address' account to make a transfer.
@@ -64,12 +64,14 @@ func TransferTheta(db StateDB, sender, recipient common.Address, amount *big.Int
 	db.AddThetaBalance(recipient, amount)
 }
 
-func getPrecompiledContracts(blockHeight uint64) map[common.Address]PrecompiledContract {
+func getPrecompiledContracts(forks *common.ForkSchedule, blockHeight uint64) map[common.Address]PrecompiledContract {
 	var precompiles map[common.Address]PrecompiledContract
-	if blockHeight < common.HeightSupportThetaTokenInSmartContract {
+	if blockHeight < forks.SupportThetaTokenInSmartContract {
 		precompiles = PrecompiledContractsByzantium
-	} else {
+	} else if blockHeight < forks.StakingAndBLSPrecompiles {
 		precompiles = PrecompiledContractsThetaSupport
+	} else {
+		precompiles = PrecompiledContractsStakingSupport
 	}
 	return precompiles
 }
@@ -78,7 +80,7 @@ func getPrecompiledContracts(blockHeight uint64) map[common.Address]PrecompiledC
 func run(evm *EVM, contract *Contract, input []byte, readOnly bool) ([]byte, error) {
 	if contract.CodeAddr != nil {
 		blockHeight := evm.StateDB.GetBlockHeight()
-		precompiles := getPrecompiledContracts(blockHeight)
+		precompiles := getPrecompiledContracts(evm.Forks(), blockHeight)
 		if p := precompiles[*contract.CodeAddr]; p != nil {
 			return RunPrecompiledContract(evm, p, input, contract)
 		}
@@ -120,6 +122,7 @@ type Context struct {
 	BlockNumber *big.Int       // Provides information for NUMBER
 	Time        *big.Int       // Provides information for TIME
 	Difficulty  *big.Int       // Provides information for DIFFICULTY
+	BaseFee     *big.Int       // Provides information for BASEFEE
 }
 
 // EVM is the Ethereum Virtual Machine base object and provides
@@ -157,19 +160,38 @@ type EVM struct {
 	// available gas is calculated in gasCall* according to the 63/64 rule and later
 	// applied in opCall*.
 	callGasTemp uint64
+	// accessList holds the addresses and storage slots warmed up by the
+	// transaction, used by the gas metering after the Berlin upgrade.
+	accessList *accessList
+	// originalStorage holds the values of the storage slots at the start
+	// of the transaction, used by the SSTORE gas metering after the Istanbul
+	// upgrade.
+	originalStorage map[common.Address]map[common.Hash]common.Hash
+}
+
+// evmSnapshot identifies a revision of both the state and the access list.
+type evmSnapshot struct {
+	state      common.Hash
+	accessList int
 }
 
 // NewEVM returns a new EVM. The returned EVM is not thread safe and should
 // only ever be used *once*.
 func NewEVM(ctx Context, statedb StateDB, chainConfig *params.ChainConfig, vmConfig Config) *EVM {
 	evm := &EVM{
-		Context:     ctx,
-		StateDB:     statedb,
-		vmConfig:    vmConfig,
-		chainConfig: chainConfig,
-		// chainRules:   chainConfig.Rules(ctx.BlockNumber),
-		interpreters: make([]Interpreter, 0, 1),
+		Context:         ctx,
+		StateDB:         statedb,
+		vmConfig:        vmConfig,
+		chainConfig:     chainConfig,
+		interpreters:    make([]Interpreter, 0, 1),
+		accessList:      newAccessList(),
+		originalStorage: make(map[common.Address]map[common.Hash]common.Hash),
+	}
+	var blockHeight uint64
+	if statedb != nil {
+		blockHeight = statedb.GetBlockHeight()
 	}
+	evm.chainRules = chainConfig.Rules(new(big.Int).SetUint64(blockHeight))
 
 	// vmConfig.EVMInterpreter will be used by EVM-C, it won't be checked here
 	// as we always want to have the built-in EVM as the failover option.
@@ -185,6 +207,37 @@ func (evm *EVM) Cancel() {
 	atomic.StoreInt32(&evm.abort, 1)
 }
 
+// snapshot takes a snapshot of the state and of the access list.
+func (evm *EVM) snapshot() evmSnapshot {
+	return evmSnapshot{
+		state:      evm.StateDB.Snapshot(),
+		accessList: evm.accessList.snapshot(),
+	}
+}
+
+// revertToSnapshot reverts the state and the access list to the given snapshot.
+func (evm *EVM) revertToSnapshot(snapshot evmSnapshot) {
+	evm.StateDB.RevertToSnapshot(snapshot.state)
+	evm.accessList.revertToSnapshot(snapshot.accessList)
+}
+
+// originalState returns the value of the storage slot at the start of the
+// transaction. The value is recorded at the first access of the slot, which
+// must happen before the slot is written.
+func (evm *EVM) originalState(addr common.Address, key common.Hash) common.Hash {
+	slots, ok := evm.originalStorage[addr]
+	if !ok {
+		slots = make(map[common.Hash]common.Hash)
+		evm.originalStorage[addr] = slots
+	}
+	value, ok := slots[key]
+	if !ok {
+		value = evm.StateDB.GetState(addr, key)
+		slots[key] = value
+	}
+	return value
+}
+
 // Interpreter returns the current interpreter
 func (evm *EVM) Interpreter() Interpreter {
 	return evm.interpreter
@@ -211,17 +264,17 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		return nil, gas, ErrInsufficientBalance
 	}
 
-	if SupportThetaTransferInEVM(blockHeight) && !CanTransferTheta(evm.StateDB, caller.Address(), thetaValue) {
+	if SupportThetaTransferInEVM(evm.Forks(), blockHeight) && !CanTransferTheta(evm.StateDB, caller.Address(), thetaValue) {
 		return nil, gas, ErrInsufficientThetaBlance
 	}
 
 	var (
 		to       = AccountRef(addr)
-		snapshot = evm.StateDB.Snapshot()
+		snapshot = evm.snapshot()
 	)
 	if !evm.StateDB.Exist(addr) {
 
-		precompiles := getPrecompiledContracts(blockHeight)
+		precompiles := getPrecompiledContracts(evm.Forks(), blockHeight)
 		if precompiles[addr] == nil && value.Sign() == 0 {
 			// Calling a non existing account, don't do anything, but ping the tracer
 			if evm.vmConfig.Debug && evm.depth == 0 {
@@ -231,7 +284,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 			return nil, gas, nil
 		}
 
-		if !SupportThetaTransferInEVM(blockHeight) { // just for backward compatibility
+		if !SupportThetaTransferInEVM(evm.Forks(), blockHeight) { // just for backward compatibility
 			evm.StateDB.CreateAccount(addr)
 		} else { // should not wipe out the Theta/TFuel balance sent to the contract address prior to contract creation
 			evm.StateDB.CreateAccountWithPreviousBalance(addr)
@@ -239,7 +292,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 	Transfer(evm.StateDB, caller.Address(), to.Address(), value)
 
-	if SupportThetaTransferInEVM(blockHeight) {
+	if SupportThetaTransferInEVM(evm.Forks(), blockHeight) {
 		TransferTheta(evm.StateDB, caller.Address(), to.Address(), thetaValue)
 	}
 
@@ -254,7 +307,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	// above we revert to the snapshot and consume any gas remaining. Additionally
 	// when we're in homestead this also counts for code storage gas errors.
 	if err != nil {
-		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.revertToSnapshot(snapshot)
 		if err != errExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
@@ -284,7 +337,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	}
 
 	var (
-		snapshot = evm.StateDB.Snapshot()
+		snapshot = evm.snapshot()
 		to       = AccountRef(caller.Address())
 	)
 	// initialise a new contract and set the code that is to be used by the
@@ -295,7 +348,7 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 
 	ret, err = run(evm, contract, input, false)
 	if err != nil {
-		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.revertToSnapshot(snapshot)
 		if err != errExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
@@ -318,7 +371,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 	}
 
 	var (
-		snapshot = evm.StateDB.Snapshot()
+		snapshot = evm.snapshot()
 		to       = AccountRef(caller.Address())
 	)
 
@@ -328,7 +381,7 @@ func (evm *EVM) DelegateCall(caller ContractRef, addr common.Address, input []by
 
 	ret, err = run(evm, contract, input, false)
 	if err != nil {
-		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.revertToSnapshot(snapshot)
 		if err != errExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
@@ -351,7 +404,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 
 	var (
 		to       = AccountRef(addr)
-		snapshot = evm.StateDB.Snapshot()
+		snapshot = evm.snapshot()
 	)
 	// Initialise a new contract and set the code that is to be used by the
 	// EVM. The contract is a scoped environment for this execution context
@@ -364,7 +417,7 @@ func (evm *EVM) StaticCall(caller ContractRef, addr common.Address, input []byte
 	// when we're in Homestead this also counts for code storage gas errors.
 	ret, err = run(evm, contract, input, true)
 	if err != nil {
-		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.revertToSnapshot(snapshot)
 		if err != errExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
@@ -396,7 +449,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	}
 
 	blockHeight := evm.StateDB.GetBlockHeight()
-	if SupportThetaTransferInEVM(blockHeight) && !CanTransferTheta(evm.StateDB, caller.Address(), thetaValue) {
+	if SupportThetaTransferInEVM(evm.Forks(), blockHeight) && !CanTransferTheta(evm.StateDB, caller.Address(), thetaValue) {
 		return nil, common.Address{}, gas, ErrInsufficientThetaBlance
 	}
 	nonce := evm.StateDB.GetNonce(caller.Address())
@@ -407,17 +460,22 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	if evm.StateDB.GetNonce(address) != 0 || (contractHash != (common.Hash{}) && contractHash != types.EmptyCodeHash) {
 		return nil, common.Address{}, 0, ErrContractAddressCollision
 	}
+	// Add the address of the new contract to the access list, the addition
+	// survives a failed creation as the address has been accessed regardless
+	if evm.chainRules.IsBerlin {
+		evm.accessList.addAddress(address)
+	}
 	// Create a new account on the state
-	snapshot := evm.StateDB.Snapshot()
+	snapshot := evm.snapshot()
 
-	if !SupportThetaTransferInEVM(blockHeight) { // just for backward compatibility
+	if !SupportThetaTransferInEVM(evm.Forks(), blockHeight) { // just for backward compatibility
 		evm.StateDB.CreateAccount(address)
 	} else { // should not wipe out the Theta/TFuel balance sent to the contract address prior to contract creation
 		evm.StateDB.CreateAccountWithPreviousBalance(address)
 	}
 	Transfer(evm.StateDB, caller.Address(), address, value)
 
-	if SupportThetaTransferInEVM(blockHeight) {
+	if SupportThetaTransferInEVM(evm.Forks(), blockHeight) {
 		TransferTheta(evm.StateDB, caller.Address(), address, thetaValue)
 	}
 
@@ -440,6 +498,10 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 
 	// check whether the max code size has been exceeded
 	maxCodeSizeExceeded := len(ret) > params.MaxCodeSize
+	// reject the code starting with the 0xEF byte (EIP-3541)
+	if err == nil && !maxCodeSizeExceeded && evm.chainRules.IsLondon && len(ret) >= 1 && ret[0] == 0xEF {
+		err = ErrInvalidCode
+	}
 	// if the contract creation ran successfully and no errors were returned
 	// calculate the gas required to store the code. If the code could not
 	// be stored due to not enough gas set an error and let it be handled
@@ -457,7 +519,7 @@ func (evm *EVM) create(caller ContractRef, codeAndHash *codeAndHash, gas uint64,
 	// above we revert to the snapshot and consume any gas remaining. Additionally
 	// when we're in homestead this also counts for code storage gas errors.
 	if maxCodeSizeExceeded || err != nil {
-		evm.StateDB.RevertToSnapshot(snapshot)
+		evm.revertToSnapshot(snapshot)
 		if err != errExecutionReverted {
 			contract.UseGas(contract.Gas)
 		}
@@ -491,3 +553,15 @@ func (evm *EVM) Create2(caller ContractRef, code []byte, gas uint64, endowment *
 
 // ChainConfig returns the environment's chain configuration
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
+
+// ChainRules returns the EVM upgrades active at the block being executed
+func (evm *EVM) ChainRules() params.Rules { return evm.chainRules }
+
+// Forks returns the fork schedule of the chain, the mainnet schedule if the
+// chain config does not specify one.
+func (evm *EVM) Forks() *common.ForkSchedule {
+	if evm.chainConfig == nil || evm.chainConfig.Forks == nil {
+		return common.MainnetForkSchedule
+	}
+	return evm.chainConfig.Forks
+}
@@ -5,17 +5,18 @@ import (
 	"encoding/hex"
 	"errors"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/clist"
 	"github.com/thetatoken/theta/common/math"
 	"github.com/thetatoken/theta/common/pqueue"
 	"github.com/thetatoken/theta/common/result"
-	"github.com/thetatoken/theta/consensus"
 	"github.com/thetatoken/theta/core"
 	dp "github.com/thetatoken/theta/dispatcher"
 )
@@ -30,6 +31,7 @@ func (m MempoolError) Error() string {
 
 const DuplicateTxError = MempoolError("Transaction already seen")
 const FastsyncSkipTxError = MempoolError("Skip tx during fastsync")
+const ReplacementUnderpricedError = MempoolError("Replacement transaction underpriced")
 
 const MaxMempoolTxCount int = 25600
 
@@ -101,6 +103,43 @@ func (mtg *mempoolTransactionGroup) PopTx() (common.Bytes, *core.TxInfo) {
 	return mptx.rawTransaction, mptx.txInfo
 }
 
+// FindTx returns the transaction with the given sequence, or nil if there is none.
+func (mtg *mempoolTransactionGroup) FindTx(sequence uint64) *mempoolTransaction {
+	for _, elem := range *mtg.txs.ElementList() {
+		mptx := elem.(*mempoolTransaction)
+		if mptx.txInfo.Sequence == sequence {
+			return mptx
+		}
+	}
+	return nil
+}
+
+// ReplaceTx replaces the given transaction in the transaction group.
+func (mtg *mempoolTransactionGroup) ReplaceTx(replaced *mempoolTransaction, rawTx common.Bytes, txInfo *core.TxInfo) {
+	mtg.txs.Remove(replaced.GetIndex())
+	mtg.AddTx(rawTx, txInfo)
+}
+
+// PrecedingRawTxs returns the transactions with lower sequences than the given sequence, ordered by sequence.
+func (mtg *mempoolTransactionGroup) PrecedingRawTxs(sequence uint64) []common.Bytes {
+	preceding := []*mempoolTransaction{}
+	for _, elem := range *mtg.txs.ElementList() {
+		mptx := elem.(*mempoolTransaction)
+		if mptx.txInfo.Sequence < sequence {
+			preceding = append(preceding, mptx)
+		}
+	}
+	sort.Slice(preceding, func(i, j int) bool {
+		return preceding[i].txInfo.Sequence < preceding[j].txInfo.Sequence
+	})
+
+	rawTxs := make([]common.Bytes, len(preceding))
+	for i, mptx := range preceding {
+		rawTxs[i] = mptx.rawTransaction
+	}
+	return rawTxs
+}
+
 func (mtg *mempoolTransactionGroup) IsEmpty() bool {
 	return mtg.txs.IsEmpty()
 }
@@ -133,6 +172,12 @@ func createMempoolTransactionGroup(rawTx common.Bytes, txInfo *core.TxInfo) *mem
 	return txGroup
 }
 
+// ConsensusEngine is the part of the consensus engine used by the mempool.
+type ConsensusEngine interface {
+	HasSynced() bool
+	NotifyNewTx()
+}
+
 //
 // Mempool manages the transactions submitted by the clients
 // or relayed from peers
@@ -140,7 +185,7 @@ func createMempoolTransactionGroup(rawTx common.Bytes, txInfo *core.TxInfo) *mem
 type Mempool struct {
 	mutex *sync.Mutex
 
-	consensus  *consensus.ConsensusEngine
+	consensus  ConsensusEngine
 	ledger     core.Ledger
 	dispatcher *dp.Dispatcher
 
@@ -159,7 +204,7 @@ type Mempool struct {
 }
 
 // CreateMempool creates an instance of Mempool
-func CreateMempool(dispatcher *dp.Dispatcher, engine *consensus.ConsensusEngine) *Mempool {
+func CreateMempool(dispatcher *dp.Dispatcher, engine ConsensusEngine) *Mempool {
 	return &Mempool{
 		mutex:            &sync.Mutex{},
 		consensus:        engine,
@@ -200,6 +245,15 @@ func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 	if mp.consensus.HasSynced() {
 		txInfo, checkTxRes = mp.ledger.ScreenTx(rawTx)
 		if !checkTxRes.IsOK() {
+			// A transaction with the same sequence as a pending one fails the screening with an invalid
+			// sequence, since the screened state already includes the pending one. It may replace the
+			// pending one.
+			if checkTxRes.Code == result.CodeInvalidSequence {
+				if replacing, err := mp.replaceTransaction(rawTx); replacing {
+					return err
+				}
+			}
+
 			logger.Debugf("Transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), checkTxRes.Message)
 			return errors.New(checkTxRes.Message)
 		}
@@ -223,6 +277,7 @@ func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 		logger.Debugf("rawTx: %v, txInfo: %v", hex.EncodeToString(rawTx), txInfo)
 		logger.Infof("Insert tx, tx.hash: 0x%v", getTransactionHash(rawTx))
 		mp.size++
+		mp.consensus.NotifyNewTx()
 
 		return nil
 	}
@@ -230,6 +285,55 @@ func (mp *Mempool) InsertTransaction(rawTx common.Bytes) error {
 	return FastsyncSkipTxError
 }
 
+// replaceTransaction replaces the pending transaction of the same address and sequence with the
+// given transaction, if its effective gas price exceeds that of the pending one by at least the
+// configured margin. This allows the clients to speed up a pending transaction with a higher fee,
+// or to cancel it with another transaction of the same sequence paying a higher fee, e.g. a
+// transfer to themselves. The returned boolean indicates whether there is such a pending
+// transaction.
+func (mp *Mempool) replaceTransaction(rawTx common.Bytes) (bool, error) {
+	txInfo, res := mp.ledger.GetTxInfo(rawTx)
+	if res.IsError() {
+		return false, nil
+	}
+	txGroup, ok := mp.addressToTxGroup[txInfo.Address]
+	if !ok {
+		return false, nil
+	}
+	replaced := txGroup.FindTx(txInfo.Sequence)
+	if replaced == nil {
+		return false, nil
+	}
+
+	margin := viper.GetInt64(common.CfgMempoolReplacementFeeMargin)
+	replacedPrice := replaced.txInfo.EffectiveGasPrice
+	minPrice := new(big.Int).Mul(replacedPrice, big.NewInt(100+margin))
+	price := new(big.Int).Mul(txInfo.EffectiveGasPrice, big.NewInt(100))
+	if txInfo.EffectiveGasPrice.Cmp(replacedPrice) <= 0 || price.Cmp(minPrice) < 0 {
+		logger.Debugf("Replacement underpriced, tx: %v, effective gas price: %v, replaced effective gas price: %v, margin: %v%%",
+			hex.EncodeToString(rawTx), txInfo.EffectiveGasPrice, replacedPrice, margin)
+		return true, ReplacementUnderpricedError
+	}
+
+	txInfo, res = mp.ledger.ScreenTxReplacement(rawTx, txGroup.PrecedingRawTxs(txInfo.Sequence))
+	if !res.IsOK() {
+		logger.Debugf("Replacement transaction screening failed, tx: %v, error: %v", hex.EncodeToString(rawTx), res.Message)
+		return true, errors.New(res.Message)
+	}
+
+	mp.txBookeepper.record(rawTx)
+	mp.txBookeepper.markReplaced(replaced.rawTransaction)
+
+	txGroup.ReplaceTx(replaced, rawTx, txInfo)
+	mp.candidateTxs.Remove(txGroup.index) // Need to re-insert txGroup into queue since its priority could change.
+	mp.candidateTxs.Push(txGroup)
+
+	logger.Infof("Replace tx, tx.hash: 0x%v, replaced tx.hash: 0x%v",
+		getTransactionHash(rawTx), getTransactionHash(replaced.rawTransaction))
+
+	return true, nil
+}
+
 // Start needs to be called when the Mempool starts
 func (mp *Mempool) Start(ctx context.Context) error {
 	c, cancel := context.WithCancel(ctx)
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/common/result"
@@ -380,6 +381,13 @@ func TestMempoolTransactionGossip(t *testing.T) {
 	assert.Equal(3, mempool.Size())
 	log.Infof(">>> Client submitted tx1, tx2, tx3")
 
+	// The inserted transactions are gossiped explicitly, as done by the RPC service
+	go func() {
+		mempool.BroadcastTx(tx1)
+		mempool.BroadcastTx(tx2)
+		mempool.BroadcastTx(tx3)
+	}()
+
 	numGossippedTxs := 2 * 3 // 2 peers, each should receive 3 transactions
 	for i := 0; i < numGossippedTxs; i++ {
 		receivedMsg := <-netMsgIntercepter.ReceivedMessages
@@ -391,6 +399,61 @@ func TestMempoolTransactionGossip(t *testing.T) {
 	}
 }
 
+func TestMempoolReplaceTransaction(t *testing.T) {
+	assert := assert.New(t)
+
+	viper.Set(common.CfgMempoolReplacementFeeMargin, 10)
+	defer viper.Set(common.CfgMempoolReplacementFeeMargin, nil)
+
+	p2psimnet := p2psim.NewSimnetWithHandler(nil)
+	mempool, _ := newTestMempool("peer0", p2psimnet)
+	ledger := newReplacementTestLedger()
+	mempool.SetLedger(ledger)
+
+	ledger.addTx("a1", "A1", 1, 100)
+	ledger.addTx("a2", "A1", 2, 100)
+	ledger.addTx("a1-equal", "A1", 1, 100)
+	ledger.addTx("a1-lower", "A1", 1, 50)
+	ledger.addTx("a1-below-margin", "A1", 1, 105)
+	ledger.addTx("a1-higher", "A1", 1, 200)
+	ledger.addTx("a1-highest", "A1", 1, 1000)
+
+	assert.Nil(mempool.InsertTransaction(createTestRawTx("a1")))
+	assert.Nil(mempool.InsertTransaction(createTestRawTx("a2")))
+	assert.Equal(2, mempool.Size())
+
+	// The replacement must pay more than the pending transaction by the margin
+	assert.Equal(ReplacementUnderpricedError, mempool.InsertTransaction(createTestRawTx("a1-equal")))
+	assert.Equal(ReplacementUnderpricedError, mempool.InsertTransaction(createTestRawTx("a1-lower")))
+	assert.Equal(ReplacementUnderpricedError, mempool.InsertTransaction(createTestRawTx("a1-below-margin")))
+	status, ok := mempool.GetTransactionStatus(getTransactionHash(createTestRawTx("a1")))
+	assert.True(ok)
+	assert.Equal(TxStatusPending, status)
+
+	// A higher fee replaces the pending transaction
+	assert.Nil(mempool.InsertTransaction(createTestRawTx("a1-higher")))
+	assert.Equal(2, mempool.Size())
+	status, ok = mempool.GetTransactionStatus(getTransactionHash(createTestRawTx("a1")))
+	assert.True(ok)
+	assert.Equal(TxStatusReplaced, status)
+	status, ok = mempool.GetTransactionStatus(getTransactionHash(createTestRawTx("a1-higher")))
+	assert.True(ok)
+	assert.Equal(TxStatusPending, status)
+
+	// The replaced transaction can not be submitted again
+	assert.Equal(DuplicateTxError, mempool.InsertTransaction(createTestRawTx("a1")))
+
+	// The replacement is compared with the latest pending transaction
+	assert.Nil(mempool.InsertTransaction(createTestRawTx("a1-highest")))
+
+	// The replaced transactions are evicted
+	reapedRawTxs := mempool.Reap(10)
+	assert.Equal(2, len(reapedRawTxs))
+	assert.Equal("a1-highest", string(reapedRawTxs[0]))
+	assert.Equal("a2", string(reapedRawTxs[1]))
+	assert.Equal(0, mempool.Size())
+}
+
 // --------------- Test Utilities --------------- //
 
 func newTestMempool(peerID string, simnet *p2psim.Simnet) (*Mempool, context.Context) {
@@ -398,7 +461,7 @@ func newTestMempool(peerID string, simnet *p2psim.Simnet) (*Mempool, context.Con
 
 	messenger := simnet.AddEndpoint(peerID)
 	dispatcher := dp.NewDispatcher(messenger, nil)
-	mempool := CreateMempool(dispatcher)
+	mempool := CreateMempool(dispatcher, &testConsensusEngine{synced: true})
 	mempool.SetLedger(newTestLedger())
 	txMsgHandler := CreateMempoolMessageHandler(mempool)
 	messenger.RegisterMessageHandler(txMsgHandler)
@@ -470,6 +533,14 @@ func (tl *TestLedger) ScreenTx(rawTx common.Bytes) (*core.TxInfo, result.Result)
 	return txInfo, result.OK
 }
 
+func (tl *TestLedger) ScreenTxReplacement(rawTx common.Bytes, precedingRawTxs []common.Bytes) (*core.TxInfo, result.Result) {
+	return tl.ScreenTx(rawTx)
+}
+
+func (tl *TestLedger) GetTxInfo(rawTx common.Bytes) (*core.TxInfo, result.Result) {
+	return nil, result.Error("Not supported")
+}
+
 func (tl *TestLedger) GetCurrentBlock() *core.Block {
 	return nil
 }
@@ -482,7 +553,7 @@ func (tl *TestLedger) ApplyBlockTxs(block *core.Block) result.Result {
 	return result.OK
 }
 
-func (tl *TestLedger) ResetState(height uint64, rootHash common.Hash) result.Result {
+func (tl *TestLedger) ResetState(block *core.Block) result.Result {
 	return result.OK
 }
 
@@ -498,6 +569,10 @@ func (tl *TestLedger) GetGuardianCandidatePool(blockHash common.Hash) (*core.Gua
 	return nil, nil
 }
 
+func (tl *TestLedger) GetEliteEdgeNodePoolOfLastCheckpoint(blockHash common.Hash) (core.EliteEdgeNodePool, error) {
+	return nil, nil
+}
+
 func (tl *TestLedger) PruneState(endHeight uint64) error {
 	return nil
 }
@@ -506,6 +581,60 @@ func (tl *TestLedger) ApplyBlockTxsForChainCorrection(block *core.Block) (common
 	return common.Hash{}, result.Result{}
 }
 
+// replacementTestLedger screens the transactions by address and sequence, a transaction with the
+// same address and sequence as a screened one fails with an invalid sequence.
+type replacementTestLedger struct {
+	TestLedger
+	txInfos  map[string]*core.TxInfo
+	screened map[string]bool
+}
+
+func newReplacementTestLedger() *replacementTestLedger {
+	return &replacementTestLedger{
+		txInfos:  make(map[string]*core.TxInfo),
+		screened: make(map[string]bool),
+	}
+}
+
+func (tl *replacementTestLedger) addTx(rawTx string, address string, sequence uint64, effectiveGasPrice uint64) {
+	tl.txInfos[rawTx] = &core.TxInfo{
+		Address:           common.HexToAddress(address),
+		Sequence:          sequence,
+		EffectiveGasPrice: new(big.Int).SetUint64(effectiveGasPrice),
+	}
+}
+
+func (tl *replacementTestLedger) ScreenTx(rawTx common.Bytes) (*core.TxInfo, result.Result) {
+	txInfo := tl.txInfos[string(rawTx)]
+	key := txInfo.Address.Hex() + "/" + strconv.FormatUint(txInfo.Sequence, 10)
+	if tl.screened[key] {
+		return nil, result.Error("Invalid sequence").WithErrorCode(result.CodeInvalidSequence)
+	}
+	tl.screened[key] = true
+	return txInfo, result.OK
+}
+
+func (tl *replacementTestLedger) ScreenTxReplacement(rawTx common.Bytes, precedingRawTxs []common.Bytes) (*core.TxInfo, result.Result) {
+	return tl.txInfos[string(rawTx)], result.OK
+}
+
+func (tl *replacementTestLedger) GetTxInfo(rawTx common.Bytes) (*core.TxInfo, result.Result) {
+	return tl.txInfos[string(rawTx)], result.OK
+}
+
+type testConsensusEngine struct {
+	synced bool
+	newTxs int
+}
+
+func (e *testConsensusEngine) HasSynced() bool {
+	return e.synced
+}
+
+func (e *testConsensusEngine) NotifyNewTx() {
+	e.newTxs++
+}
+
 type TestNetworkMessageInterceptor struct {
 	lock             *sync.Mutex
 	ReceivedMessages chan p2ptypes.Message
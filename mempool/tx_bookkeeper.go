@@ -41,6 +41,7 @@ type TxStatus int
 const (
 	TxStatusPending TxStatus = iota
 	TxStatusAbandoned
+	TxStatusReplaced
 )
 
 func createTransactionBookkeeper(maxNumTxs uint) transactionBookkeeper {
@@ -147,6 +148,17 @@ func (tb *transactionBookkeeper) markAbandoned(rawTx common.Bytes) {
 	tb.txMap[txhash].Status = TxStatusAbandoned
 }
 
+func (tb *transactionBookkeeper) markReplaced(rawTx common.Bytes) {
+	tb.mutex.Lock()
+	defer tb.mutex.Unlock()
+
+	txhash := getTransactionHash(rawTx)
+	if _, exists := tb.txMap[txhash]; !exists {
+		return
+	}
+	tb.txMap[txhash].Status = TxStatusReplaced
+}
+
 func (tb *transactionBookkeeper) remove(rawTx common.Bytes) {
 	tb.mutex.Lock()
 	defer tb.mutex.Unlock()
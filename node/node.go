@@ -25,6 +25,7 @@ import (
 	"github.com/thetatoken/theta/store/database"
 	"github.com/thetatoken/theta/store/kvstore"
 	"github.com/thetatoken/theta/store/rollingdb"
+	"github.com/thetatoken/theta/store/trie"
 )
 
 type Node struct {
@@ -73,7 +74,11 @@ func NewNode(params *Params) *Node {
 	// TODO: check if this is a guardian node
 	syncMgr := netsync.NewSyncManager(chain, consensus, params.NetworkOld, params.Network, dispatcher, consensus, reporter)
 	mempool := mp.CreateMempool(dispatcher, consensus)
-	ledger := ld.NewLedger(params.ChainID, params.RollingDB, params.RollingDB, chain, consensus, validatorManager, mempool)
+	var stateDB database.Database = params.RollingDB
+	if cacheSize := viper.GetInt(common.CfgStorageTrieCacheSize); cacheSize > 0 {
+		stateDB = trie.NewNodeCache(params.RollingDB, cacheSize)
+	}
+	ledger := ld.NewLedger(params.ChainID, stateDB, params.RollingDB, chain, consensus, validatorManager, mempool)
 
 	validatorManager.SetConsensusEngine(consensus)
 	consensus.SetLedger(ledger)
@@ -119,7 +124,7 @@ func NewNode(params *Params) *Node {
 	}
 
 	if viper.GetBool(common.CfgRPCEnabled) {
-		node.RPC = rpc.NewThetaRPCServer(mempool, ledger, dispatcher, chain, consensus)
+		node.RPC = rpc.NewThetaRPCServer(mempool, ledger, dispatcher, chain, consensus, store)
 	}
 	return node
 }
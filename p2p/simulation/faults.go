@@ -0,0 +1,157 @@
+package simulation
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// defaultReorderDelay is how long a reordered message is held back when the link
+// does not specify it
+const defaultReorderDelay = 100 * time.Millisecond
+
+// LinkConfig describes the faults of the link from one endpoint to another.
+type LinkConfig struct {
+	Latency       time.Duration // Delay of every message
+	Jitter        time.Duration // Upper bound of the random delay added to the latency
+	DropRate      float64       // Probability that a message is lost
+	DuplicateRate float64       // Probability that a message is delivered twice
+	ReorderRate   float64       // Probability that a message is held back so that later messages overtake it
+	ReorderDelay  time.Duration // How long reordered messages are held back, 100ms if not set
+}
+
+type link struct {
+	from string
+	to   string
+}
+
+// faults keeps the link configurations and the partitions of a Simnet, and decides
+// the fate of each message.
+type faults struct {
+	mu          sync.Mutex
+	rand        *rand.Rand
+	defaultLink LinkConfig
+	links       map[link]LinkConfig
+	partitions  map[string]map[string]int // partition name -> endpoint ID -> group
+}
+
+func newFaults() *faults {
+	return &faults{
+		rand:       rand.New(rand.NewSource(time.Now().UnixNano())),
+		links:      make(map[link]LinkConfig),
+		partitions: make(map[string]map[string]int),
+	}
+}
+
+// schedule returns the delays after which a message from one endpoint to another
+// is delivered. It returns no delay if the message is dropped, and two if the message
+// is duplicated.
+func (f *faults) schedule(from, to string) []time.Duration {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.isPartitioned(from, to) {
+		return nil
+	}
+
+	config, ok := f.links[link{from, to}]
+	if !ok {
+		config = f.defaultLink
+	}
+	if f.rand.Float64() < config.DropRate {
+		return nil
+	}
+	copies := 1
+	if f.rand.Float64() < config.DuplicateRate {
+		copies = 2
+	}
+	delays := make([]time.Duration, 0, copies)
+	for i := 0; i < copies; i++ {
+		delay := config.Latency
+		if config.Jitter > 0 {
+			delay += time.Duration(f.rand.Int63n(int64(config.Jitter)))
+		}
+		if f.rand.Float64() < config.ReorderRate {
+			if config.ReorderDelay > 0 {
+				delay += config.ReorderDelay
+			} else {
+				delay += defaultReorderDelay
+			}
+		}
+		delays = append(delays, delay)
+	}
+	return delays
+}
+
+// isPartitioned returns whether any partition puts the two endpoints in different
+// groups. Endpoints that are not in any group of a partition are not affected by it.
+func (f *faults) isPartitioned(from, to string) bool {
+	for _, groups := range f.partitions {
+		fromGroup, ok1 := groups[from]
+		toGroup, ok2 := groups[to]
+		if ok1 && ok2 && fromGroup != toGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSeed seeds the random source of the faults, so that a run can be reproduced.
+func (sn *Simnet) SetSeed(seed int64) {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.rand = rand.New(rand.NewSource(seed))
+}
+
+// SetDefaultLink sets the faults of all the links that are not configured with SetLink.
+func (sn *Simnet) SetDefaultLink(config LinkConfig) {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.defaultLink = config
+}
+
+// SetLink sets the faults of the link from one endpoint to another. The link in the
+// opposite direction is not affected.
+func (sn *Simnet) SetLink(from, to string, config LinkConfig) {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.links[link{from, to}] = config
+}
+
+// ResetLinks makes all the links reliable and instant again.
+func (sn *Simnet) ResetLinks() {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.defaultLink = LinkConfig{}
+	sn.faults.links = make(map[link]LinkConfig)
+}
+
+// Partition splits the endpoints into groups that can not reach each other until the
+// partition is healed. Endpoints not listed in any group can still reach everyone. A
+// partition with the same name replaces the previous one. Messages already in flight
+// are still delivered.
+func (sn *Simnet) Partition(name string, groups ...[]string) {
+	membership := make(map[string]int)
+	for i, group := range groups {
+		for _, id := range group {
+			membership[id] = i
+		}
+	}
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.partitions[name] = membership
+}
+
+// Heal removes the partition with the given name.
+func (sn *Simnet) Heal(name string) {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	delete(sn.faults.partitions, name)
+}
+
+// HealAll removes all the partitions.
+func (sn *Simnet) HealAll() {
+	sn.faults.mu.Lock()
+	defer sn.faults.mu.Unlock()
+	sn.faults.partitions = make(map[string]map[string]int)
+}
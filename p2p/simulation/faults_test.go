@@ -0,0 +1,171 @@
+package simulation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/timer"
+	p2ptypes "github.com/thetatoken/theta/p2p/types"
+	"github.com/thetatoken/theta/rlp"
+)
+
+type recordingHandler struct {
+	id       string
+	lock     *sync.Mutex
+	received *[]string
+}
+
+func (rh *recordingHandler) GetChannelIDs() []common.ChannelIDEnum {
+	return []common.ChannelIDEnum{common.ChannelIDVote}
+}
+
+func (rh *recordingHandler) EncodeMessage(message interface{}) (common.Bytes, error) {
+	return rlp.EncodeToBytes(message)
+}
+
+func (rh *recordingHandler) ParseMessage(peerID string, channelID common.ChannelIDEnum, rawMessageBytes common.Bytes) (p2ptypes.Message, error) {
+	var content string
+	err := rlp.DecodeBytes(rawMessageBytes, &content)
+	return p2ptypes.Message{PeerID: peerID, ChannelID: channelID, Content: content}, err
+}
+
+func (rh *recordingHandler) HandleMessage(msg p2ptypes.Message) error {
+	rh.lock.Lock()
+	defer rh.lock.Unlock()
+	*rh.received = append(*rh.received, msg.PeerID+" -> "+rh.id+": "+msg.Content.(string))
+	return nil
+}
+
+type faultyNet struct {
+	*Simnet
+	lock     *sync.Mutex
+	received *[]string
+}
+
+func newFaultyNet(ids ...string) *faultyNet {
+	fn := &faultyNet{Simnet: NewSimnet(), lock: &sync.Mutex{}, received: &[]string{}}
+	for _, id := range ids {
+		endpoint := fn.AddEndpoint(id)
+		endpoint.RegisterMessageHandler(&recordingHandler{id: id, lock: fn.lock, received: fn.received})
+	}
+	return fn
+}
+
+func (fn *faultyNet) broadcast(from int, content string) {
+	fn.Endpoints[from].Broadcast(p2ptypes.Message{ChannelID: common.ChannelIDVote, Content: content}, false)
+}
+
+func (fn *faultyNet) take() []string {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	received := *fn.received
+	*fn.received = []string{}
+	sort.Strings(received)
+	return received
+}
+
+func (fn *faultyNet) count() int {
+	fn.lock.Lock()
+	defer fn.lock.Unlock()
+	return len(*fn.received)
+}
+
+func TestSimnetPartition(t *testing.T) {
+	assert := assert.New(t)
+
+	fn := newFaultyNet("e1", "e2", "e3")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fn.Start(ctx)
+
+	fn.Partition("split", []string{"e1"}, []string{"e2", "e3"})
+	fn.broadcast(0, "a")
+	fn.broadcast(1, "b")
+	assert.Eventually(func() bool { return fn.count() >= 1 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal([]string{"e2 -> e3: b"}, fn.take())
+
+	fn.Heal("split")
+	fn.broadcast(0, "c")
+	assert.Eventually(func() bool { return fn.count() >= 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal([]string{"e1 -> e2: c", "e1 -> e3: c"}, fn.take())
+}
+
+func TestSimnetDropAndDuplicate(t *testing.T) {
+	assert := assert.New(t)
+
+	fn := newFaultyNet("e1", "e2", "e3")
+	fn.SetSeed(1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fn.Start(ctx)
+
+	fn.SetLink("e1", "e2", LinkConfig{DropRate: 1})
+	fn.SetLink("e1", "e3", LinkConfig{DuplicateRate: 1})
+	fn.broadcast(0, "a")
+	assert.Eventually(func() bool { return fn.count() >= 2 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal([]string{"e1 -> e3: a", "e1 -> e3: a"}, fn.take())
+
+	fn.ResetLinks()
+	fn.broadcast(0, "b")
+	assert.Eventually(func() bool { return fn.count() >= 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal([]string{"e1 -> e2: b", "e1 -> e3: b"}, fn.take())
+}
+
+func TestSimnetLatency(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := timer.NewSimulatedClock(time.Unix(0, 0))
+	fn := newFaultyNet("e1", "e2")
+	fn.SetClock(clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fn.Start(ctx)
+
+	fn.SetDefaultLink(LinkConfig{Latency: time.Second})
+	fn.broadcast(0, "a")
+	fn.broadcast(1, "b")
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(0, fn.count())
+
+	clock.Advance(500 * time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(0, fn.count())
+
+	clock.Advance(500 * time.Millisecond)
+	assert.Eventually(func() bool { return fn.count() >= 2 }, time.Second, 10*time.Millisecond)
+	assert.Equal([]string{"e1 -> e2: a", "e2 -> e1: b"}, fn.take())
+}
+
+func TestSimnetReorder(t *testing.T) {
+	assert := assert.New(t)
+
+	clock := timer.NewSimulatedClock(time.Unix(0, 0))
+	fn := newFaultyNet("e1", "e2")
+	fn.SetClock(clock)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	fn.Start(ctx)
+
+	// The first message is held back, the second one overtakes it
+	fn.SetLink("e1", "e2", LinkConfig{Latency: time.Second, ReorderRate: 1, ReorderDelay: time.Second})
+	fn.broadcast(0, "a")
+	time.Sleep(100 * time.Millisecond)
+	fn.SetLink("e1", "e2", LinkConfig{Latency: time.Second})
+	fn.broadcast(0, "b")
+	time.Sleep(100 * time.Millisecond)
+
+	clock.Advance(time.Second)
+	assert.Eventually(func() bool { return fn.count() >= 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal([]string{"e1 -> e2: b"}, fn.take())
+
+	clock.Advance(time.Second)
+	assert.Eventually(func() bool { return fn.count() >= 1 }, time.Second, 10*time.Millisecond)
+	assert.Equal([]string{"e1 -> e2: a"}, fn.take())
+}
@@ -7,23 +7,28 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/timer"
 	"github.com/thetatoken/theta/p2p"
 	p2ptypes "github.com/thetatoken/theta/p2p/types"
 )
 
 // Envelope wraps a message with network information for delivery.
 type Envelope struct {
-	From    string
-	To      string
-	Content interface{}
+	From      string
+	To        string
+	ChannelID common.ChannelIDEnum
+	Content   interface{}
 }
 
 // Simnet represents an instance of simulated network.
 type Simnet struct {
 	Endpoints  []*SimnetEndpoint
+	endpointMu *sync.RWMutex
 	msgHandler p2p.MessageHandler
 	messages   chan Envelope
 	MsgLogs    []Envelope
+	faults     *faults
+	clock      timer.Clock
 
 	// Life cycle.
 	wg      *sync.WaitGroup
@@ -36,10 +41,13 @@ type Simnet struct {
 // NewSimnet creates a new instance of Simnet.
 func NewSimnet() *Simnet {
 	return &Simnet{
-		messages: make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
-		MsgLogs:  []Envelope{},
-		wg:       &sync.WaitGroup{},
-		mu:       &sync.Mutex{},
+		messages:   make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
+		MsgLogs:    []Envelope{},
+		faults:     newFaults(),
+		clock:      timer.SystemClock,
+		endpointMu: &sync.RWMutex{},
+		wg:         &sync.WaitGroup{},
+		mu:         &sync.Mutex{},
 	}
 }
 
@@ -48,30 +56,62 @@ func NewSimnetWithHandler(msgHandler p2p.MessageHandler) *Simnet {
 	return &Simnet{
 		msgHandler: msgHandler,
 		messages:   make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
+		faults:     newFaults(),
+		clock:      timer.SystemClock,
+		endpointMu: &sync.RWMutex{},
 		wg:         &sync.WaitGroup{},
 		mu:         &sync.Mutex{},
 	}
 }
 
-// AddEndpoint adds an endpoint with given ID to the Simnet instance.
+// SetClock sets the clock that times the message delays. It needs to be called before
+// the Simnet starts.
+func (sn *Simnet) SetClock(clock timer.Clock) {
+	sn.clock = clock
+}
+
+// AddEndpoint adds an endpoint with given ID to the Simnet instance. Endpoints added after
+// the Simnet has started need to be started by their owners.
 func (sn *Simnet) AddEndpoint(id string) *SimnetEndpoint {
 	endpoint := &SimnetEndpoint{
-		id:       id,
-		network:  sn,
-		incoming: make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
-		outgoing: make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
+		id:         id,
+		network:    sn,
+		handlerMap: make(map[common.ChannelIDEnum]p2p.MessageHandler),
+		incoming:   make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
+		outgoing:   make(chan Envelope, viper.GetInt(common.CfgP2PMessageQueueSize)),
 	}
+	sn.endpointMu.Lock()
+	defer sn.endpointMu.Unlock()
 	sn.Endpoints = append(sn.Endpoints, endpoint)
 	return endpoint
 }
 
+// RemoveEndpoint disconnects the endpoint with given ID from the Simnet instance. Messages
+// in flight to the endpoint are dropped.
+func (sn *Simnet) RemoveEndpoint(id string) {
+	sn.endpointMu.Lock()
+	defer sn.endpointMu.Unlock()
+	for i, endpoint := range sn.Endpoints {
+		if endpoint.ID() == id {
+			sn.Endpoints = append(sn.Endpoints[:i:i], sn.Endpoints[i+1:]...)
+			return
+		}
+	}
+}
+
+func (sn *Simnet) endpoints() []*SimnetEndpoint {
+	sn.endpointMu.RLock()
+	defer sn.endpointMu.RUnlock()
+	return sn.Endpoints
+}
+
 // Start is the main entry point for Simnet. It starts all endpoints and start a goroutine to handle message dlivery.
 func (sn *Simnet) Start(ctx context.Context) {
 	c, cancel := context.WithCancel(ctx)
 	sn.ctx = c
 	sn.cancel = cancel
 
-	for _, endpoint := range sn.Endpoints {
+	for _, endpoint := range sn.endpoints() {
 		endpoint.Start(ctx)
 	}
 
@@ -101,22 +141,44 @@ func (sn *Simnet) mainLoop() {
 			return
 		case envelope := <-sn.messages:
 			time.Sleep(1 * time.Microsecond)
-			for _, endpoint := range sn.Endpoints {
+			for _, endpoint := range sn.endpoints() {
 				if (envelope.To == "" && envelope.From != endpoint.ID()) || envelope.To == endpoint.ID() {
-					go func(endpoint *SimnetEndpoint, envelope Envelope) {
-						// Simulate network delay except for messages to self.
-						if envelope.From != endpoint.ID() {
-							// time.Sleep(100 * time.Millisecond)
-						}
-						endpoint.incoming <- envelope
-
-					}(endpoint, envelope)
+					sn.deliver(endpoint, envelope)
 				}
 			}
 		}
 	}
 }
 
+// deliver passes the message to the endpoint, subject to the faults of the link
+// between the sender and the endpoint. Messages to self are always delivered at once.
+func (sn *Simnet) deliver(endpoint *SimnetEndpoint, envelope Envelope) {
+	delays := []time.Duration{0}
+	if envelope.From != endpoint.ID() {
+		delays = sn.faults.schedule(envelope.From, endpoint.ID())
+	}
+	for _, delay := range delays {
+		var t timer.Timer
+		if delay > 0 {
+			t = sn.clock.NewTimer(delay)
+		}
+		go func(t timer.Timer) {
+			if t != nil {
+				select {
+				case <-t.C():
+				case <-sn.ctx.Done():
+					t.Stop()
+					return
+				}
+			}
+			select {
+			case endpoint.incoming <- envelope:
+			case <-sn.ctx.Done():
+			}
+		}(t)
+	}
+}
+
 // AddMessage send a message through the network.
 func (sn *Simnet) AddMessage(msg Envelope) {
 	sn.mu.Lock()
@@ -132,11 +194,12 @@ func (sn *Simnet) AddMessage(msg Envelope) {
 
 // SimnetEndpoint is the implementation of Network interface for Simnet.
 type SimnetEndpoint struct {
-	id       string
-	network  *Simnet
-	handlers []p2p.MessageHandler
-	incoming chan Envelope
-	outgoing chan Envelope
+	id         string
+	network    *Simnet
+	handlers   []p2p.MessageHandler
+	handlerMap map[common.ChannelIDEnum]p2p.MessageHandler
+	incoming   chan Envelope
+	outgoing   chan Envelope
 }
 
 var _ p2p.Network = &SimnetEndpoint{}
@@ -146,10 +209,13 @@ func (se *SimnetEndpoint) Start(ctx context.Context) error {
 	go func() {
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case envelope := <-se.incoming:
 				message := p2ptypes.Message{
-					PeerID:  envelope.From,
-					Content: envelope.Content,
+					PeerID:    envelope.From,
+					ChannelID: envelope.ChannelID,
+					Content:   envelope.Content,
 				}
 				se.HandleMessage(message)
 			}
@@ -159,6 +225,8 @@ func (se *SimnetEndpoint) Start(ctx context.Context) error {
 	go func() {
 		for {
 			select {
+			case <-ctx.Done():
+				return
 			case envelope := <-se.outgoing:
 				se.network.messages <- envelope
 			}
@@ -180,7 +248,7 @@ func (se *SimnetEndpoint) Wait() {
 func (se *SimnetEndpoint) Broadcast(message p2ptypes.Message, skipEdgeNode bool) (successes chan bool) {
 	successes = make(chan bool, 10)
 	go func() {
-		se.network.AddMessage(Envelope{From: se.ID(), Content: message.Content})
+		se.network.AddMessage(Envelope{From: se.ID(), ChannelID: message.ChannelID, Content: message.Content})
 		successes <- true
 	}()
 	return successes
@@ -190,7 +258,7 @@ func (se *SimnetEndpoint) Broadcast(message p2ptypes.Message, skipEdgeNode bool)
 func (se *SimnetEndpoint) BroadcastToNeighbors(message p2ptypes.Message, maxNumPeersToBroadcast int, skipEdgeNode bool) (successes chan bool) {
 	successes = make(chan bool, 10)
 	go func() {
-		se.network.AddMessage(Envelope{From: se.ID(), Content: message.Content})
+		se.network.AddMessage(Envelope{From: se.ID(), ChannelID: message.ChannelID, Content: message.Content})
 		successes <- true
 	}()
 	return successes
@@ -199,14 +267,20 @@ func (se *SimnetEndpoint) BroadcastToNeighbors(message p2ptypes.Message, maxNumP
 // Send implements the Network interface.
 func (se *SimnetEndpoint) Send(id string, message p2ptypes.Message) bool {
 	go func() {
-		se.network.AddMessage(Envelope{From: se.ID(), To: id, Content: message.Content})
+		se.network.AddMessage(Envelope{From: se.ID(), To: id, ChannelID: message.ChannelID, Content: message.Content})
 	}()
 	return true
 }
 
-// Peers returns the IDs of all peers
+// Peers returns the IDs of all the other endpoints in the network
 func (se *SimnetEndpoint) Peers(skipEdgeNode bool) []string {
-	return []string{}
+	peers := []string{}
+	for _, endpoint := range se.network.endpoints() {
+		if endpoint.ID() != se.ID() {
+			peers = append(peers, endpoint.ID())
+		}
+	}
+	return peers
 }
 
 // PeerURLs returns the URLs of all peers
@@ -216,12 +290,20 @@ func (se *SimnetEndpoint) PeerURLs(skipEdgeNode bool) []string {
 
 // PeerExists indicates if the given peerID is a neighboring peer
 func (se *SimnetEndpoint) PeerExists(peerID string) bool {
+	for _, peer := range se.Peers(false) {
+		if peer == peerID {
+			return true
+		}
+	}
 	return false
 }
 
 // RegisterMessageHandler implements the Network interface.
 func (se *SimnetEndpoint) RegisterMessageHandler(handler p2p.MessageHandler) {
 	se.handlers = append(se.handlers, handler)
+	for _, channelID := range handler.GetChannelIDs() {
+		se.handlerMap[channelID] = handler
+	}
 }
 
 // ID implements the Network interface.
@@ -229,10 +311,25 @@ func (se *SimnetEndpoint) ID() string {
 	return se.id
 }
 
-// HandleMessage implements the MessageHandler interface.
+// HandleMessage implements the MessageHandler interface. Like the real messenger, a message
+// on a channel goes to the handler registered for the channel, after a round trip through
+// the handler's encoding so that the nodes do not share the message objects. Messages
+// without a channel go to all the handlers as they are.
 func (se *SimnetEndpoint) HandleMessage(message p2ptypes.Message) error {
-	for _, handler := range se.handlers {
-		handler.HandleMessage(message)
+	if message.ChannelID == common.ChannelIDInvalid {
+		for _, handler := range se.handlers {
+			handler.HandleMessage(message)
+		}
+	} else if handler, ok := se.handlerMap[message.ChannelID]; ok {
+		raw, err := handler.EncodeMessage(message.Content)
+		if err != nil {
+			return err
+		}
+		decoded, err := handler.ParseMessage(message.PeerID, message.ChannelID, raw)
+		if err != nil {
+			return err
+		}
+		handler.HandleMessage(decoded)
 	}
 	if se.network.msgHandler != nil {
 		se.network.msgHandler.HandleMessage(message)
@@ -8,6 +8,7 @@ import (
 	"github.com/thetatoken/theta/ledger/state"
 	"github.com/thetatoken/theta/ledger/types"
 	"github.com/thetatoken/theta/ledger/vm"
+	"github.com/thetatoken/theta/ledger/vm/abi"
 )
 
 // ------------------------------- CallSmartContract -----------------------------------
@@ -21,6 +22,8 @@ type CallSmartContractResult struct {
 	ContractAddress common.Address    `json:"contract_address"`
 	GasUsed         common.JSONUint64 `json:"gas_used"`
 	VmError         string            `json:"vm_error"`
+	Logs            []*types.Log      `json:"logs"`
+	DecodedReturn   []abi.Value       `json:"decoded_return,omitempty"` // only available for the verified contracts
 }
 
 // CallSmartContract calls the smart contract. However, calling a smart contract does NOT modify
@@ -34,8 +37,9 @@ func (t *ThetaRPCService) CallSmartContract(args *CallSmartContractArgs, result
 	}
 
 	blockHeight := ledgerState.Height() + 1 // the view points to the parent of the current block
-	if blockHeight < common.HeightEnableSmartContract {
-		return fmt.Errorf("Smart contract feature not enabled until block height %v.", common.HeightEnableSmartContract)
+	forks := common.GetForkSchedule(t.chain.ChainID)
+	if blockHeight < forks.EnableSmartContract {
+		return fmt.Errorf("Smart contract feature not enabled until block height %v.", forks.EnableSmartContract)
 	}
 
 	sctxBytes, err := hex.DecodeString(args.SctxBytes)
@@ -53,14 +57,19 @@ func (t *ThetaRPCService) CallSmartContract(args *CallSmartContractArgs, result
 	}
 
 	parentBlock := t.ledger.State().ParentBlock()
+	ledgerState.ResetLogs()
 	vmRet, contractAddr, gasUsed, vmErr := vm.Execute(parentBlock, sctx, ledgerState)
 	ledgerState.Save()
+	logs := ledgerState.PopLogs()
 
 	result.VmReturn = hex.EncodeToString(vmRet)
 	result.ContractAddress = contractAddr
 	result.GasUsed = common.JSONUint64(gasUsed)
 	if vmErr != nil {
 		result.VmError = vmErr.Error()
+	} else { // the events of a reverted call are discarded, same as the transaction receipts
+		result.Logs = logs
+		result.DecodedReturn = t.decodeCallResult(sctx, vmRet)
 	}
 
 	return nil
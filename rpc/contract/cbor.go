@@ -0,0 +1,112 @@
+package contract
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// CBOR major types
+const (
+	cborUint   = 0
+	cborBytes  = 2
+	cborText   = 3
+	cborMap    = 5
+	cborSimple = 7
+)
+
+var errInvalidCBOR = errors.New("invalid CBOR encoding")
+
+// decodeCBORMap decodes a CBOR map with text keys, which is the format of the
+// metadata trailer of the contract code. Only the value types the compilers
+// emit are supported, i.e. unsigned integers, byte strings, text strings and
+// booleans.
+func decodeCBORMap(data []byte) (map[string]interface{}, error) {
+	major, count, rest, err := decodeCBORHead(data)
+	if err != nil {
+		return nil, err
+	}
+	if major != cborMap || count > uint64(len(rest)) {
+		return nil, errInvalidCBOR
+	}
+
+	fields := make(map[string]interface{})
+	for i := uint64(0); i < count; i++ {
+		var key, value interface{}
+		if key, rest, err = decodeCBORItem(rest); err != nil {
+			return nil, err
+		}
+		keyStr, ok := key.(string)
+		if !ok {
+			return nil, errInvalidCBOR
+		}
+		if value, rest, err = decodeCBORItem(rest); err != nil {
+			return nil, err
+		}
+		fields[keyStr] = value
+	}
+	if len(rest) != 0 {
+		return nil, errInvalidCBOR
+	}
+	return fields, nil
+}
+
+func decodeCBORItem(data []byte) (interface{}, []byte, error) {
+	major, arg, rest, err := decodeCBORHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch major {
+	case cborUint:
+		return arg, rest, nil
+	case cborBytes, cborText:
+		if arg > uint64(len(rest)) {
+			return nil, nil, errInvalidCBOR
+		}
+		content := append([]byte{}, rest[:arg]...)
+		if major == cborText {
+			return string(content), rest[arg:], nil
+		}
+		return content, rest[arg:], nil
+	case cborSimple:
+		switch arg {
+		case 20:
+			return false, rest, nil
+		case 21:
+			return true, rest, nil
+		}
+	}
+	return nil, nil, errInvalidCBOR
+}
+
+// decodeCBORHead decodes the major type and the argument of a data item.
+func decodeCBORHead(data []byte) (byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, nil, errInvalidCBOR
+	}
+	major := data[0] >> 5
+	info := data[0] & 0x1f
+	data = data[1:]
+
+	var size int
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info == 24:
+		size = 1
+	case info == 25:
+		size = 2
+	case info == 26:
+		size = 4
+	case info == 27:
+		size = 8
+	default:
+		return 0, 0, nil, errInvalidCBOR
+	}
+	if len(data) < size {
+		return 0, 0, nil, errInvalidCBOR
+	}
+
+	var buf [8]byte
+	copy(buf[8-size:], data[:size])
+	return major, binary.BigEndian.Uint64(buf[:]), data[size:], nil
+}
@@ -0,0 +1,114 @@
+package contract
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+const (
+	// maxIPFSChunkSize is the largest content IPFS stores in a single block
+	// with the default chunker, larger metadata would be split into a DAG.
+	maxIPFSChunkSize = 256 * 1024
+
+	multihashSha256 = 0x12
+	sha256Length    = 32
+
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+)
+
+var (
+	ErrNoMetadataHash      = errors.New("no metadata hash found in the contract code")
+	ErrUnsupportedMetadata = errors.New("only the IPFS metadata hash is supported")
+)
+
+// IPFSHash returns the sha256 multihash of the content as added to IPFS with
+// the default settings, which is the metadata hash the Solidity compiler
+// embeds into the contract code. The content is wrapped into a UnixFS file
+// node and then into a dag-pb node before hashing.
+func IPFSHash(content []byte) ([]byte, error) {
+	if len(content) > maxIPFSChunkSize {
+		return nil, fmt.Errorf("content larger than %v bytes is not supported", maxIPFSChunkSize)
+	}
+
+	// UnixFS Data{Type: File, Data: content, filesize: len(content)}
+	var unixfs []byte
+	unixfs = append(unixfs, 0x08, 0x02)
+	unixfs = append(unixfs, 0x12)
+	unixfs = appendUvarint(unixfs, uint64(len(content)))
+	unixfs = append(unixfs, content...)
+	unixfs = append(unixfs, 0x18)
+	unixfs = appendUvarint(unixfs, uint64(len(content)))
+
+	// PBNode{Data: unixfs}
+	var node []byte
+	node = append(node, 0x0a)
+	node = appendUvarint(node, uint64(len(unixfs)))
+	node = append(node, unixfs...)
+
+	digest := sha256.Sum256(node)
+	return append([]byte{multihashSha256, sha256Length}, digest[:]...), nil
+}
+
+func appendUvarint(buf []byte, x uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], x)
+	return append(buf, tmp[:n]...)
+}
+
+// ExtractMetadataHash returns the IPFS multihash of the metadata from the
+// CBOR encoded trailer the Solidity compiler appends to the runtime code.
+// The last two bytes of the code are the length of the trailer.
+func ExtractMetadataHash(code []byte) ([]byte, error) {
+	if len(code) < 2 {
+		return nil, ErrNoMetadataHash
+	}
+	length := int(binary.BigEndian.Uint16(code[len(code)-2:]))
+	if length == 0 || length+2 > len(code) {
+		return nil, ErrNoMetadataHash
+	}
+	fields, err := decodeCBORMap(code[len(code)-2-length : len(code)-2])
+	if err != nil {
+		return nil, ErrNoMetadataHash
+	}
+
+	hash, ok := fields["ipfs"].([]byte)
+	if !ok {
+		if _, ok := fields["bzzr0"]; ok {
+			return nil, ErrUnsupportedMetadata
+		}
+		if _, ok := fields["bzzr1"]; ok {
+			return nil, ErrUnsupportedMetadata
+		}
+		return nil, ErrNoMetadataHash
+	}
+	if len(hash) != 2+sha256Length || hash[0] != multihashSha256 || hash[1] != sha256Length {
+		return nil, fmt.Errorf("invalid IPFS multihash: %x", hash)
+	}
+	return hash, nil
+}
+
+// EncodeCIDv0 returns the base58 form of the multihash, e.g. "Qm...".
+func EncodeCIDv0(multihash []byte) string {
+	num := new(big.Int).SetBytes(multihash)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	var encoded []byte
+	for num.Sign() > 0 {
+		num.DivMod(num, base, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range multihash {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+	return string(encoded)
+}
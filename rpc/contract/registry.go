@@ -0,0 +1,186 @@
+package contract
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger/vm/abi"
+	"github.com/thetatoken/theta/store"
+)
+
+// maxSourcesSize limits the total size of the sources submitted for a contract.
+const maxSourcesSize = 16 * 1024 * 1024
+
+var (
+	ErrMetadataMismatch = errors.New("metadata does not match the metadata hash in the contract code")
+	ErrNotVerified      = errors.New("contract is not verified")
+)
+
+// Source is a source file of a verified contract.
+type Source struct {
+	Path    string
+	Content string
+}
+
+// Metadata is the registry entry of a verified contract.
+type Metadata struct {
+	Address         common.Address
+	MetadataHash    string // CIDv0 of the metadata on IPFS
+	ContractName    string
+	CompilerVersion string
+	Language        string
+	ABI             string   // JSON ABI
+	Metadata        string   // the Solidity metadata JSON as emitted by the compiler
+	Sources         []Source // the sources with matching hashes, sorted by path
+	SourcesComplete bool     // true if all the sources of the compilation are present
+}
+
+// solidityMetadata is the subset of the Solidity metadata JSON the registry uses.
+type solidityMetadata struct {
+	Language string `json:"language"`
+	Compiler struct {
+		Version string `json:"version"`
+	} `json:"compiler"`
+	Settings struct {
+		CompilationTarget map[string]string `json:"compilationTarget"`
+	} `json:"settings"`
+	Sources map[string]struct {
+		Keccak256 string  `json:"keccak256"`
+		Content   *string `json:"content"`
+	} `json:"sources"`
+	Output struct {
+		ABI json.RawMessage `json:"abi"`
+	} `json:"output"`
+}
+
+// Registry stores the metadata of the verified contracts. A contract is
+// verified by submitting the metadata JSON the compiler emitted for it, whose
+// IPFS hash must match the hash the compiler embedded into the contract code.
+// The submitted sources must match the keccak256 hashes in the metadata.
+type Registry struct {
+	store store.Store
+}
+
+// NewRegistry creates a new instance of Registry.
+func NewRegistry(store store.Store) *Registry {
+	return &Registry{store: store}
+}
+
+func metadataKey(address common.Address) common.Bytes {
+	return append(common.Bytes("cmd/"), address[:]...)
+}
+
+// Verify verifies the metadata and the sources against the deployed code of
+// the contract, and stores them into the registry on success. Verifying a
+// contract again replaces its registry entry, e.g. to add missing sources.
+func (r *Registry) Verify(address common.Address, code []byte, metadataJSON string,
+	sources map[string]string) (*Metadata, error) {
+	if len(code) == 0 {
+		return nil, fmt.Errorf("no contract code at address %v", address.Hex())
+	}
+	expectedHash, err := ExtractMetadataHash(code)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := IPFSHash([]byte(metadataJSON))
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(hash, expectedHash) {
+		return nil, ErrMetadataMismatch
+	}
+
+	var md solidityMetadata
+	if err := json.Unmarshal([]byte(metadataJSON), &md); err != nil {
+		return nil, fmt.Errorf("failed to parse metadata: %v", err)
+	}
+	if _, err := abi.Parse(md.Output.ABI); err != nil {
+		return nil, fmt.Errorf("failed to parse ABI: %v", err)
+	}
+
+	verifiedSources, complete, err := verifySources(md, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &Metadata{
+		Address:         address,
+		MetadataHash:    EncodeCIDv0(hash),
+		CompilerVersion: md.Compiler.Version,
+		Language:        md.Language,
+		ABI:             string(md.Output.ABI),
+		Metadata:        metadataJSON,
+		Sources:         verifiedSources,
+		SourcesComplete: complete,
+	}
+	for _, name := range md.Settings.CompilationTarget {
+		entry.ContractName = name
+	}
+
+	if err := r.store.Put(metadataKey(address), entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func verifySources(md solidityMetadata, sources map[string]string) ([]Source, bool, error) {
+	totalSize := 0
+	for path, content := range sources {
+		totalSize += len(content)
+		if totalSize > maxSourcesSize {
+			return nil, false, fmt.Errorf("sources larger than %v bytes", maxSourcesSize)
+		}
+		if _, ok := md.Sources[path]; !ok {
+			return nil, false, fmt.Errorf("source %v is not part of the compilation", path)
+		}
+	}
+
+	verified := []Source{}
+	for path, source := range md.Sources {
+		var content string
+		if source.Content != nil {
+			content = *source.Content
+		} else if submitted, ok := sources[path]; ok {
+			content = submitted
+		} else {
+			continue
+		}
+
+		hash := crypto.Keccak256Hash([]byte(content))
+		if !strings.EqualFold(hash.Hex(), source.Keccak256) {
+			return nil, false, fmt.Errorf("source %v does not match its keccak256 hash in the metadata", path)
+		}
+		verified = append(verified, Source{Path: path, Content: content})
+	}
+	sort.Slice(verified, func(i, j int) bool { return verified[i].Path < verified[j].Path })
+
+	return verified, len(verified) == len(md.Sources), nil
+}
+
+// Get returns the registry entry of the contract.
+func (r *Registry) Get(address common.Address) (*Metadata, error) {
+	entry := &Metadata{}
+	err := r.store.Get(metadataKey(address), entry)
+	if err == store.ErrKeyNotFound {
+		return nil, ErrNotVerified
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// GetABI returns the parsed ABI of the contract.
+func (r *Registry) GetABI(address common.Address) (*abi.ABI, error) {
+	entry, err := r.Get(address)
+	if err != nil {
+		return nil, err
+	}
+	return abi.Parse([]byte(entry.ABI))
+}
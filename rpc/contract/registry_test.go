@@ -0,0 +1,130 @@
+package contract
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/store/database/backend"
+	"github.com/thetatoken/theta/store/kvstore"
+)
+
+const (
+	testSourcePath = "contracts/Counter.sol"
+	testSource     = "pragma solidity ^0.8.0; contract Counter { uint public count; event Incremented(address indexed by, uint count); function inc() public { count++; emit Incremented(msg.sender, count); } }"
+	testABI        = `[{"anonymous":false,"inputs":[{"indexed":true,"name":"by","type":"address"},{"indexed":false,"name":"count","type":"uint256"}],"name":"Incremented","type":"event"},{"inputs":[],"name":"count","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},{"inputs":[],"name":"inc","outputs":[],"stateMutability":"nonpayable","type":"function"}]`
+)
+
+func testMetadata() string {
+	md := map[string]interface{}{
+		"compiler": map[string]interface{}{"version": "0.8.19+commit.7dd6d404"},
+		"language": "Solidity",
+		"output":   map[string]interface{}{"abi": json.RawMessage(testABI)},
+		"settings": map[string]interface{}{
+			"compilationTarget": map[string]string{testSourcePath: "Counter"},
+		},
+		"sources": map[string]interface{}{
+			testSourcePath: map[string]interface{}{
+				"keccak256": crypto.Keccak256Hash([]byte(testSource)).Hex(),
+			},
+		},
+		"version": 1,
+	}
+	bytes, _ := json.Marshal(md)
+	return string(bytes)
+}
+
+// testCode returns the contract code with the CBOR trailer the compiler appends,
+// i.e. {"ipfs": <multihash>, "solc": 0x000813}.
+func testCode(metadataHash []byte) []byte {
+	trailer := []byte{0xa2, 0x64, 'i', 'p', 'f', 's', 0x58, byte(len(metadataHash))}
+	trailer = append(trailer, metadataHash...)
+	trailer = append(trailer, 0x64, 's', 'o', 'l', 'c', 0x43, 0x00, 0x08, 0x13)
+
+	code := []byte{0x60, 0x80, 0x60, 0x40, 0x52, 0xfe}
+	code = append(code, trailer...)
+	length := make([]byte, 2)
+	binary.BigEndian.PutUint16(length, uint16(len(trailer)))
+	return append(code, length...)
+}
+
+func TestIPFSHash(t *testing.T) {
+	assert := assert.New(t)
+
+	hash, err := IPFSHash([]byte("hello world\n"))
+	assert.Nil(err)
+	assert.Equal("QmT78zSuBmuS4z925WZfrqQ1qHaJ56DQaTfyMUF7F8ff5o", EncodeCIDv0(hash))
+
+	_, err = IPFSHash(make([]byte, maxIPFSChunkSize+1))
+	assert.NotNil(err)
+}
+
+func TestExtractMetadataHash(t *testing.T) {
+	assert := assert.New(t)
+
+	expected, _ := IPFSHash([]byte("metadata"))
+	hash, err := ExtractMetadataHash(testCode(expected))
+	assert.Nil(err)
+	assert.Equal(expected, hash)
+
+	_, err = ExtractMetadataHash([]byte{0x60, 0x80, 0x60, 0x40})
+	assert.Equal(ErrNoMetadataHash, err)
+	_, err = ExtractMetadataHash(nil)
+	assert.Equal(ErrNoMetadataHash, err)
+
+	// Swarm hashes of the older compilers
+	bzzr := []byte{0xa1, 0x65, 'b', 'z', 'z', 'r', '0', 0x58, 0x20}
+	bzzr = append(bzzr, make([]byte, 32)...)
+	bzzr = append(bzzr, 0x00, byte(len(bzzr)))
+	_, err = ExtractMetadataHash(bzzr)
+	assert.Equal(ErrUnsupportedMetadata, err)
+}
+
+func TestRegistryVerify(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewRegistry(kvstore.NewKVStore(backend.NewMemDatabase()))
+	address := common.HexToAddress("0x5e1b5d4c24d5ae4ac6b6cbee2ef4fc2c1ad3c8f0")
+	metadata := testMetadata()
+	hash, _ := IPFSHash([]byte(metadata))
+	code := testCode(hash)
+
+	_, err := registry.Get(address)
+	assert.Equal(ErrNotVerified, err)
+
+	// The metadata must match the hash in the code byte for byte
+	_, err = registry.Verify(address, code, metadata+" ", nil)
+	assert.Equal(ErrMetadataMismatch, err)
+
+	// The sources must match the hashes in the metadata
+	_, err = registry.Verify(address, code, metadata, map[string]string{testSourcePath: testSource + " "})
+	assert.NotNil(err)
+	_, err = registry.Verify(address, code, metadata, map[string]string{"contracts/Other.sol": testSource})
+	assert.NotNil(err)
+
+	entry, err := registry.Verify(address, code, metadata, nil)
+	assert.Nil(err)
+	assert.False(entry.SourcesComplete)
+	assert.Equal(0, len(entry.Sources))
+
+	entry, err = registry.Verify(address, code, metadata, map[string]string{testSourcePath: testSource})
+	assert.Nil(err)
+	assert.True(entry.SourcesComplete)
+
+	entry, err = registry.Get(address)
+	assert.Nil(err)
+	assert.Equal(address, entry.Address)
+	assert.Equal(EncodeCIDv0(hash), entry.MetadataHash)
+	assert.Equal("Counter", entry.ContractName)
+	assert.Equal("0.8.19+commit.7dd6d404", entry.CompilerVersion)
+	assert.Equal(testABI, entry.ABI)
+	assert.Equal([]Source{{Path: testSourcePath, Content: testSource}}, entry.Sources)
+
+	contractABI, err := registry.GetABI(address)
+	assert.Nil(err)
+	assert.Equal(2, len(contractABI.Methods))
+	assert.Equal(1, len(contractABI.Events))
+}
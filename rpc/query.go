@@ -162,6 +162,7 @@ const (
 	TxStatusPending   = "pending"
 	TxStatusFinalized = "finalized"
 	TxStatusAbandoned = "abandoned"
+	TxStatusReplaced  = "replaced"
 )
 
 func (t *ThetaRPCService) GetTransaction(args *GetTransactionArgs, result *GetTransactionResult) (err error) {
@@ -174,9 +175,12 @@ func (t *ThetaRPCService) GetTransaction(args *GetTransactionArgs, result *GetTr
 	if !found {
 		txStatus, exists := t.mempool.GetTransactionStatus(args.Hash)
 		if exists {
-			if txStatus == mempool.TxStatusAbandoned {
+			switch txStatus {
+			case mempool.TxStatusAbandoned:
 				result.Status = TxStatusAbandoned
-			} else {
+			case mempool.TxStatusReplaced:
+				result.Status = TxStatusReplaced
+			default:
 				result.Status = TxStatusPending
 			}
 		} else {
@@ -294,6 +298,11 @@ const (
 	TxTypeWithdrawStake
 	TxTypeDepositStakeTxV2
 	TxTypeStakeRewardDistributionTx
+	TxTypeWithdrawStakeTxV2
+	TxTypeRedelegateStakeTx
+	TxTypeGovernanceProposalTx
+	TxTypeGovernanceVoteTx
+	TxTypeTimeLockTx
 )
 
 func (t *ThetaRPCService) GetBlock(args *GetBlockArgs, result *GetBlockResult) (err error) {
@@ -823,6 +832,109 @@ func (t *ThetaRPCService) GetStakeRewardDistributionByHeight(
 	return nil
 }
 
+// ------------------------------ GetRewards -----------------------------------
+
+type GetRewardsArgs struct {
+	Address    string            `json:"address"`
+	FromHeight common.JSONUint64 `json:"from_height"`
+	ToHeight   common.JSONUint64 `json:"to_height"` // the last finalized height if not specified
+}
+
+type GetRewardsResult struct {
+	Rewards []BlockRewards `json:"rewards"`
+}
+
+type BlockRewards struct {
+	Height    common.JSONUint64 `json:"height"`
+	BlockHash common.Hash       `json:"block_hash"`
+	Records   []RewardRecord    `json:"records"`
+}
+
+type RewardRecord struct {
+	Type        string          `json:"type"`
+	Staker      common.Address  `json:"staker"`
+	StakeHolder common.Address  `json:"stake_holder"` // empty if the reward was calculated over all the stakes of the staker
+	Amount      *common.JSONBig `json:"amount"`
+	Beneficiary common.Address  `json:"beneficiary"`
+	SplitAmount *common.JSONBig `json:"split_amount"`
+}
+
+// GetRewards returns the TFuel rewards received by the address, either as a staker or as the
+// beneficiary of a reward split, in the finalized blocks between the given heights.
+func (t *ThetaRPCService) GetRewards(args *GetRewardsArgs, result *GetRewardsResult) (err error) {
+	if !viper.GetBool(common.CfgStorageRewardRecordsEnabled) {
+		return fmt.Errorf("Reward records are disabled, set %v to enable them", common.CfgStorageRewardRecordsEnabled)
+	}
+	if args.Address == "" {
+		return errors.New("Address must be specified")
+	}
+	address := common.HexToAddress(args.Address)
+
+	fromHeight := uint64(args.FromHeight)
+	toHeight := uint64(args.ToHeight)
+	lastFinalizedHeight := t.consensus.GetLastFinalizedBlock().Height
+	if toHeight == 0 || toHeight > lastFinalizedHeight {
+		toHeight = lastFinalizedHeight
+	}
+	if fromHeight > toHeight {
+		return errors.New("From height must not be greater than to height")
+	}
+
+	maxRewardsBlockRange := uint64(100000)
+	if toHeight-fromHeight > maxRewardsBlockRange {
+		return fmt.Errorf("Can't retrieve the rewards of more than %v blocks at a time", maxRewardsBlockRange)
+	}
+
+	result.Rewards = []BlockRewards{}
+
+	// Rewards are only granted at the checkpoint heights
+	height := fromHeight
+	for height <= toHeight && !common.IsCheckPointHeight(height) {
+		height++
+	}
+	for ; height <= toHeight; height += uint64(common.CheckpointInterval) {
+		var block *core.ExtendedBlock
+		for _, b := range t.chain.FindBlocksByHeight(height) {
+			if b.Status.IsFinalized() {
+				block = b
+				break
+			}
+		}
+		if block == nil {
+			continue
+		}
+
+		records, found := t.chain.FindRewardRecords(block)
+		if !found {
+			continue
+		}
+
+		blockRewards := BlockRewards{
+			Height:    common.JSONUint64(height),
+			BlockHash: block.Hash(),
+			Records:   []RewardRecord{},
+		}
+		for _, record := range records {
+			if record.Staker != address && record.Beneficiary != address {
+				continue
+			}
+			blockRewards.Records = append(blockRewards.Records, RewardRecord{
+				Type:        record.Type.String(),
+				Staker:      record.Staker,
+				StakeHolder: record.StakeHolder,
+				Amount:      (*common.JSONBig)(record.Amount),
+				Beneficiary: record.Beneficiary,
+				SplitAmount: (*common.JSONBig)(record.SplitAmount),
+			})
+		}
+		if len(blockRewards.Records) > 0 {
+			result.Rewards = append(result.Rewards, blockRewards)
+		}
+	}
+
+	return nil
+}
+
 // ------------------------------ GetEliteEdgeNodeStakeReturnsByHeight -----------------------------------
 
 type GetEliteEdgeNodeStakeReturnsByHeightArgs struct {
@@ -891,6 +1003,107 @@ func (t *ThetaRPCService) GetAllPendingEliteEdgeNodeStakeReturns(
 	return nil
 }
 
+// ------------------------------ GetGovernanceParams -----------------------------------
+
+type GetGovernanceParamsArgs struct {
+}
+
+type GetGovernanceParamsResult struct {
+	Params            map[string]*big.Int `json:"params"` // the parameters set by the governance
+	ActiveProposalIDs []common.JSONUint64 `json:"active_proposal_ids"`
+}
+
+func (t *ThetaRPCService) GetGovernanceParams(args *GetGovernanceParamsArgs, result *GetGovernanceParamsResult) (err error) {
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return err
+	}
+
+	gov := state.NewGovernance(deliveredView)
+	result.Params = gov.GetAllParams()
+	result.ActiveProposalIDs = []common.JSONUint64{}
+	for _, id := range gov.GetActiveProposalIDs() {
+		result.ActiveProposalIDs = append(result.ActiveProposalIDs, common.JSONUint64(id))
+	}
+
+	return nil
+}
+
+// ------------------------------ GetMinimumTransactionFee -----------------------------------
+
+type GetMinimumTransactionFeeArgs struct {
+}
+
+type GetMinimumTransactionFeeResult struct {
+	MinimumFee *common.JSONBig `json:"minimum_fee"` // the minimum fee of a regular transaction in TFuelWei
+}
+
+// GetMinimumTransactionFee returns the minimum fee of a regular transaction in the next block,
+// which is the one set by the governance if any, or else the protocol fee of the chain.
+func (t *ThetaRPCService) GetMinimumTransactionFee(args *GetMinimumTransactionFeeArgs, result *GetMinimumTransactionFeeResult) (err error) {
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return err
+	}
+
+	chainID := t.consensus.Chain().ChainID
+	blockHeight := deliveredView.Height() + 1
+	minimumFee := state.NewGovernance(deliveredView).GetParamOrDefault(core.ParamMinTxFee,
+		types.GetMinimumTransactionFeeTFuelWei(chainID, blockHeight))
+	result.MinimumFee = (*common.JSONBig)(minimumFee)
+
+	return nil
+}
+
+// ------------------------------ GetGovernanceProposal -----------------------------------
+
+type GetGovernanceProposalArgs struct {
+	ID common.JSONUint64 `json:"id"`
+}
+
+type GetGovernanceProposalResult struct {
+	Proposal *core.GovernanceProposal `json:"proposal"`
+}
+
+func (t *ThetaRPCService) GetGovernanceProposal(args *GetGovernanceProposalArgs, result *GetGovernanceProposalResult) (err error) {
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return err
+	}
+
+	proposal := state.NewGovernance(deliveredView).GetProposal(uint64(args.ID))
+	if proposal == nil {
+		return fmt.Errorf("governance proposal %v not found", uint64(args.ID))
+	}
+	result.Proposal = proposal
+
+	return nil
+}
+
+// ------------------------------ GetTimeLocks -----------------------------------
+
+type GetTimeLocksArgs struct {
+	Address string `json:"address"`
+}
+
+type GetTimeLocksResult struct {
+	TimeLocks []*types.TimeLock `json:"time_locks"` // the pending time locks of the address, as the source or the beneficiary
+}
+
+func (t *ThetaRPCService) GetTimeLocks(args *GetTimeLocksArgs, result *GetTimeLocksResult) (err error) {
+	if args.Address == "" {
+		return errors.New("Address must be specified")
+	}
+	deliveredView, err := t.ledger.GetDeliveredSnapshot()
+	if err != nil {
+		return err
+	}
+
+	result.TimeLocks = state.NewTimeLocks(deliveredView).GetByAddress(common.HexToAddress(args.Address))
+
+	return nil
+}
+
 // ------------------------------- GetCode -----------------------------------
 
 type GetCodeArgs struct {
@@ -1078,6 +1291,16 @@ func getTxType(tx types.Tx) byte {
 		t = TxTypeDepositStakeTxV2
 	case *types.StakeRewardDistributionTx:
 		t = TxTypeStakeRewardDistributionTx
+	case *types.WithdrawStakeTxV2:
+		t = TxTypeWithdrawStakeTxV2
+	case *types.RedelegateStakeTx:
+		t = TxTypeRedelegateStakeTx
+	case *types.GovernanceProposalTx:
+		t = TxTypeGovernanceProposalTx
+	case *types.GovernanceVoteTx:
+		t = TxTypeGovernanceVoteTx
+	case *types.TimeLockTx:
+		t = TxTypeTimeLockTx
 	}
 
 	return t
@@ -0,0 +1,180 @@
+package rpc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/ledger/vm/abi"
+	"github.com/thetatoken/theta/rpc/contract"
+)
+
+// ------------------------------- VerifyContract -----------------------------------
+
+type VerifyContractArgs struct {
+	Address  string            `json:"address"`
+	Metadata string            `json:"metadata"` // the metadata JSON emitted by the Solidity compiler
+	Sources  map[string]string `json:"sources"`  // source path -> source content
+}
+
+type GetContractMetadataResult struct {
+	Address         common.Address    `json:"address"`
+	MetadataHash    string            `json:"metadata_hash"`
+	ContractName    string            `json:"contract_name"`
+	CompilerVersion string            `json:"compiler_version"`
+	Language        string            `json:"language"`
+	ABI             string            `json:"abi"`
+	Metadata        string            `json:"metadata"`
+	Sources         map[string]string `json:"sources"`
+	SourcesComplete bool              `json:"sources_complete"`
+}
+
+// VerifyContract verifies the compiler metadata and the sources of a deployed contract against
+// the metadata hash embedded in its code, and adds them to the contract registry of the node.
+func (t *ThetaRPCService) VerifyContract(args *VerifyContractArgs, result *GetContractMetadataResult) (err error) {
+	if args.Address == "" {
+		return errors.New("address must be specified")
+	}
+	if args.Metadata == "" {
+		return errors.New("metadata must be specified")
+	}
+	address := common.HexToAddress(args.Address)
+
+	ledgerState, err := t.ledger.GetFinalizedSnapshot()
+	if err != nil {
+		return err
+	}
+	code := ledgerState.GetCode(address)
+
+	entry, err := t.contracts.Verify(address, code, args.Metadata, args.Sources)
+	if err != nil {
+		return err
+	}
+	setContractMetadataResult(entry, result)
+
+	return nil
+}
+
+// ------------------------------- GetContractMetadata -----------------------------------
+
+type GetContractMetadataArgs struct {
+	Address string `json:"address"`
+}
+
+// GetContractMetadata returns the ABI, the metadata and the sources of a verified contract.
+func (t *ThetaRPCService) GetContractMetadata(args *GetContractMetadataArgs, result *GetContractMetadataResult) (err error) {
+	if args.Address == "" {
+		return errors.New("address must be specified")
+	}
+	entry, err := t.contracts.Get(common.HexToAddress(args.Address))
+	if err != nil {
+		return err
+	}
+	setContractMetadataResult(entry, result)
+
+	return nil
+}
+
+func setContractMetadataResult(entry *contract.Metadata, result *GetContractMetadataResult) {
+	result.Address = entry.Address
+	result.MetadataHash = entry.MetadataHash
+	result.ContractName = entry.ContractName
+	result.CompilerVersion = entry.CompilerVersion
+	result.Language = entry.Language
+	result.ABI = entry.ABI
+	result.Metadata = entry.Metadata
+	result.Sources = make(map[string]string)
+	for _, source := range entry.Sources {
+		result.Sources[source.Path] = source.Content
+	}
+	result.SourcesComplete = entry.SourcesComplete
+}
+
+// ------------------------------- DecodeTransaction -----------------------------------
+
+type DecodeTransactionArgs struct {
+	Hash string `json:"hash"`
+}
+
+type DecodeTransactionResult struct {
+	TxHash          common.Hash       `json:"hash"`
+	Status          TxStatus          `json:"status"`
+	ContractAddress common.Address    `json:"contract_address"`
+	Function        string            `json:"function,omitempty"`
+	Inputs          []abi.Value       `json:"inputs,omitempty"`
+	Outputs         []abi.Value       `json:"outputs,omitempty"`
+	Logs            []*abi.DecodedLog `json:"logs"`
+}
+
+// DecodeTransaction decodes the call data, the return value and the logs of a smart contract
+// transaction against the ABIs of the verified contracts. The parts involving the contracts
+// not in the registry are left undecoded.
+func (t *ThetaRPCService) DecodeTransaction(args *DecodeTransactionArgs, result *DecodeTransactionResult) (err error) {
+	txResult := &GetTransactionResult{}
+	if err = t.GetTransaction(&GetTransactionArgs{Hash: args.Hash}, txResult); err != nil {
+		return err
+	}
+	result.Status = txResult.Status
+	if txResult.Tx == nil {
+		return fmt.Errorf("transaction %v is %v", args.Hash, txResult.Status)
+	}
+	sctx, ok := txResult.Tx.(*types.SmartContractTx)
+	if !ok {
+		return fmt.Errorf("transaction %v is not a smart contract transaction", args.Hash)
+	}
+	result.TxHash = txResult.TxHash
+	result.Logs = []*abi.DecodedLog{}
+
+	receipt := txResult.Receipt
+	result.ContractAddress = sctx.To.Address
+	if (result.ContractAddress == common.Address{}) && receipt != nil {
+		// The constructor arguments of a deployment can not be separated from the init code
+		result.ContractAddress = receipt.ContractAddress
+	} else if contractABI, err := t.contracts.GetABI(sctx.To.Address); err == nil {
+		if method, ok := contractABI.MethodByID(sctx.Data); ok {
+			result.Function = method.Sig()
+			if result.Inputs, err = method.Inputs.UnpackValues(sctx.Data[4:]); err != nil {
+				return fmt.Errorf("failed to decode the inputs of %v: %v", method.Sig(), err)
+			}
+			if receipt != nil && receipt.EvmErr == "" {
+				if result.Outputs, err = method.Outputs.UnpackValues(receipt.EvmRet); err != nil {
+					return fmt.Errorf("failed to decode the outputs of %v: %v", method.Sig(), err)
+				}
+			}
+		}
+	}
+
+	if receipt == nil {
+		return nil
+	}
+	abis := make(map[common.Address]*abi.ABI)
+	for _, log := range receipt.Logs {
+		contractABI, cached := abis[log.Address]
+		if !cached {
+			contractABI, _ = t.contracts.GetABI(log.Address)
+			abis[log.Address] = contractABI
+		}
+		result.Logs = append(result.Logs, abi.DecodeLog(contractABI, log))
+	}
+
+	return nil
+}
+
+// decodeCallResult decodes the return value of a call to a verified contract, it returns nil
+// if the contract is not verified or the called function is unknown.
+func (t *ThetaRPCService) decodeCallResult(sctx *types.SmartContractTx, vmRet []byte) []abi.Value {
+	contractABI, err := t.contracts.GetABI(sctx.To.Address)
+	if err != nil {
+		return nil
+	}
+	method, ok := contractABI.MethodByID(sctx.Data)
+	if !ok {
+		return nil
+	}
+	values, err := method.Outputs.UnpackValues(vmRet)
+	if err != nil {
+		return nil
+	}
+	return values
+}
@@ -0,0 +1,46 @@
+package sequence
+
+import (
+	"math/big"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/ledger/types"
+)
+
+// Signer signs the transactions of the addresses, e.g. a wallet.
+type Signer interface {
+	Sign(address common.Address, txBytes common.Bytes) (*crypto.Signature, error)
+}
+
+// NewSendTxGapFiller returns a GapFiller which fills a sequence with a SendTx transferring
+// nothing to the zero address, which only costs the fee.
+func NewSendTxGapFiller(chainID string, signer Signer, fee *big.Int) GapFiller {
+	return func(address common.Address, sequence uint64) (common.Bytes, error) {
+		sendTx := &types.SendTx{
+			Fee: types.Coins{
+				ThetaWei: big.NewInt(0),
+				TFuelWei: fee,
+			},
+			Inputs: []types.TxInput{{
+				Address: address,
+				Coins: types.Coins{
+					ThetaWei: big.NewInt(0),
+					TFuelWei: fee,
+				},
+				Sequence: sequence,
+			}},
+			Outputs: []types.TxOutput{{
+				Address: common.Address{},
+				Coins:   types.NewCoins(0, 0),
+			}},
+		}
+
+		sig, err := signer.Sign(address, sendTx.SignBytes(chainID))
+		if err != nil {
+			return nil, err
+		}
+		sendTx.SetSignature(address, sig)
+		return types.TxToBytes(sendTx)
+	}
+}
@@ -0,0 +1,562 @@
+package sequence
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/rpc"
+)
+
+var logger *log.Entry = log.WithFields(log.Fields{"prefix": "sequence"})
+
+const (
+	// DefaultSyncInterval is the default interval between the reconciliations of the
+	// in-flight transactions with the node.
+	DefaultSyncInterval = 5 * time.Second
+
+	// DefaultResubmitDelay is the default time a transaction can be missing from the mempool
+	// before it is considered dropped.
+	DefaultResubmitDelay = 30 * time.Second
+
+	// DefaultMaxResubmits is the default number of times a dropped transaction is resubmitted
+	// before its sequence is considered a gap.
+	DefaultMaxResubmits = 3
+)
+
+// SignTxFunc builds and signs the transaction with the given sequence, and returns the raw
+// transaction bytes.
+type SignTxFunc func(sequence uint64) (common.Bytes, error)
+
+// BroadcastFunc broadcasts the raw transaction bytes.
+type BroadcastFunc func(txBytes common.Bytes) error
+
+// GapFiller returns the raw bytes of a transaction which takes the sequence of a dropped
+// transaction, so that the transactions with the later sequences can be processed.
+type GapFiller func(address common.Address, sequence uint64) (common.Bytes, error)
+
+// EventHandler handles the events of the in-flight transactions.
+type EventHandler func(event Event)
+
+// Config is the configuration of the sequence manager.
+type Config struct {
+	SyncInterval  time.Duration
+	ResubmitDelay time.Duration
+	MaxResubmits  int
+
+	// GapFiller fills the sequences of the transactions dropped despite the resubmissions.
+	// If not set, the sequences are assigned to the next transactions sent instead.
+	GapFiller GapFiller
+
+	// EventHandler is notified of the events detected by the periodic reconciliation.
+	EventHandler EventHandler
+}
+
+// DefaultConfig returns the default configuration of the sequence manager.
+func DefaultConfig() Config {
+	return Config{
+		SyncInterval:  DefaultSyncInterval,
+		ResubmitDelay: DefaultResubmitDelay,
+		MaxResubmits:  DefaultMaxResubmits,
+	}
+}
+
+// EventType is the type of an event of an in-flight transaction.
+type EventType int
+
+const (
+	// EventFinalized means the transaction is finalized.
+	EventFinalized EventType = iota
+
+	// EventReplaced means another transaction with the same sequence is finalized.
+	EventReplaced
+
+	// EventResubmitted means the transaction was missing from the mempool and is resubmitted.
+	EventResubmitted
+
+	// EventDropped means the transaction was dropped and could not be resubmitted.
+	EventDropped
+
+	// EventGapFilled means the sequence of a dropped transaction is taken by a filler
+	// transaction.
+	EventGapFilled
+)
+
+func (t EventType) String() string {
+	switch t {
+	case EventFinalized:
+		return "finalized"
+	case EventReplaced:
+		return "replaced"
+	case EventResubmitted:
+		return "resubmitted"
+	case EventDropped:
+		return "dropped"
+	case EventGapFilled:
+		return "gap_filled"
+	}
+	return fmt.Sprintf("EventType(%d)", int(t))
+}
+
+// Event is an event of an in-flight transaction.
+type Event struct {
+	Type     EventType
+	Address  common.Address
+	Sequence uint64
+	TxHash   common.Hash
+}
+
+func (e Event) String() string {
+	return fmt.Sprintf("Event{%v, %v, %v, %v}", e.Type, e.Address.Hex(), e.Sequence, e.TxHash.Hex())
+}
+
+// PendingTx is an in-flight transaction tracked by the sequence manager.
+type PendingTx struct {
+	Address   common.Address
+	Sequence  uint64
+	TxHash    common.Hash
+	TxBytes   common.Bytes
+	SentAt    time.Time
+	Resubmits int
+	Filler    bool // whether it is a filler of the sequence of a dropped transaction
+}
+
+// account is the state of the sequences of an address.
+type account struct {
+	mutex *sync.Mutex
+
+	synced  bool
+	next    uint64                // the next sequence to assign
+	gaps    []uint64              // the sequences of the dropped transactions, to be assigned first
+	pending map[uint64]*PendingTx // the in-flight transactions by sequence
+
+	lastBroadcast chan struct{} // closed once the last reserved transaction is broadcasted
+}
+
+// Manager assigns the sequences of the transactions sent from the same addresses, so that
+// many transactions can be in flight at the same time. It tracks the in-flight transactions,
+// reconciles them with the node periodically, and resubmits the dropped transactions or
+// fills their sequences, which would otherwise block the transactions with later sequences.
+//
+// The manager assumes that it is the only sender from the addresses. Transactions sent
+// otherwise are detected when they cause a broadcast to fail or replace a tracked
+// transaction.
+type Manager struct {
+	node   Node
+	config Config
+
+	mutex    *sync.Mutex
+	accounts map[common.Address]*account
+
+	// Life cycle
+	wg     *sync.WaitGroup
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewManager creates a sequence manager.
+func NewManager(node Node, config Config) *Manager {
+	return &Manager{
+		node:     node,
+		config:   config,
+		mutex:    &sync.Mutex{},
+		accounts: make(map[common.Address]*account),
+		wg:       &sync.WaitGroup{},
+	}
+}
+
+// Send signs the transaction with the next sequence of the address and broadcasts it. The
+// transaction is broadcasted by broadcast if not nil, and asynchronously through the node
+// otherwise. The broadcasts from the same address are ordered by sequence, so that the
+// transactions reach the mempool in order, but do not block the other operations on the
+// address.
+func (m *Manager) Send(address common.Address, sign SignTxFunc, broadcast BroadcastFunc) (*PendingTx, error) {
+	if broadcast == nil {
+		broadcast = m.node.BroadcastRawTransaction
+	}
+
+	acc := m.getAccount(address)
+	acc.mutex.Lock()
+	tx, err := m.reserve(address, acc, sign)
+	if err != nil {
+		acc.mutex.Unlock()
+		return nil, err
+	}
+	acc.pending[tx.Sequence] = tx
+	prev := acc.lastBroadcast
+	done := make(chan struct{})
+	acc.lastBroadcast = done
+	acc.mutex.Unlock()
+
+	if prev != nil {
+		<-prev
+	}
+	err = broadcast(tx.TxBytes)
+	close(done)
+	if err == nil {
+		return tx, nil
+	}
+
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+	if acc.pending[tx.Sequence] == tx {
+		delete(acc.pending, tx.Sequence)
+		acc.addGap(tx.Sequence)
+	}
+	// The sequence may be out of sync with the node, e.g. due to transactions sent otherwise.
+	// Sync it again for the next transaction.
+	acc.synced = false
+	return nil, err
+}
+
+// Sign signs the transaction with the next sequence of the address without broadcasting it.
+// The sequence is reserved for the transaction, but the transaction is not tracked, so the
+// caller is responsible for broadcasting it.
+func (m *Manager) Sign(address common.Address, sign SignTxFunc) (*PendingTx, error) {
+	acc := m.getAccount(address)
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+
+	return m.reserve(address, acc, sign)
+}
+
+// reserve signs the transaction with the next sequence of the address and takes the sequence.
+// Should be called with the account locked.
+func (m *Manager) reserve(address common.Address, acc *account, sign SignTxFunc) (*PendingTx, error) {
+	if !acc.synced {
+		if err := m.resetAccount(address, acc); err != nil {
+			return nil, err
+		}
+	}
+
+	useGap := len(acc.gaps) > 0
+	sequence := acc.next
+	if useGap {
+		sequence = acc.gaps[0]
+	}
+
+	txBytes, err := sign(sequence)
+	if err != nil {
+		return nil, err
+	}
+
+	if useGap {
+		acc.gaps = acc.gaps[1:]
+	} else {
+		acc.next++
+	}
+	return &PendingTx{
+		Address:  address,
+		Sequence: sequence,
+		TxHash:   crypto.Keccak256Hash(txBytes),
+		TxBytes:  txBytes,
+		SentAt:   time.Now(),
+	}, nil
+}
+
+// Pending returns the in-flight transactions of the address, ordered by sequence.
+func (m *Manager) Pending(address common.Address) []*PendingTx {
+	acc := m.getAccount(address)
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+
+	txs := []*PendingTx{}
+	for _, sequence := range acc.sortedSequences() {
+		tx := *acc.pending[sequence]
+		txs = append(txs, &tx)
+	}
+	return txs
+}
+
+// Reset stops tracking the transactions of the address. The sequence is fetched from the
+// node again for the next transaction.
+func (m *Manager) Reset(address common.Address) {
+	acc := m.getAccount(address)
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+
+	acc.synced = false
+	acc.gaps = nil
+	acc.pending = make(map[uint64]*PendingTx)
+}
+
+// SyncAll reconciles the in-flight transactions of all the addresses with the node.
+func (m *Manager) SyncAll() ([]Event, error) {
+	pendingHashes, err := m.getPendingHashes()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mutex.Lock()
+	addresses := make([]common.Address, 0, len(m.accounts))
+	for address := range m.accounts {
+		addresses = append(addresses, address)
+	}
+	m.mutex.Unlock()
+
+	events := []Event{}
+	for _, address := range addresses {
+		accountEvents, err := m.sync(address, pendingHashes)
+		if err != nil {
+			logger.Warnf("Failed to sync the sequence of %v: %v", address.Hex(), err)
+			continue
+		}
+		events = append(events, accountEvents...)
+	}
+	return events, nil
+}
+
+// Sync reconciles the in-flight transactions of the address with the node.
+func (m *Manager) Sync(address common.Address) ([]Event, error) {
+	pendingHashes, err := m.getPendingHashes()
+	if err != nil {
+		return nil, err
+	}
+	return m.sync(address, pendingHashes)
+}
+
+func (m *Manager) sync(address common.Address, pendingHashes map[common.Hash]bool) ([]Event, error) {
+	acc := m.getAccount(address)
+	acc.mutex.Lock()
+	defer acc.mutex.Unlock()
+
+	if len(acc.pending) == 0 && len(acc.gaps) == 0 {
+		return []Event{}, nil
+	}
+
+	confirmed, err := m.node.GetSequence(address, false)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []Event{}
+	for _, sequence := range acc.sortedSequences() {
+		tx := acc.pending[sequence]
+
+		if sequence <= confirmed {
+			status, err := m.node.GetTransactionStatus(tx.TxHash)
+			if err != nil {
+				return events, err
+			}
+			eventType := EventReplaced
+			if status == rpc.TxStatusFinalized {
+				eventType = EventFinalized
+			}
+			events = append(events, newEvent(eventType, tx))
+			delete(acc.pending, sequence)
+			continue
+		}
+
+		if pendingHashes[tx.TxHash] {
+			continue
+		}
+		status, err := m.node.GetTransactionStatus(tx.TxHash)
+		if err != nil {
+			return events, err
+		}
+		// A transaction replaced in the mempool is reported once its sequence is confirmed
+		if status == rpc.TxStatusPending || status == rpc.TxStatusFinalized || status == rpc.TxStatusReplaced {
+			continue
+		}
+		if time.Since(tx.SentAt) < m.config.ResubmitDelay {
+			continue
+		}
+
+		event, err := m.handleDroppedTx(acc, tx)
+		if err != nil {
+			return events, err
+		}
+		events = append(events, event...)
+	}
+
+	// Skip the sequences taken by the transactions sent otherwise
+	if acc.next <= confirmed {
+		acc.next = confirmed + 1
+	}
+	gaps := []uint64{}
+	for _, sequence := range acc.gaps {
+		if sequence > confirmed {
+			gaps = append(gaps, sequence)
+		}
+	}
+	acc.gaps = gaps
+
+	return events, nil
+}
+
+// handleDroppedTx resubmits the dropped transaction, or fills its sequence once it has been
+// resubmitted too many times.
+func (m *Manager) handleDroppedTx(acc *account, tx *PendingTx) ([]Event, error) {
+	if tx.Resubmits < m.config.MaxResubmits {
+		tx.Resubmits++
+		tx.SentAt = time.Now()
+		err := m.node.BroadcastRawTransaction(tx.TxBytes)
+		if err == nil {
+			logger.Infof("Resubmitted transaction %v, address: %v, sequence: %v", tx.TxHash.Hex(), tx.Address.Hex(), tx.Sequence)
+			return []Event{newEvent(EventResubmitted, tx)}, nil
+		}
+		logger.Warnf("Failed to resubmit transaction %v, address: %v, sequence: %v, err: %v", tx.TxHash.Hex(), tx.Address.Hex(), tx.Sequence, err)
+		return []Event{}, nil
+	}
+
+	events := []Event{newEvent(EventDropped, tx)}
+	delete(acc.pending, tx.Sequence)
+
+	if m.config.GapFiller == nil {
+		acc.addGap(tx.Sequence)
+		return events, nil
+	}
+
+	txBytes, err := m.config.GapFiller(tx.Address, tx.Sequence)
+	if err != nil {
+		return events, err
+	}
+	filler := &PendingTx{
+		Address:  tx.Address,
+		Sequence: tx.Sequence,
+		TxHash:   crypto.Keccak256Hash(txBytes),
+		TxBytes:  txBytes,
+		SentAt:   time.Now(),
+		Filler:   true,
+	}
+	acc.pending[tx.Sequence] = filler
+	if err := m.node.BroadcastRawTransaction(txBytes); err != nil {
+		// Resubmitted by the next syncs
+		logger.Warnf("Failed to broadcast filler transaction %v, address: %v, sequence: %v, err: %v", filler.TxHash.Hex(), filler.Address.Hex(), filler.Sequence, err)
+	}
+	logger.Infof("Filled the sequence %v of %v with transaction %v", filler.Sequence, filler.Address.Hex(), filler.TxHash.Hex())
+
+	return append(events, newEvent(EventGapFilled, filler)), nil
+}
+
+// Start starts the periodic reconciliation of the in-flight transactions.
+func (m *Manager) Start(ctx context.Context) {
+	c, cancel := context.WithCancel(ctx)
+	m.ctx = c
+	m.cancel = cancel
+
+	m.wg.Add(1)
+	go m.mainLoop()
+}
+
+func (m *Manager) mainLoop() {
+	defer m.wg.Done()
+
+	interval := m.config.SyncInterval
+	if interval <= 0 {
+		interval = DefaultSyncInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+			events, err := m.SyncAll()
+			if err != nil {
+				logger.Warnf("Failed to sync the sequences: %v", err)
+				continue
+			}
+			if m.config.EventHandler != nil {
+				for _, event := range events {
+					m.config.EventHandler(event)
+				}
+			}
+		}
+	}
+}
+
+// Stop notifies all goroutines to stop without blocking.
+func (m *Manager) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+// Wait blocks until all goroutines stop.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+func (m *Manager) getAccount(address common.Address) *account {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	acc, ok := m.accounts[address]
+	if !ok {
+		acc = &account{
+			mutex:   &sync.Mutex{},
+			pending: make(map[uint64]*PendingTx),
+		}
+		m.accounts[address] = acc
+	}
+	return acc
+}
+
+// resetAccount syncs the next sequence of the address with the node, including the
+// transactions pending in the mempool. Should be called with the account locked.
+func (m *Manager) resetAccount(address common.Address, acc *account) error {
+	sequence, err := m.node.GetSequence(address, true)
+	if err != nil {
+		return err
+	}
+
+	acc.next = sequence + 1
+	for seq := range acc.pending {
+		if seq >= acc.next {
+			acc.next = seq + 1
+		}
+	}
+	gaps := []uint64{}
+	for _, seq := range acc.gaps {
+		if seq > sequence && seq < acc.next {
+			gaps = append(gaps, seq)
+		}
+	}
+	acc.gaps = gaps
+	acc.synced = true
+	return nil
+}
+
+func (m *Manager) getPendingHashes() (map[common.Hash]bool, error) {
+	hashes, err := m.node.GetPendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	pendingHashes := make(map[common.Hash]bool, len(hashes))
+	for _, hash := range hashes {
+		pendingHashes[hash] = true
+	}
+	return pendingHashes, nil
+}
+
+func (acc *account) addGap(sequence uint64) {
+	acc.gaps = append(acc.gaps, sequence)
+	sort.Slice(acc.gaps, func(i, j int) bool { return acc.gaps[i] < acc.gaps[j] })
+}
+
+func (acc *account) sortedSequences() []uint64 {
+	sequences := make([]uint64, 0, len(acc.pending))
+	for sequence := range acc.pending {
+		sequences = append(sequences, sequence)
+	}
+	sort.Slice(sequences, func(i, j int) bool { return sequences[i] < sequences[j] })
+	return sequences
+}
+
+func newEvent(eventType EventType, tx *PendingTx) Event {
+	return Event{
+		Type:     eventType,
+		Address:  tx.Address,
+		Sequence: tx.Sequence,
+		TxHash:   tx.TxHash,
+	}
+}
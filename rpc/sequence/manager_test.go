@@ -0,0 +1,391 @@
+package sequence
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/rpc"
+)
+
+var testAddr = common.HexToAddress("0x2E833968E5bB786Ae419c4d13189fB081Cc43bab")
+
+func TestManagerAssignSequences(t *testing.T) {
+	assert := assert.New(t)
+
+	node := newFakeNode()
+	node.preview[testAddr] = 10
+	manager := NewManager(node, testConfig())
+
+	// Concurrent sends get consecutive sequences, and reach the mempool in order
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+			assert.Nil(err)
+		}()
+	}
+	wg.Wait()
+
+	pending := manager.Pending(testAddr)
+	assert.Equal(20, len(pending))
+	for i, tx := range pending {
+		assert.Equal(uint64(11+i), tx.Sequence)
+		assert.Equal(signTx(testAddr, "tx").mustSign(uint64(11+i)), node.broadcasted[i])
+	}
+
+	// Signed only, the sequence is taken but the transaction is not tracked
+	tx, err := manager.Sign(testAddr, signTx(testAddr, "signed"))
+	assert.Nil(err)
+	assert.Equal(uint64(31), tx.Sequence)
+	assert.Equal(20, len(node.broadcasted))
+	assert.Equal(20, len(manager.Pending(testAddr)))
+
+	// Failed broadcasts do not take the sequence, and sync the sequence with the node
+	node.broadcastErr = errors.New("ValidateInputAdvanced: Got 32, expected 33")
+	_, err = manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.NotNil(err)
+	node.broadcastErr = nil
+	node.preview[testAddr] = 32 // sent otherwise
+	tx, err = manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(33), tx.Sequence)
+
+	// Custom broadcast
+	var broadcasted common.Bytes
+	tx, err = manager.Send(testAddr, signTx(testAddr, "tx"), func(txBytes common.Bytes) error {
+		broadcasted = txBytes
+		return nil
+	})
+	assert.Nil(err)
+	assert.Equal(uint64(34), tx.Sequence)
+	assert.Equal(tx.TxBytes, broadcasted)
+
+	// Reset
+	manager.Reset(testAddr)
+	assert.Equal(0, len(manager.Pending(testAddr)))
+	tx, err = manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(33), tx.Sequence)
+}
+
+func TestManagerSendBroadcast(t *testing.T) {
+	assert := assert.New(t)
+
+	node := newFakeNode()
+	node.preview[testAddr] = 10
+	manager := NewManager(node, testConfig())
+
+	// The address is not locked during the broadcast
+	started := make(chan struct{})
+	release := make(chan struct{})
+	result := make(chan *PendingTx)
+	go func() {
+		tx, err := manager.Send(testAddr, signTx(testAddr, "slow"), func(txBytes common.Bytes) error {
+			close(started)
+			<-release
+			return node.BroadcastRawTransaction(txBytes)
+		})
+		assert.Nil(err)
+		result <- tx
+	}()
+	<-started
+	pending := manager.Pending(testAddr)
+	assert.Equal(1, len(pending))
+	assert.Equal(uint64(11), pending[0].Sequence)
+
+	// The later broadcasts wait for the earlier ones
+	go func() {
+		tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+		assert.Nil(err)
+		result <- tx
+	}()
+	for len(manager.Pending(testAddr)) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(0, len(node.broadcasted))
+	close(release)
+	assert.Equal(uint64(11), (<-result).Sequence)
+	assert.Equal(uint64(12), (<-result).Sequence)
+	assert.Equal([]common.Bytes{
+		signTx(testAddr, "slow").mustSign(11),
+		signTx(testAddr, "tx").mustSign(12),
+	}, node.broadcasted)
+
+	// A failed broadcast releases the sequence and stops tracking the transaction
+	_, err := manager.Send(testAddr, signTx(testAddr, "tx"), func(txBytes common.Bytes) error {
+		return errors.New("connection refused")
+	})
+	assert.NotNil(err)
+	assert.Equal(2, len(manager.Pending(testAddr)))
+	node.preview[testAddr] = 12
+	tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(13), tx.Sequence)
+
+	// Signed only transactions are not resubmitted
+	tx, err = manager.Sign(testAddr, signTx(testAddr, "signed"))
+	assert.Nil(err)
+	assert.Equal(uint64(14), tx.Sequence)
+	node.statuses[tx.TxHash] = rpc.TxStatusNotFound
+	events, err := manager.SyncAll()
+	assert.Nil(err)
+	assert.Equal(0, len(events))
+	assert.Equal(3, len(node.broadcasted))
+
+	// Stopping a manager never started is a no-op
+	manager.Stop()
+	manager.Wait()
+}
+
+func TestManagerSync(t *testing.T) {
+	assert := assert.New(t)
+
+	node := newFakeNode()
+	node.preview[testAddr] = 0
+	manager := NewManager(node, testConfig())
+
+	txs := []*PendingTx{}
+	for i := 0; i < 5; i++ {
+		tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+		assert.Nil(err)
+		txs = append(txs, tx)
+	}
+
+	// Nothing happened yet
+	events, err := manager.SyncAll()
+	assert.Nil(err)
+	assert.Equal(0, len(events))
+
+	// Sequence 1 is finalized, 2 is replaced
+	node.finalize(testAddr, txs[0].TxHash, 1)
+	node.finalize(testAddr, crypto.Keccak256Hash([]byte("other")), 2)
+	delete(node.mempool, txs[1].TxHash)
+	node.statuses[txs[1].TxHash] = rpc.TxStatusAbandoned
+
+	// Sequence 3 is dropped from the mempool, and resubmitted
+	delete(node.mempool, txs[2].TxHash)
+	node.statuses[txs[2].TxHash] = rpc.TxStatusNotFound
+
+	// Sequence 4 is replaced in the mempool, and waits for the replacement to be finalized
+	delete(node.mempool, txs[3].TxHash)
+	node.statuses[txs[3].TxHash] = rpc.TxStatusReplaced
+
+	events, err = manager.Sync(testAddr)
+	assert.Nil(err)
+	assert.Equal([]Event{
+		newEvent(EventFinalized, txs[0]),
+		newEvent(EventReplaced, txs[1]),
+		newEvent(EventResubmitted, txs[2]),
+	}, events)
+	assert.True(node.mempool[txs[2].TxHash])
+	assert.Equal(3, len(manager.Pending(testAddr)))
+
+	// The next transaction gets the next sequence
+	tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(6), tx.Sequence)
+}
+
+func TestManagerFillGaps(t *testing.T) {
+	assert := assert.New(t)
+
+	// Without a gap filler, the sequence is taken by the next transaction
+	node := newFakeNode()
+	manager := NewManager(node, testConfig())
+	txs := []*PendingTx{}
+	for i := 0; i < 3; i++ {
+		tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+		assert.Nil(err)
+		txs = append(txs, tx)
+	}
+
+	node.drop(txs[1].TxHash)
+	node.broadcastErr = errors.New("Insufficient fund")
+	for i := 0; i < testConfig().MaxResubmits; i++ {
+		events, err := manager.SyncAll()
+		assert.Nil(err)
+		assert.Equal(0, len(events))
+	}
+	events, err := manager.SyncAll()
+	assert.Nil(err)
+	assert.Equal([]Event{newEvent(EventDropped, txs[1])}, events)
+	node.broadcastErr = nil
+
+	tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(2), tx.Sequence)
+	tx, err = manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(4), tx.Sequence)
+
+	// With a gap filler, the sequence is filled at once
+	node = newFakeNode()
+	config := testConfig()
+	config.MaxResubmits = 0
+	config.GapFiller = func(address common.Address, sequence uint64) (common.Bytes, error) {
+		return signTx(address, "filler").mustSign(sequence), nil
+	}
+	manager = NewManager(node, config)
+	txs = []*PendingTx{}
+	for i := 0; i < 3; i++ {
+		tx, err := manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+		assert.Nil(err)
+		txs = append(txs, tx)
+	}
+
+	node.drop(txs[1].TxHash)
+	events, err = manager.SyncAll()
+	assert.Nil(err)
+	assert.Equal(2, len(events))
+	assert.Equal(newEvent(EventDropped, txs[1]), events[0])
+	assert.Equal(EventGapFilled, events[1].Type)
+	assert.Equal(uint64(2), events[1].Sequence)
+
+	pending := manager.Pending(testAddr)
+	assert.Equal(3, len(pending))
+	assert.True(pending[1].Filler)
+	assert.True(node.mempool[pending[1].TxHash])
+
+	tx, err = manager.Send(testAddr, signTx(testAddr, "tx"), nil)
+	assert.Nil(err)
+	assert.Equal(uint64(4), tx.Sequence)
+}
+
+func TestSendTxGapFiller(t *testing.T) {
+	assert := assert.New(t)
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	assert.Nil(err)
+	address := privKey.PublicKey().Address()
+
+	filler := NewSendTxGapFiller("privatenet", testSigner{privKey}, common.Big1)
+	txBytes, err := filler(address, 7)
+	assert.Nil(err)
+	assert.NotEqual(0, len(txBytes))
+
+	_, err = filler(testAddr, 7)
+	assert.NotNil(err)
+}
+
+// ---------------- Test Utilities ---------------- //
+
+func testConfig() Config {
+	config := DefaultConfig()
+	config.ResubmitDelay = 0
+	return config
+}
+
+func signTx(address common.Address, tag string) SignTxFunc {
+	return func(sequence uint64) (common.Bytes, error) {
+		return common.Bytes(fmt.Sprintf("%v-%v-%v", address.Hex(), sequence, tag)), nil
+	}
+}
+
+func (sign SignTxFunc) mustSign(sequence uint64) common.Bytes {
+	txBytes, _ := sign(sequence)
+	return txBytes
+}
+
+type testSigner struct {
+	privKey *crypto.PrivateKey
+}
+
+func (s testSigner) Sign(address common.Address, txBytes common.Bytes) (*crypto.Signature, error) {
+	if address != s.privKey.PublicKey().Address() {
+		return nil, errors.New("Unknown address")
+	}
+	return s.privKey.Sign(txBytes)
+}
+
+type fakeNode struct {
+	mutex *sync.Mutex
+
+	sequences    map[common.Address]uint64
+	preview      map[common.Address]uint64
+	statuses     map[common.Hash]string
+	mempool      map[common.Hash]bool
+	broadcasted  []common.Bytes
+	broadcastErr error
+}
+
+func newFakeNode() *fakeNode {
+	return &fakeNode{
+		mutex:     &sync.Mutex{},
+		sequences: make(map[common.Address]uint64),
+		preview:   make(map[common.Address]uint64),
+		statuses:  make(map[common.Hash]string),
+		mempool:   make(map[common.Hash]bool),
+	}
+}
+
+func (n *fakeNode) GetSequence(address common.Address, preview bool) (uint64, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if preview {
+		return n.preview[address], nil
+	}
+	return n.sequences[address], nil
+}
+
+func (n *fakeNode) GetTransactionStatus(hash common.Hash) (string, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if status, ok := n.statuses[hash]; ok {
+		return status, nil
+	}
+	return rpc.TxStatusNotFound, nil
+}
+
+func (n *fakeNode) GetPendingTransactions() ([]common.Hash, error) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	hashes := []common.Hash{}
+	for hash := range n.mempool {
+		hashes = append(hashes, hash)
+	}
+	sort.Slice(hashes, func(i, j int) bool { return hashes[i].Hex() < hashes[j].Hex() })
+	return hashes, nil
+}
+
+func (n *fakeNode) BroadcastRawTransaction(txBytes common.Bytes) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	if n.broadcastErr != nil {
+		return n.broadcastErr
+	}
+	hash := crypto.Keccak256Hash(txBytes)
+	n.broadcasted = append(n.broadcasted, txBytes)
+	n.mempool[hash] = true
+	n.statuses[hash] = rpc.TxStatusPending
+	return nil
+}
+
+func (n *fakeNode) finalize(address common.Address, hash common.Hash, sequence uint64) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.mempool, hash)
+	n.statuses[hash] = rpc.TxStatusFinalized
+	n.sequences[address] = sequence
+}
+
+func (n *fakeNode) drop(hash common.Hash) {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	delete(n.mempool, hash)
+	n.statuses[hash] = rpc.TxStatusAbandoned
+}
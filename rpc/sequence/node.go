@@ -0,0 +1,84 @@
+package sequence
+
+import (
+	"encoding/hex"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/rpc"
+)
+
+// Node is the interface of the Theta node used by the sequence manager.
+type Node interface {
+	// GetSequence returns the sequence of the account in the finalized state, or in the
+	// screened state which includes the transactions pending in the mempool.
+	GetSequence(address common.Address, preview bool) (uint64, error)
+
+	// GetTransactionStatus returns the status of the transaction, one of rpc.TxStatusNotFound,
+	// rpc.TxStatusPending, rpc.TxStatusFinalized, rpc.TxStatusAbandoned and rpc.TxStatusReplaced.
+	GetTransactionStatus(hash common.Hash) (string, error)
+
+	// GetPendingTransactions returns the hashes of the transactions in the mempool.
+	GetPendingTransactions() ([]common.Hash, error)
+
+	// BroadcastRawTransaction inserts the signed transaction into the mempool of the node,
+	// without waiting for it to be included in a block.
+	BroadcastRawTransaction(txBytes common.Bytes) error
+}
+
+var _ Node = (*RPCNode)(nil)
+
+// RPCNode is a Node accessed through the RPC API.
+type RPCNode struct {
+	client rpc.Client
+}
+
+// NewRPCNode creates a Node accessed through the RPC client.
+func NewRPCNode(client rpc.Client) *RPCNode {
+	return &RPCNode{client: client}
+}
+
+func (n *RPCNode) GetSequence(address common.Address, preview bool) (uint64, error) {
+	result := &rpc.GetAccountResult{}
+	args := rpc.GetAccountArgs{Address: address.Hex(), Preview: preview}
+	if err := n.client.Call("theta.GetAccount", []interface{}{args}, result); err != nil {
+		return 0, err
+	}
+	if result.Account == nil {
+		return 0, nil
+	}
+	return result.Account.Sequence, nil
+}
+
+// txStatusResult is the part of rpc.GetTransactionResult needed by the sequence manager.
+type txStatusResult struct {
+	Status string `json:"status"`
+}
+
+func (n *RPCNode) GetTransactionStatus(hash common.Hash) (string, error) {
+	result := &txStatusResult{}
+	args := rpc.GetTransactionArgs{Hash: hash.Hex()}
+	if err := n.client.Call("theta.GetTransaction", []interface{}{args}, result); err != nil {
+		return "", err
+	}
+	return result.Status, nil
+}
+
+func (n *RPCNode) GetPendingTransactions() ([]common.Hash, error) {
+	result := &rpc.GetPendingTransactionsResult{}
+	args := rpc.GetPendingTransactionsArgs{}
+	if err := n.client.Call("theta.GetPendingTransactions", []interface{}{args}, result); err != nil {
+		return nil, err
+	}
+
+	hashes := make([]common.Hash, len(result.TxHashes))
+	for i, hash := range result.TxHashes {
+		hashes[i] = common.HexToHash(hash)
+	}
+	return hashes, nil
+}
+
+func (n *RPCNode) BroadcastRawTransaction(txBytes common.Bytes) error {
+	result := &rpc.BroadcastRawTransactionAsyncResult{}
+	args := rpc.BroadcastRawTransactionAsyncArgs{TxBytes: hex.EncodeToString(txBytes)}
+	return n.client.Call("theta.BroadcastRawTransactionAsync", []interface{}{args}, result)
+}
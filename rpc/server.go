@@ -24,7 +24,9 @@ import (
 	"github.com/thetatoken/theta/dispatcher"
 	"github.com/thetatoken/theta/ledger"
 	"github.com/thetatoken/theta/mempool"
+	"github.com/thetatoken/theta/rpc/contract"
 	"github.com/thetatoken/theta/rpc/lib/rpc-codec/jsonrpc2"
+	"github.com/thetatoken/theta/store"
 	"golang.org/x/net/netutil"
 	"golang.org/x/net/websocket"
 )
@@ -37,6 +39,7 @@ type ThetaRPCService struct {
 	dispatcher *dispatcher.Dispatcher
 	chain      *blockchain.Chain
 	consensus  *consensus.ConsensusEngine
+	contracts  *contract.Registry
 
 	// Life cycle
 	wg      *sync.WaitGroup
@@ -57,7 +60,7 @@ type ThetaRPCServer struct {
 
 // NewThetaRPCServer creates a new instance of ThetaRPCServer.
 func NewThetaRPCServer(mempool *mempool.Mempool, ledger *ledger.Ledger, dispatcher *dispatcher.Dispatcher,
-	chain *blockchain.Chain, consensus *consensus.ConsensusEngine) *ThetaRPCServer {
+	chain *blockchain.Chain, consensus *consensus.ConsensusEngine, store store.Store) *ThetaRPCServer {
 	t := &ThetaRPCServer{
 		ThetaRPCService: &ThetaRPCService{
 			wg: &sync.WaitGroup{},
@@ -69,6 +72,7 @@ func NewThetaRPCServer(mempool *mempool.Mempool, ledger *ledger.Ledger, dispatch
 	t.dispatcher = dispatcher
 	t.chain = chain
 	t.consensus = consensus
+	t.contracts = contract.NewRegistry(store)
 
 	s := rpc.NewServer()
 	s.RegisterName("theta", t.ThetaRPCService)
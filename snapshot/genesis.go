@@ -0,0 +1,405 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto/bls"
+	"github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/store/database/backend"
+)
+
+// GenesisStake is a stake deposit made in the genesis block.
+type GenesisStake struct {
+	Source common.Address
+	Holder common.Address
+	Amount *big.Int
+}
+
+// GenesisSpec describes the initial state of a new chain.
+type GenesisSpec struct {
+	ChainID        string                    `json:"chain_id"`
+	Timestamp      int64                     `json:"timestamp"` // unix time of the genesis block, the current time if zero
+	Forks          *GenesisForks             `json:"forks,omitempty"`
+	Accounts       []*GenesisAccount         `json:"accounts"`
+	Validators     []*GenesisStakeDeposit    `json:"validators"`
+	Guardians      []*GenesisBLSStakeDeposit `json:"guardians"`
+	EliteEdgeNodes []*GenesisBLSStakeDeposit `json:"elite_edge_nodes"`
+}
+
+// GenesisForks is the fork schedule of the chain: the heights override the heights of the
+// preset, or of the schedule currently registered for the chain if no preset is given. The
+// heights are keyed by the JSON names of the ForkSchedule fields, e.g. "enableSmartContract".
+type GenesisForks struct {
+	Preset  string            `json:"preset"`
+	Heights map[string]uint64 `json:"heights"`
+}
+
+// GenesisAccount is an account created in the genesis block. Contracts are deployed by
+// setting their runtime bytecode and, optionally, their initial storage.
+type GenesisAccount struct {
+	Address  common.Address              `json:"address"`
+	ThetaWei *common.JSONBig             `json:"theta_wei"`
+	TFuelWei *common.JSONBig             `json:"tfuel_wei"`
+	Code     hexutil.Bytes               `json:"code,omitempty"`
+	Storage  map[common.Hash]common.Hash `json:"storage,omitempty"`
+}
+
+// GenesisStakeDeposit is a validator stake deposit made in the genesis block. The amount
+// is in ThetaWei and is deducted from the balance of the source account.
+type GenesisStakeDeposit struct {
+	Source common.Address  `json:"source"`
+	Holder common.Address  `json:"holder"`
+	Amount *common.JSONBig `json:"amount"`
+}
+
+// GenesisBLSStakeDeposit is a guardian or elite edge node stake deposit made in the genesis
+// block, along with the BLS public key of the holder and its proof of possession. The amount
+// is in ThetaWei for the guardians and in TFuelWei for the elite edge nodes, and is deducted
+// from the balance of the source account.
+type GenesisBLSStakeDeposit struct {
+	GenesisStakeDeposit
+	BlsPubkey hexutil.Bytes `json:"bls_pubkey"`
+	BlsPop    hexutil.Bytes `json:"bls_pop"`
+}
+
+// LoadGenesisSpec reads a genesis spec from a JSON file.
+func LoadGenesisSpec(filePath string) (*GenesisSpec, error) {
+	data, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	spec := &GenesisSpec{}
+	if err := decoder.Decode(spec); err != nil {
+		return nil, fmt.Errorf("failed to parse the genesis spec: %v", err)
+	}
+	return spec, nil
+}
+
+// ForkSchedule returns the fork schedule described by the spec.
+func (spec *GenesisSpec) ForkSchedule() (*common.ForkSchedule, error) {
+	base := common.GetForkSchedule(spec.ChainID)
+	if spec.Forks == nil {
+		return base, nil
+	}
+	if spec.Forks.Preset != "" {
+		var ok bool
+		if base, ok = common.ForkSchedulePresets[spec.Forks.Preset]; !ok {
+			return nil, fmt.Errorf("unknown fork schedule preset: %v", spec.Forks.Preset)
+		}
+	}
+	fs := base.Copy()
+	if len(spec.Forks.Heights) == 0 {
+		return fs, nil
+	}
+
+	raw, err := json.Marshal(spec.Forks.Heights)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(fs); err != nil {
+		return nil, fmt.Errorf("invalid fork heights: %v", err)
+	}
+	return fs, nil
+}
+
+// GenerateGenesisSnapshot builds the state and the snapshot metadata of a new chain from
+// the initial account balances and stake deposits. The stakes are deducted from the
+// ThetaWei balance of their source accounts.
+func GenerateGenesisSnapshot(chainID string, balances map[common.Address]types.Coins, stakes []GenesisStake) (*state.StoreView, *core.SnapshotMetadata, error) {
+	spec := &GenesisSpec{ChainID: chainID}
+	for address, balance := range balances {
+		balance = balance.NoNil()
+		spec.Accounts = append(spec.Accounts, &GenesisAccount{
+			Address:  address,
+			ThetaWei: (*common.JSONBig)(balance.ThetaWei),
+			TFuelWei: (*common.JSONBig)(balance.TFuelWei),
+		})
+	}
+	for _, stake := range stakes {
+		spec.Validators = append(spec.Validators, &GenesisStakeDeposit{
+			Source: stake.Source,
+			Holder: stake.Holder,
+			Amount: (*common.JSONBig)(stake.Amount),
+		})
+	}
+	return BuildGenesisSnapshot(spec)
+}
+
+// BuildGenesisSnapshot builds the state and the snapshot metadata of a new chain from the
+// genesis spec. If the spec has a fork schedule, it is registered for the chain, since the
+// genesis block header is encoded according to it.
+func BuildGenesisSnapshot(spec *GenesisSpec) (*state.StoreView, *core.SnapshotMetadata, error) {
+	chainID := spec.ChainID
+	if chainID == "" {
+		return nil, nil, fmt.Errorf("the chain ID is not set")
+	}
+	if chainID == core.MainnetChainID {
+		return nil, nil, fmt.Errorf("cannot build the genesis of %v", core.MainnetChainID)
+	}
+	if spec.Forks != nil {
+		fs, err := spec.ForkSchedule()
+		if err != nil {
+			return nil, nil, err
+		}
+		common.RegisterForkSchedule(chainID, fs)
+	}
+
+	genesisHeight := core.GenesisBlockHeight
+	sv := state.NewStoreView(genesisHeight, common.Hash{}, backend.NewMemDatabase())
+
+	for _, genesisAccount := range spec.Accounts {
+		address := genesisAccount.Address
+		if sv.GetAccount(address) != nil {
+			return nil, nil, fmt.Errorf("duplicate account %v", address)
+		}
+		balance := types.Coins{
+			ThetaWei: jsonBigOrZero(genesisAccount.ThetaWei),
+			TFuelWei: jsonBigOrZero(genesisAccount.TFuelWei),
+		}
+		if !balance.IsNonnegative() {
+			return nil, nil, fmt.Errorf("negative balance for %v", address)
+		}
+		acc := &types.Account{
+			Address:  address,
+			Root:     common.Hash{},
+			CodeHash: types.EmptyCodeHash,
+			Balance:  balance,
+		}
+		sv.SetAccount(address, acc)
+
+		if len(genesisAccount.Code) > 0 {
+			sv.SetCode(address, genesisAccount.Code)
+		} else if len(genesisAccount.Storage) > 0 {
+			return nil, nil, fmt.Errorf("account %v has storage but no code", address)
+		}
+		for key, value := range genesisAccount.Storage {
+			sv.SetState(address, key, value)
+		}
+	}
+
+	vcp := &core.ValidatorCandidatePool{}
+	for _, deposit := range spec.Validators {
+		amount, err := withdrawGenesisStake(sv, deposit, true)
+		if err != nil {
+			return nil, nil, err
+		}
+		err = vcp.DepositStake(deposit.Source, deposit.Holder, amount, chainID, genesisHeight)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to deposit the stake of validator %v: %v", deposit.Holder, err)
+		}
+	}
+	if len(vcp.SortedCandidates) == 0 {
+		return nil, nil, fmt.Errorf("no validator stake")
+	}
+	sv.UpdateValidatorCandidatePool(vcp)
+
+	if len(spec.Guardians) > 0 {
+		gcp := core.NewGuardianCandidatePool()
+		for _, deposit := range spec.Guardians {
+			pubkey, err := deposit.verifyBLSKey()
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid BLS key of guardian %v: %v", deposit.Holder, err)
+			}
+			amount, err := withdrawGenesisStake(sv, &deposit.GenesisStakeDeposit, true)
+			if err != nil {
+				return nil, nil, err
+			}
+			err = gcp.DepositStake(deposit.Source, deposit.Holder, amount, pubkey, chainID, genesisHeight)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to deposit the stake of guardian %v: %v", deposit.Holder, err)
+			}
+		}
+		sv.UpdateGuardianCandidatePool(gcp)
+	}
+
+	if len(spec.EliteEdgeNodes) > 0 {
+		eenp := state.NewEliteEdgeNodePool(sv, false)
+		for _, deposit := range spec.EliteEdgeNodes {
+			pubkey, err := deposit.verifyBLSKey()
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid BLS key of elite edge node %v: %v", deposit.Holder, err)
+			}
+			amount, err := withdrawGenesisStake(sv, &deposit.GenesisStakeDeposit, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			err = eenp.DepositStake(deposit.Source, deposit.Holder, amount, pubkey, genesisHeight)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to deposit the stake of elite edge node %v: %v", deposit.Holder, err)
+			}
+		}
+	}
+
+	hl := &types.HeightList{}
+	hl.Append(genesisHeight)
+	sv.UpdateStakeTransactionHeightList(hl)
+
+	timestamp := spec.Timestamp
+	if timestamp == 0 {
+		timestamp = time.Now().Unix()
+	}
+
+	genesisBlock := core.NewBlock()
+	genesisBlock.ChainID = chainID
+	genesisBlock.Height = genesisHeight
+	genesisBlock.Epoch = genesisBlock.Height
+	genesisBlock.Parent = common.Hash{}
+	genesisBlock.StateHash = sv.Hash()
+	genesisBlock.Timestamp = big.NewInt(timestamp)
+
+	metadata := &core.SnapshotMetadata{
+		TailTrio: core.SnapshotBlockTrio{
+			First:  core.SnapshotFirstBlock{},
+			Second: core.SnapshotSecondBlock{Header: genesisBlock.BlockHeader},
+			Third:  core.SnapshotThirdBlock{},
+		},
+	}
+
+	return sv, metadata, nil
+}
+
+// withdrawGenesisStake deducts the amount of the stake deposit from the ThetaWei or the
+// TFuelWei balance of its source account.
+func withdrawGenesisStake(sv *state.StoreView, deposit *GenesisStakeDeposit, isTheta bool) (*big.Int, error) {
+	amount := jsonBigOrZero(deposit.Amount)
+	if amount.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid stake amount of %v: %v", deposit.Holder, amount)
+	}
+
+	sourceAccount := sv.GetAccount(deposit.Source)
+	if sourceAccount == nil {
+		return nil, fmt.Errorf("no balance for stake source %v", deposit.Source)
+	}
+	coins := types.Coins{ThetaWei: big.NewInt(0), TFuelWei: big.NewInt(0)}
+	if isTheta {
+		coins.ThetaWei = amount
+	} else {
+		coins.TFuelWei = amount
+	}
+	if !sourceAccount.Balance.IsGTE(coins) {
+		return nil, fmt.Errorf("insufficient balance for the stake of %v, balance: %v, stake: %v",
+			deposit.Source, sourceAccount.Balance, coins)
+	}
+	sourceAccount.Balance = sourceAccount.Balance.Minus(coins)
+	sv.SetAccount(deposit.Source, sourceAccount)
+
+	return amount, nil
+}
+
+// verifyBLSKey decodes the BLS public key of the deposit and checks its proof of possession.
+func (deposit *GenesisBLSStakeDeposit) verifyBLSKey() (*bls.PublicKey, error) {
+	pubkey, err := bls.PublicKeyFromBytes(deposit.BlsPubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %v", err)
+	}
+	pop, err := bls.SignatureFromBytes(deposit.BlsPop)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof of possession: %v", err)
+	}
+	if !pop.PopVerify(pubkey) {
+		return nil, fmt.Errorf("proof of possession verification failed")
+	}
+	return pubkey, nil
+}
+
+func jsonBigOrZero(value *common.JSONBig) *big.Int {
+	if value == nil {
+		return big.NewInt(0)
+	}
+	return new(big.Int).Set(value.ToInt())
+}
+
+// WriteGenesisSnapshot writes the genesis snapshot to the file system.
+func WriteGenesisSnapshot(sv *state.StoreView, metadata *core.SnapshotMetadata, filePath string) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := bufio.NewWriter(file)
+	err = core.WriteMetadata(writer, metadata)
+	if err != nil {
+		return err
+	}
+	writeStoreView(sv, true, writer, sv.GetDB())
+	return nil
+}
+
+// VerifyGenesisSnapshot loads the genesis snapshot into a temporary in-memory database and
+// checks that its state matches the state hash of the genesis block, that the genesis block
+// hash is the one set by the config, and that the state has validators. Returns the genesis
+// block header.
+func VerifyGenesisSnapshot(snapshotFilePath string) (*core.BlockHeader, error) {
+
+	db := backend.NewMemDatabase()
+	header, _, err := loadSnapshot(snapshotFilePath, db, "Verifying genesis snapshot")
+	if err != nil {
+		return nil, err
+	}
+	if header.Height != core.GenesisBlockHeight {
+		return nil, fmt.Errorf("not a genesis snapshot, height: %v", header.Height)
+	}
+
+	sv := state.NewStoreView(header.Height, header.StateHash, db)
+	if validators := getValidatorSetFromSV(sv); validators.Size() == 0 {
+		return nil, fmt.Errorf("the genesis state has no validator")
+	}
+
+	return header, nil
+}
+
+// CheckGenesisSpec checks that the genesis snapshot is the one built from the spec, and
+// returns the genesis block hash. The timestamp of the snapshot is used if the spec does
+// not set one.
+func CheckGenesisSpec(snapshotFilePath string, spec *GenesisSpec) (common.Hash, error) {
+	if spec.Forks != nil {
+		fs, err := spec.ForkSchedule()
+		if err != nil {
+			return common.Hash{}, err
+		}
+		common.RegisterForkSchedule(spec.ChainID, fs)
+	}
+
+	header := LoadSnapshotCheckpointHeader(snapshotFilePath)
+	if header == nil {
+		return common.Hash{}, fmt.Errorf("failed to read the genesis block header from %v", snapshotFilePath)
+	}
+	if spec.ChainID != header.ChainID {
+		return common.Hash{}, fmt.Errorf("chain ID mismatch, spec: %v, snapshot: %v", spec.ChainID, header.ChainID)
+	}
+
+	specCopy := *spec
+	if specCopy.Timestamp == 0 && header.Timestamp != nil {
+		specCopy.Timestamp = header.Timestamp.Int64()
+	}
+	_, metadata, err := BuildGenesisSnapshot(&specCopy)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to build the genesis from the spec: %v", err)
+	}
+	expected := metadata.TailTrio.Second.Header
+	if expected.StateHash != header.StateHash {
+		return common.Hash{}, fmt.Errorf("state hash mismatch, spec: %v, snapshot: %v",
+			expected.StateHash.Hex(), header.StateHash.Hex())
+	}
+	if expected.Hash() != header.Hash() {
+		return common.Hash{}, fmt.Errorf("genesis block hash mismatch, spec: %v, snapshot: %v",
+			expected.Hash().Hex(), header.Hash().Hex())
+	}
+
+	return expected.Hash(), nil
+}
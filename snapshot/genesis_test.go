@@ -0,0 +1,212 @@
+package snapshot
+
+import (
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/core"
+	"github.com/thetatoken/theta/crypto/bls"
+	"github.com/thetatoken/theta/ledger/state"
+	"github.com/thetatoken/theta/ledger/types"
+	"github.com/thetatoken/theta/store/database/backend"
+)
+
+func newGenesisBLSStakeDeposit(t *testing.T, source, holder common.Address, amount *big.Int) *GenesisBLSStakeDeposit {
+	blsKey, err := bls.RandKey()
+	require.Nil(t, err)
+	return &GenesisBLSStakeDeposit{
+		GenesisStakeDeposit: GenesisStakeDeposit{Source: source, Holder: holder, Amount: (*common.JSONBig)(amount)},
+		BlsPubkey:           hexutil.Bytes(blsKey.PublicKey().ToBytes()),
+		BlsPop:              hexutil.Bytes(blsKey.PopProve().ToBytes()),
+	}
+}
+
+func TestBuildAndVerifyGenesisSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "genesis_test"
+	defer common.RegisterForkSchedule(chainID, common.MainnetForkSchedule)
+
+	validator := common.HexToAddress("0x2E833968E5bB786Ae419c4d13189fB081Cc43bab")
+	guardian := common.HexToAddress("0x70f587259738cB626A1720Af7038B8DcDb6a42a0")
+	een := common.HexToAddress("0xcd56123D0c5D6C1Ba4D39367b88cba61D93F5405")
+	contract := common.HexToAddress("0xa5cc0BfEB09742C5e4C610f2018f03Ba2A80F98C")
+
+	balance := new(big.Int).Mul(big.NewInt(1000000), big.NewInt(1e18))
+	storageKey := common.BigToHash(big.NewInt(0))
+	storageValue := common.BigToHash(big.NewInt(42))
+	spec := &GenesisSpec{
+		ChainID:   chainID,
+		Timestamp: 1700000000,
+		Forks:     &GenesisForks{Preset: "dev", Heights: map[string]uint64{"governance": 1000}},
+		Accounts: []*GenesisAccount{
+			{Address: validator, ThetaWei: (*common.JSONBig)(balance), TFuelWei: (*common.JSONBig)(balance)},
+			{Address: guardian, ThetaWei: (*common.JSONBig)(balance), TFuelWei: (*common.JSONBig)(balance)},
+			{Address: een, TFuelWei: (*common.JSONBig)(balance)},
+			{Address: contract, Code: common.Hex2Bytes("6080604052"), Storage: map[common.Hash]common.Hash{storageKey: storageValue}},
+		},
+		Validators: []*GenesisStakeDeposit{
+			{Source: validator, Holder: validator, Amount: (*common.JSONBig)(core.MinValidatorStakeDeposit200K)},
+		},
+		Guardians: []*GenesisBLSStakeDeposit{
+			newGenesisBLSStakeDeposit(t, guardian, guardian, core.MinGuardianStakeDeposit1000),
+		},
+		EliteEdgeNodes: []*GenesisBLSStakeDeposit{
+			newGenesisBLSStakeDeposit(t, een, een, core.MinEliteEdgeNodeStakeDeposit),
+		},
+	}
+
+	sv, metadata, err := BuildGenesisSnapshot(spec)
+	require.Nil(err)
+	assert.Equal(uint64(1000), common.GetForkSchedule(chainID).Governance)
+	assert.Equal(uint64(0), common.GetForkSchedule(chainID).EnableSmartContract)
+
+	assert.Equal(new(big.Int).Sub(balance, core.MinValidatorStakeDeposit200K), sv.GetAccount(validator).Balance.ThetaWei)
+	assert.Equal(new(big.Int).Sub(balance, core.MinEliteEdgeNodeStakeDeposit), sv.GetAccount(een).Balance.TFuelWei)
+	assert.Equal(1, len(sv.GetGuardianCandidatePool().SortedGuardians))
+	assert.NotNil(state.NewEliteEdgeNodePool(sv, true).Get(een))
+	assert.Equal(storageValue, sv.GetState(contract, storageKey))
+
+	dir, err := ioutil.TempDir("", "genesis_test")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	genesisPath := path.Join(dir, "genesis")
+	require.Nil(WriteGenesisSnapshot(sv, metadata, genesisPath))
+
+	genesisHash := metadata.TailTrio.Second.Header.Hash()
+	viper.Set(common.CfgGenesisHash, "")
+	defer viper.Set(common.CfgGenesisHash, "")
+
+	specHash, err := CheckGenesisSpec(genesisPath, spec)
+	require.Nil(err)
+	assert.Equal(genesisHash, specHash)
+	assert.Equal("", viper.GetString(common.CfgGenesisHash))
+
+	viper.Set(common.CfgGenesisHash, specHash.Hex())
+	header, err := VerifyGenesisSnapshot(genesisPath)
+	require.Nil(err)
+	assert.Equal(genesisHash, header.Hash())
+
+	// The contract storage is part of the snapshot
+	db := backend.NewMemDatabase()
+	_, _, err = loadSnapshot(genesisPath, db, "")
+	require.Nil(err)
+	loaded := state.NewStoreView(header.Height, header.StateHash, db)
+	assert.Equal(storageValue, loaded.GetState(contract, storageKey))
+	assert.Equal(common.Hex2Bytes("6080604052"), loaded.GetCode(contract))
+
+	// A snapshot built from a different spec is rejected
+	spec.Accounts[0].TFuelWei = (*common.JSONBig)(new(big.Int).Add(balance, big.NewInt(1)))
+	_, err = CheckGenesisSpec(genesisPath, spec)
+	assert.NotNil(err)
+
+	viper.Set(common.CfgGenesisHash, common.Hash{}.Hex())
+	_, err = VerifyGenesisSnapshot(genesisPath)
+	assert.NotNil(err)
+}
+
+func TestBuildGenesisSnapshotInvalidSpec(t *testing.T) {
+	assert := assert.New(t)
+
+	chainID := "genesis_test"
+	validator := common.HexToAddress("0x2E833968E5bB786Ae419c4d13189fB081Cc43bab")
+	balance := new(big.Int).Mul(big.NewInt(10000000), big.NewInt(1e18))
+	newSpec := func() *GenesisSpec {
+		return &GenesisSpec{
+			ChainID: chainID,
+			Accounts: []*GenesisAccount{
+				{Address: validator, ThetaWei: (*common.JSONBig)(balance)},
+			},
+			Validators: []*GenesisStakeDeposit{
+				{Source: validator, Holder: validator, Amount: (*common.JSONBig)(core.MinValidatorStakeDeposit)},
+			},
+		}
+	}
+
+	_, _, err := BuildGenesisSnapshot(newSpec())
+	assert.Nil(err)
+
+	spec := newSpec()
+	spec.Forks = &GenesisForks{Heights: map[string]uint64{"enableTheta4": 1}}
+	_, _, err = BuildGenesisSnapshot(spec)
+	assert.NotNil(err, "unknown fork")
+
+	spec = newSpec()
+	spec.Validators[0].Amount = (*common.JSONBig)(new(big.Int).Add(balance, big.NewInt(1)))
+	_, _, err = BuildGenesisSnapshot(spec)
+	assert.NotNil(err, "insufficient balance")
+
+	spec = newSpec()
+	spec.Validators = nil
+	_, _, err = BuildGenesisSnapshot(spec)
+	assert.NotNil(err, "no validator")
+
+	spec = newSpec()
+	deposit := newGenesisBLSStakeDeposit(t, validator, validator, core.MinGuardianStakeDeposit)
+	otherKey, _ := bls.RandKey()
+	deposit.BlsPop = hexutil.Bytes(otherKey.PopProve().ToBytes())
+	spec.Guardians = []*GenesisBLSStakeDeposit{deposit}
+	_, _, err = BuildGenesisSnapshot(spec)
+	assert.NotNil(err, "invalid proof of possession")
+
+	spec = newSpec()
+	spec.ChainID = core.MainnetChainID
+	_, _, err = BuildGenesisSnapshot(spec)
+	assert.NotNil(err)
+}
+
+func TestGenerateDevGenesisSnapshot(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	chainID := "genesis_dev_test"
+	common.RegisterForkSchedule(chainID, common.DevForkSchedule)
+	defer common.RegisterForkSchedule(chainID, common.MainnetForkSchedule)
+
+	validator := common.HexToAddress("0x2E833968E5bB786Ae419c4d13189fB081Cc43bab")
+	account := common.HexToAddress("0x70f587259738cB626A1720Af7038B8DcDb6a42a0")
+	balance := new(big.Int).Mul(big.NewInt(1000000), big.NewInt(1e18))
+	stake := new(big.Int).Set(core.MinValidatorStakeDeposit)
+	balances := map[common.Address]types.Coins{
+		validator: {ThetaWei: new(big.Int).Add(balance, stake), TFuelWei: new(big.Int).Set(balance)},
+		account:   {ThetaWei: new(big.Int).Set(balance), TFuelWei: new(big.Int).Set(balance)},
+	}
+	sv, metadata, err := GenerateGenesisSnapshot(chainID, balances,
+		[]GenesisStake{{Source: validator, Holder: validator, Amount: stake}})
+	require.Nil(err)
+
+	dir, err := ioutil.TempDir("", "genesis_dev_test")
+	require.Nil(err)
+	defer os.RemoveAll(dir)
+	genesisPath := path.Join(dir, "snapshot")
+	require.Nil(WriteGenesisSnapshot(sv, metadata, genesisPath))
+
+	// The snapshot loads as the genesis of the chain set by the config, as done by theta start --dev
+	genesisHash := metadata.TailTrio.Second.Header.Hash()
+	viper.Set(common.CfgGenesisHash, genesisHash.Hex())
+	defer viper.Set(common.CfgGenesisHash, "")
+	header, err := VerifyGenesisSnapshot(genesisPath)
+	require.Nil(err)
+	assert.Equal(genesisHash, header.Hash())
+	assert.Equal(chainID, header.ChainID)
+
+	db := backend.NewMemDatabase()
+	_, _, err = loadSnapshot(genesisPath, db, "")
+	require.Nil(err)
+	loaded := state.NewStoreView(header.Height, header.StateHash, db)
+	assert.Equal(balance, loaded.GetAccount(validator).Balance.ThetaWei)
+	assert.Equal(balance, loaded.GetAccount(account).Balance.TFuelWei)
+	validators := getValidatorSetFromSV(loaded)
+	require.Equal(1, validators.Size())
+	assert.Equal(validator, validators.Validators()[0].Address)
+	assert.Equal(stake, validators.Validators()[0].Stake)
+}
@@ -151,14 +151,14 @@ func LoadSnapshotCheckpointHeader(snapshotFilePath string) *core.BlockHeader {
 
 	snapshotHeader := &core.SnapshotHeader{}
 	_, err = core.ReadRecord(snapshotFile, snapshotHeader)
-	if err != nil {
-		return nil
-	}
-
-	lastCheckpoint := core.LastCheckpoint{}
-	_, err = core.ReadRecord(snapshotFile, &lastCheckpoint)
-	if err != nil {
-		return nil
+	if err != nil || snapshotHeader.Magic != core.SnapshotHeaderMagic { // older version, e.g. a genesis snapshot
+		snapshotFile.Seek(0, 0)
+	} else if snapshotHeader.Version >= 2 {
+		lastCheckpoint := core.LastCheckpoint{}
+		_, err = core.ReadRecord(snapshotFile, &lastCheckpoint)
+		if err != nil {
+			return nil
+		}
 	}
 
 	metadata := core.SnapshotMetadata{}
@@ -48,6 +48,7 @@ func (db *AerospikeDatabase) Put(key []byte, value []byte) error {
 	bin := aerospike.NewBin(ValueBin, value)
 	writePolicy := aerospike.NewWritePolicy(0, 0)
 	writePolicy.Timeout = 300 * time.Millisecond
+	writePolicy.SendKey = true // store the user key so that scans can return it
 	err := db.client.PutBins(writePolicy, getDBKey(key), bin)
 	return err
 }
@@ -142,6 +143,41 @@ func (db *AerospikeDatabase) CountReference(key []byte) (int, error) {
 	return ref, nil
 }
 
+// NewIterator returns an iterator over the keys in the range [start, end). Aerospike
+// records are not ordered, so the set is scanned and the keys in range are sorted
+// in memory. Records written without their user key are skipped.
+func (db *AerospikeDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	recordset, err := db.client.ScanAll(nil, Namespace, Set, ValueBin)
+	if err != nil {
+		return newErrIterator(err)
+	}
+	defer recordset.Close()
+
+	keys := [][]byte{}
+	values := [][]byte{}
+	for res := range recordset.Results() {
+		if res.Err != nil {
+			return newErrIterator(res.Err)
+		}
+		if res.Record.Key.Value() == nil {
+			continue
+		}
+		key, ok := res.Record.Key.Value().GetObject().([]byte)
+		if !ok || !database.InRange(key, start, end) {
+			continue
+		}
+		value, _ := res.Record.Bins[ValueBin].([]byte)
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return newSliceIterator(keys, values, reverse)
+}
+
+func (db *AerospikeDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
 func (db *AerospikeDatabase) Close() {
 	db.client.Close()
 }
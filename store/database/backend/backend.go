@@ -0,0 +1,67 @@
+package backend
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/thetatoken/theta/store/database"
+)
+
+const (
+	// LevelDB is the name of the LevelDB storage backend
+	LevelDB = "leveldb"
+	// Pebble is the name of the Pebble storage backend
+	Pebble = "pebble"
+	// Memory is the name of the in-memory storage backend, nothing is persisted
+	Memory = "memory"
+)
+
+// DatabasePaths returns the paths of the main and the reference databases of
+// the given storage backend under the data directory of a node. The Pebble
+// databases are kept apart, so that both can coexist during a migration.
+func DatabasePaths(dataPath string, kind string) (string, string) {
+	if kind == Pebble {
+		return path.Join(dataPath, "db", "pebble", "main"), path.Join(dataPath, "db", "pebble", "ref")
+	}
+	return path.Join(dataPath, "db", "main"), path.Join(dataPath, "db", "ref")
+}
+
+// NewDatabase opens the main and the reference databases with the given
+// storage backend.
+func NewDatabase(kind string, file string, reffile string, cache int, handles int) (database.Database, error) {
+	switch kind {
+	case LevelDB, "":
+		db, err := NewLDBDatabase(file, reffile, cache, handles)
+		if err != nil {
+			return nil, err
+		}
+		return db, nil
+	case Pebble:
+		return newPebbleDatabase(file, reffile, cache, handles)
+	case Memory:
+		return NewMemDatabase(), nil
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %v", kind)
+	}
+}
+
+// NewNodeDatabase opens the database of a node under the data directory with
+// the given storage backend. It refuses to start a fresh Pebble database while
+// a LevelDB one is present, since the data needs to be migrated first.
+func NewNodeDatabase(dataPath string, kind string, cache int, handles int) (database.Database, error) {
+	file, reffile := DatabasePaths(dataPath, kind)
+	if kind == Pebble && PebbleEnabled && !dbExists(file) {
+		if ldbFile, _ := DatabasePaths(dataPath, LevelDB); dbExists(ldbFile) {
+			return nil, fmt.Errorf("found a leveldb database at %v, run `theta db migrate` first or "+
+				"switch back to the leveldb backend", ldbFile)
+		}
+	}
+	return NewDatabase(kind, file, reffile, cache, handles)
+}
+
+// dbExists returns whether a LevelDB or Pebble database exists at the given path.
+func dbExists(file string) bool {
+	_, err := os.Stat(path.Join(file, "CURRENT"))
+	return err == nil
+}
@@ -1,6 +1,7 @@
 package backend
 
 import (
+	"bytes"
 	"encoding/json"
 
 	"github.com/dgraph-io/badger"
@@ -164,6 +165,26 @@ func (db *BadgerDatabase) CountReference(key []byte) (int, error) {
 	return document.Reference, nil
 }
 
+// NewIterator returns an iterator over the keys in the range [start, end), reading
+// from a consistent snapshot of the database.
+func (db *BadgerDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	txn := db.db.NewTransaction(false)
+	opts := badger.DefaultIteratorOptions
+	opts.Reverse = reverse
+	return &badgerIterator{
+		txn:     txn,
+		it:      txn.NewIterator(opts),
+		start:   start,
+		end:     end,
+		reverse: reverse,
+	}
+}
+
+func (db *BadgerDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
 func (db *BadgerDatabase) Close() {
 	db.db.Close()
 }
@@ -317,3 +338,81 @@ func (b *badgerdbBatch) Reset() {
 	b.references = make(map[string]int)
 	b.size = 0
 }
+
+type badgerIterator struct {
+	txn     *badger.Txn
+	it      *badger.Iterator
+	start   []byte
+	end     []byte
+	reverse bool
+	started bool
+	key     []byte
+	value   []byte
+	err     error
+}
+
+func (bi *badgerIterator) Next() bool {
+	if bi.err != nil {
+		return false
+	}
+
+	if !bi.started {
+		bi.started = true
+		if bi.reverse && bi.end != nil {
+			bi.it.Seek(bi.end)
+		} else if !bi.reverse && bi.start != nil {
+			bi.it.Seek(bi.start)
+		} else {
+			bi.it.Rewind()
+		}
+	} else {
+		bi.it.Next()
+	}
+
+	for ; bi.it.Valid(); bi.it.Next() {
+		item := bi.it.Item()
+		key := item.Key()
+		if bi.reverse {
+			if bi.end != nil && bytes.Compare(key, bi.end) >= 0 {
+				// Seek stops at the end key itself, which is excluded
+				continue
+			}
+			if bi.start != nil && bytes.Compare(key, bi.start) < 0 {
+				break
+			}
+		} else if bi.end != nil && bytes.Compare(key, bi.end) >= 0 {
+			break
+		}
+
+		var document Document
+		bi.err = item.Value(func(val []byte) error {
+			return json.Unmarshal(val, &document)
+		})
+		if bi.err != nil {
+			break
+		}
+		bi.key = item.KeyCopy(nil)
+		bi.value = document.Value
+		return true
+	}
+
+	bi.key, bi.value = nil, nil
+	return false
+}
+
+func (bi *badgerIterator) Error() error {
+	return bi.err
+}
+
+func (bi *badgerIterator) Key() []byte {
+	return bi.key
+}
+
+func (bi *badgerIterator) Value() []byte {
+	return bi.value
+}
+
+func (bi *badgerIterator) Release() {
+	bi.it.Close()
+	bi.txn.Discard()
+}
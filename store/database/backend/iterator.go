@@ -0,0 +1,154 @@
+package backend
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/syndtr/goleveldb/leveldb/iterator"
+	"github.com/thetatoken/theta/store/database"
+)
+
+//
+// ------ ldbIterator -----
+//
+
+type ldbIterator struct {
+	it      iterator.Iterator
+	reverse bool
+	started bool
+}
+
+var _ database.Iterator = (*ldbIterator)(nil)
+
+// NewLDBIterator wraps a LevelDB iterator, which can be positioned in either
+// direction, into a database.Iterator walking forward or in reverse.
+func NewLDBIterator(it iterator.Iterator, reverse bool) database.Iterator {
+	return &ldbIterator{
+		it:      it,
+		reverse: reverse,
+	}
+}
+
+func (li *ldbIterator) Next() bool {
+	if !li.reverse {
+		return li.it.Next()
+	}
+	if !li.started {
+		li.started = true
+		return li.it.Last()
+	}
+	return li.it.Prev()
+}
+
+func (li *ldbIterator) Error() error {
+	return li.it.Error()
+}
+
+func (li *ldbIterator) Key() []byte {
+	return li.it.Key()
+}
+
+func (li *ldbIterator) Value() []byte {
+	return li.it.Value()
+}
+
+func (li *ldbIterator) Release() {
+	li.it.Release()
+}
+
+//
+// ------ sliceIterator -----
+//
+
+// sliceIterator iterates over key/value pairs held in memory. It is used by
+// the backends that can't iterate over their keys in order natively.
+type sliceIterator struct {
+	keys   [][]byte
+	values [][]byte
+	index  int
+}
+
+var _ database.Iterator = (*sliceIterator)(nil)
+
+// newSliceIterator sorts the given key/value pairs and returns an iterator
+// over them. The slices are taken over by the iterator.
+func newSliceIterator(keys, values [][]byte, reverse bool) *sliceIterator {
+	si := &sliceIterator{
+		keys:   keys,
+		values: values,
+		index:  -1,
+	}
+	sort.Sort(si)
+	if reverse {
+		for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+			si.Swap(i, j)
+		}
+	}
+	return si
+}
+
+func (si *sliceIterator) Len() int {
+	return len(si.keys)
+}
+
+func (si *sliceIterator) Less(i, j int) bool {
+	return bytes.Compare(si.keys[i], si.keys[j]) < 0
+}
+
+func (si *sliceIterator) Swap(i, j int) {
+	si.keys[i], si.keys[j] = si.keys[j], si.keys[i]
+	si.values[i], si.values[j] = si.values[j], si.values[i]
+}
+
+func (si *sliceIterator) Next() bool {
+	if si.index >= len(si.keys) {
+		return false
+	}
+	si.index++
+	return si.index < len(si.keys)
+}
+
+func (si *sliceIterator) Error() error {
+	return nil
+}
+
+func (si *sliceIterator) Key() []byte {
+	if si.index < 0 || si.index >= len(si.keys) {
+		return nil
+	}
+	return si.keys[si.index]
+}
+
+func (si *sliceIterator) Value() []byte {
+	if si.index < 0 || si.index >= len(si.values) {
+		return nil
+	}
+	return si.values[si.index]
+}
+
+func (si *sliceIterator) Release() {
+	si.keys = nil
+	si.values = nil
+}
+
+//
+// ------ errIterator -----
+//
+
+// errIterator is an empty iterator reporting the error that prevented the
+// creation of a real one.
+type errIterator struct {
+	err error
+}
+
+var _ database.Iterator = (*errIterator)(nil)
+
+func newErrIterator(err error) *errIterator {
+	return &errIterator{err: err}
+}
+
+func (ei *errIterator) Next() bool    { return false }
+func (ei *errIterator) Error() error  { return ei.err }
+func (ei *errIterator) Key() []byte   { return nil }
+func (ei *errIterator) Value() []byte { return nil }
+func (ei *errIterator) Release()      {}
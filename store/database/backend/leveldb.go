@@ -28,7 +28,6 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/thetatoken/theta/common/metrics"
@@ -236,13 +235,23 @@ func (db *LDBDatabase) CountReference(key []byte) (int, error) {
 	return ref, nil
 }
 
-func (db *LDBDatabase) NewIterator() iterator.Iterator {
-	return db.db.NewIterator(nil, nil)
+// NewIterator returns a iterator to iterate over the database content in the range [start, end).
+func (db *LDBDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	return NewLDBIterator(db.db.NewIterator(&util.Range{Start: start, Limit: end}, nil), reverse)
 }
 
 // NewIteratorWithPrefix returns a iterator to iterate over subset of database content with a particular prefix.
-func (db *LDBDatabase) NewIteratorWithPrefix(prefix []byte) iterator.Iterator {
-	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
+func (db *LDBDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	return NewLDBIterator(db.db.NewIterator(util.BytesPrefix(prefix), nil), reverse)
+}
+
+// Compact flattens the underlying data store and the reference store for the
+// given key range.
+func (db *LDBDatabase) Compact(start []byte, limit []byte) error {
+	if err := db.db.CompactRange(util.Range{Start: start, Limit: limit}); err != nil {
+		return err
+	}
+	return db.refdb.CompactRange(util.Range{Start: start, Limit: limit})
 }
 
 func (db *LDBDatabase) Close() {
@@ -604,10 +613,40 @@ func (dt *table) CountReference(key []byte) (int, error) {
 	return dt.db.CountReference(key)
 }
 
+func (dt *table) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	prefix := []byte(dt.prefix)
+	pstart, pend := database.PrefixRange(prefix)
+	if start != nil {
+		pstart = append(append([]byte{}, prefix...), start...)
+	}
+	if end != nil {
+		pend = append(append([]byte{}, prefix...), end...)
+	}
+	return &tableIterator{dt.db.NewIterator(pstart, pend, reverse), len(prefix)}
+}
+
+func (dt *table) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	return &tableIterator{dt.db.NewIteratorWithPrefix(append([]byte(dt.prefix), prefix...), reverse), len(dt.prefix)}
+}
+
 func (dt *table) Close() {
 	// Do nothing; don't close the underlying DB.
 }
 
+// tableIterator strips the table prefix from the keys of the underlying iterator.
+type tableIterator struct {
+	database.Iterator
+	prefixLen int
+}
+
+func (ti *tableIterator) Key() []byte {
+	key := ti.Iterator.Key()
+	if key == nil {
+		return nil
+	}
+	return key[ti.prefixLen:]
+}
+
 type tableBatch struct {
 	batch  database.Batch
 	prefix string
@@ -496,3 +496,133 @@ func testParallelPutGet(db database.Database, t *testing.T) {
 	}
 	pending.Wait()
 }
+
+func TestLDB_Iterator(t *testing.T) {
+	db, remove := newTestLDB()
+	defer remove()
+	testIterator(db, t)
+}
+
+func TestMemoryDB_Iterator(t *testing.T) {
+	testIterator(NewMemDatabase(), t)
+}
+
+func TestTable_Iterator(t *testing.T) {
+	memDB := NewMemDatabase()
+	memDB.Put([]byte("aa"), []byte("outside"))
+	memDB.Put([]byte("tc"), []byte("outside"))
+	testIterator(NewTable(memDB, "t"), t)
+}
+
+func testIterator(db database.Database, t *testing.T) {
+	keys := []string{"a", "ab", "abc", "b", "ba", "c", "\xff", "\xff\x01"}
+	for _, k := range keys {
+		if err := db.Put([]byte(k), []byte("v"+k)); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+	}
+
+	collect := func(it database.Iterator) []string {
+		defer it.Release()
+		res := []string{}
+		for it.Next() {
+			if !bytes.Equal(it.Value(), []byte("v"+string(it.Key()))) {
+				t.Fatalf("iterator returned wrong value for %q: %q", it.Key(), it.Value())
+			}
+			res = append(res, string(it.Key()))
+		}
+		if err := it.Error(); err != nil {
+			t.Fatalf("iterator failed: %v", err)
+		}
+		return res
+	}
+
+	check := func(name string, got []string, expected ...string) {
+		if fmt.Sprintf("%q", got) != fmt.Sprintf("%q", expected) {
+			t.Fatalf("%s: got %q expected %q", name, got, expected)
+		}
+	}
+
+	check("all", collect(db.NewIterator(nil, nil, false)), keys...)
+	check("all reverse", collect(db.NewIterator(nil, nil, true)), "\xff\x01", "\xff", "c", "ba", "b", "abc", "ab", "a")
+	check("range", collect(db.NewIterator([]byte("ab"), []byte("ba"), false)), "ab", "abc", "b")
+	check("range reverse", collect(db.NewIterator([]byte("ab"), []byte("ba"), true)), "b", "abc", "ab")
+	check("open start", collect(db.NewIterator(nil, []byte("b"), true)), "abc", "ab", "a")
+	check("open end", collect(db.NewIterator([]byte("bb"), nil, false)), "c", "\xff", "\xff\x01")
+	check("empty", collect(db.NewIterator([]byte("x"), []byte("y"), false)))
+	check("prefix", collect(db.NewIteratorWithPrefix([]byte("ab"), false)), "ab", "abc")
+	check("prefix reverse", collect(db.NewIteratorWithPrefix([]byte("a"), true)), "abc", "ab", "a")
+	check("prefix 0xff", collect(db.NewIteratorWithPrefix([]byte("\xff"), false)), "\xff", "\xff\x01")
+}
+
+func TestMergedIterator(t *testing.T) {
+	newer := NewMemDatabase()
+	older := NewMemDatabase()
+	for _, k := range []string{"a", "c", "d"} {
+		newer.Put([]byte(k), []byte("new"))
+	}
+	for _, k := range []string{"b", "c", "e"} {
+		older.Put([]byte(k), []byte("old"))
+	}
+
+	for _, reverse := range []bool{false, true} {
+		it := database.NewMergedIterator([]database.Iterator{
+			newer.NewIterator(nil, nil, reverse),
+			older.NewIterator(nil, nil, reverse),
+		}, reverse)
+		res := ""
+		for it.Next() {
+			res += fmt.Sprintf("%s=%s ", it.Key(), it.Value())
+		}
+		it.Release()
+
+		expected := "a=new b=old c=new d=new e=old "
+		if reverse {
+			expected = "e=old d=new c=new b=old a=new "
+		}
+		if res != expected {
+			t.Fatalf("merged iterator returned wrong result, reverse=%v, got %q expected %q", reverse, res, expected)
+		}
+	}
+}
+
+func TestLDB_Compact(t *testing.T) {
+	db, remove := newTestLDB()
+	defer remove()
+
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%03d", i))
+		if err := db.Put(key, []byte("value")); err != nil {
+			t.Fatalf("put failed: %v", err)
+		}
+		if err := db.Reference(key); err != nil {
+			t.Fatalf("reference failed: %v", err)
+		}
+		if i%2 == 0 {
+			if err := db.Delete(key); err != nil {
+				t.Fatalf("delete failed: %v", err)
+			}
+		}
+	}
+	if err := db.Compact(nil, nil); err != nil {
+		t.Fatalf("compact failed: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		key := []byte(fmt.Sprintf("key%03d", i))
+		has, err := db.Has(key)
+		if err != nil {
+			t.Fatalf("has failed: %v", err)
+		}
+		if has != (i%2 != 0) {
+			t.Fatalf("has returned wrong result for %q, got %v", key, has)
+		}
+		ref, err := db.CountReference(key)
+		if i%2 == 0 && err != store.ErrKeyNotFound {
+			t.Fatalf("expect reference of %q to be deleted, got %v, %v", key, ref, err)
+		}
+		if i%2 != 0 && (err != nil || ref != 1) {
+			t.Fatalf("expect reference of %q to be 1, got %v, %v", key, ref, err)
+		}
+	}
+}
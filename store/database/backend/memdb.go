@@ -131,6 +131,28 @@ func (db *MemDatabase) CountReference(key []byte) (int, error) {
 	return 0, store.ErrKeyNotFound
 }
 
+// NewIterator returns an iterator over a snapshot of the keys in the range [start, end).
+func (db *MemDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	db.lock.RLock()
+	defer db.lock.RUnlock()
+
+	keys := [][]byte{}
+	values := [][]byte{}
+	for key, value := range db.db {
+		if !database.InRange([]byte(key), start, end) {
+			continue
+		}
+		keys = append(keys, []byte(key))
+		values = append(values, common.CopyBytes(value))
+	}
+	return newSliceIterator(keys, values, reverse)
+}
+
+func (db *MemDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
 func (db *MemDatabase) Close() {}
 
 func (db *MemDatabase) NewBatch() database.Batch {
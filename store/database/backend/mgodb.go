@@ -116,6 +116,32 @@ func (db *MgoDatabase) CountReference(key []byte) (int, error) {
 	return result.Reference, nil
 }
 
+// NewIterator returns an iterator over the keys in the range [start, end). MongoDB
+// orders binary ids by length first, so the collection is scanned and the keys
+// in range are sorted in memory.
+func (db *MgoDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	keys := [][]byte{}
+	values := [][]byte{}
+	iter := db.collection.Find(nil).Iter()
+	var document Document
+	for iter.Next(&document) {
+		if database.InRange(document.Key, start, end) {
+			keys = append(keys, document.Key)
+			values = append(values, document.Value)
+		}
+		document = Document{}
+	}
+	if err := iter.Close(); err != nil {
+		return newErrIterator(err)
+	}
+	return newSliceIterator(keys, values, reverse)
+}
+
+func (db *MgoDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
 func (db *MgoDatabase) Close() {
 	db.session.Close()
 }
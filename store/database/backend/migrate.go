@@ -0,0 +1,102 @@
+package backend
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/store"
+	"github.com/thetatoken/theta/store/database"
+)
+
+// IsEmpty returns whether the database holds no entry.
+func IsEmpty(db database.Database) bool {
+	it := db.NewIterator(nil, nil, false)
+	defer it.Release()
+	return !it.Next()
+}
+
+// CopyDatabase copies all the entries of src, with their reference counts, to dst,
+// and returns the number of entries and of referenced entries copied. The progress
+// callback, if any, is called with the number of entries copied so far after each entry.
+func CopyDatabase(src database.Database, dst database.Database, progress func(entries int)) (entries int, refs int, err error) {
+	batch := dst.NewBatch()
+	it := src.NewIterator(nil, nil, false)
+	defer it.Release()
+	for it.Next() {
+		key := common.CopyBytes(it.Key())
+		if err = batch.Put(key, common.CopyBytes(it.Value())); err != nil {
+			return entries, refs, fmt.Errorf("failed to copy entry %v: %v", key, err)
+		}
+		ref, refErr := src.CountReference(key)
+		if refErr != nil && refErr != store.ErrKeyNotFound {
+			return entries, refs, fmt.Errorf("failed to count references of %v: %v", key, refErr)
+		}
+		if ref > 0 {
+			refs++
+		}
+		for i := 0; i < ref; i++ {
+			if err = batch.Reference(key); err != nil {
+				return entries, refs, fmt.Errorf("failed to copy references of %v: %v", key, err)
+			}
+		}
+
+		entries++
+		if progress != nil {
+			progress(entries)
+		}
+		if batch.ValueSize() > database.IdealBatchSize {
+			if err = batch.Write(); err != nil {
+				return entries, refs, fmt.Errorf("failed to write to the target db: %v", err)
+			}
+			batch.Reset()
+		}
+	}
+	if err = it.Error(); err != nil {
+		return entries, refs, fmt.Errorf("failed to iterate over the source db: %v", err)
+	}
+	if err = batch.Write(); err != nil {
+		return entries, refs, fmt.Errorf("failed to write to the target db: %v", err)
+	}
+	return entries, refs, nil
+}
+
+// VerifyCopy checks that dst holds the same entries and reference counts as src, and
+// returns the number of entries verified. The progress callback, if any, is called with
+// the number of entries verified so far after each entry.
+func VerifyCopy(src database.Database, dst database.Database, progress func(entries int)) (entries int, err error) {
+	srcIt := src.NewIterator(nil, nil, false)
+	defer srcIt.Release()
+	dstIt := dst.NewIterator(nil, nil, false)
+	defer dstIt.Release()
+
+	for srcIt.Next() {
+		if !dstIt.Next() {
+			return entries, fmt.Errorf("entry %v missing from the target db", srcIt.Key())
+		}
+		if !bytes.Equal(srcIt.Key(), dstIt.Key()) || !bytes.Equal(srcIt.Value(), dstIt.Value()) {
+			return entries, fmt.Errorf("entry %v differs in the target db", srcIt.Key())
+		}
+		srcRef, _ := src.CountReference(srcIt.Key())
+		dstRef, _ := dst.CountReference(dstIt.Key())
+		if srcRef != dstRef {
+			return entries, fmt.Errorf("reference count of %v differs in the target db, %v != %v",
+				srcIt.Key(), srcRef, dstRef)
+		}
+
+		entries++
+		if progress != nil {
+			progress(entries)
+		}
+	}
+	if dstIt.Next() {
+		return entries, fmt.Errorf("unexpected entry %v in the target db", dstIt.Key())
+	}
+	if err = srcIt.Error(); err != nil {
+		return entries, fmt.Errorf("failed to iterate over the source db: %v", err)
+	}
+	if err = dstIt.Error(); err != nil {
+		return entries, fmt.Errorf("failed to iterate over the target db: %v", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,112 @@
+package backend
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thetatoken/theta/store/database"
+)
+
+// fillTestDB writes entries to the database, with a reference count on every other one.
+func fillTestDB(db database.Database, n int) {
+	for i := 0; i < n; i++ {
+		key := []byte(fmt.Sprintf("key%04d", i))
+		db.Put(key, []byte(fmt.Sprintf("value%d", i)))
+		for j := 0; j < i%2*(i%5+1); j++ {
+			db.Reference(key)
+		}
+	}
+}
+
+func testMigrate(src database.Database, dst database.Database, t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	fillTestDB(src, 1000)
+	assert.True(IsEmpty(dst))
+
+	progress := 0
+	entries, refs, err := CopyDatabase(src, dst, func(entries int) { progress = entries })
+	require.Nil(err)
+	assert.Equal(1000, entries)
+	assert.Equal(500, refs)
+	assert.Equal(1000, progress)
+	assert.False(IsEmpty(dst))
+
+	verified, err := VerifyCopy(src, dst, nil)
+	require.Nil(err)
+	assert.Equal(1000, verified)
+
+	ref, err := dst.CountReference([]byte("key0003"))
+	require.Nil(err)
+	assert.Equal(4, ref)
+
+	// A differing reference count, value, missing or extra entry fails the verification
+	dst.Reference([]byte("key0003"))
+	_, err = VerifyCopy(src, dst, nil)
+	assert.NotNil(err)
+	dst.Dereference([]byte("key0003"))
+
+	dst.Put([]byte("key0010"), []byte("other"))
+	_, err = VerifyCopy(src, dst, nil)
+	assert.NotNil(err)
+	dst.Put([]byte("key0010"), []byte("value10"))
+
+	dst.Put([]byte("key9999"), []byte("extra"))
+	_, err = VerifyCopy(src, dst, nil)
+	assert.NotNil(err)
+	dst.Delete([]byte("key9999"))
+
+	src.Put([]byte("key9999"), []byte("missing"))
+	_, err = VerifyCopy(src, dst, nil)
+	assert.NotNil(err)
+}
+
+func TestMemoryDB_Migrate(t *testing.T) {
+	testMigrate(NewMemDatabase(), NewMemDatabase(), t)
+}
+
+func TestLDB_Migrate(t *testing.T) {
+	src, removeSrc := newTestLDB()
+	defer removeSrc()
+	dst, removeDst := newTestLDB()
+	defer removeDst()
+	testMigrate(src, dst, t)
+}
+
+func TestNewDatabase(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	dataPath, err := ioutil.TempDir(os.TempDir(), "backend_test_")
+	require.Nil(err)
+	defer os.RemoveAll(dataPath)
+
+	file, reffile := DatabasePaths(dataPath, LevelDB)
+	assert.Equal(path.Join(dataPath, "db", "main"), file)
+	assert.Equal(path.Join(dataPath, "db", "ref"), reffile)
+	pebbleFile, pebbleReffile := DatabasePaths(dataPath, Pebble)
+	assert.Equal(path.Join(dataPath, "db", "pebble", "main"), pebbleFile)
+	assert.Equal(path.Join(dataPath, "db", "pebble", "ref"), pebbleReffile)
+
+	db, err := NewDatabase(Memory, "", "", 0, 0)
+	require.Nil(err)
+	assert.IsType(&MemDatabase{}, db)
+
+	_, err = NewDatabase("unknown", file, reffile, 0, 0)
+	assert.NotNil(err)
+
+	db, err = NewNodeDatabase(dataPath, LevelDB, 0, 0)
+	require.Nil(err)
+	db.Put([]byte("key"), []byte("value"))
+	db.Close()
+
+	// A fresh Pebble database is not opened while the data is still in the LevelDB one
+	_, err = NewNodeDatabase(dataPath, Pebble, 0, 0)
+	assert.NotNil(err)
+}
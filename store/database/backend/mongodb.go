@@ -155,6 +155,39 @@ func (db *MongoDatabase) CountReference(key []byte) (int, error) {
 	return result.Reference, err
 }
 
+// NewIterator returns an iterator over the keys in the range [start, end). MongoDB
+// orders binary ids by length first, so the collection is scanned and the keys
+// in range are sorted in memory.
+func (db *MongoDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	cursor, err := db.collection.Find(nil, bson.NewDocument())
+	if err != nil {
+		return newErrIterator(err)
+	}
+	defer cursor.Close(nil)
+
+	keys := [][]byte{}
+	values := [][]byte{}
+	for cursor.Next(nil) {
+		result := new(Document)
+		if err := cursor.Decode(result); err != nil {
+			return newErrIterator(err)
+		}
+		if database.InRange(result.Key, start, end) {
+			keys = append(keys, result.Key)
+			values = append(values, result.Value)
+		}
+	}
+	if err := cursor.Err(); err != nil {
+		return newErrIterator(err)
+	}
+	return newSliceIterator(keys, values, reverse)
+}
+
+func (db *MongoDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
 func (db *MongoDatabase) Close() {
 	err := db.client.Disconnect(context.Background())
 	if err == nil {
@@ -0,0 +1,543 @@
+// +build pebble
+
+package backend
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/bloom"
+	"github.com/thetatoken/theta/common/metrics"
+	"github.com/thetatoken/theta/store"
+	"github.com/thetatoken/theta/store/database"
+)
+
+// PebbleEnabled indicates whether the Pebble backend is compiled in.
+const PebbleEnabled = true
+
+// PebbleDatabase a Pebble wrapped object. Like LDBDatabase, the reference
+// counts are kept in a separate database.
+type PebbleDatabase struct {
+	fn    string     // filename for reporting
+	db    *pebble.DB // Pebble instance
+	refdb *pebble.DB // Pebble instance for references
+
+	compTimeMeter    metrics.Meter // Meter for measuring the total time spent in database compaction
+	compReadMeter    metrics.Meter // Meter for measuring the data read during compaction
+	compWriteMeter   metrics.Meter // Meter for measuring the data written during compaction
+	writeDelayNMeter metrics.Meter // Meter for measuring the write delay number due to database compaction
+	writeDelayMeter  metrics.Meter // Meter for measuring the write delay duration due to database compaction
+	diskReadMeter    metrics.Meter // Meter for measuring the effective amount of data read
+	diskWriteMeter   metrics.Meter // Meter for measuring the effective amount of data written
+
+	statsLock           sync.Mutex    // Mutex protecting the compaction and write stall statistics
+	activeComp          int           // Current number of active compactions
+	compStartTime       time.Time     // The start time of the earliest currently-active compaction
+	compTime            time.Duration // Total time spent in compaction
+	writeDelayStartTime time.Time     // The start time of the latest write stall
+	writeDelayCount     int64         // Total number of write stall counts
+	writeDelayTime      time.Duration // Total time spent in write stalls
+
+	quitLock sync.Mutex      // Mutex protecting the quit channel access
+	quitChan chan chan error // Quit channel to stop the metrics collection before closing the database
+}
+
+var _ database.Database = (*PebbleDatabase)(nil)
+
+func newPebbleDatabase(file string, reffile string, cache int, handles int) (database.Database, error) {
+	db, err := NewPebbleDatabase(file, reffile, cache, handles)
+	if err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+// NewPebbleDatabase returns a Pebble wrapped object.
+func NewPebbleDatabase(file string, reffile string, cache int, handles int) (*PebbleDatabase, error) {
+	// Ensure we have some minimal caching and file guarantees
+	if cache < 16 {
+		cache = 16
+	}
+	if handles < 16 {
+		handles = 16
+	}
+	logger.Infof("Allocated cache and file handles, cache: %v, handles: %v", cache, handles)
+
+	pdb := &PebbleDatabase{fn: file}
+
+	db, err := pebble.Open(file, pdb.options(cache/2, handles, true))
+	if err != nil {
+		return nil, err
+	}
+	refdb, err := pebble.Open(reffile, pdb.options(cache/2, handles, false))
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	pdb.db = db
+	pdb.refdb = refdb
+	return pdb, nil
+}
+
+func (db *PebbleDatabase) options(cache int, handles int, listen bool) *pebble.Options {
+	opts := &pebble.Options{
+		Cache:        pebble.NewCache(int64(cache * 1024 * 1024)),
+		MaxOpenFiles: handles,
+		// Two memory tables are used, one is being flushed while the other
+		// one receives the writes.
+		MemTableSize:                cache / 4 * 1024 * 1024,
+		MemTableStopWritesThreshold: 2,
+		MaxConcurrentCompactions:    func() int { return 4 },
+		Levels: []pebble.LevelOptions{
+			{TargetFileSize: 2 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 4 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 8 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 16 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 32 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 64 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+			{TargetFileSize: 128 * 1024 * 1024, FilterPolicy: bloom.FilterPolicy(10)},
+		},
+	}
+	if listen {
+		opts.EventListener = &pebble.EventListener{
+			CompactionBegin: db.onCompactionBegin,
+			CompactionEnd:   db.onCompactionEnd,
+			WriteStallBegin: db.onWriteStallBegin,
+			WriteStallEnd:   db.onWriteStallEnd,
+		}
+	}
+	return opts
+}
+
+func (db *PebbleDatabase) onCompactionBegin(info pebble.CompactionInfo) {
+	db.statsLock.Lock()
+	defer db.statsLock.Unlock()
+
+	if db.activeComp == 0 {
+		db.compStartTime = time.Now()
+	}
+	db.activeComp++
+}
+
+func (db *PebbleDatabase) onCompactionEnd(info pebble.CompactionInfo) {
+	db.statsLock.Lock()
+	defer db.statsLock.Unlock()
+
+	if db.activeComp == 1 {
+		db.compTime += time.Since(db.compStartTime)
+	}
+	if db.activeComp > 0 {
+		db.activeComp--
+	}
+}
+
+func (db *PebbleDatabase) onWriteStallBegin(info pebble.WriteStallBeginInfo) {
+	db.statsLock.Lock()
+	defer db.statsLock.Unlock()
+
+	db.writeDelayStartTime = time.Now()
+	db.writeDelayCount++
+}
+
+func (db *PebbleDatabase) onWriteStallEnd() {
+	db.statsLock.Lock()
+	defer db.statsLock.Unlock()
+
+	db.writeDelayTime += time.Since(db.writeDelayStartTime)
+}
+
+// Path returns the path to the database directory.
+func (db *PebbleDatabase) Path() string {
+	return db.fn
+}
+
+// Put puts the given key / value to the queue
+func (db *PebbleDatabase) Put(key []byte, value []byte) error {
+	return db.db.Set(key, value, pebble.NoSync)
+}
+
+func (db *PebbleDatabase) Has(key []byte) (bool, error) {
+	_, closer, err := db.db.Get(key)
+	if err == pebble.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	closer.Close()
+	return true, nil
+}
+
+// Get returns the given key if it's present.
+func (db *PebbleDatabase) Get(key []byte) ([]byte, error) {
+	return pebbleGet(db.db, key)
+}
+
+// Delete deletes the key from the queue and database
+func (db *PebbleDatabase) Delete(key []byte) error {
+	db.refdb.Delete(key, pebble.NoSync)
+	return db.db.Delete(key, pebble.NoSync)
+}
+
+func (db *PebbleDatabase) Reference(key []byte) error {
+	// check if k/v exists
+	if _, err := db.Get(key); err != nil {
+		return err
+	}
+
+	var ref int
+	dat, err := pebbleGet(db.refdb, key)
+	if err != nil {
+		if err != store.ErrKeyNotFound {
+			return err
+		}
+		ref = 1
+	} else {
+		ref, err = strconv.Atoi(string(dat))
+		if err != nil {
+			return err
+		}
+		ref++
+	}
+	return db.refdb.Set(key, []byte(strconv.Itoa(ref)), pebble.NoSync)
+}
+
+func (db *PebbleDatabase) Dereference(key []byte) error {
+	// check if k/v exists
+	if _, err := db.Get(key); err != nil {
+		return err
+	}
+
+	dat, err := pebbleGet(db.refdb, key)
+	if err != nil {
+		if err != store.ErrKeyNotFound {
+			return err
+		}
+		return nil
+	}
+	ref, err := strconv.Atoi(string(dat))
+	if err != nil {
+		return err
+	}
+	if ref > 0 {
+		return db.refdb.Set(key, []byte(strconv.Itoa(ref-1)), pebble.NoSync)
+	}
+	return nil
+}
+
+func (db *PebbleDatabase) CountReference(key []byte) (int, error) {
+	dat, err := pebbleGet(db.refdb, key)
+	if err != nil {
+		return 0, err
+	}
+	if len(dat) == 0 {
+		return 0, nil
+	}
+	return strconv.Atoi(string(dat))
+}
+
+// NewIterator returns a iterator to iterate over the database content in the range [start, end).
+func (db *PebbleDatabase) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	return &pebbleIterator{
+		it:      db.db.NewIter(&pebble.IterOptions{LowerBound: start, UpperBound: end}),
+		reverse: reverse,
+	}
+}
+
+// NewIteratorWithPrefix returns a iterator to iterate over subset of database content with a particular prefix.
+func (db *PebbleDatabase) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return db.NewIterator(start, end, reverse)
+}
+
+// Compact flattens the underlying data store and the reference store for the
+// given key range.
+func (db *PebbleDatabase) Compact(start []byte, limit []byte) error {
+	// Pebble has no way to express an unbounded range, a key of 32 0xff bytes
+	// is past all the keys in use, including trie node hashes.
+	if limit == nil {
+		limit = bytes.Repeat([]byte{0xff}, 32)
+	}
+	if err := db.db.Compact(start, limit, true); err != nil {
+		return err
+	}
+	return db.refdb.Compact(start, limit, true)
+}
+
+func (db *PebbleDatabase) Close() {
+	// Stop the metrics collection to avoid internal database races
+	db.quitLock.Lock()
+	defer db.quitLock.Unlock()
+
+	if db.quitChan != nil {
+		errc := make(chan error)
+		db.quitChan <- errc
+		if err := <-errc; err != nil {
+			logger.Errorf("Metrics collection failed, err: %v", err)
+		}
+		db.quitChan = nil
+	}
+	err := db.db.Close()
+	if refErr := db.refdb.Close(); err == nil {
+		err = refErr
+	}
+	if err == nil {
+		logger.Infof("Database closed")
+	} else {
+		logger.Errorf("Failed to close database, err: %v", err)
+	}
+}
+
+// Meter configures the database metrics collectors and
+func (db *PebbleDatabase) Meter(prefix string) {
+	if metrics.Enabled {
+		// Initialize all the metrics collector at the requested prefix
+		db.compTimeMeter = metrics.NewRegisteredMeter(prefix+"compact/time", nil)
+		db.compReadMeter = metrics.NewRegisteredMeter(prefix+"compact/input", nil)
+		db.compWriteMeter = metrics.NewRegisteredMeter(prefix+"compact/output", nil)
+		db.diskReadMeter = metrics.NewRegisteredMeter(prefix+"disk/read", nil)
+		db.diskWriteMeter = metrics.NewRegisteredMeter(prefix+"disk/write", nil)
+	}
+	// Initialize write delay metrics no matter we are in metric mode or not.
+	db.writeDelayMeter = metrics.NewRegisteredMeter(prefix+"compact/writedelay/duration", nil)
+	db.writeDelayNMeter = metrics.NewRegisteredMeter(prefix+"compact/writedelay/counter", nil)
+
+	// Create a quit channel for the periodic collector and run it
+	db.quitLock.Lock()
+	db.quitChan = make(chan chan error)
+	db.quitLock.Unlock()
+
+	go db.meter(3 * time.Second)
+}
+
+// meter periodically retrieves the Pebble metrics and the statistics collected
+// by the event listener, and reports them to the metrics subsystem under the
+// same names as the LevelDB backend.
+func (db *PebbleDatabase) meter(refresh time.Duration) {
+	var (
+		compTime, writeDelayTime           time.Duration
+		writeDelayCount                    int64
+		compRead, compWrite, nRead, nWrite uint64
+		lastWritePaused                    time.Time
+		errc                               chan error
+	)
+
+	for errc == nil {
+		stats := db.db.Metrics()
+		var curCompRead, curCompWrite, curFlush uint64
+		for _, level := range stats.Levels {
+			curCompRead += level.BytesRead
+			curCompWrite += level.BytesCompacted
+			curFlush += level.BytesFlushed
+		}
+		// Pebble does not count the reads served from the tables, only those
+		// of compactions are reported.
+		curRead := curCompRead
+		curWrite := stats.WAL.BytesWritten + curFlush + curCompWrite
+
+		db.statsLock.Lock()
+		curCompTime := db.compTime
+		if db.activeComp > 0 {
+			curCompTime += time.Since(db.compStartTime)
+		}
+		curWriteDelayCount, curWriteDelayTime := db.writeDelayCount, db.writeDelayTime
+		db.statsLock.Unlock()
+
+		if db.compTimeMeter != nil {
+			db.compTimeMeter.Mark(int64(curCompTime - compTime))
+		}
+		if db.compReadMeter != nil {
+			db.compReadMeter.Mark(int64(curCompRead - compRead))
+		}
+		if db.compWriteMeter != nil {
+			db.compWriteMeter.Mark(int64(curCompWrite - compWrite))
+		}
+		if db.writeDelayNMeter != nil {
+			db.writeDelayNMeter.Mark(curWriteDelayCount - writeDelayCount)
+		}
+		if db.writeDelayMeter != nil {
+			db.writeDelayMeter.Mark(int64(curWriteDelayTime - writeDelayTime))
+		}
+		if db.diskReadMeter != nil {
+			db.diskReadMeter.Mark(int64(curRead - nRead))
+		}
+		if db.diskWriteMeter != nil {
+			db.diskWriteMeter.Mark(int64(curWrite - nWrite))
+		}
+		// If a warning that db is stalling writes has been displayed, any subsequent
+		// warnings will be withheld for one minute not to overwhelm the user.
+		if curWriteDelayCount > writeDelayCount && time.Now().After(lastWritePaused.Add(writePauseWarningThrottler)) {
+			logger.Warnf("Database compacting, degraded performance")
+			lastWritePaused = time.Now()
+		}
+
+		compTime, writeDelayCount, writeDelayTime = curCompTime, curWriteDelayCount, curWriteDelayTime
+		compRead, compWrite, nRead, nWrite = curCompRead, curCompWrite, curRead, curWrite
+
+		// Sleep a bit, then repeat the stats collection
+		select {
+		case errc = <-db.quitChan:
+			// Quit requesting, stop hammering the database
+		case <-time.After(refresh):
+			// Timeout, gather a new set of stats
+		}
+	}
+	errc <- nil
+}
+
+func (db *PebbleDatabase) NewBatch() database.Batch {
+	return &pebbleBatch{db: db.db, refdb: db.refdb, b: db.db.NewBatch(), references: make(map[string]int)}
+}
+
+// pebbleGet returns a copy of the value of the given key, the value returned
+// by Pebble is only valid until the closer is closed.
+func pebbleGet(db *pebble.DB, key []byte) ([]byte, error) {
+	dat, closer, err := db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, store.ErrKeyNotFound
+		}
+		return nil, err
+	}
+	ret := make([]byte, len(dat))
+	copy(ret, dat)
+	closer.Close()
+	return ret, nil
+}
+
+type pebbleBatch struct {
+	db         *pebble.DB
+	refdb      *pebble.DB
+	b          *pebble.Batch
+	references map[string]int
+	size       int
+}
+
+func (b *pebbleBatch) Put(key, value []byte) error {
+	b.b.Set(key, value, nil)
+	b.size += len(value)
+	return nil
+}
+
+func (b *pebbleBatch) Delete(key []byte) error {
+	b.refdb.Delete(key, pebble.NoSync)
+	b.b.Delete(key, nil)
+	b.size += 1
+	return nil
+}
+
+func (b *pebbleBatch) Reference(key []byte) error {
+	b.references[string(key)]++
+	b.size++
+	return nil
+}
+
+func (b *pebbleBatch) Dereference(key []byte) error {
+	b.references[string(key)]--
+	b.size++
+	return nil
+}
+
+func (b *pebbleBatch) Write() error {
+	err := b.b.Commit(pebble.NoSync)
+	if err != nil {
+		return err
+	}
+
+	refs := b.refdb.NewBatch()
+	for k, v := range b.references {
+		if v == 0 {
+			// refs and derefs canceled out
+			continue
+		}
+
+		var ref int
+		dat, err := pebbleGet(b.refdb, []byte(k))
+		if err != nil {
+			if err != store.ErrKeyNotFound {
+				return err
+			}
+			if v < 0 {
+				continue
+			}
+			ref = v
+		} else {
+			ref, err = strconv.Atoi(string(dat))
+			if err != nil {
+				return err
+			}
+			if ref <= 0 && v < 0 {
+				continue
+			}
+			ref = ref + v
+			if ref < 0 {
+				ref = 0
+			}
+		}
+		refs.Set([]byte(k), []byte(strconv.Itoa(ref)), nil)
+	}
+	if err := refs.Commit(pebble.NoSync); err != nil {
+		return err
+	}
+
+	b.Reset()
+
+	return nil
+}
+
+func (b *pebbleBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *pebbleBatch) Reset() {
+	b.b.Reset()
+	b.references = make(map[string]int)
+	b.size = 0
+}
+
+// pebbleIterator adapts a Pebble iterator, which can be positioned in either
+// direction, into a database.Iterator walking forward or in reverse.
+type pebbleIterator struct {
+	it      *pebble.Iterator
+	reverse bool
+	started bool
+}
+
+func (pi *pebbleIterator) Next() bool {
+	if !pi.started {
+		pi.started = true
+		if pi.reverse {
+			return pi.it.Last()
+		}
+		return pi.it.First()
+	}
+	if pi.reverse {
+		return pi.it.Prev()
+	}
+	return pi.it.Next()
+}
+
+func (pi *pebbleIterator) Error() error {
+	return pi.it.Error()
+}
+
+func (pi *pebbleIterator) Key() []byte {
+	if !pi.it.Valid() {
+		return nil
+	}
+	return pi.it.Key()
+}
+
+func (pi *pebbleIterator) Value() []byte {
+	if !pi.it.Valid() {
+		return nil
+	}
+	return pi.it.Value()
+}
+
+func (pi *pebbleIterator) Release() {
+	pi.it.Close()
+}
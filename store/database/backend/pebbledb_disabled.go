@@ -0,0 +1,18 @@
+// +build !pebble
+
+package backend
+
+import (
+	"errors"
+
+	"github.com/thetatoken/theta/store/database"
+)
+
+// PebbleEnabled indicates whether the Pebble backend is compiled in.
+const PebbleEnabled = false
+
+var errPebbleDisabled = errors.New("the pebble backend is not compiled in, rebuild with -tags pebble")
+
+func newPebbleDatabase(file string, reffile string, cache int, handles int) (database.Database, error) {
+	return nil, errPebbleDisabled
+}
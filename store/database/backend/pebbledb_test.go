@@ -0,0 +1,59 @@
+// +build pebble
+
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func newTestPebbleDB() (*PebbleDatabase, func()) {
+	dirname, err := ioutil.TempDir(os.TempDir(), "pebble_test_")
+	if err != nil {
+		panic("failed to create test file: " + err.Error())
+	}
+
+	refname, err := ioutil.TempDir(os.TempDir(), "pebble_ref_test_")
+	if err != nil {
+		panic("failed to create test file: " + err.Error())
+	}
+
+	db, err := NewPebbleDatabase(dirname, refname, 0, 0)
+	if err != nil {
+		panic("failed to create test database: " + err.Error())
+	}
+
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dirname)
+		os.RemoveAll(refname)
+	}
+}
+
+func TestPebbleDB_PutGet(t *testing.T) {
+	db, remove := newTestPebbleDB()
+	batch := db.NewBatch()
+	defer remove()
+	testPutGet(db, batch, t)
+}
+
+func TestPebbleDB_ParallelPutGet(t *testing.T) {
+	db, remove := newTestPebbleDB()
+	defer remove()
+	testParallelPutGet(db, t)
+}
+
+func TestPebbleDB_Iterator(t *testing.T) {
+	db, remove := newTestPebbleDB()
+	defer remove()
+	testIterator(db, t)
+}
+
+func TestLDBToPebbleDB_Migrate(t *testing.T) {
+	src, removeSrc := newTestLDB()
+	defer removeSrc()
+	dst, removeDst := newTestPebbleDB()
+	defer removeDst()
+	testMigrate(src, dst, t)
+}
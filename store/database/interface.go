@@ -41,12 +41,46 @@ type Dereferencer interface {
 	Dereference(key []byte) error
 }
 
+// Iterator iterates over a database's key/value pairs in key order. An iterator
+// must be released after use. Key and Value are only valid until the next call
+// to Next, callers that retain them should make a copy.
+type Iterator interface {
+	// Next moves the iterator to the next key/value pair. It returns false when
+	// the iterator is exhausted or an error has occurred.
+	Next() bool
+	// Error returns any accumulated error.
+	Error() error
+	Key() []byte
+	Value() []byte
+	// Release releases the resources associated with the iterator.
+	Release()
+}
+
+// Iteratee wraps the iteration operations supported by databases.
+type Iteratee interface {
+	// NewIterator returns an iterator over the keys in the range [start, end).
+	// A nil start or end leaves the range unbounded on that side. Keys are
+	// visited in ascending order, or in descending order if reverse is set.
+	NewIterator(start, end []byte, reverse bool) Iterator
+	// NewIteratorWithPrefix returns an iterator over the keys with the given prefix.
+	NewIteratorWithPrefix(prefix []byte, reverse bool) Iterator
+}
+
+// Compacter wraps the compaction operation supported by some databases.
+type Compacter interface {
+	// Compact flattens the underlying storage for the key range [start, limit),
+	// discarding deleted and overwritten versions. A nil start or limit leaves
+	// the range unbounded on that side.
+	Compact(start []byte, limit []byte) error
+}
+
 // Database wraps all database operations. All methods are safe for concurrent use.
 type Database interface {
 	Putter
 	Deleter
 	Referencer
 	Dereferencer
+	Iteratee
 	Get(key []byte) ([]byte, error)
 	Has(key []byte) (bool, error)
 	CountReference(key []byte) (int, error)
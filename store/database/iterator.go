@@ -0,0 +1,129 @@
+package database
+
+import (
+	"bytes"
+)
+
+// PrefixRange returns the key range [start, end) that covers exactly the keys
+// with the given prefix. A nil end means the range is unbounded above.
+func PrefixRange(prefix []byte) (start, end []byte) {
+	var limit []byte
+	for i := len(prefix) - 1; i >= 0; i-- {
+		c := prefix[i]
+		if c < 0xff {
+			limit = make([]byte, i+1)
+			copy(limit, prefix)
+			limit[i] = c + 1
+			break
+		}
+	}
+	return prefix, limit
+}
+
+// InRange returns true if the key falls into [start, end). A nil start or end
+// leaves the range unbounded on that side.
+func InRange(key, start, end []byte) bool {
+	if start != nil && bytes.Compare(key, start) < 0 {
+		return false
+	}
+	if end != nil && bytes.Compare(key, end) >= 0 {
+		return false
+	}
+	return true
+}
+
+//
+// ------ mergedIterator -----
+//
+
+type mergedIterator struct {
+	iters   []Iterator
+	valid   []bool
+	reverse bool
+	started bool
+	current int
+	err     error
+}
+
+var _ Iterator = (*mergedIterator)(nil)
+
+// NewMergedIterator returns an iterator that merges the given iterators, which
+// must all iterate in the same direction, into a single ordered stream. When a
+// key is present in more than one iterator, the value of the iterator that comes
+// first in iters is returned and the others are skipped.
+func NewMergedIterator(iters []Iterator, reverse bool) Iterator {
+	return &mergedIterator{
+		iters:   iters,
+		valid:   make([]bool, len(iters)),
+		reverse: reverse,
+		current: -1,
+	}
+}
+
+func (mi *mergedIterator) Next() bool {
+	if mi.err != nil {
+		return false
+	}
+
+	if !mi.started {
+		mi.started = true
+		for i, it := range mi.iters {
+			mi.valid[i] = it.Next()
+		}
+	} else if mi.current >= 0 {
+		key := append([]byte{}, mi.iters[mi.current].Key()...)
+		for i, it := range mi.iters {
+			if mi.valid[i] && bytes.Equal(it.Key(), key) {
+				mi.valid[i] = it.Next()
+			}
+		}
+	}
+
+	mi.current = -1
+	for i, it := range mi.iters {
+		if err := it.Error(); err != nil {
+			mi.err = err
+			return false
+		}
+		if !mi.valid[i] {
+			continue
+		}
+		if mi.current < 0 || mi.before(it.Key(), mi.iters[mi.current].Key()) {
+			mi.current = i
+		}
+	}
+	return mi.current >= 0
+}
+
+// before returns true if a should be visited before b.
+func (mi *mergedIterator) before(a, b []byte) bool {
+	if mi.reverse {
+		return bytes.Compare(a, b) > 0
+	}
+	return bytes.Compare(a, b) < 0
+}
+
+func (mi *mergedIterator) Error() error {
+	return mi.err
+}
+
+func (mi *mergedIterator) Key() []byte {
+	if mi.current < 0 {
+		return nil
+	}
+	return mi.iters[mi.current].Key()
+}
+
+func (mi *mergedIterator) Value() []byte {
+	if mi.current < 0 {
+		return nil
+	}
+	return mi.iters[mi.current].Value()
+}
+
+func (mi *mergedIterator) Release() {
+	for _, it := range mi.iters {
+		it.Release()
+	}
+	mi.current = -1
+}
@@ -7,12 +7,12 @@ import (
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/errors"
 	"github.com/syndtr/goleveldb/leveldb/filter"
-	"github.com/syndtr/goleveldb/leveldb/iterator"
 	"github.com/syndtr/goleveldb/leveldb/opt"
 	"github.com/syndtr/goleveldb/leveldb/util"
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/store"
 	"github.com/thetatoken/theta/store/database"
+	"github.com/thetatoken/theta/store/database/backend"
 )
 
 type RawDB struct {
@@ -109,13 +109,19 @@ func (db *RawDB) CountReference(key []byte) (int, error) {
 	return 0, nil
 }
 
-func (db *RawDB) NewIterator() iterator.Iterator {
-	return db.db.NewIterator(nil, nil)
+// NewIterator returns a iterator to iterate over the database content in the range [start, end).
+func (db *RawDB) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	return backend.NewLDBIterator(db.db.NewIterator(&util.Range{Start: start, Limit: end}, nil), reverse)
 }
 
 // NewIteratorWithPrefix returns a iterator to iterate over subset of database content with a particular prefix.
-func (db *RawDB) NewIteratorWithPrefix(prefix []byte) iterator.Iterator {
-	return db.db.NewIterator(util.BytesPrefix(prefix), nil)
+func (db *RawDB) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	return backend.NewLDBIterator(db.db.NewIterator(util.BytesPrefix(prefix), nil), reverse)
+}
+
+// Compact flattens the underlying data store for the given key range.
+func (db *RawDB) Compact(start []byte, limit []byte) error {
+	return db.db.CompactRange(util.Range{Start: start, Limit: limit})
 }
 
 func (db *RawDB) Close() {
@@ -41,7 +41,7 @@ func NewRollingDB(parentPath string, root database.Database) *RollingDB {
 	}
 
 	rollingPath := path.Join(parentPath, "db", "rolling")
-	_ = os.Mkdir(rollingPath, 0700)
+	_ = os.MkdirAll(rollingPath, 0700)
 
 	rdb := &RollingDB{
 		parentPath: parentPath,
@@ -65,7 +65,7 @@ func (rdb *RollingDB) SetChain(chain *blockchain.Chain) {
 func (rdb *RollingDB) loadLayers(rollingPath string) (*DBLayer, []*DBLayer) {
 	files, err := ioutil.ReadDir(rollingPath)
 	if err != nil {
-		logger.Panicf("Failed to load layers", err)
+		logger.Panicf("Failed to load layers: %v", err)
 	}
 	names := []int{}
 	for _, file := range files {
@@ -262,7 +262,7 @@ func (rdb *RollingDB) Has(key []byte) (bool, error) {
 
 	for _, layer := range rdb.allLayers() {
 		result, err = layer.db.Has(key)
-		if err == nil {
+		if err == nil && result {
 			return result, err
 		}
 	}
@@ -287,6 +287,39 @@ func (rdb *RollingDB) Delete(key []byte) error {
 	return nil
 }
 
+// NewIterator returns an iterator over the keys in the range [start, end) across
+// all layers. A key present in several layers takes its value from the newest one.
+func (rdb *RollingDB) NewIterator(start, end []byte, reverse bool) database.Iterator {
+	rdb.mu.RLock()
+	defer rdb.mu.RUnlock()
+
+	iters := []database.Iterator{}
+	for _, layer := range rdb.allLayers() {
+		iters = append(iters, layer.db.NewIterator(start, end, reverse))
+	}
+	return database.NewMergedIterator(iters, reverse)
+}
+
+func (rdb *RollingDB) NewIteratorWithPrefix(prefix []byte, reverse bool) database.Iterator {
+	start, end := database.PrefixRange(prefix)
+	return rdb.NewIterator(start, end, reverse)
+}
+
+// Compact compacts the given key range of all the layers supporting compaction.
+func (rdb *RollingDB) Compact(start []byte, limit []byte) error {
+	rdb.mu.RLock()
+	defer rdb.mu.RUnlock()
+
+	for _, layer := range rdb.allLayers() {
+		if compacter, ok := layer.db.(database.Compacter); ok {
+			if err := compacter.Compact(start, limit); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (rdb *RollingDB) Close() {
 	for _, dbLayer := range rdb.layers {
 		dbLayer.db.Close()
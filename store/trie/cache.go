@@ -0,0 +1,112 @@
+package trie
+
+import (
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/metrics"
+	"github.com/thetatoken/theta/store/database"
+)
+
+var (
+	cleancacheHitMeter  = metrics.NewRegisteredMeter("trie/cleancache/hit", nil)
+	cleancacheMissMeter = metrics.NewRegisteredMeter("trie/cleancache/miss", nil)
+)
+
+// NodeCache is a disk database wrapper holding a bounded LRU cache of clean
+// trie nodes. All the trie Databases created on top of the same NodeCache,
+// e.g. by the copies of a StoreView, share the cache. Only the trie node reads
+// go through the cache, the other keys are read directly. Nodes deleted
+// through the wrapper, e.g. by pruning, are evicted from the cache. Since the
+// content of a node never changes, a node removed from the disk by other means,
+// e.g. with a rolling database layer, can only be served to readers of a state
+// that is no longer retained.
+type NodeCache struct {
+	database.Database
+
+	cache *lru.Cache
+}
+
+var _ database.Database = (*NodeCache)(nil)
+
+// NewNodeCache creates a cache of at most size trie nodes on top of db.
+func NewNodeCache(db database.Database, size int) *NodeCache {
+	cache, err := lru.New(size)
+	if err != nil {
+		logger.Panicf("Failed to create trie node cache: %v", err)
+	}
+	return &NodeCache{
+		Database: db,
+		cache:    cache,
+	}
+}
+
+// node returns the encoded trie node with the given hash, from the cache if
+// possible.
+func (nc *NodeCache) node(hash common.Hash) ([]byte, error) {
+	if blob, ok := nc.cache.Get(hash); ok {
+		cleancacheHitMeter.Mark(1)
+		return blob.([]byte), nil
+	}
+	cleancacheMissMeter.Mark(1)
+
+	blob, err := nc.Database.Get(hash[:])
+	if err != nil || blob == nil {
+		return blob, err
+	}
+	nc.cache.Add(hash, blob)
+	return blob, nil
+}
+
+// Len returns the number of cached nodes.
+func (nc *NodeCache) Len() int {
+	return nc.cache.Len()
+}
+
+// Delete evicts the key from the cache and deletes it from the database.
+func (nc *NodeCache) Delete(key []byte) error {
+	nc.evict(key)
+	return nc.Database.Delete(key)
+}
+
+// NewBatch returns a batch evicting the keys it deletes from the cache.
+func (nc *NodeCache) NewBatch() database.Batch {
+	return &nodeCacheBatch{
+		Batch: nc.Database.NewBatch(),
+		nc:    nc,
+	}
+}
+
+func (nc *NodeCache) evict(key []byte) {
+	if len(key) == common.HashLength {
+		nc.cache.Remove(common.BytesToHash(key))
+	}
+}
+
+type nodeCacheBatch struct {
+	database.Batch
+
+	nc      *NodeCache
+	deletes [][]byte
+}
+
+func (b *nodeCacheBatch) Delete(key []byte) error {
+	b.nc.evict(key)
+	b.deletes = append(b.deletes, common.CopyBytes(key))
+	return b.Batch.Delete(key)
+}
+
+func (b *nodeCacheBatch) Write() error {
+	err := b.Batch.Write()
+	// The nodes might have been cached again before the deletion was written
+	for _, key := range b.deletes {
+		b.nc.evict(key)
+	}
+	b.deletes = nil
+	return err
+}
+
+func (b *nodeCacheBatch) Reset() {
+	b.Batch.Reset()
+	b.deletes = nil
+}
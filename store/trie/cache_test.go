@@ -0,0 +1,104 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/thetatoken/theta/common"
+	dbbackend "github.com/thetatoken/theta/store/database/backend"
+)
+
+func TestNodeCache(t *testing.T) {
+	diskdb := dbbackend.NewMemDatabase()
+	cache := NewNodeCache(diskdb, 1024)
+	triedb := NewDatabase(cache)
+
+	trie, _ := New(common.Hash{}, triedb)
+	for i := 0; i < 200; i++ {
+		updateString(trie, fmt.Sprintf("key-%d", i), fmt.Sprintf("value-%d-qwerqwerqwerqwerqwerqwerqwer", i))
+	}
+	root, _ := trie.Commit(nil)
+	triedb.Commit(root, true)
+	if cache.Len() != 0 {
+		t.Fatalf("committed nodes should not be cached, got %d", cache.Len())
+	}
+
+	// Reads through a fresh trie database fill the shared cache
+	readAll := func(db *Database) {
+		tr, err := New(root, db)
+		if err != nil {
+			t.Fatalf("failed to open trie: %v", err)
+		}
+		for i := 0; i < 200; i++ {
+			want := fmt.Sprintf("value-%d-qwerqwerqwerqwerqwerqwerqwer", i)
+			if got := string(getString(tr, fmt.Sprintf("key-%d", i))); got != want {
+				t.Fatalf("wrong value for key-%d: got %q want %q", i, got, want)
+			}
+		}
+	}
+	readAll(NewDatabase(cache))
+	if cache.Len() != diskdb.Len() {
+		t.Fatalf("cached %d nodes, expected %d", cache.Len(), diskdb.Len())
+	}
+
+	// Another trie database on the same cache is served from memory
+	nodes := diskdb.Keys()
+	for _, key := range nodes {
+		diskdb.Delete(key)
+	}
+	readAll(NewDatabase(cache))
+
+	// Restore the nodes and their reference counts
+	for _, key := range nodes {
+		diskdb.Put(key, mustGet(t, cache, key))
+		diskdb.Reference(key)
+	}
+
+	// Pruning deletes through the cache, the pruned nodes must be evicted
+	tr, _ := New(root, NewDatabase(cache))
+	if err := tr.Prune(nil); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	if diskdb.Len() != 0 {
+		t.Fatalf("pruned trie still has %d nodes on disk", diskdb.Len())
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("pruned trie still has %d nodes in cache", cache.Len())
+	}
+	if _, err := New(root, NewDatabase(cache)); err == nil {
+		t.Fatalf("pruned trie should not be readable")
+	}
+}
+
+func TestNodeCacheBatchDelete(t *testing.T) {
+	diskdb := dbbackend.NewMemDatabase()
+	cache := NewNodeCache(diskdb, 16)
+
+	hash := common.BytesToHash([]byte("node"))
+	diskdb.Put(hash[:], []byte("blob"))
+	if blob, _ := cache.node(hash); string(blob) != "blob" {
+		t.Fatalf("unexpected node %q", blob)
+	}
+
+	batch := cache.NewBatch()
+	batch.Delete(hash[:])
+	// Read again before the deletion is written
+	cache.node(hash)
+	if err := batch.Write(); err != nil {
+		t.Fatalf("batch write failed: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("deleted node still in cache")
+	}
+	if blob, _ := cache.node(hash); blob != nil {
+		t.Fatalf("deleted node still readable: %q", blob)
+	}
+}
+
+func mustGet(t *testing.T, cache *NodeCache, key []byte) []byte {
+	blob, err := cache.node(common.BytesToHash(key))
+	if err != nil {
+		t.Fatalf("failed to get node %x: %v", key, err)
+	}
+	return blob
+}
@@ -66,6 +66,7 @@ type DatabaseReader interface {
 // periodically flush a couple tries to disk, garbage collecting the remainder.
 type Database struct {
 	diskdb database.Database // Persistent storage for matured trie nodes
+	cleans *NodeCache        // Cache of clean nodes shared with the other databases on the same disk database, if any
 
 	nodes  map[common.Hash]*cachedNode // Data and references relationships of a node
 	oldest common.Hash                 // Oldest tracked node, flush-list head
@@ -267,10 +268,13 @@ func expandNode(hash hashNode, n node, cachegen uint16) node {
 }
 
 // NewDatabase creates a new trie database to store ephemeral trie content before
-// its written out to disk or garbage collected.
+// its written out to disk or garbage collected. If diskdb is a NodeCache, the
+// clean nodes are read through its cache.
 func NewDatabase(diskdb database.Database) *Database {
+	cleans, _ := diskdb.(*NodeCache)
 	return &Database{
 		diskdb:    diskdb,
+		cleans:    cleans,
 		nodes:     map[common.Hash]*cachedNode{{}: {}},
 		preimages: make(map[common.Hash][]byte),
 	}
@@ -347,7 +351,7 @@ func (db *Database) node(hash common.Hash, cachegen uint16) node {
 		return node.obj(hash, cachegen)
 	}
 	// Content unavailable in memory, attempt to retrieve from disk
-	enc, err := db.diskdb.Get(hash[:])
+	enc, err := db.diskNode(hash)
 	if err != nil || enc == nil {
 		return nil
 	}
@@ -366,6 +370,15 @@ func (db *Database) Node(hash common.Hash) ([]byte, error) {
 		return node.rlp(), nil
 	}
 	// Content unavailable in memory, attempt to retrieve from disk
+	return db.diskNode(hash)
+}
+
+// diskNode retrieves an encoded trie node from the clean cache if available,
+// or else from the persistent database.
+func (db *Database) diskNode(hash common.Hash) ([]byte, error) {
+	if db.cleans != nil {
+		return db.cleans.node(hash)
+	}
 	return db.diskdb.Get(hash[:])
 }
 
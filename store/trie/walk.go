@@ -0,0 +1,71 @@
+package trie
+
+import (
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/store"
+	"github.com/thetatoken/theta/store/database"
+)
+
+// NodeVisitor is called by WalkNodes for every node stored under its own hash.
+// blob is nil if the node is missing from the database. Returning false skips
+// the subtrie rooted at the node.
+type NodeVisitor func(hash common.Hash, blob []byte) bool
+
+// LeafVisitor is called by WalkNodes for every key/value pair of the trie.
+type LeafVisitor func(key, value []byte)
+
+// WalkNodes traverses the trie with the given root directly on the disk
+// database, depth first. Unlike the NodeIterator, it does not stop at missing
+// nodes, which are reported to visit and skipped, so it can be used to check
+// the completeness of a trie. Either callback may be nil.
+func WalkNodes(root common.Hash, db database.Database, visit NodeVisitor, leaf LeafVisitor) error {
+	if root == (common.Hash{}) || root == emptyRoot {
+		return nil
+	}
+	return walkHashNode(hashNode(root[:]), nil, db, visit, leaf)
+}
+
+func walkHashNode(hash hashNode, path []byte, db database.Database, visit NodeVisitor, leaf LeafVisitor) error {
+	blob, err := db.Get(hash)
+	if err != nil && err != store.ErrKeyNotFound {
+		return err
+	}
+	if visit != nil && !visit(common.BytesToHash(hash), blob) {
+		return nil
+	}
+	if blob == nil {
+		return nil
+	}
+	n, err := decodeNode(hash, blob, 0)
+	if err != nil {
+		return err
+	}
+	return walkNode(n, path, db, visit, leaf)
+}
+
+func walkNode(nd node, path []byte, db database.Database, visit NodeVisitor, leaf LeafVisitor) error {
+	switch n := nd.(type) {
+	case *shortNode:
+		return walkNode(n.Val, append(append([]byte{}, path...), n.Key...), db, visit, leaf)
+	case *fullNode:
+		for i, child := range &n.Children {
+			if child == nil {
+				continue
+			}
+			childPath := path
+			if i < 16 {
+				childPath = append(append([]byte{}, path...), byte(i))
+			}
+			if err := walkNode(child, childPath, db, visit, leaf); err != nil {
+				return err
+			}
+		}
+	case hashNode:
+		return walkHashNode(n, path, db, visit, leaf)
+	case valueNode:
+		if leaf != nil {
+			leaf(hexToKeybytes(path), n)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/thetatoken/theta/common"
+	dbbackend "github.com/thetatoken/theta/store/database/backend"
+)
+
+func TestWalkNodes(t *testing.T) {
+	diskdb := dbbackend.NewMemDatabase()
+	triedb := NewDatabase(diskdb)
+
+	trie, _ := New(common.Hash{}, triedb)
+	all := make(map[string]string)
+	for i := 0; i < 200; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		value := fmt.Sprintf("value-%d-qwerqwerqwerqwerqwerqwerqwer", i)
+		all[key] = value
+		updateString(trie, key, value)
+	}
+	root, _ := trie.Commit(nil)
+	triedb.Commit(root, true)
+
+	found := make(map[string]string)
+	nodes := []common.Hash{}
+	err := WalkNodes(root, diskdb, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			t.Fatalf("unexpected missing node %v", hash.Hex())
+		}
+		nodes = append(nodes, hash)
+		return true
+	}, func(key, value []byte) {
+		found[string(key)] = string(value)
+	})
+	if err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	if len(found) != len(all) {
+		t.Fatalf("walk found %d leaves, expected %d", len(found), len(all))
+	}
+	for k, v := range all {
+		if found[k] != v {
+			t.Errorf("walk value mismatch for %s: got %q want %q", k, found[k], v)
+		}
+	}
+	if len(nodes) != diskdb.Len() {
+		t.Errorf("walk visited %d nodes, expected %d", len(nodes), diskdb.Len())
+	}
+
+	// Remove an inner node, its subtrie should be reported missing and skipped
+	diskdb.Delete(nodes[1][:])
+	missing := []common.Hash{}
+	leaves := 0
+	err = WalkNodes(root, diskdb, func(hash common.Hash, blob []byte) bool {
+		if blob == nil {
+			missing = append(missing, hash)
+		}
+		return true
+	}, func(key, value []byte) {
+		leaves++
+	})
+	if err != nil {
+		t.Fatalf("walk failed: %v", err)
+	}
+	if len(missing) != 1 || missing[0] != nodes[1] {
+		t.Errorf("walk reported wrong missing nodes: %v", missing)
+	}
+	if leaves >= len(all) {
+		t.Errorf("walk found %d leaves, expected less than %d", leaves, len(all))
+	}
+}
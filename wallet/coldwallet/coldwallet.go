@@ -146,6 +146,37 @@ func (w *ColdWallet) GetPublicKey(address common.Address) (*crypto.PublicKey, er
 }
 
 func (w *ColdWallet) Sign(address common.Address, txrlp common.Bytes) (*crypto.Signature, error) {
+	return w.signWithDevice(address, func(path types.DerivationPath) (common.Address, *crypto.Signature, error) {
+		return w.driver.SignTx(path, txrlp)
+	})
+}
+
+// SignMessage signs a message as personal_sign (EIP-191) on the device
+func (w *ColdWallet) SignMessage(address common.Address, message common.Bytes) (*crypto.Signature, error) {
+	return w.signWithDevice(address, func(path types.DerivationPath) (common.Address, *crypto.Signature, error) {
+		return w.driver.SignMessage(path, message)
+	})
+}
+
+// SignTypedData signs EIP-712 typed data on the device. The device signs the domain separator
+// and the hash of the message.
+func (w *ColdWallet) SignTypedData(address common.Address, typedData *types.TypedData) (*crypto.Signature, error) {
+	domainSeparator, err := typedData.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := typedData.MessageHash()
+	if err != nil {
+		return nil, err
+	}
+	return w.signWithDevice(address, func(path types.DerivationPath) (common.Address, *crypto.Signature, error) {
+		return w.driver.SignTypedData(path, domainSeparator, messageHash)
+	})
+}
+
+// signWithDevice signs with the key of the address on the device, and verifies the signer
+func (w *ColdWallet) signWithDevice(address common.Address,
+	sign func(path types.DerivationPath) (common.Address, *crypto.Signature, error)) (*crypto.Signature, error) {
 	w.stateLock.RLock()
 	defer w.stateLock.RUnlock()
 
@@ -167,8 +198,8 @@ func (w *ColdWallet) Sign(address common.Address, txrlp common.Bytes) (*crypto.S
 		w.hub.commsLock.Unlock()
 	}()
 
-	// Sign the transaction and verify the sender to avoid hardware fault surprises
-	senderAddr, signed, err := w.driver.SignTx(path, txrlp)
+	// Sign and verify the sender to avoid hardware fault surprises
+	senderAddr, signed, err := sign(path)
 	if err != nil {
 		return nil, err
 	}
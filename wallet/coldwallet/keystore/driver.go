@@ -16,4 +16,6 @@ type Driver interface {
 	Heartbeat() error
 	Derive(path types.DerivationPath) (common.Address, error)
 	SignTx(path types.DerivationPath, txrlp common.Bytes) (common.Address, *crypto.Signature, error)
+	SignMessage(path types.DerivationPath, message common.Bytes) (common.Address, *crypto.Signature, error)
+	SignTypedData(path types.DerivationPath, domainSeparator, messageHash common.Hash) (common.Address, *crypto.Signature, error)
 }
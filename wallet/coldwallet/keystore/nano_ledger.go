@@ -53,10 +53,14 @@ const (
 	ledgerOpRetrieveAddress  ledgerOpcode = 0x02 // Returns the public key and Ethereum address for a given BIP 32 path
 	ledgerOpSignTransaction  ledgerOpcode = 0x04 // Signs an Ethereum transaction after having the user validate the parameters
 	ledgerOpGetConfiguration ledgerOpcode = 0x06 // Returns specific wallet application configuration
+	ledgerOpSignMessage      ledgerOpcode = 0x08 // Signs a personal message after having the user validate it
+	ledgerOpSignTypedData    ledgerOpcode = 0x0c // Signs the hashes of EIP-712 typed data after having the user validate them
 
 	ledgerP1DirectlyFetchAddress    ledgerParam1 = 0x00 // Return address directly from the wallet
 	ledgerP1InitTransactionData     ledgerParam1 = 0x00 // First transaction data block for signing
 	ledgerP1ContTransactionData     ledgerParam1 = 0x80 // Subsequent transaction data block for signing
+	ledgerP1InitMessageData         ledgerParam1 = 0x00 // First message data block for signing
+	ledgerP1ContMessageData         ledgerParam1 = 0x80 // Subsequent message data block for signing
 	ledgerP2DiscardAddressChainCode ledgerParam2 = 0x00 // Do not return the chain code along with the address
 )
 
@@ -163,6 +167,31 @@ func (w *ledgerDriver) SignTx(path types.DerivationPath, txrlp common.Bytes) (co
 	return w.ledgerSign(path, txrlp)
 }
 
+// SignMessage implements keystore.Driver, sending the message to the Ledger and
+// waiting for the user to confirm or deny signing it.
+func (w *ledgerDriver) SignMessage(path types.DerivationPath, message common.Bytes) (common.Address, *crypto.Signature, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return common.Address{}, nil, errors.New("wallet closed")
+	}
+	return w.ledgerSignMessage(path, message)
+}
+
+// SignTypedData implements keystore.Driver, sending the hashes of the typed data to the
+// Ledger and waiting for the user to confirm or deny signing them.
+func (w *ledgerDriver) SignTypedData(path types.DerivationPath, domainSeparator, messageHash common.Hash) (common.Address, *crypto.Signature, error) {
+	// If the Ethereum app doesn't run, abort
+	if w.offline() {
+		return common.Address{}, nil, errors.New("wallet closed")
+	}
+	// EIP-712 signing is supported since v1.5.0 of the Ethereum app
+	if w.version[0] < 1 || (w.version[0] == 1 && w.version[1] < 5) {
+		return common.Address{}, nil, fmt.Errorf("Ledger v%d.%d.%d doesn't support EIP-712 signing, v1.5.0 or later required",
+			w.version[0], w.version[1], w.version[2])
+	}
+	return w.ledgerSignTypedData(path, domainSeparator, messageHash)
+}
+
 // ledgerVersion retrieves the current version of the Ethereum wallet app running
 // on the Ledger wallet.
 //
@@ -343,6 +372,148 @@ func (w *ledgerDriver) ledgerSign(derivationPath []uint32, txrlp common.Bytes) (
 	return sender, signature, nil
 }
 
+// ledgerSignMessage sends the message to the Ledger wallet, and waits for the user
+// to confirm or deny signing it as personal_sign.
+//
+// The message signing protocol is defined as follows:
+//
+//   CLA | INS | P1 | P2 | Lc  | Le
+//   ----+-----+----+----+-----+---
+//    E0 | 08  | 00: first message data block
+//               80: subsequent message data block
+//                  | 00 | variable | variable
+//
+// Where the input for the first message block (first 255 bytes) is:
+//
+//   Description                                      | Length
+//   -------------------------------------------------+----------
+//   Number of BIP 32 derivations to perform (max 10) | 1 byte
+//   First derivation index (big endian)              | 4 bytes
+//   ...                                              | 4 bytes
+//   Last derivation index (big endian)               | 4 bytes
+//   Message length (big endian)                      | 4 bytes
+//   Message chunk                                    | arbitrary
+//
+// And the input for subsequent message blocks (first 255 bytes) are:
+//
+//   Description   | Length
+//   --------------+----------
+//   Message chunk | arbitrary
+//
+// And the output data is:
+//
+//   Description | Length
+//   ------------+---------
+//   signature V | 1 byte
+//   signature R | 32 bytes
+//   signature S | 32 bytes
+func (w *ledgerDriver) ledgerSignMessage(derivationPath []uint32, message common.Bytes) (common.Address, *crypto.Signature, error) {
+	payload := ledgerDerivationPath(derivationPath)
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(message)))
+	payload = append(payload, length...)
+	payload = append(payload, message...)
+
+	// Send the request and wait for the response
+	var (
+		op    = ledgerP1InitMessageData
+		reply []byte
+		err   error
+	)
+	for len(payload) > 0 {
+		// Calculate the size of the next data chunk
+		chunk := 255
+		if chunk > len(payload) {
+			chunk = len(payload)
+		}
+		// Send the chunk over, ensuring it's processed correctly
+		reply, err = w.ledgerExchange(ledgerOpSignMessage, op, 0, payload[:chunk])
+		if err != nil {
+			return common.Address{}, nil, err
+		}
+		// Shift the payload and ensure subsequent chunks are marked as such
+		payload = payload[chunk:]
+		op = ledgerP1ContMessageData
+	}
+	return ledgerSignature(reply, types.PersonalMessage(message))
+}
+
+// ledgerSignTypedData sends the hashes of EIP-712 typed data to the Ledger wallet, and
+// waits for the user to confirm or deny signing them.
+//
+// The typed data signing protocol is defined as follows:
+//
+//   CLA | INS | P1 | P2 | Lc  | Le
+//   ----+-----+----+----+-----+---
+//    E0 | 0C  | 00 | 00 | variable | variable
+//
+// Where the input is:
+//
+//   Description                                      | Length
+//   -------------------------------------------------+----------
+//   Number of BIP 32 derivations to perform (max 10) | 1 byte
+//   First derivation index (big endian)              | 4 bytes
+//   ...                                              | 4 bytes
+//   Last derivation index (big endian)               | 4 bytes
+//   Domain separator                                 | 32 bytes
+//   Message hash                                     | 32 bytes
+//
+// And the output data is:
+//
+//   Description | Length
+//   ------------+---------
+//   signature V | 1 byte
+//   signature R | 32 bytes
+//   signature S | 32 bytes
+func (w *ledgerDriver) ledgerSignTypedData(derivationPath []uint32, domainSeparator, messageHash common.Hash) (common.Address, *crypto.Signature, error) {
+	payload := ledgerDerivationPath(derivationPath)
+	payload = append(payload, domainSeparator[:]...)
+	payload = append(payload, messageHash[:]...)
+
+	reply, err := w.ledgerExchange(ledgerOpSignTypedData, 0, 0, payload)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	message := append([]byte{0x19, 0x01}, domainSeparator[:]...)
+	message = append(message, messageHash[:]...)
+	return ledgerSignature(reply, message)
+}
+
+// ledgerDerivationPath flattens the derivation path into a Ledger request.
+func ledgerDerivationPath(derivationPath []uint32) []byte {
+	path := make([]byte, 1+4*len(derivationPath))
+	path[0] = byte(len(derivationPath))
+	for i, component := range derivationPath {
+		binary.BigEndian.PutUint32(path[1+4*i:], component)
+	}
+	return path
+}
+
+// ledgerSignature extracts the signature of the signed message from the reply of the
+// Ledger, and recovers the signer.
+func ledgerSignature(reply []byte, signedMessage common.Bytes) (common.Address, *crypto.Signature, error) {
+	if len(reply) != 65 {
+		return common.Address{}, nil, errors.New("reply lacks signature")
+	}
+
+	sigBytes := append(reply[1:], reply[0])
+	if sigBytes[64] >= 27 {
+		sigBytes[64] -= byte(27)
+	}
+
+	signature, err := crypto.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	sender, err := signature.RecoverSignerAddress(signedMessage)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return sender, signature, nil
+}
+
 // ledgerExchange performs a data exchange with the Ledger wallet, sending it a
 // message and retrieving the response.
 //
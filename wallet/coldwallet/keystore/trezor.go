@@ -130,6 +130,26 @@ func (w *trezorDriver) SignTx(path types.DerivationPath, txrlp common.Bytes) (co
 	return w.trezorSign(path, txrlp)
 }
 
+// SignMessage implements keystore.Driver, sending the message to the Trezor and
+// waiting for the user to confirm or deny signing it.
+func (w *trezorDriver) SignMessage(path types.DerivationPath, message common.Bytes) (common.Address, *crypto.Signature, error) {
+	if w.device == nil {
+		return common.Address{}, nil, errors.New("wallet closed")
+	}
+
+	return w.trezorSignMsg(path, message)
+}
+
+// SignTypedData implements keystore.Driver, sending the hashes of the typed data to the
+// Trezor and waiting for the user to confirm or deny signing them.
+func (w *trezorDriver) SignTypedData(path types.DerivationPath, domainSeparator, messageHash common.Hash) (common.Address, *crypto.Signature, error) {
+	if w.device == nil {
+		return common.Address{}, nil, errors.New("wallet closed")
+	}
+
+	return w.trezorSignTypedHash(path, domainSeparator, messageHash)
+}
+
 // trezorDerive sends a derivation request to the Trezor device and returns the
 // Theta address located on that path.
 func (w *trezorDriver) trezorDerive(derivationPath []uint32) (common.Address, error) {
@@ -159,7 +179,9 @@ func (w *trezorDriver) trezorDerive(derivationPath []uint32) (common.Address, er
 	return addr, nil
 }
 
-func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, txrlp common.Bytes) (common.Address, *crypto.Signature, error) {
+// trezorSignMsg sends the message to the Trezor wallet, which signs it as personal_sign,
+// and waits for the user to confirm or deny signing it.
+func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, message common.Bytes) (common.Address, *crypto.Signature, error) {
 	err := w.bridge.BeginSession()
 	if err != nil {
 		return common.Address{}, nil, err
@@ -168,7 +190,7 @@ func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, txrlp common.Bytes
 
 	request := &trezor.ThetaSignMessage{
 		AddressN: derivationPath,
-		Message:  txrlp,
+		Message:  message,
 	}
 
 	res, msgType, err := w.trezorExchange(request)
@@ -184,7 +206,10 @@ func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, txrlp common.Bytes
 	if len(responseSig) != 65 {
 		return common.Address{}, nil, errors.New("Signature should be 65 bytes long")
 	}
-	sigBytes := responseSig
+	sigBytes := append([]byte{}, responseSig...)
+	if sigBytes[64] >= 27 {
+		sigBytes[64] -= byte(27)
+	}
 
 	// Create the correct signer and signature
 	signature, err := crypto.SignatureFromBytes(sigBytes)
@@ -192,7 +217,7 @@ func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, txrlp common.Bytes
 		return common.Address{}, nil, err
 	}
 
-	sender, err := signature.RecoverSignerAddress(txrlp)
+	sender, err := signature.RecoverSignerAddress(types.PersonalMessage(message))
 	logger.Infof("Sender address: %v", sender.Hex())
 
 	if err != nil {
@@ -201,6 +226,54 @@ func (w *trezorDriver) trezorSignMsg(derivationPath []uint32, txrlp common.Bytes
 	return sender, signature, nil
 }
 
+// trezorSignTypedHash sends the hashes of EIP-712 typed data to the Trezor wallet, and
+// waits for the user to confirm or deny signing them.
+func (w *trezorDriver) trezorSignTypedHash(derivationPath []uint32, domainSeparator, messageHash common.Hash) (common.Address, *crypto.Signature, error) {
+	err := w.bridge.BeginSession()
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	defer w.bridge.EndSession()
+
+	request := &trezor.EthereumSignTypedHash{
+		AddressN:            derivationPath,
+		DomainSeparatorHash: domainSeparator[:],
+		MessageHash:         messageHash[:],
+	}
+
+	res, msgType, err := w.trezorExchange(request)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	res, err = w.handleResponse(res, msgType, err)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	response := res.(*trezor.EthereumTypedDataSignature)
+	responseSig := response.Signature
+	if len(responseSig) != 65 {
+		return common.Address{}, nil, errors.New("Signature should be 65 bytes long")
+	}
+	sigBytes := append([]byte{}, responseSig...)
+	if sigBytes[64] >= 27 {
+		sigBytes[64] -= byte(27)
+	}
+
+	// Create the correct signer and signature
+	signature, err := crypto.SignatureFromBytes(sigBytes)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+
+	message := append([]byte{0x19, 0x01}, domainSeparator[:]...)
+	message = append(message, messageHash[:]...)
+	sender, err := signature.RecoverSignerAddress(message)
+	if err != nil {
+		return common.Address{}, nil, err
+	}
+	return sender, signature, nil
+}
+
 // trezorSign sends the transaction to the Trezor wallet, and waits for the user
 // to confirm or deny the transaction.
 func (w *trezorDriver) trezorSign(derivationPath []uint32, txrlp common.Bytes) (common.Address, *crypto.Signature, error) {
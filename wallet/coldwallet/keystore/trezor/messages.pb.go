@@ -26,79 +26,81 @@ var _ = math.Inf
 type MessageType int32
 
 const (
-	MessageType_MessageType_Initialize            MessageType = 0
-	MessageType_MessageType_Ping                  MessageType = 1
-	MessageType_MessageType_Success               MessageType = 2
-	MessageType_MessageType_Failure               MessageType = 3
-	MessageType_MessageType_ChangePin             MessageType = 4
-	MessageType_MessageType_WipeDevice            MessageType = 5
-	MessageType_MessageType_FirmwareErase         MessageType = 6
-	MessageType_MessageType_FirmwareUpload        MessageType = 7
-	MessageType_MessageType_FirmwareRequest       MessageType = 8
-	MessageType_MessageType_GetEntropy            MessageType = 9
-	MessageType_MessageType_Entropy               MessageType = 10
-	MessageType_MessageType_GetPublicKey          MessageType = 11
-	MessageType_MessageType_PublicKey             MessageType = 12
-	MessageType_MessageType_LoadDevice            MessageType = 13
-	MessageType_MessageType_ResetDevice           MessageType = 14
-	MessageType_MessageType_SignTx                MessageType = 15
-	MessageType_MessageType_SimpleSignTx          MessageType = 16 // Deprecated: Do not use.
-	MessageType_MessageType_Features              MessageType = 17
-	MessageType_MessageType_PinMatrixRequest      MessageType = 18
-	MessageType_MessageType_PinMatrixAck          MessageType = 19
-	MessageType_MessageType_Cancel                MessageType = 20
-	MessageType_MessageType_TxRequest             MessageType = 21
-	MessageType_MessageType_TxAck                 MessageType = 22
-	MessageType_MessageType_CipherKeyValue        MessageType = 23
-	MessageType_MessageType_ClearSession          MessageType = 24
-	MessageType_MessageType_ApplySettings         MessageType = 25
-	MessageType_MessageType_ButtonRequest         MessageType = 26
-	MessageType_MessageType_ButtonAck             MessageType = 27
-	MessageType_MessageType_ApplyFlags            MessageType = 28
-	MessageType_MessageType_GetAddress            MessageType = 29
-	MessageType_MessageType_Address               MessageType = 30
-	MessageType_MessageType_SelfTest              MessageType = 32
-	MessageType_MessageType_BackupDevice          MessageType = 34
-	MessageType_MessageType_EntropyRequest        MessageType = 35
-	MessageType_MessageType_EntropyAck            MessageType = 36
-	MessageType_MessageType_SignMessage           MessageType = 38
-	MessageType_MessageType_VerifyMessage         MessageType = 39
-	MessageType_MessageType_MessageSignature      MessageType = 40
-	MessageType_MessageType_PassphraseRequest     MessageType = 41
-	MessageType_MessageType_PassphraseAck         MessageType = 42
-	MessageType_MessageType_EstimateTxSize        MessageType = 43 // Deprecated: Do not use.
-	MessageType_MessageType_TxSize                MessageType = 44 // Deprecated: Do not use.
-	MessageType_MessageType_RecoveryDevice        MessageType = 45
-	MessageType_MessageType_WordRequest           MessageType = 46
-	MessageType_MessageType_WordAck               MessageType = 47
-	MessageType_MessageType_CipheredKeyValue      MessageType = 48
-	MessageType_MessageType_EncryptMessage        MessageType = 49 // Deprecated: Do not use.
-	MessageType_MessageType_EncryptedMessage      MessageType = 50 // Deprecated: Do not use.
-	MessageType_MessageType_DecryptMessage        MessageType = 51 // Deprecated: Do not use.
-	MessageType_MessageType_DecryptedMessage      MessageType = 52 // Deprecated: Do not use.
-	MessageType_MessageType_SignIdentity          MessageType = 53
-	MessageType_MessageType_SignedIdentity        MessageType = 54
-	MessageType_MessageType_GetFeatures           MessageType = 55
-	MessageType_MessageType_ThetaGetAddress       MessageType = 56
-	MessageType_MessageType_ThetaAddress          MessageType = 57
-	MessageType_MessageType_ThetaSignTx           MessageType = 58
-	MessageType_MessageType_ThetaTxRequest        MessageType = 59
-	MessageType_MessageType_ThetaTxAck            MessageType = 60
-	MessageType_MessageType_GetECDHSessionKey     MessageType = 61
-	MessageType_MessageType_ECDHSessionKey        MessageType = 62
-	MessageType_MessageType_SetU2FCounter         MessageType = 63
-	MessageType_MessageType_ThetaSignMessage      MessageType = 64
-	MessageType_MessageType_ThetaVerifyMessage    MessageType = 65
-	MessageType_MessageType_ThetaMessageSignature MessageType = 66
-	MessageType_MessageType_DebugLinkDecision     MessageType = 100
-	MessageType_MessageType_DebugLinkGetState     MessageType = 101
-	MessageType_MessageType_DebugLinkState        MessageType = 102
-	MessageType_MessageType_DebugLinkStop         MessageType = 103
-	MessageType_MessageType_DebugLinkLog          MessageType = 104
-	MessageType_MessageType_DebugLinkMemoryRead   MessageType = 110
-	MessageType_MessageType_DebugLinkMemory       MessageType = 111
-	MessageType_MessageType_DebugLinkMemoryWrite  MessageType = 112
-	MessageType_MessageType_DebugLinkFlashErase   MessageType = 113
+	MessageType_MessageType_Initialize                 MessageType = 0
+	MessageType_MessageType_Ping                       MessageType = 1
+	MessageType_MessageType_Success                    MessageType = 2
+	MessageType_MessageType_Failure                    MessageType = 3
+	MessageType_MessageType_ChangePin                  MessageType = 4
+	MessageType_MessageType_WipeDevice                 MessageType = 5
+	MessageType_MessageType_FirmwareErase              MessageType = 6
+	MessageType_MessageType_FirmwareUpload             MessageType = 7
+	MessageType_MessageType_FirmwareRequest            MessageType = 8
+	MessageType_MessageType_GetEntropy                 MessageType = 9
+	MessageType_MessageType_Entropy                    MessageType = 10
+	MessageType_MessageType_GetPublicKey               MessageType = 11
+	MessageType_MessageType_PublicKey                  MessageType = 12
+	MessageType_MessageType_LoadDevice                 MessageType = 13
+	MessageType_MessageType_ResetDevice                MessageType = 14
+	MessageType_MessageType_SignTx                     MessageType = 15
+	MessageType_MessageType_SimpleSignTx               MessageType = 16 // Deprecated: Do not use.
+	MessageType_MessageType_Features                   MessageType = 17
+	MessageType_MessageType_PinMatrixRequest           MessageType = 18
+	MessageType_MessageType_PinMatrixAck               MessageType = 19
+	MessageType_MessageType_Cancel                     MessageType = 20
+	MessageType_MessageType_TxRequest                  MessageType = 21
+	MessageType_MessageType_TxAck                      MessageType = 22
+	MessageType_MessageType_CipherKeyValue             MessageType = 23
+	MessageType_MessageType_ClearSession               MessageType = 24
+	MessageType_MessageType_ApplySettings              MessageType = 25
+	MessageType_MessageType_ButtonRequest              MessageType = 26
+	MessageType_MessageType_ButtonAck                  MessageType = 27
+	MessageType_MessageType_ApplyFlags                 MessageType = 28
+	MessageType_MessageType_GetAddress                 MessageType = 29
+	MessageType_MessageType_Address                    MessageType = 30
+	MessageType_MessageType_SelfTest                   MessageType = 32
+	MessageType_MessageType_BackupDevice               MessageType = 34
+	MessageType_MessageType_EntropyRequest             MessageType = 35
+	MessageType_MessageType_EntropyAck                 MessageType = 36
+	MessageType_MessageType_SignMessage                MessageType = 38
+	MessageType_MessageType_VerifyMessage              MessageType = 39
+	MessageType_MessageType_MessageSignature           MessageType = 40
+	MessageType_MessageType_PassphraseRequest          MessageType = 41
+	MessageType_MessageType_PassphraseAck              MessageType = 42
+	MessageType_MessageType_EstimateTxSize             MessageType = 43 // Deprecated: Do not use.
+	MessageType_MessageType_TxSize                     MessageType = 44 // Deprecated: Do not use.
+	MessageType_MessageType_RecoveryDevice             MessageType = 45
+	MessageType_MessageType_WordRequest                MessageType = 46
+	MessageType_MessageType_WordAck                    MessageType = 47
+	MessageType_MessageType_CipheredKeyValue           MessageType = 48
+	MessageType_MessageType_EncryptMessage             MessageType = 49 // Deprecated: Do not use.
+	MessageType_MessageType_EncryptedMessage           MessageType = 50 // Deprecated: Do not use.
+	MessageType_MessageType_DecryptMessage             MessageType = 51 // Deprecated: Do not use.
+	MessageType_MessageType_DecryptedMessage           MessageType = 52 // Deprecated: Do not use.
+	MessageType_MessageType_SignIdentity               MessageType = 53
+	MessageType_MessageType_SignedIdentity             MessageType = 54
+	MessageType_MessageType_GetFeatures                MessageType = 55
+	MessageType_MessageType_ThetaGetAddress            MessageType = 56
+	MessageType_MessageType_ThetaAddress               MessageType = 57
+	MessageType_MessageType_ThetaSignTx                MessageType = 58
+	MessageType_MessageType_ThetaTxRequest             MessageType = 59
+	MessageType_MessageType_ThetaTxAck                 MessageType = 60
+	MessageType_MessageType_GetECDHSessionKey          MessageType = 61
+	MessageType_MessageType_ECDHSessionKey             MessageType = 62
+	MessageType_MessageType_SetU2FCounter              MessageType = 63
+	MessageType_MessageType_ThetaSignMessage           MessageType = 64
+	MessageType_MessageType_ThetaVerifyMessage         MessageType = 65
+	MessageType_MessageType_ThetaMessageSignature      MessageType = 66
+	MessageType_MessageType_EthereumTypedDataSignature MessageType = 469
+	MessageType_MessageType_EthereumSignTypedHash      MessageType = 470
+	MessageType_MessageType_DebugLinkDecision          MessageType = 100
+	MessageType_MessageType_DebugLinkGetState          MessageType = 101
+	MessageType_MessageType_DebugLinkState             MessageType = 102
+	MessageType_MessageType_DebugLinkStop              MessageType = 103
+	MessageType_MessageType_DebugLinkLog               MessageType = 104
+	MessageType_MessageType_DebugLinkMemoryRead        MessageType = 110
+	MessageType_MessageType_DebugLinkMemory            MessageType = 111
+	MessageType_MessageType_DebugLinkMemoryWrite       MessageType = 112
+	MessageType_MessageType_DebugLinkFlashErase        MessageType = 113
 )
 
 var MessageType_name = map[int32]string{
@@ -166,6 +168,8 @@ var MessageType_name = map[int32]string{
 	64:  "MessageType_ThetaSignMessage",
 	65:  "MessageType_ThetaVerifyMessage",
 	66:  "MessageType_ThetaMessageSignature",
+	469: "MessageType_EthereumTypedDataSignature",
+	470: "MessageType_EthereumSignTypedHash",
 	100: "MessageType_DebugLinkDecision",
 	101: "MessageType_DebugLinkGetState",
 	102: "MessageType_DebugLinkState",
@@ -178,79 +182,81 @@ var MessageType_name = map[int32]string{
 }
 
 var MessageType_value = map[string]int32{
-	"MessageType_Initialize":            0,
-	"MessageType_Ping":                  1,
-	"MessageType_Success":               2,
-	"MessageType_Failure":               3,
-	"MessageType_ChangePin":             4,
-	"MessageType_WipeDevice":            5,
-	"MessageType_FirmwareErase":         6,
-	"MessageType_FirmwareUpload":        7,
-	"MessageType_FirmwareRequest":       8,
-	"MessageType_GetEntropy":            9,
-	"MessageType_Entropy":               10,
-	"MessageType_GetPublicKey":          11,
-	"MessageType_PublicKey":             12,
-	"MessageType_LoadDevice":            13,
-	"MessageType_ResetDevice":           14,
-	"MessageType_SignTx":                15,
-	"MessageType_SimpleSignTx":          16,
-	"MessageType_Features":              17,
-	"MessageType_PinMatrixRequest":      18,
-	"MessageType_PinMatrixAck":          19,
-	"MessageType_Cancel":                20,
-	"MessageType_TxRequest":             21,
-	"MessageType_TxAck":                 22,
-	"MessageType_CipherKeyValue":        23,
-	"MessageType_ClearSession":          24,
-	"MessageType_ApplySettings":         25,
-	"MessageType_ButtonRequest":         26,
-	"MessageType_ButtonAck":             27,
-	"MessageType_ApplyFlags":            28,
-	"MessageType_GetAddress":            29,
-	"MessageType_Address":               30,
-	"MessageType_SelfTest":              32,
-	"MessageType_BackupDevice":          34,
-	"MessageType_EntropyRequest":        35,
-	"MessageType_EntropyAck":            36,
-	"MessageType_SignMessage":           38,
-	"MessageType_VerifyMessage":         39,
-	"MessageType_MessageSignature":      40,
-	"MessageType_PassphraseRequest":     41,
-	"MessageType_PassphraseAck":         42,
-	"MessageType_EstimateTxSize":        43,
-	"MessageType_TxSize":                44,
-	"MessageType_RecoveryDevice":        45,
-	"MessageType_WordRequest":           46,
-	"MessageType_WordAck":               47,
-	"MessageType_CipheredKeyValue":      48,
-	"MessageType_EncryptMessage":        49,
-	"MessageType_EncryptedMessage":      50,
-	"MessageType_DecryptMessage":        51,
-	"MessageType_DecryptedMessage":      52,
-	"MessageType_SignIdentity":          53,
-	"MessageType_SignedIdentity":        54,
-	"MessageType_GetFeatures":           55,
-	"MessageType_ThetaGetAddress":       56,
-	"MessageType_ThetaAddress":          57,
-	"MessageType_ThetaSignTx":           58,
-	"MessageType_ThetaTxRequest":        59,
-	"MessageType_ThetaTxAck":            60,
-	"MessageType_GetECDHSessionKey":     61,
-	"MessageType_ECDHSessionKey":        62,
-	"MessageType_SetU2FCounter":         63,
-	"MessageType_ThetaSignMessage":      64,
-	"MessageType_ThetaVerifyMessage":    65,
-	"MessageType_ThetaMessageSignature": 66,
-	"MessageType_DebugLinkDecision":     100,
-	"MessageType_DebugLinkGetState":     101,
-	"MessageType_DebugLinkState":        102,
-	"MessageType_DebugLinkStop":         103,
-	"MessageType_DebugLinkLog":          104,
-	"MessageType_DebugLinkMemoryRead":   110,
-	"MessageType_DebugLinkMemory":       111,
-	"MessageType_DebugLinkMemoryWrite":  112,
-	"MessageType_DebugLinkFlashErase":   113,
+	"MessageType_Initialize":                 0,
+	"MessageType_Ping":                       1,
+	"MessageType_Success":                    2,
+	"MessageType_Failure":                    3,
+	"MessageType_ChangePin":                  4,
+	"MessageType_WipeDevice":                 5,
+	"MessageType_FirmwareErase":              6,
+	"MessageType_FirmwareUpload":             7,
+	"MessageType_FirmwareRequest":            8,
+	"MessageType_GetEntropy":                 9,
+	"MessageType_Entropy":                    10,
+	"MessageType_GetPublicKey":               11,
+	"MessageType_PublicKey":                  12,
+	"MessageType_LoadDevice":                 13,
+	"MessageType_ResetDevice":                14,
+	"MessageType_SignTx":                     15,
+	"MessageType_SimpleSignTx":               16,
+	"MessageType_Features":                   17,
+	"MessageType_PinMatrixRequest":           18,
+	"MessageType_PinMatrixAck":               19,
+	"MessageType_Cancel":                     20,
+	"MessageType_TxRequest":                  21,
+	"MessageType_TxAck":                      22,
+	"MessageType_CipherKeyValue":             23,
+	"MessageType_ClearSession":               24,
+	"MessageType_ApplySettings":              25,
+	"MessageType_ButtonRequest":              26,
+	"MessageType_ButtonAck":                  27,
+	"MessageType_ApplyFlags":                 28,
+	"MessageType_GetAddress":                 29,
+	"MessageType_Address":                    30,
+	"MessageType_SelfTest":                   32,
+	"MessageType_BackupDevice":               34,
+	"MessageType_EntropyRequest":             35,
+	"MessageType_EntropyAck":                 36,
+	"MessageType_SignMessage":                38,
+	"MessageType_VerifyMessage":              39,
+	"MessageType_MessageSignature":           40,
+	"MessageType_PassphraseRequest":          41,
+	"MessageType_PassphraseAck":              42,
+	"MessageType_EstimateTxSize":             43,
+	"MessageType_TxSize":                     44,
+	"MessageType_RecoveryDevice":             45,
+	"MessageType_WordRequest":                46,
+	"MessageType_WordAck":                    47,
+	"MessageType_CipheredKeyValue":           48,
+	"MessageType_EncryptMessage":             49,
+	"MessageType_EncryptedMessage":           50,
+	"MessageType_DecryptMessage":             51,
+	"MessageType_DecryptedMessage":           52,
+	"MessageType_SignIdentity":               53,
+	"MessageType_SignedIdentity":             54,
+	"MessageType_GetFeatures":                55,
+	"MessageType_ThetaGetAddress":            56,
+	"MessageType_ThetaAddress":               57,
+	"MessageType_ThetaSignTx":                58,
+	"MessageType_ThetaTxRequest":             59,
+	"MessageType_ThetaTxAck":                 60,
+	"MessageType_GetECDHSessionKey":          61,
+	"MessageType_ECDHSessionKey":             62,
+	"MessageType_SetU2FCounter":              63,
+	"MessageType_ThetaSignMessage":           64,
+	"MessageType_ThetaVerifyMessage":         65,
+	"MessageType_ThetaMessageSignature":      66,
+	"MessageType_EthereumTypedDataSignature": 469,
+	"MessageType_EthereumSignTypedHash":      470,
+	"MessageType_DebugLinkDecision":          100,
+	"MessageType_DebugLinkGetState":          101,
+	"MessageType_DebugLinkState":             102,
+	"MessageType_DebugLinkStop":              103,
+	"MessageType_DebugLinkLog":               104,
+	"MessageType_DebugLinkMemoryRead":        110,
+	"MessageType_DebugLinkMemory":            111,
+	"MessageType_DebugLinkMemoryWrite":       112,
+	"MessageType_DebugLinkFlashErase":        113,
 }
 
 func (x MessageType) String() string {
@@ -3058,15 +3064,14 @@ func (m *TxAck) GetTx() *TransactionType {
 // @next ThetaTxRequest
 // @next Failure
 type ThetaSignTx struct {
-	AddressN         []uint32 `protobuf:"varint,1,rep,packed,name=address_n,json=addressN,proto3" json:"address_n,omitempty"`
-	Nonce            []byte   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	GasPrice         []byte   `protobuf:"bytes,3,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
-	GasLimit         []byte   `protobuf:"bytes,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	To               []byte   `protobuf:"bytes,11,opt,name=to,proto3" json:"to,omitempty"`
-	Value            []byte   `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
-	DataInitialChunk []byte   `protobuf:"bytes,7,opt,name=data_initial_chunk,json=dataInitialChunk,proto3" json:"data_initial_chunk,omitempty"`
-	DataLength       uint32   `protobuf:"varint,8,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
-	// ChainId              uint32   `protobuf:"varint,9,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	AddressN             []uint32 `protobuf:"varint,1,rep,packed,name=address_n,json=addressN,proto3" json:"address_n,omitempty"`
+	Nonce                []byte   `protobuf:"bytes,2,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	GasPrice             []byte   `protobuf:"bytes,3,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasLimit             []byte   `protobuf:"bytes,4,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	To                   []byte   `protobuf:"bytes,11,opt,name=to,proto3" json:"to,omitempty"`
+	Value                []byte   `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	DataInitialChunk     []byte   `protobuf:"bytes,7,opt,name=data_initial_chunk,json=dataInitialChunk,proto3" json:"data_initial_chunk,omitempty"`
+	DataLength           uint32   `protobuf:"varint,8,opt,name=data_length,json=dataLength,proto3" json:"data_length,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3153,13 +3158,6 @@ func (m *ThetaSignTx) GetDataLength() uint32 {
 	return 0
 }
 
-// func (m *ThetaSignTx) GetChainId() uint32 {
-// 	if m != nil {
-// 		return m.ChainId
-// 	}
-// 	return 0
-// }
-
 //*
 // Response: Device asks for more data from transaction payload, or returns the signature.
 // If data_length is set, device awaits that many more bytes of payload.
@@ -3432,6 +3430,115 @@ func (m *ThetaMessageSignature) GetSignature() []byte {
 	return nil
 }
 
+//*
+// Request: Ask device to sign the hashes of EIP-712 typed data
+// @next EthereumTypedDataSignature
+// @next Failure
+type EthereumSignTypedHash struct {
+	AddressN             []uint32 `protobuf:"varint,1,rep,packed,name=address_n,json=addressN,proto3" json:"address_n,omitempty"`
+	DomainSeparatorHash  []byte   `protobuf:"bytes,2,opt,name=domain_separator_hash,json=domainSeparatorHash,proto3" json:"domain_separator_hash,omitempty"`
+	MessageHash          []byte   `protobuf:"bytes,3,opt,name=message_hash,json=messageHash,proto3" json:"message_hash,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EthereumSignTypedHash) Reset()         { *m = EthereumSignTypedHash{} }
+func (m *EthereumSignTypedHash) String() string { return proto.CompactTextString(m) }
+func (*EthereumSignTypedHash) ProtoMessage()    {}
+func (*EthereumSignTypedHash) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{55}
+}
+
+func (m *EthereumSignTypedHash) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EthereumSignTypedHash.Unmarshal(m, b)
+}
+func (m *EthereumSignTypedHash) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EthereumSignTypedHash.Marshal(b, m, deterministic)
+}
+func (m *EthereumSignTypedHash) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EthereumSignTypedHash.Merge(m, src)
+}
+func (m *EthereumSignTypedHash) XXX_Size() int {
+	return xxx_messageInfo_EthereumSignTypedHash.Size(m)
+}
+func (m *EthereumSignTypedHash) XXX_DiscardUnknown() {
+	xxx_messageInfo_EthereumSignTypedHash.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EthereumSignTypedHash proto.InternalMessageInfo
+
+func (m *EthereumSignTypedHash) GetAddressN() []uint32 {
+	if m != nil {
+		return m.AddressN
+	}
+	return nil
+}
+
+func (m *EthereumSignTypedHash) GetDomainSeparatorHash() []byte {
+	if m != nil {
+		return m.DomainSeparatorHash
+	}
+	return nil
+}
+
+func (m *EthereumSignTypedHash) GetMessageHash() []byte {
+	if m != nil {
+		return m.MessageHash
+	}
+	return nil
+}
+
+//*
+// Response: Signed EIP-712 typed data
+// @prev EthereumSignTypedHash
+type EthereumTypedDataSignature struct {
+	Signature            []byte   `protobuf:"bytes,1,opt,name=signature,proto3" json:"signature,omitempty"`
+	Address              string   `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EthereumTypedDataSignature) Reset()         { *m = EthereumTypedDataSignature{} }
+func (m *EthereumTypedDataSignature) String() string { return proto.CompactTextString(m) }
+func (*EthereumTypedDataSignature) ProtoMessage()    {}
+func (*EthereumTypedDataSignature) Descriptor() ([]byte, []int) {
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{56}
+}
+
+func (m *EthereumTypedDataSignature) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EthereumTypedDataSignature.Unmarshal(m, b)
+}
+func (m *EthereumTypedDataSignature) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EthereumTypedDataSignature.Marshal(b, m, deterministic)
+}
+func (m *EthereumTypedDataSignature) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EthereumTypedDataSignature.Merge(m, src)
+}
+func (m *EthereumTypedDataSignature) XXX_Size() int {
+	return xxx_messageInfo_EthereumTypedDataSignature.Size(m)
+}
+func (m *EthereumTypedDataSignature) XXX_DiscardUnknown() {
+	xxx_messageInfo_EthereumTypedDataSignature.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EthereumTypedDataSignature proto.InternalMessageInfo
+
+func (m *EthereumTypedDataSignature) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *EthereumTypedDataSignature) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
+}
+
 //*
 // Request: Ask device to sign identity
 // @next SignedIdentity
@@ -3450,7 +3557,7 @@ func (m *SignIdentity) Reset()         { *m = SignIdentity{} }
 func (m *SignIdentity) String() string { return proto.CompactTextString(m) }
 func (*SignIdentity) ProtoMessage()    {}
 func (*SignIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{55}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{57}
 }
 
 func (m *SignIdentity) XXX_Unmarshal(b []byte) error {
@@ -3515,7 +3622,7 @@ func (m *SignedIdentity) Reset()         { *m = SignedIdentity{} }
 func (m *SignedIdentity) String() string { return proto.CompactTextString(m) }
 func (*SignedIdentity) ProtoMessage()    {}
 func (*SignedIdentity) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{56}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{58}
 }
 
 func (m *SignedIdentity) XXX_Unmarshal(b []byte) error {
@@ -3574,7 +3681,7 @@ func (m *GetECDHSessionKey) Reset()         { *m = GetECDHSessionKey{} }
 func (m *GetECDHSessionKey) String() string { return proto.CompactTextString(m) }
 func (*GetECDHSessionKey) ProtoMessage()    {}
 func (*GetECDHSessionKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{57}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{59}
 }
 
 func (m *GetECDHSessionKey) XXX_Unmarshal(b []byte) error {
@@ -3630,7 +3737,7 @@ func (m *ECDHSessionKey) Reset()         { *m = ECDHSessionKey{} }
 func (m *ECDHSessionKey) String() string { return proto.CompactTextString(m) }
 func (*ECDHSessionKey) ProtoMessage()    {}
 func (*ECDHSessionKey) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{58}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{60}
 }
 
 func (m *ECDHSessionKey) XXX_Unmarshal(b []byte) error {
@@ -3672,7 +3779,7 @@ func (m *SetU2FCounter) Reset()         { *m = SetU2FCounter{} }
 func (m *SetU2FCounter) String() string { return proto.CompactTextString(m) }
 func (*SetU2FCounter) ProtoMessage()    {}
 func (*SetU2FCounter) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{59}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{61}
 }
 
 func (m *SetU2FCounter) XXX_Unmarshal(b []byte) error {
@@ -3716,7 +3823,7 @@ func (m *FirmwareErase) Reset()         { *m = FirmwareErase{} }
 func (m *FirmwareErase) String() string { return proto.CompactTextString(m) }
 func (*FirmwareErase) ProtoMessage()    {}
 func (*FirmwareErase) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{60}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{62}
 }
 
 func (m *FirmwareErase) XXX_Unmarshal(b []byte) error {
@@ -3759,7 +3866,7 @@ func (m *FirmwareRequest) Reset()         { *m = FirmwareRequest{} }
 func (m *FirmwareRequest) String() string { return proto.CompactTextString(m) }
 func (*FirmwareRequest) ProtoMessage()    {}
 func (*FirmwareRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{61}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{63}
 }
 
 func (m *FirmwareRequest) XXX_Unmarshal(b []byte) error {
@@ -3810,7 +3917,7 @@ func (m *FirmwareUpload) Reset()         { *m = FirmwareUpload{} }
 func (m *FirmwareUpload) String() string { return proto.CompactTextString(m) }
 func (*FirmwareUpload) ProtoMessage()    {}
 func (*FirmwareUpload) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{62}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{64}
 }
 
 func (m *FirmwareUpload) XXX_Unmarshal(b []byte) error {
@@ -3860,7 +3967,7 @@ func (m *SelfTest) Reset()         { *m = SelfTest{} }
 func (m *SelfTest) String() string { return proto.CompactTextString(m) }
 func (*SelfTest) ProtoMessage()    {}
 func (*SelfTest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{63}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{65}
 }
 
 func (m *SelfTest) XXX_Unmarshal(b []byte) error {
@@ -3902,7 +4009,7 @@ func (m *DebugLinkDecision) Reset()         { *m = DebugLinkDecision{} }
 func (m *DebugLinkDecision) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkDecision) ProtoMessage()    {}
 func (*DebugLinkDecision) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{64}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{66}
 }
 
 func (m *DebugLinkDecision) XXX_Unmarshal(b []byte) error {
@@ -3943,7 +4050,7 @@ func (m *DebugLinkGetState) Reset()         { *m = DebugLinkGetState{} }
 func (m *DebugLinkGetState) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkGetState) ProtoMessage()    {}
 func (*DebugLinkGetState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{65}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{67}
 }
 
 func (m *DebugLinkGetState) XXX_Unmarshal(b []byte) error {
@@ -3987,7 +4094,7 @@ func (m *DebugLinkState) Reset()         { *m = DebugLinkState{} }
 func (m *DebugLinkState) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkState) ProtoMessage()    {}
 func (*DebugLinkState) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{66}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{68}
 }
 
 func (m *DebugLinkState) XXX_Unmarshal(b []byte) error {
@@ -4090,7 +4197,7 @@ func (m *DebugLinkStop) Reset()         { *m = DebugLinkStop{} }
 func (m *DebugLinkStop) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkStop) ProtoMessage()    {}
 func (*DebugLinkStop) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{67}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{69}
 }
 
 func (m *DebugLinkStop) XXX_Unmarshal(b []byte) error {
@@ -4126,7 +4233,7 @@ func (m *DebugLinkLog) Reset()         { *m = DebugLinkLog{} }
 func (m *DebugLinkLog) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkLog) ProtoMessage()    {}
 func (*DebugLinkLog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{68}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{70}
 }
 
 func (m *DebugLinkLog) XXX_Unmarshal(b []byte) error {
@@ -4183,7 +4290,7 @@ func (m *DebugLinkMemoryRead) Reset()         { *m = DebugLinkMemoryRead{} }
 func (m *DebugLinkMemoryRead) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkMemoryRead) ProtoMessage()    {}
 func (*DebugLinkMemoryRead) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{69}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{71}
 }
 
 func (m *DebugLinkMemoryRead) XXX_Unmarshal(b []byte) error {
@@ -4232,7 +4339,7 @@ func (m *DebugLinkMemory) Reset()         { *m = DebugLinkMemory{} }
 func (m *DebugLinkMemory) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkMemory) ProtoMessage()    {}
 func (*DebugLinkMemory) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{70}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{72}
 }
 
 func (m *DebugLinkMemory) XXX_Unmarshal(b []byte) error {
@@ -4276,7 +4383,7 @@ func (m *DebugLinkMemoryWrite) Reset()         { *m = DebugLinkMemoryWrite{} }
 func (m *DebugLinkMemoryWrite) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkMemoryWrite) ProtoMessage()    {}
 func (*DebugLinkMemoryWrite) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{71}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{73}
 }
 
 func (m *DebugLinkMemoryWrite) XXX_Unmarshal(b []byte) error {
@@ -4332,7 +4439,7 @@ func (m *DebugLinkFlashErase) Reset()         { *m = DebugLinkFlashErase{} }
 func (m *DebugLinkFlashErase) String() string { return proto.CompactTextString(m) }
 func (*DebugLinkFlashErase) ProtoMessage()    {}
 func (*DebugLinkFlashErase) Descriptor() ([]byte, []int) {
-	return fileDescriptor_4dc296cbfe5ffcd5, []int{72}
+	return fileDescriptor_4dc296cbfe5ffcd5, []int{74}
 }
 
 func (m *DebugLinkFlashErase) XXX_Unmarshal(b []byte) error {
@@ -4417,6 +4524,8 @@ func init() {
 	proto.RegisterType((*ThetaSignMessage)(nil), "trezor.ThetaSignMessage")
 	proto.RegisterType((*ThetaVerifyMessage)(nil), "trezor.ThetaVerifyMessage")
 	proto.RegisterType((*ThetaMessageSignature)(nil), "trezor.ThetaMessageSignature")
+	proto.RegisterType((*EthereumSignTypedHash)(nil), "trezor.EthereumSignTypedHash")
+	proto.RegisterType((*EthereumTypedDataSignature)(nil), "trezor.EthereumTypedDataSignature")
 	proto.RegisterType((*SignIdentity)(nil), "trezor.SignIdentity")
 	proto.RegisterType((*SignedIdentity)(nil), "trezor.SignedIdentity")
 	proto.RegisterType((*GetECDHSessionKey)(nil), "trezor.GetECDHSessionKey")
@@ -4440,215 +4549,220 @@ func init() {
 func init() { proto.RegisterFile("messages.proto", fileDescriptor_4dc296cbfe5ffcd5) }
 
 var fileDescriptor_4dc296cbfe5ffcd5 = []byte{
-	// 3354 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0xcd, 0x73, 0xdc, 0xc6,
-	0x72, 0xcf, 0x7e, 0x70, 0xb9, 0xdb, 0xfb, 0xc1, 0x21, 0x44, 0x49, 0x2b, 0x4a, 0x94, 0x28, 0x50,
-	0x12, 0x29, 0xc9, 0x96, 0x65, 0xda, 0x71, 0x1c, 0xdb, 0xb1, 0x23, 0x91, 0xa2, 0xa4, 0x58, 0x1f,
-	0xcc, 0x92, 0x96, 0x6f, 0x41, 0x81, 0xc0, 0x70, 0x17, 0xe1, 0x2e, 0x00, 0x63, 0x00, 0x9a, 0xab,
-	0x43, 0xce, 0xa9, 0x5c, 0x92, 0xaa, 0x24, 0x15, 0x57, 0xe5, 0x10, 0x1f, 0x72, 0x48, 0x6e, 0xb9,
-	0xe4, 0xd5, 0x3b, 0xbc, 0x83, 0xef, 0xef, 0xaf, 0x78, 0xc7, 0xf7, 0x3f, 0xbc, 0xc3, 0xab, 0x99,
-	0xe9, 0x01, 0x06, 0x20, 0x96, 0x92, 0xec, 0xaa, 0x77, 0xc3, 0xf4, 0xfc, 0xb6, 0xa7, 0xbb, 0xa7,
-	0xa7, 0xa7, 0xbb, 0x67, 0xa1, 0x37, 0xa1, 0x8c, 0xd9, 0x43, 0xca, 0xee, 0x85, 0x51, 0x10, 0x07,
-	0x46, 0x23, 0x8e, 0xe8, 0xeb, 0x20, 0x5a, 0x6e, 0xc7, 0xd3, 0x50, 0x11, 0xcd, 0x0e, 0xc0, 0x53,
-	0xdf, 0x8b, 0x3d, 0x7b, 0xec, 0xbd, 0xa6, 0x66, 0x17, 0xda, 0x8f, 0x69, 0xbc, 0x43, 0xed, 0x38,
-	0x89, 0x28, 0x33, 0x7f, 0x9a, 0x83, 0xa6, 0x1a, 0x18, 0x17, 0xa0, 0x71, 0x4c, 0x7d, 0x37, 0x88,
-	0xfa, 0x95, 0xd5, 0xca, 0x46, 0x6b, 0x80, 0x23, 0x63, 0x0d, 0xba, 0x13, 0xfb, 0xef, 0x83, 0xc8,
-	0x3a, 0xa6, 0x11, 0xf3, 0x02, 0xbf, 0x5f, 0x5d, 0xad, 0x6c, 0x74, 0x07, 0x1d, 0x41, 0x7c, 0x25,
-	0x69, 0x02, 0xe4, 0xf9, 0x1a, 0xa8, 0x86, 0x20, 0x4e, 0xd4, 0x40, 0xa1, 0x1d, 0x3b, 0xa3, 0x14,
-	0x54, 0x97, 0x20, 0x41, 0x54, 0xa0, 0x75, 0x58, 0x38, 0x08, 0x82, 0x78, 0x1c, 0xd8, 0x2e, 0x8d,
-	0xac, 0x49, 0xe0, 0xd2, 0xfe, 0xdc, 0x6a, 0x65, 0xa3, 0x39, 0xe8, 0x65, 0xe4, 0xe7, 0x81, 0x4b,
-	0x8d, 0xcb, 0xd0, 0x72, 0xe9, 0xb1, 0xe7, 0x50, 0xcb, 0x73, 0xfb, 0x0d, 0x21, 0x72, 0x53, 0x12,
-	0x9e, 0xba, 0xc6, 0x4d, 0xe8, 0x85, 0x9e, 0x6f, 0x71, 0x1b, 0x50, 0x27, 0xe6, 0x6b, 0xcd, 0x0b,
-	0x26, 0xdd, 0xd0, 0xf3, 0x77, 0x53, 0xa2, 0xf1, 0x11, 0x9c, 0x0f, 0x6d, 0xc6, 0xc2, 0x51, 0x64,
-	0x33, 0xaa, 0xa3, 0x9b, 0x02, 0xbd, 0x94, 0x4d, 0x6a, 0x3f, 0x5a, 0x86, 0xe6, 0xd8, 0xf6, 0x87,
-	0x89, 0x3d, 0xa4, 0xfd, 0x96, 0x5c, 0x57, 0x8d, 0x8d, 0x25, 0x98, 0x1b, 0xdb, 0x07, 0x74, 0xdc,
-	0x07, 0x31, 0x21, 0x07, 0xc6, 0x2d, 0x98, 0x73, 0x02, 0xcf, 0x67, 0xfd, 0xf6, 0x6a, 0x6d, 0xa3,
-	0xbd, 0x49, 0xee, 0xc9, 0x9d, 0xba, 0xb7, 0x15, 0x78, 0xfe, 0xfe, 0x34, 0xa4, 0x03, 0x39, 0x6d,
-	0xac, 0x42, 0xdb, 0x4b, 0x37, 0xcb, 0xed, 0x77, 0x84, 0x10, 0x3a, 0x89, 0xaf, 0x1d, 0xd1, 0x63,
-	0x4f, 0x58, 0xaf, 0xbb, 0x5a, 0xd9, 0xe8, 0x0c, 0xd2, 0x71, 0xc1, 0x72, 0x23, 0x9b, 0x8d, 0xfa,
-	0x3d, 0x01, 0xd1, 0x2c, 0xf7, 0xc4, 0x66, 0x23, 0xce, 0xc4, 0x9b, 0x84, 0x41, 0x14, 0x53, 0xb7,
-	0xbf, 0x20, 0xd6, 0x48, 0xc7, 0xc6, 0x0a, 0x00, 0x37, 0x9c, 0x63, 0x3b, 0x23, 0xea, 0xf6, 0x89,
-	0x98, 0x6d, 0x85, 0x9e, 0xbf, 0x25, 0x08, 0xc6, 0x5d, 0x58, 0xd4, 0x0c, 0x86, 0xa8, 0x45, 0x81,
-	0x22, 0xd9, 0x04, 0x82, 0x6f, 0x03, 0x39, 0xf4, 0xa2, 0xc9, 0xf7, 0x76, 0xc4, 0x6d, 0x4b, 0x19,
-	0xf5, 0xe3, 0xbe, 0x21, 0xb0, 0x0b, 0x8a, 0xbe, 0x2b, 0xc9, 0xc6, 0x75, 0xe8, 0xf8, 0x94, 0xba,
-	0xcc, 0x3a, 0xb0, 0x9d, 0xa3, 0x24, 0xec, 0x9f, 0x93, 0xaa, 0x0b, 0xda, 0x43, 0x41, 0xe2, 0xa6,
-	0x3d, 0x1c, 0xdb, 0x43, 0xd6, 0x5f, 0x12, 0x5e, 0x23, 0x07, 0x66, 0x0f, 0x3a, 0x5b, 0x63, 0x6a,
-	0x47, 0x7b, 0x94, 0x71, 0x23, 0x98, 0xff, 0x58, 0x81, 0xee, 0x83, 0x30, 0x1c, 0x4f, 0xf7, 0x68,
-	0x1c, 0x7b, 0xfe, 0x90, 0xe5, 0xb6, 0xab, 0x32, 0x6b, 0xbb, 0xaa, 0xfa, 0x76, 0xdd, 0x84, 0x5e,
-	0xc2, 0xdd, 0x21, 0xd5, 0x47, 0x78, 0x73, 0x73, 0xd0, 0x4d, 0x18, 0xdd, 0x4d, 0x89, 0xc6, 0x55,
-	0x80, 0x51, 0x30, 0xa1, 0xcc, 0x89, 0x28, 0x95, 0xbe, 0xdc, 0x19, 0x68, 0x14, 0xd3, 0x04, 0x10,
-	0x92, 0xec, 0x70, 0x41, 0x33, 0xf1, 0x2b, 0xba, 0xf8, 0x6b, 0xd0, 0xda, 0x1a, 0xd9, 0xfe, 0x90,
-	0xee, 0x7a, 0x3e, 0x3f, 0x81, 0x11, 0x9d, 0x04, 0xc7, 0x52, 0xce, 0xe6, 0x00, 0x47, 0xe6, 0xff,
-	0x56, 0xa0, 0xbe, 0xeb, 0xf9, 0x43, 0xa3, 0x0f, 0xf3, 0x78, 0xe6, 0x51, 0x13, 0x35, 0xe4, 0xfb,
-	0x72, 0x90, 0xc4, 0x71, 0x90, 0x73, 0xf9, 0xaa, 0xdc, 0x17, 0x39, 0xa1, 0x39, 0xf0, 0xe9, 0xc3,
-	0x51, 0x7b, 0xa7, 0xc3, 0x51, 0x9f, 0x7d, 0x38, 0xcc, 0x35, 0x98, 0xdf, 0x4b, 0x1c, 0x87, 0x32,
-	0x36, 0x5b, 0x5a, 0xf3, 0x19, 0xcc, 0xef, 0xd8, 0xde, 0x38, 0x89, 0xa8, 0xb1, 0x0e, 0x75, 0x87,
-	0x9f, 0x71, 0x8e, 0xe8, 0x6d, 0x9e, 0x53, 0x27, 0x03, 0xa7, 0xc5, 0xe1, 0x10, 0x00, 0x9d, 0x5b,
-	0x35, 0xcf, 0x6d, 0x00, 0xdd, 0x87, 0x42, 0xc5, 0x01, 0xfd, 0x2e, 0xa1, 0x2c, 0x36, 0xde, 0xcf,
-	0xf1, 0xbc, 0xa4, 0x78, 0xe6, 0x40, 0x1a, 0x67, 0x03, 0xea, 0xae, 0x1d, 0xdb, 0xc8, 0x56, 0x7c,
-	0x9b, 0x6d, 0x68, 0x49, 0xf8, 0x03, 0xe7, 0xc8, 0xdc, 0x06, 0xb2, 0xeb, 0xf9, 0xcf, 0xed, 0x38,
-	0xf2, 0x4e, 0xd4, 0x1a, 0xf7, 0xa1, 0xce, 0xc3, 0x2c, 0xae, 0x71, 0x45, 0xad, 0x51, 0xc4, 0xc9,
-	0x65, 0x38, 0xd2, 0x5c, 0x85, 0x4e, 0x3a, 0xfb, 0xc0, 0x39, 0x32, 0x08, 0xd4, 0x42, 0xcf, 0x47,
-	0xd3, 0xf0, 0x4f, 0xb3, 0x09, 0x8d, 0x2d, 0xdb, 0x77, 0xe8, 0xd8, 0x3c, 0x07, 0x8b, 0x99, 0xa3,
-	0x21, 0x2b, 0xf3, 0x03, 0xe8, 0x66, 0x44, 0xce, 0xe1, 0x2a, 0x80, 0xe6, 0xa3, 0x92, 0x91, 0x46,
-	0x31, 0x57, 0x01, 0x1e, 0xd3, 0xf8, 0x91, 0x1f, 0x47, 0x41, 0x38, 0xe5, 0x6a, 0x32, 0xef, 0x35,
-	0x45, 0xff, 0x13, 0xdf, 0x7c, 0xb7, 0xd4, 0x74, 0x1f, 0xe6, 0xa9, 0xfc, 0x14, 0x88, 0xce, 0x40,
-	0x0d, 0xcd, 0x7f, 0xaf, 0x40, 0xe7, 0x31, 0x8d, 0x77, 0x93, 0x83, 0xb1, 0xe7, 0x7c, 0x4d, 0xa7,
-	0x3c, 0xf2, 0xda, 0xae, 0x1b, 0x51, 0xc6, 0x2c, 0x2e, 0x7f, 0x6d, 0xa3, 0x3b, 0x68, 0x22, 0xe1,
-	0x85, 0xb1, 0x01, 0x84, 0x3a, 0x2e, 0xb3, 0x2d, 0x27, 0x89, 0x8e, 0xa9, 0xe5, 0xdb, 0x13, 0xb5,
-	0x61, 0x3d, 0x41, 0xdf, 0xe2, 0xe4, 0x17, 0xf6, 0x84, 0xf2, 0x33, 0xcf, 0x46, 0xc1, 0xf7, 0x96,
-	0xeb, 0xb1, 0x70, 0x6c, 0x4f, 0xd1, 0x09, 0xdb, 0x9c, 0xb6, 0x2d, 0x49, 0x7c, 0x25, 0x1e, 0x19,
-	0x25, 0x97, 0xba, 0x3c, 0xbc, 0x9c, 0xc0, 0x7f, 0x6f, 0x3e, 0x86, 0x56, 0x26, 0xd3, 0x2d, 0xa8,
-	0xfb, 0x6a, 0xcf, 0xdb, 0x9b, 0x86, 0xda, 0x8f, 0x27, 0xdb, 0x2f, 0x02, 0x17, 0xdd, 0xc8, 0xc7,
-	0xcd, 0x3e, 0x09, 0x93, 0x03, 0xb5, 0xd9, 0xfc, 0xdb, 0xfc, 0x5d, 0x45, 0x18, 0xea, 0x81, 0x54,
-	0xe1, 0x6c, 0xf5, 0x72, 0x12, 0x55, 0xf3, 0x12, 0xbd, 0x8d, 0x46, 0x5f, 0x40, 0x73, 0x92, 0x8c,
-	0x63, 0x8f, 0x79, 0x43, 0xa1, 0x50, 0x7b, 0x73, 0x55, 0xc9, 0xfa, 0x1c, 0xe9, 0x03, 0xea, 0x52,
-	0x3a, 0xd9, 0x73, 0x22, 0x2f, 0x94, 0xfe, 0x93, 0xfe, 0xc2, 0xf8, 0x14, 0xda, 0x4c, 0xd0, 0x2d,
-	0xe1, 0x7c, 0x73, 0xc2, 0xf9, 0x2e, 0x2a, 0x06, 0x4f, 0xfd, 0x30, 0x89, 0xb5, 0xdf, 0x01, 0x4b,
-	0xbf, 0xcd, 0xbf, 0x85, 0x85, 0xfd, 0x11, 0x8d, 0xed, 0xb7, 0xd5, 0xb3, 0xa8, 0x4a, 0xf5, 0x94,
-	0x2a, 0xdc, 0x79, 0x14, 0xab, 0x3e, 0xcc, 0xe3, 0x2f, 0xd5, 0x51, 0xc7, 0xa1, 0xb9, 0x01, 0x1d,
-	0xb1, 0xee, 0x0c, 0x64, 0x27, 0x43, 0x76, 0x00, 0xbe, 0xf5, 0x42, 0xba, 0x2d, 0xae, 0x70, 0xf3,
-	0x9f, 0xab, 0x00, 0xcf, 0x02, 0xdb, 0x95, 0x43, 0x1e, 0xc4, 0x27, 0x3e, 0x9d, 0x04, 0xbe, 0xe7,
-	0xa8, 0x20, 0xae, 0xc6, 0xe9, 0xd6, 0x57, 0xdf, 0xb0, 0xf5, 0x78, 0xe0, 0x6a, 0xe9, 0x81, 0xfb,
-	0x59, 0x11, 0x2e, 0x77, 0x9f, 0xcc, 0xcd, 0xba, 0x4f, 0x1a, 0xfa, 0x7d, 0xb2, 0x06, 0x5d, 0x76,
-	0xe4, 0x85, 0x96, 0x33, 0xa2, 0xce, 0x11, 0x4b, 0x26, 0x98, 0x8b, 0x74, 0x38, 0x71, 0x0b, 0x69,
-	0xc6, 0x35, 0x68, 0x27, 0x9b, 0x87, 0x96, 0x13, 0x24, 0x7e, 0x4c, 0x23, 0x91, 0x80, 0x74, 0x07,
-	0x90, 0x6c, 0x1e, 0x6e, 0x49, 0x8a, 0xf9, 0x63, 0x15, 0xda, 0x03, 0xca, 0x68, 0x8c, 0x26, 0xb9,
-	0x09, 0x3d, 0xdc, 0x1c, 0x2b, 0xb2, 0x7d, 0x37, 0x98, 0xe0, 0xad, 0xd1, 0x45, 0xea, 0x40, 0x10,
-	0xb9, 0xb8, 0x2c, 0x8e, 0xa8, 0x3f, 0x8c, 0x47, 0x98, 0xb9, 0xa5, 0xe3, 0xd9, 0xfa, 0xd7, 0xce,
-	0xd0, 0xff, 0xf4, 0xed, 0x51, 0x2f, 0xbb, 0x3d, 0xde, 0xdd, 0x4c, 0x05, 0x0b, 0xcc, 0x17, 0x2d,
-	0xc0, 0x01, 0xc2, 0x8e, 0x98, 0x23, 0xc8, 0x1c, 0x0d, 0x38, 0x49, 0xa6, 0x08, 0x3c, 0x19, 0x90,
-	0x5f, 0xe8, 0x44, 0x04, 0x7a, 0x18, 0xde, 0x54, 0x0c, 0xbd, 0x05, 0x80, 0x14, 0x1e, 0x40, 0x67,
-	0xc7, 0xbc, 0x5f, 0x55, 0xa1, 0x37, 0xa0, 0x4e, 0x70, 0x4c, 0xa3, 0x29, 0xda, 0x7b, 0x05, 0xe0,
-	0xfb, 0x20, 0x72, 0xa5, 0x7c, 0x18, 0x45, 0x5b, 0x9c, 0x22, 0xc4, 0x9b, 0x6d, 0xcb, 0xea, 0x3b,
-	0xd9, 0xb2, 0xf6, 0x26, 0x5b, 0xd6, 0x67, 0xd9, 0x72, 0x4e, 0xb7, 0xe5, 0x6d, 0x20, 0xd4, 0x3f,
-	0x0c, 0x22, 0x87, 0x5a, 0x5c, 0xc4, 0xb1, 0xc7, 0x62, 0x61, 0xec, 0xe6, 0x60, 0x01, 0xe9, 0xdf,
-	0x22, 0x99, 0x47, 0x44, 0x11, 0x4c, 0xa4, 0xc7, 0x89, 0xef, 0xe2, 0x56, 0xb4, 0x4e, 0x6d, 0xc5,
-	0x45, 0x98, 0x77, 0xa3, 0xa9, 0x15, 0x25, 0xbe, 0xc8, 0x74, 0x9b, 0x83, 0x86, 0x1b, 0x4d, 0x07,
-	0x89, 0x6f, 0x7e, 0x06, 0x6d, 0xce, 0x59, 0x5d, 0x93, 0x77, 0x73, 0xd7, 0x64, 0x1a, 0xa9, 0x34,
-	0x88, 0x76, 0x43, 0xae, 0xc0, 0x3c, 0x9f, 0xe0, 0x3b, 0x63, 0x40, 0x9d, 0xcb, 0x8d, 0x67, 0x5d,
-	0x7c, 0x9b, 0xff, 0x59, 0x81, 0xf6, 0x9e, 0x37, 0xf4, 0x9f, 0x63, 0xce, 0x73, 0x66, 0xfc, 0x2a,
-	0xa4, 0x0b, 0x9d, 0x2c, 0x55, 0xca, 0x45, 0xf0, 0x5a, 0x21, 0x82, 0x17, 0x02, 0x6c, 0xfd, 0xed,
-	0x03, 0xec, 0x3f, 0x40, 0xf7, 0x15, 0x8d, 0xbc, 0xc3, 0xa9, 0x12, 0x6f, 0x66, 0x4c, 0x34, 0xae,
-	0x40, 0x8b, 0x79, 0x43, 0x5f, 0xd4, 0x5d, 0x28, 0x5d, 0x46, 0xd0, 0x25, 0xaf, 0x9d, 0x21, 0x79,
-	0xf1, 0x36, 0xfc, 0x1b, 0x20, 0xb8, 0xf2, 0x9e, 0xce, 0xea, 0xe7, 0x88, 0x60, 0xfe, 0x57, 0x85,
-	0x1f, 0x1c, 0x27, 0x9a, 0x86, 0xb1, 0xd2, 0xe6, 0x02, 0x34, 0xc2, 0xe4, 0xe0, 0x88, 0xaa, 0x93,
-	0x82, 0xa3, 0x33, 0xec, 0x7c, 0x1d, 0x3a, 0x2a, 0x3e, 0x05, 0xfe, 0x38, 0xbd, 0x0c, 0x91, 0xf6,
-	0xd2, 0x1f, 0x17, 0x12, 0x89, 0xfa, 0x59, 0x37, 0xed, 0x5c, 0x41, 0xdb, 0x57, 0x40, 0x50, 0x40,
-	0xea, 0x2a, 0x11, 0x97, 0x60, 0xce, 0x0f, 0x7c, 0x87, 0xa2, 0x84, 0x72, 0x70, 0x86, 0x80, 0x06,
-	0xd4, 0x47, 0x13, 0xdb, 0x41, 0x2b, 0x8b, 0x6f, 0xf3, 0x3b, 0xe8, 0x6d, 0xd3, 0x9c, 0xe2, 0x67,
-	0x7a, 0x59, 0xba, 0x64, 0x75, 0xc6, 0x92, 0xb5, 0xf2, 0x25, 0xeb, 0xda, 0x92, 0x3b, 0x40, 0x70,
-	0xc9, 0x4c, 0x95, 0x42, 0xea, 0xac, 0x71, 0xd0, 0xb6, 0xb4, 0x9a, 0xbf, 0x69, 0x7f, 0x5b, 0x81,
-	0xde, 0x96, 0x17, 0x8e, 0x68, 0xf4, 0x35, 0x9d, 0xbe, 0xb2, 0xc7, 0xc9, 0x1b, 0x64, 0x27, 0x50,
-	0xe3, 0xdb, 0x29, 0xb9, 0xf0, 0x4f, 0xae, 0xcd, 0x31, 0xff, 0x1d, 0x4a, 0x2d, 0x07, 0x32, 0x48,
-	0x0a, 0xf9, 0x30, 0xd0, 0xab, 0xa1, 0x71, 0x03, 0x7a, 0x36, 0x3b, 0xb2, 0x02, 0xdf, 0x52, 0x00,
-	0x59, 0xa9, 0x77, 0x6c, 0x76, 0xf4, 0xd2, 0x7f, 0x74, 0x0a, 0xe5, 0x4a, 0x35, 0x31, 0x10, 0x49,
-	0x14, 0xaa, 0x6e, 0xf4, 0xa0, 0xea, 0x1d, 0x8b, 0x98, 0xdf, 0x19, 0x54, 0xbd, 0x63, 0x73, 0x03,
-	0x88, 0x54, 0x86, 0xba, 0xa9, 0x3a, 0xa9, 0x7c, 0x15, 0x4d, 0x3e, 0x33, 0x81, 0xde, 0x23, 0x16,
-	0x7b, 0x13, 0x3b, 0xa6, 0xfb, 0x27, 0x7b, 0xde, 0x6b, 0xca, 0xef, 0xdb, 0x20, 0x89, 0xc3, 0x24,
-	0x66, 0xb9, 0x60, 0xdd, 0x41, 0xa2, 0x8c, 0xd7, 0xd7, 0xa1, 0xe3, 0xf9, 0x1a, 0x46, 0xde, 0x8d,
-	0x6d, 0x49, 0x93, 0x90, 0xb3, 0x22, 0x85, 0x79, 0x1d, 0x1a, 0xb8, 0xdc, 0x45, 0x98, 0x8f, 0x4f,
-	0x2c, 0x2d, 0xb7, 0x6e, 0xc4, 0x62, 0xc2, 0xfc, 0xef, 0x0a, 0x34, 0xf8, 0x61, 0xdc, 0x3f, 0xf9,
-	0x93, 0x88, 0xc4, 0x77, 0x2a, 0xdf, 0x59, 0x51, 0x43, 0xfe, 0xb3, 0x71, 0xe0, 0x1c, 0x59, 0xb1,
-	0x87, 0x67, 0xa9, 0x3b, 0x68, 0x72, 0xc2, 0xbe, 0x37, 0xa1, 0xe6, 0x1f, 0x2a, 0xd0, 0xd9, 0xf3,
-	0x26, 0xe1, 0x98, 0xa2, 0xb0, 0x77, 0xa1, 0x21, 0xd7, 0x14, 0x3e, 0xd3, 0xce, 0xaa, 0xb2, 0xfd,
-	0x13, 0x11, 0x01, 0x45, 0xec, 0x43, 0x88, 0x71, 0x0f, 0xe6, 0x51, 0x89, 0x7e, 0x55, 0xa0, 0x97,
-	0x32, 0xf4, 0x4b, 0x31, 0x21, 0xe0, 0x0a, 0x64, 0x7c, 0x0e, 0x9d, 0x38, 0xb2, 0x7d, 0x66, 0x8b,
-	0xab, 0x8d, 0xf5, 0x6b, 0xe2, 0x47, 0x69, 0x88, 0xdd, 0xcf, 0xe6, 0xc4, 0xef, 0x72, 0xe0, 0x33,
-	0x23, 0xa0, 0xae, 0xfe, 0xdc, 0x19, 0xea, 0x37, 0x0a, 0xea, 0xff, 0xa6, 0x02, 0xad, 0xfd, 0xb4,
-	0xae, 0xfb, 0x04, 0x3a, 0x91, 0xfc, 0xb4, 0xb4, 0x8b, 0x2b, 0xb5, 0x80, 0x7e, 0x69, 0xb5, 0xa3,
-	0x6c, 0x60, 0x7c, 0x02, 0xf3, 0x2e, 0x8d, 0x6d, 0x6f, 0xcc, 0x30, 0x0f, 0xbd, 0x92, 0x99, 0x01,
-	0x7f, 0xb4, 0x2d, 0xe7, 0xa5, 0x39, 0x10, 0x6c, 0x7c, 0x05, 0xc0, 0x68, 0xa4, 0x3a, 0x3e, 0x35,
-	0xf1, 0xd3, 0x6b, 0xa7, 0x7e, 0xba, 0x97, 0x42, 0xf0, 0xde, 0x49, 0xc7, 0xe6, 0x7d, 0x98, 0xdb,
-	0x17, 0xf5, 0xe4, 0x3a, 0x54, 0xe3, 0x13, 0xac, 0x7f, 0x66, 0x9a, 0xb3, 0x1a, 0x9f, 0x98, 0xff,
-	0x54, 0x85, 0xb6, 0xc8, 0xc9, 0x71, 0xbb, 0x7f, 0x46, 0x84, 0xbb, 0x0c, 0xad, 0xa1, 0xcd, 0xac,
-	0x30, 0xf2, 0x1c, 0x15, 0x2d, 0x9a, 0x43, 0x9b, 0xed, 0xf2, 0xb1, 0x9a, 0x1c, 0x7b, 0x13, 0x2f,
-	0xc6, 0x48, 0xc7, 0x27, 0x9f, 0xf1, 0x31, 0x3f, 0xe7, 0x71, 0x20, 0xf6, 0xa7, 0x33, 0xa8, 0xc6,
-	0x41, 0x76, 0xa6, 0x1b, 0x7a, 0xcc, 0x79, 0x0f, 0x0c, 0x5e, 0x86, 0x5b, 0xd8, 0xfa, 0xb2, 0x9c,
-	0x51, 0xe2, 0x1f, 0x61, 0x74, 0x20, 0x7c, 0x06, 0x7b, 0x9a, 0x5b, 0x9c, 0xce, 0xb3, 0x15, 0x81,
-	0x1e, 0xcb, 0x2c, 0x17, 0x53, 0x67, 0x4e, 0x7a, 0x26, 0xf3, 0xdc, 0x4b, 0xd0, 0x74, 0x46, 0xb6,
-	0xe7, 0x5b, 0x9e, 0x8b, 0xb9, 0xcc, 0xbc, 0x18, 0x3f, 0x75, 0xcd, 0x7f, 0xab, 0x40, 0x4f, 0x18,
-	0x23, 0x73, 0x81, 0x02, 0xbb, 0xca, 0x29, 0x76, 0x3c, 0x0f, 0x55, 0x77, 0xa5, 0x75, 0x8c, 0xc7,
-	0x14, 0x52, 0xd2, 0xab, 0x3c, 0x20, 0x42, 0x03, 0x65, 0x80, 0x41, 0x1e, 0xc0, 0x54, 0xef, 0x28,
-	0x25, 0xed, 0x99, 0x77, 0x01, 0x50, 0x2a, 0xbe, 0xb5, 0x2b, 0x20, 0x96, 0x47, 0x33, 0xc8, 0xe8,
-	0xd7, 0xe2, 0x14, 0xa1, 0xbf, 0xf9, 0x14, 0x48, 0xba, 0x9f, 0xbf, 0x2c, 0x39, 0x32, 0x0f, 0xc1,
-	0x10, 0xac, 0xce, 0x4c, 0x65, 0x3a, 0xbf, 0x38, 0x95, 0x31, 0x5f, 0xc2, 0x79, 0xb1, 0xce, 0x9b,
-	0x52, 0x96, 0xb7, 0x5d, 0xca, 0xfc, 0x49, 0x04, 0xb1, 0xa1, 0xff, 0xd4, 0xa5, 0x7e, 0xec, 0xc5,
-	0x53, 0xe3, 0x3e, 0x34, 0x3d, 0xfc, 0xc6, 0x43, 0x91, 0x06, 0x26, 0x85, 0x91, 0xc5, 0xb5, 0x42,
-	0xf1, 0x9c, 0xd9, 0x19, 0xd9, 0x63, 0xbe, 0xed, 0xd4, 0x1a, 0x79, 0xae, 0x4b, 0x7d, 0x5c, 0x67,
-	0x21, 0xa5, 0x3f, 0x11, 0xe4, 0x3c, 0xf4, 0xd8, 0x63, 0x89, 0x3d, 0xc6, 0x68, 0x9c, 0x41, 0x5f,
-	0x09, 0x72, 0x69, 0x3f, 0xa4, 0x5e, 0xd6, 0x0f, 0x31, 0x87, 0xd0, 0xe3, 0x1a, 0x50, 0x37, 0xd5,
-	0x61, 0x76, 0xfe, 0xb6, 0x02, 0x10, 0x8a, 0xde, 0x87, 0xa5, 0xee, 0xf0, 0xce, 0xa0, 0x15, 0xa6,
-	0xdd, 0x90, 0x9c, 0xad, 0x6a, 0x45, 0x5b, 0xfd, 0x47, 0x05, 0x16, 0x1f, 0xd3, 0xf8, 0xd1, 0xd6,
-	0xf6, 0x13, 0x6c, 0x9b, 0xf2, 0xdf, 0xbc, 0xbb, 0xc1, 0x6e, 0xc1, 0x42, 0x48, 0x69, 0x64, 0x9d,
-	0x92, 0xa4, 0xcb, 0xc9, 0x59, 0x6f, 0xa6, 0xcc, 0x04, 0xb5, 0x52, 0x13, 0x7c, 0x08, 0xbd, 0x82,
-	0x54, 0xfc, 0xa4, 0xc8, 0x91, 0x95, 0x25, 0x9f, 0xc0, 0x52, 0x80, 0x79, 0x1f, 0xba, 0x7b, 0x34,
-	0xfe, 0x66, 0x73, 0x47, 0xab, 0x12, 0xf5, 0xda, 0xa5, 0x72, 0xaa, 0x90, 0x5e, 0x87, 0xee, 0x0e,
-	0xb6, 0x9f, 0x1f, 0x89, 0x46, 0xee, 0x05, 0x68, 0xe4, 0xce, 0x3a, 0x8e, 0xcc, 0x07, 0xb0, 0xa0,
-	0x80, 0x2a, 0x36, 0x5c, 0x80, 0x46, 0x70, 0x78, 0xc8, 0xa8, 0xba, 0xc0, 0x71, 0xa4, 0xb1, 0xa8,
-	0xe6, 0x58, 0x7c, 0x09, 0x3d, 0xc5, 0xe2, 0x9b, 0x70, 0x1c, 0xd8, 0x2e, 0xdf, 0xd3, 0xd0, 0x9e,
-	0xf2, 0x4f, 0xe5, 0xe0, 0x38, 0x14, 0xc9, 0xa1, 0xcd, 0x46, 0x68, 0x43, 0xf1, 0x6d, 0xde, 0x80,
-	0xe6, 0x1e, 0x1d, 0x1f, 0xee, 0xf3, 0xb5, 0x67, 0xfe, 0xd2, 0xbc, 0x03, 0x8b, 0xdb, 0xf4, 0x20,
-	0x19, 0x3e, 0xf3, 0xfc, 0xa3, 0x6d, 0xea, 0xc8, 0xe7, 0x80, 0xf3, 0xd0, 0x98, 0x52, 0x66, 0xf9,
-	0x01, 0xf6, 0x05, 0xe6, 0xa6, 0x94, 0xbd, 0x08, 0xcc, 0x73, 0x1a, 0xf6, 0x31, 0x8d, 0xf7, 0x62,
-	0x3b, 0xa6, 0xe6, 0xef, 0xab, 0x3c, 0xef, 0x45, 0xaa, 0x20, 0x09, 0x8d, 0xec, 0x69, 0x90, 0xc4,
-	0x2a, 0xe1, 0x97, 0x23, 0xd5, 0x45, 0xa9, 0x66, 0x5d, 0x94, 0x0b, 0xd0, 0x98, 0x88, 0xae, 0x26,
-	0x6e, 0x2a, 0x8e, 0x72, 0x3d, 0x9b, 0xfa, 0x8c, 0x9e, 0xcd, 0xdc, 0x1b, 0x7a, 0x36, 0x33, 0xab,
-	0xea, 0xc6, 0x19, 0x55, 0xf5, 0x0a, 0x40, 0x44, 0x19, 0x8d, 0x45, 0xe9, 0x2b, 0x6e, 0x8d, 0xd6,
-	0xa0, 0x25, 0x28, 0xbc, 0xbc, 0xe4, 0xb9, 0x98, 0x9c, 0x56, 0xb5, 0x7f, 0x53, 0x28, 0xd8, 0x11,
-	0x44, 0xd5, 0x0e, 0x7d, 0x0f, 0x8c, 0x08, 0xeb, 0x7f, 0xeb, 0xd0, 0x3e, 0x92, 0x65, 0x34, 0x3e,
-	0xf7, 0x10, 0x35, 0xb3, 0x63, 0x1f, 0x89, 0x3a, 0xda, 0xb8, 0x03, 0x8b, 0x29, 0x5a, 0x34, 0x09,
-	0xc2, 0x80, 0x89, 0xc2, 0xb8, 0x3b, 0x58, 0x50, 0x13, 0x1c, 0xb8, 0x1b, 0x30, 0x73, 0x01, 0xba,
-	0x9a, 0xa9, 0x83, 0xd0, 0xdc, 0x85, 0x4e, 0x4a, 0x78, 0x16, 0x0c, 0x45, 0x45, 0x4f, 0x8f, 0xe9,
-	0x58, 0xbd, 0x14, 0x88, 0x01, 0xb7, 0xf2, 0x41, 0xe2, 0x1c, 0xd1, 0x18, 0x4d, 0x8f, 0x23, 0x51,
-	0xbe, 0xd3, 0x93, 0x18, 0x6d, 0x2f, 0xbe, 0xcd, 0xc7, 0x70, 0x2e, 0xe5, 0xf8, 0x9c, 0x4e, 0x82,
-	0x68, 0x3a, 0xa0, 0xd2, 0xf5, 0xf4, 0x70, 0xd2, 0xcd, 0xc2, 0xc9, 0x2c, 0xf7, 0xbd, 0x0d, 0x0b,
-	0x05, 0x46, 0x62, 0xb7, 0xc5, 0x97, 0xf2, 0x0b, 0x39, 0x32, 0xff, 0x0e, 0x96, 0x0a, 0xd0, 0x6f,
-	0x23, 0x2f, 0xa6, 0x67, 0x2f, 0x8a, 0x9c, 0xaa, 0x3a, 0x27, 0x7c, 0x29, 0x61, 0x23, 0xac, 0x18,
-	0xe5, 0xc0, 0x7c, 0x5f, 0xd3, 0x69, 0x87, 0x53, 0xd2, 0xb3, 0xcb, 0xa8, 0x13, 0x07, 0xea, 0xa0,
-	0xe3, 0xe8, 0xce, 0xbf, 0x9e, 0x87, 0x36, 0x5e, 0x2e, 0x22, 0x3f, 0x5b, 0x85, 0x0b, 0xda, 0xd0,
-	0xca, 0xde, 0x44, 0xc9, 0x9f, 0x2d, 0xd7, 0xff, 0xe5, 0xff, 0xfb, 0x15, 0x63, 0x39, 0x2d, 0xa0,
-	0x05, 0x62, 0xd7, 0xf3, 0x87, 0xa4, 0x82, 0x73, 0x2b, 0x70, 0x4e, 0x9f, 0xc3, 0x17, 0x0e, 0x52,
-	0x5d, 0xae, 0xff, 0x50, 0x32, 0x8d, 0x8f, 0x17, 0xa4, 0x86, 0xd3, 0xd7, 0xe0, 0xbc, 0x3e, 0x9d,
-	0x3e, 0xf8, 0x90, 0x3a, 0xb2, 0x2f, 0x08, 0x97, 0xb5, 0x41, 0xc9, 0x1c, 0x22, 0xd6, 0xe1, 0x52,
-	0x6e, 0x05, 0x3d, 0x7e, 0x91, 0xc6, 0x72, 0x93, 0x83, 0x7e, 0xcd, 0x81, 0x1b, 0xb0, 0x5c, 0x06,
-	0x94, 0xc1, 0x87, 0xcc, 0x6b, 0xc8, 0xdb, 0x70, 0xb9, 0x0c, 0x89, 0x91, 0x8e, 0x34, 0x97, 0x9b,
-	0x3f, 0x28, 0x68, 0x41, 0xbe, 0xec, 0x51, 0x81, 0xb4, 0xca, 0x0d, 0xa4, 0xa6, 0x01, 0x2d, 0x60,
-	0x42, 0xbf, 0xc0, 0x20, 0xbd, 0x1d, 0x48, 0x1b, 0x59, 0x14, 0xac, 0x94, 0x01, 0x3a, 0xc8, 0xa4,
-	0x20, 0x45, 0xd6, 0x1d, 0x26, 0x5d, 0x64, 0x71, 0x1d, 0x2e, 0xea, 0x08, 0xad, 0x5b, 0x4a, 0x7a,
-	0x08, 0xb9, 0x02, 0x46, 0x6e, 0x27, 0x45, 0x0a, 0x4c, 0x16, 0x70, 0xf6, 0x46, 0x5e, 0x4e, 0xbd,
-	0x2a, 0x22, 0x64, 0xb9, 0xc1, 0x31, 0xcd, 0x8a, 0x71, 0x15, 0x96, 0x72, 0x96, 0xc3, 0x17, 0x74,
-	0xb2, 0x88, 0x82, 0xde, 0x82, 0x2b, 0x05, 0x4f, 0xca, 0xbd, 0x09, 0x11, 0x23, 0xc5, 0xf5, 0x4b,
-	0x71, 0x0f, 0x9c, 0x23, 0x72, 0x4e, 0xee, 0xd4, 0xff, 0x95, 0xc8, 0x2c, 0xdf, 0x88, 0xc8, 0x52,
-	0xb9, 0xdd, 0xd2, 0x3c, 0x96, 0x9c, 0xc7, 0x65, 0x2e, 0xc3, 0x62, 0x1e, 0xc0, 0xf9, 0x5f, 0x48,
-	0x35, 0xce, 0xf9, 0x4b, 0xbe, 0x81, 0x40, 0x2e, 0x22, 0xaa, 0xb0, 0x7f, 0xfa, 0x8b, 0x2b, 0xe9,
-	0x23, 0x66, 0x2d, 0xef, 0xa2, 0xb9, 0x47, 0x58, 0x72, 0xa9, 0x1c, 0x94, 0x7b, 0x92, 0x23, 0xcb,
-	0x28, 0xf0, 0x5a, 0x5e, 0xa3, 0xf4, 0x21, 0x8e, 0x5c, 0xd6, 0x8c, 0x52, 0xf0, 0x86, 0xec, 0xa5,
-	0x95, 0x5c, 0x29, 0x3f, 0x55, 0xd9, 0xcb, 0x07, 0x59, 0x29, 0xf7, 0x5a, 0x35, 0x7d, 0x35, 0xf5,
-	0xda, 0xdc, 0x3e, 0xab, 0x8b, 0x98, 0xac, 0x6a, 0xa7, 0xa8, 0x60, 0x19, 0xbd, 0xfd, 0x4c, 0xcc,
-	0x72, 0x1b, 0xe7, 0x5b, 0xd2, 0x64, 0xad, 0xdc, 0xbd, 0xb3, 0x36, 0x35, 0xb9, 0x51, 0xee, 0xde,
-	0x5a, 0xba, 0x4f, 0x6e, 0x95, 0xdb, 0x37, 0x97, 0xc6, 0x93, 0x75, 0x04, 0x15, 0xfc, 0xb3, 0x98,
-	0x83, 0x93, 0x0d, 0x94, 0x68, 0x1d, 0x56, 0x72, 0xfe, 0x59, 0x7c, 0x91, 0x24, 0xb7, 0x53, 0xe0,
-	0xa5, 0x72, 0x20, 0x97, 0xfe, 0x8e, 0xb6, 0x69, 0xb7, 0x0a, 0x96, 0xc8, 0xf5, 0x6d, 0xc8, 0x5d,
-	0xed, 0x84, 0x19, 0x79, 0x97, 0x15, 0xf3, 0xef, 0x2d, 0x37, 0x7e, 0x90, 0xf3, 0x05, 0x8b, 0xe6,
-	0x3b, 0xf5, 0xe4, 0xfd, 0x72, 0x7b, 0x69, 0x4d, 0x67, 0x72, 0xaf, 0x3c, 0x72, 0x63, 0xfb, 0x99,
-	0x7c, 0x50, 0x6e, 0xa9, 0x62, 0x47, 0x8a, 0xdc, 0x4f, 0x4f, 0x72, 0x61, 0x87, 0xf5, 0x16, 0x22,
-	0xf9, 0x30, 0xd5, 0x6b, 0x23, 0xcf, 0xaf, 0xd8, 0xc2, 0x24, 0x9b, 0xa9, 0x86, 0x05, 0x8e, 0xf9,
-	0xa6, 0x24, 0xf9, 0x68, 0x16, 0xc7, 0x62, 0x27, 0x91, 0x7c, 0x9c, 0x72, 0x34, 0x8b, 0xb1, 0x2d,
-	0x2b, 0x96, 0xc8, 0x9f, 0x97, 0x7b, 0x6a, 0xbe, 0x1c, 0x21, 0x9f, 0xa0, 0xb6, 0x05, 0xbb, 0x6a,
-	0xff, 0x28, 0x22, 0x7f, 0x81, 0x8c, 0x6e, 0xe6, 0x2f, 0x97, 0xc2, 0xd3, 0x23, 0xf9, 0xb4, 0x3c,
-	0xae, 0xe8, 0x2f, 0x85, 0xe4, 0x2f, 0xcb, 0x57, 0xd3, 0x3a, 0x17, 0xe4, 0xb3, 0x72, 0xb1, 0xf3,
-	0xf5, 0x3c, 0xf9, 0xbc, 0xfc, 0x80, 0x65, 0xf5, 0x35, 0xf9, 0x22, 0xbd, 0x65, 0x57, 0x8a, 0xf7,
-	0x5c, 0xae, 0x1a, 0x21, 0x7f, 0x35, 0xe3, 0x44, 0xe7, 0x51, 0x5f, 0xa6, 0x71, 0xec, 0x52, 0x3e,
-	0x7e, 0x68, 0x65, 0x0a, 0xf9, 0xaa, 0xdc, 0xc5, 0x8a, 0x85, 0x3c, 0xf9, 0x6b, 0xc4, 0x6d, 0xc0,
-	0xd5, 0x53, 0xb8, 0xfc, 0xf1, 0x7e, 0x80, 0xc8, 0xbb, 0x70, 0xfd, 0x14, 0xf2, 0xd4, 0x19, 0x7f,
-	0x88, 0x32, 0xde, 0xcd, 0xab, 0x7c, 0xaa, 0x8c, 0x20, 0xee, 0x72, 0xf3, 0x47, 0x75, 0x7c, 0xd7,
-	0x67, 0x80, 0x55, 0x1d, 0x41, 0xe8, 0x72, 0xfd, 0xc7, 0x12, 0xfb, 0xe4, 0x4b, 0x0b, 0x72, 0xb8,
-	0x5c, 0xff, 0x9f, 0x12, 0xfb, 0xe4, 0xb2, 0x62, 0x32, 0x44, 0x56, 0x05, 0x17, 0xd1, 0x33, 0x65,
-	0x32, 0x42, 0x46, 0xb7, 0xe1, 0x5a, 0x29, 0x26, 0xcb, 0x7d, 0x89, 0x8f, 0xec, 0x0a, 0x8e, 0x59,
-	0x80, 0x92, 0x00, 0x39, 0xde, 0x81, 0xd5, 0x33, 0x60, 0x22, 0xb3, 0x25, 0x21, 0xb2, 0x9c, 0xb5,
-	0x7a, 0x96, 0xa5, 0x92, 0xef, 0x24, 0xf4, 0xe1, 0xc7, 0xb0, 0xe6, 0x04, 0x93, 0x7b, 0xcc, 0x8e,
-	0x03, 0x36, 0xf2, 0xc6, 0xf6, 0x01, 0x53, 0x95, 0xcf, 0xd8, 0x3b, 0x90, 0xff, 0xd8, 0x3b, 0x48,
-	0x0e, 0x1f, 0x76, 0xf7, 0x05, 0x11, 0xb9, 0x1e, 0x34, 0xc4, 0xc4, 0x47, 0x7f, 0x0c, 0x00, 0x00,
-	0xff, 0xff, 0xae, 0x03, 0x03, 0xb2, 0xf1, 0x27, 0x00, 0x00,
+	// 3435 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x5a, 0x3d, 0x70, 0x1c, 0x47,
+	0x76, 0xf6, 0xfe, 0x60, 0xb1, 0xfb, 0xf6, 0x07, 0x8d, 0x01, 0x40, 0x2e, 0x41, 0x82, 0x04, 0x06,
+	0x24, 0x00, 0xfe, 0x88, 0xa2, 0x20, 0x59, 0x96, 0x25, 0x59, 0x32, 0x09, 0x10, 0x24, 0x2d, 0xfe,
+	0xc0, 0x0b, 0x88, 0xca, 0x3c, 0x35, 0x98, 0x69, 0xec, 0x8e, 0xb1, 0x3b, 0x33, 0x9a, 0x1f, 0x08,
+	0xcb, 0xc0, 0xb1, 0xab, 0x1c, 0xd8, 0x81, 0x5d, 0x56, 0x95, 0x03, 0x2b, 0xb8, 0xe0, 0x2e, 0xbb,
+	0xe4, 0xae, 0x2e, 0xb8, 0x40, 0xf9, 0xc5, 0x77, 0xf9, 0x85, 0x17, 0x5d, 0x72, 0xe1, 0x05, 0x57,
+	0xdd, 0xfd, 0x7a, 0xa6, 0x67, 0x30, 0xbb, 0x14, 0xa9, 0xaa, 0xcb, 0xa6, 0x5f, 0x7f, 0xfb, 0xfa,
+	0xfd, 0xf5, 0xeb, 0xd7, 0xaf, 0x17, 0x3a, 0x23, 0x1a, 0x86, 0x66, 0x9f, 0x86, 0x77, 0xfd, 0xc0,
+	0x8b, 0x3c, 0xad, 0x16, 0x05, 0xf4, 0x95, 0x17, 0x2c, 0x37, 0xa3, 0xb1, 0x2f, 0x89, 0x7a, 0x0b,
+	0xe0, 0x89, 0xeb, 0x44, 0x8e, 0x39, 0x74, 0x5e, 0x51, 0xbd, 0x0d, 0xcd, 0x47, 0x34, 0xda, 0xa3,
+	0x66, 0x14, 0x07, 0x34, 0xd4, 0xbf, 0x9f, 0x81, 0xba, 0x1c, 0x68, 0x17, 0xa0, 0x76, 0x4a, 0x5d,
+	0xdb, 0x0b, 0xba, 0xa5, 0xd5, 0xd2, 0x56, 0xa3, 0x87, 0x23, 0x6d, 0x1d, 0xda, 0x23, 0xf3, 0x5f,
+	0xbd, 0xc0, 0x38, 0xa5, 0x41, 0xe8, 0x78, 0x6e, 0xb7, 0xbc, 0x5a, 0xda, 0x6a, 0xf7, 0x5a, 0x9c,
+	0xf8, 0x52, 0xd0, 0x38, 0xc8, 0x71, 0x15, 0x50, 0x05, 0x41, 0x8e, 0x9b, 0x01, 0xf9, 0x66, 0x64,
+	0x0d, 0x12, 0x50, 0x55, 0x80, 0x38, 0x51, 0x82, 0x36, 0x61, 0xee, 0xc8, 0xf3, 0xa2, 0xa1, 0x67,
+	0xda, 0x34, 0x30, 0x46, 0x9e, 0x4d, 0xbb, 0x33, 0xab, 0xa5, 0xad, 0x7a, 0xaf, 0x93, 0x92, 0x9f,
+	0x79, 0x36, 0xd5, 0x2e, 0x43, 0xc3, 0xa6, 0xa7, 0x8e, 0x45, 0x0d, 0xc7, 0xee, 0xd6, 0xb8, 0xc8,
+	0x75, 0x41, 0x78, 0x62, 0x6b, 0x37, 0xa0, 0xe3, 0x3b, 0xae, 0xc1, 0x6c, 0x40, 0xad, 0x88, 0xad,
+	0x35, 0xcb, 0x99, 0xb4, 0x7d, 0xc7, 0xdd, 0x4f, 0x88, 0xda, 0xfb, 0xb0, 0xe4, 0x9b, 0x61, 0xe8,
+	0x0f, 0x02, 0x33, 0xa4, 0x2a, 0xba, 0xce, 0xd1, 0x8b, 0xe9, 0xa4, 0xf2, 0xa3, 0x65, 0xa8, 0x0f,
+	0x4d, 0xb7, 0x1f, 0x9b, 0x7d, 0xda, 0x6d, 0x88, 0x75, 0xe5, 0x58, 0x5b, 0x84, 0x99, 0xa1, 0x79,
+	0x44, 0x87, 0x5d, 0xe0, 0x13, 0x62, 0xa0, 0x6d, 0xc0, 0x8c, 0xe5, 0x39, 0x6e, 0xd8, 0x6d, 0xae,
+	0x56, 0xb6, 0x9a, 0xdb, 0xe4, 0xae, 0xf0, 0xd4, 0xdd, 0x1d, 0xcf, 0x71, 0x0f, 0xc7, 0x3e, 0xed,
+	0x89, 0x69, 0x6d, 0x15, 0x9a, 0x4e, 0xe2, 0x2c, 0xbb, 0xdb, 0xe2, 0x42, 0xa8, 0x24, 0xb6, 0x76,
+	0x40, 0x4f, 0x1d, 0x6e, 0xbd, 0xf6, 0x6a, 0x69, 0xab, 0xd5, 0x4b, 0xc6, 0x39, 0xcb, 0x0d, 0xcc,
+	0x70, 0xd0, 0xed, 0x70, 0x88, 0x62, 0xb9, 0xc7, 0x66, 0x38, 0x60, 0x4c, 0x9c, 0x91, 0xef, 0x05,
+	0x11, 0xb5, 0xbb, 0x73, 0x7c, 0x8d, 0x64, 0xac, 0xad, 0x00, 0x30, 0xc3, 0x59, 0xa6, 0x35, 0xa0,
+	0x76, 0x97, 0xf0, 0xd9, 0x86, 0xef, 0xb8, 0x3b, 0x9c, 0xa0, 0xdd, 0x86, 0x79, 0xc5, 0x60, 0x88,
+	0x9a, 0xe7, 0x28, 0x92, 0x4e, 0x20, 0xf8, 0x26, 0x90, 0x63, 0x27, 0x18, 0x7d, 0x63, 0x06, 0xcc,
+	0xb6, 0x34, 0xa4, 0x6e, 0xd4, 0xd5, 0x38, 0x76, 0x4e, 0xd2, 0xf7, 0x05, 0x59, 0x5b, 0x83, 0x96,
+	0x4b, 0xa9, 0x1d, 0x1a, 0x47, 0xa6, 0x75, 0x12, 0xfb, 0xdd, 0x05, 0xa1, 0x3a, 0xa7, 0x3d, 0xe0,
+	0x24, 0x66, 0xda, 0xe3, 0xa1, 0xd9, 0x0f, 0xbb, 0x8b, 0x3c, 0x6a, 0xc4, 0x40, 0xef, 0x40, 0x6b,
+	0x67, 0x48, 0xcd, 0xe0, 0x80, 0x86, 0xcc, 0x08, 0xfa, 0xbf, 0x97, 0xa0, 0x7d, 0xdf, 0xf7, 0x87,
+	0xe3, 0x03, 0x1a, 0x45, 0x8e, 0xdb, 0x0f, 0x33, 0xee, 0x2a, 0x4d, 0x72, 0x57, 0x59, 0x75, 0xd7,
+	0x0d, 0xe8, 0xc4, 0x2c, 0x1c, 0x12, 0x7d, 0x78, 0x34, 0xd7, 0x7b, 0xed, 0x38, 0xa4, 0xfb, 0x09,
+	0x51, 0xbb, 0x0a, 0x30, 0xf0, 0x46, 0x34, 0xb4, 0x02, 0x4a, 0x45, 0x2c, 0xb7, 0x7a, 0x0a, 0x45,
+	0xd7, 0x01, 0xb8, 0x24, 0x7b, 0x4c, 0xd0, 0x54, 0xfc, 0x92, 0x2a, 0xfe, 0x3a, 0x34, 0x76, 0x06,
+	0xa6, 0xdb, 0xa7, 0xfb, 0x8e, 0xcb, 0x76, 0x60, 0x40, 0x47, 0xde, 0xa9, 0x90, 0xb3, 0xde, 0xc3,
+	0x91, 0xfe, 0xb3, 0x12, 0x54, 0xf7, 0x1d, 0xb7, 0xaf, 0x75, 0x61, 0x16, 0xf7, 0x3c, 0x6a, 0x22,
+	0x87, 0xcc, 0x2f, 0x47, 0x71, 0x14, 0x79, 0x99, 0x90, 0x2f, 0x0b, 0xbf, 0x88, 0x09, 0x25, 0x80,
+	0xcf, 0x6f, 0x8e, 0xca, 0x1b, 0x6d, 0x8e, 0xea, 0xe4, 0xcd, 0xa1, 0xaf, 0xc3, 0xec, 0x41, 0x6c,
+	0x59, 0x34, 0x0c, 0x27, 0x4b, 0xab, 0x3f, 0x85, 0xd9, 0x3d, 0xd3, 0x19, 0xc6, 0x01, 0xd5, 0x36,
+	0xa1, 0x6a, 0x79, 0xb6, 0x40, 0x74, 0xb6, 0x17, 0xe4, 0xce, 0xc0, 0x69, 0xbe, 0x39, 0x38, 0x40,
+	0xe5, 0x56, 0xce, 0x72, 0xeb, 0x41, 0xfb, 0x01, 0x57, 0xb1, 0x47, 0xbf, 0x8e, 0x69, 0x18, 0x69,
+	0xef, 0x64, 0x78, 0x5e, 0x92, 0x3c, 0x33, 0x20, 0x85, 0xb3, 0x06, 0x55, 0xdb, 0x8c, 0x4c, 0x64,
+	0xcb, 0xbf, 0xf5, 0x26, 0x34, 0x04, 0xfc, 0xbe, 0x75, 0xa2, 0xef, 0x02, 0xd9, 0x77, 0xdc, 0x67,
+	0x66, 0x14, 0x38, 0x67, 0x72, 0x8d, 0x7b, 0x50, 0x65, 0x69, 0x16, 0xd7, 0xb8, 0x22, 0xd7, 0xc8,
+	0xe3, 0xc4, 0x32, 0x0c, 0xa9, 0xaf, 0x42, 0x2b, 0x99, 0xbd, 0x6f, 0x9d, 0x68, 0x04, 0x2a, 0xbe,
+	0xe3, 0xa2, 0x69, 0xd8, 0xa7, 0x5e, 0x87, 0xda, 0x8e, 0xe9, 0x5a, 0x74, 0xa8, 0x2f, 0xc0, 0x7c,
+	0x1a, 0x68, 0xc8, 0x4a, 0x7f, 0x17, 0xda, 0x29, 0x91, 0x71, 0xb8, 0x0a, 0xa0, 0xc4, 0xa8, 0x60,
+	0xa4, 0x50, 0xf4, 0x55, 0x80, 0x47, 0x34, 0x7a, 0xe8, 0x46, 0x81, 0xe7, 0x8f, 0x99, 0x9a, 0xa1,
+	0xf3, 0x8a, 0x62, 0xfc, 0xf1, 0x6f, 0xe6, 0x2d, 0x39, 0xdd, 0x85, 0x59, 0x2a, 0x3e, 0x39, 0xa2,
+	0xd5, 0x93, 0x43, 0xfd, 0x7f, 0x4a, 0xd0, 0x7a, 0x44, 0xa3, 0xfd, 0xf8, 0x68, 0xe8, 0x58, 0x5f,
+	0xd0, 0x31, 0xcb, 0xbc, 0xa6, 0x6d, 0x07, 0x34, 0x0c, 0x0d, 0x26, 0x7f, 0x65, 0xab, 0xdd, 0xab,
+	0x23, 0xe1, 0xb9, 0xb6, 0x05, 0x84, 0x5a, 0x76, 0x68, 0x1a, 0x56, 0x1c, 0x9c, 0x52, 0xc3, 0x35,
+	0x47, 0xd2, 0x61, 0x1d, 0x4e, 0xdf, 0x61, 0xe4, 0xe7, 0xe6, 0x88, 0xb2, 0x3d, 0x1f, 0x0e, 0xbc,
+	0x6f, 0x0c, 0xdb, 0x09, 0xfd, 0xa1, 0x39, 0xc6, 0x20, 0x6c, 0x32, 0xda, 0xae, 0x20, 0xb1, 0x95,
+	0x58, 0x66, 0x14, 0x5c, 0xaa, 0x62, 0xf3, 0x32, 0x02, 0xfb, 0xbd, 0xfe, 0x08, 0x1a, 0xa9, 0x4c,
+	0x1b, 0x50, 0x75, 0xa5, 0xcf, 0x9b, 0xdb, 0x9a, 0xf4, 0xc7, 0xe3, 0xdd, 0xe7, 0x9e, 0x8d, 0x61,
+	0xe4, 0xa2, 0xb3, 0xcf, 0xfc, 0xf8, 0x48, 0x3a, 0x9b, 0x7d, 0xeb, 0xbf, 0x2f, 0x71, 0x43, 0xdd,
+	0x17, 0x2a, 0x4c, 0x57, 0x2f, 0x23, 0x51, 0x39, 0x2b, 0xd1, 0x0f, 0xd1, 0xe8, 0x53, 0xa8, 0x8f,
+	0xe2, 0x61, 0xe4, 0x84, 0x4e, 0x9f, 0x2b, 0xd4, 0xdc, 0x5e, 0x95, 0xb2, 0x3e, 0x43, 0x7a, 0x8f,
+	0xda, 0x94, 0x8e, 0x0e, 0xac, 0xc0, 0xf1, 0x45, 0xfc, 0x24, 0xbf, 0xd0, 0x3e, 0x82, 0x66, 0xc8,
+	0xe9, 0x06, 0x0f, 0xbe, 0x19, 0x1e, 0x7c, 0x17, 0x25, 0x83, 0x27, 0xae, 0x1f, 0x47, 0xca, 0xef,
+	0x20, 0x4c, 0xbe, 0xf5, 0x7f, 0x86, 0xb9, 0xc3, 0x01, 0x8d, 0xcc, 0x1f, 0xaa, 0x67, 0x5e, 0x95,
+	0xf2, 0x39, 0x55, 0x58, 0xf0, 0x48, 0x56, 0x5d, 0x98, 0xc5, 0x5f, 0xca, 0xad, 0x8e, 0x43, 0x7d,
+	0x0b, 0x5a, 0x7c, 0xdd, 0x02, 0x64, 0x59, 0x84, 0x99, 0x44, 0xb6, 0x00, 0xbe, 0x72, 0x7c, 0xba,
+	0xcb, 0x8f, 0x70, 0xfd, 0x3f, 0xcb, 0x00, 0x4f, 0x3d, 0xd3, 0x16, 0x43, 0x96, 0xc4, 0x47, 0x2e,
+	0x1d, 0x79, 0xae, 0x63, 0xc9, 0x24, 0x2e, 0xc7, 0x89, 0xeb, 0xcb, 0xaf, 0x71, 0x3d, 0x6e, 0xb8,
+	0x4a, 0xb2, 0xe1, 0xde, 0x2a, 0xc3, 0x65, 0xce, 0x93, 0x99, 0x49, 0xe7, 0x49, 0x4d, 0x3d, 0x4f,
+	0xd6, 0xa1, 0x1d, 0x9e, 0x38, 0xbe, 0x61, 0x0d, 0xa8, 0x75, 0x12, 0xc6, 0x23, 0xac, 0x45, 0x5a,
+	0x8c, 0xb8, 0x83, 0x34, 0xed, 0x1a, 0x34, 0xe3, 0xed, 0x63, 0xc3, 0xf2, 0x62, 0x37, 0xa2, 0x01,
+	0x2f, 0x40, 0xda, 0x3d, 0x88, 0xb7, 0x8f, 0x77, 0x04, 0x45, 0xff, 0xae, 0x0c, 0xcd, 0x1e, 0x0d,
+	0x69, 0x84, 0x26, 0xb9, 0x01, 0x1d, 0x74, 0x8e, 0x11, 0x98, 0xae, 0xed, 0x8d, 0xf0, 0xd4, 0x68,
+	0x23, 0xb5, 0xc7, 0x89, 0x4c, 0xdc, 0x30, 0x0a, 0xa8, 0xdb, 0x8f, 0x06, 0x58, 0xb9, 0x25, 0xe3,
+	0xc9, 0xfa, 0x57, 0xa6, 0xe8, 0x7f, 0xfe, 0xf4, 0xa8, 0x16, 0x9d, 0x1e, 0x6f, 0x6e, 0xa6, 0x9c,
+	0x05, 0x66, 0xf3, 0x16, 0x60, 0x00, 0x6e, 0x47, 0xac, 0x11, 0x44, 0x8d, 0x06, 0x8c, 0x24, 0x4a,
+	0x04, 0x56, 0x0c, 0x88, 0x2f, 0x0c, 0x22, 0x02, 0x1d, 0x4c, 0x6f, 0x32, 0x87, 0x6e, 0x00, 0x20,
+	0x85, 0x25, 0xd0, 0xc9, 0x39, 0xef, 0x97, 0x65, 0xe8, 0xf4, 0xa8, 0xe5, 0x9d, 0xd2, 0x60, 0x8c,
+	0xf6, 0x5e, 0x01, 0xf8, 0xc6, 0x0b, 0x6c, 0x21, 0x1f, 0x66, 0xd1, 0x06, 0xa3, 0x70, 0xf1, 0x26,
+	0xdb, 0xb2, 0xfc, 0x46, 0xb6, 0xac, 0xbc, 0xce, 0x96, 0xd5, 0x49, 0xb6, 0x9c, 0x51, 0x6d, 0x79,
+	0x13, 0x08, 0x75, 0x8f, 0xbd, 0xc0, 0xa2, 0x06, 0x13, 0x71, 0xe8, 0x84, 0x11, 0x37, 0x76, 0xbd,
+	0x37, 0x87, 0xf4, 0xaf, 0x90, 0xcc, 0x32, 0x22, 0x4f, 0x26, 0x22, 0xe2, 0xf8, 0x77, 0xde, 0x15,
+	0x8d, 0x73, 0xae, 0xb8, 0x08, 0xb3, 0x76, 0x30, 0x36, 0x82, 0xd8, 0xe5, 0x95, 0x6e, 0xbd, 0x57,
+	0xb3, 0x83, 0x71, 0x2f, 0x76, 0xf5, 0x8f, 0xa1, 0xc9, 0x38, 0xcb, 0x63, 0xf2, 0x76, 0xe6, 0x98,
+	0x4c, 0x32, 0x95, 0x02, 0x51, 0x4e, 0xc8, 0x15, 0x98, 0x65, 0x13, 0xcc, 0x33, 0x1a, 0x54, 0x99,
+	0xdc, 0xb8, 0xd7, 0xf9, 0xb7, 0xfe, 0x7f, 0x25, 0x68, 0x1e, 0x38, 0x7d, 0xf7, 0x19, 0xd6, 0x3c,
+	0x53, 0xf3, 0x57, 0xae, 0x5c, 0x68, 0xa5, 0xa5, 0x52, 0x26, 0x83, 0x57, 0x72, 0x19, 0x3c, 0x97,
+	0x60, 0xab, 0x3f, 0x3c, 0xc1, 0xfe, 0x1b, 0xb4, 0x5f, 0xd2, 0xc0, 0x39, 0x1e, 0x4b, 0xf1, 0x26,
+	0xe6, 0x44, 0xed, 0x0a, 0x34, 0x42, 0xa7, 0xef, 0xf2, 0x7b, 0x17, 0x4a, 0x97, 0x12, 0x54, 0xc9,
+	0x2b, 0x53, 0x24, 0xcf, 0x9f, 0x86, 0xff, 0x04, 0x04, 0x57, 0x3e, 0x50, 0x59, 0xbd, 0x8d, 0x08,
+	0xfa, 0xff, 0x97, 0xd8, 0xc6, 0xb1, 0x82, 0xb1, 0x1f, 0x49, 0x6d, 0x2e, 0x40, 0xcd, 0x8f, 0x8f,
+	0x4e, 0xa8, 0xdc, 0x29, 0x38, 0x9a, 0x62, 0xe7, 0x35, 0x68, 0xc9, 0xfc, 0xe4, 0xb9, 0xc3, 0xe4,
+	0x30, 0x44, 0xda, 0x0b, 0x77, 0x98, 0x2b, 0x24, 0xaa, 0xd3, 0x4e, 0xda, 0x99, 0x9c, 0xb6, 0x2f,
+	0x81, 0xa0, 0x80, 0xd4, 0x96, 0x22, 0x2e, 0xc2, 0x8c, 0xeb, 0xb9, 0x16, 0x45, 0x09, 0xc5, 0x60,
+	0x8a, 0x80, 0x1a, 0x54, 0x07, 0x23, 0xd3, 0x42, 0x2b, 0xf3, 0x6f, 0xfd, 0x6b, 0xe8, 0xec, 0xd2,
+	0x8c, 0xe2, 0x53, 0xa3, 0x2c, 0x59, 0xb2, 0x3c, 0x61, 0xc9, 0x4a, 0xf1, 0x92, 0x55, 0x65, 0xc9,
+	0x3d, 0x20, 0xbb, 0x34, 0xa7, 0x4a, 0xae, 0x74, 0x56, 0x38, 0xe4, 0xce, 0x4f, 0xe5, 0xa4, 0xfd,
+	0x4d, 0x09, 0x3a, 0x3b, 0x8e, 0x3f, 0xa0, 0xc1, 0x17, 0x74, 0xfc, 0xd2, 0x1c, 0xc6, 0xaf, 0x91,
+	0x9d, 0x40, 0x85, 0xb9, 0x53, 0x70, 0x61, 0x9f, 0x4c, 0x9b, 0x53, 0xf6, 0x3b, 0x94, 0x5a, 0x0c,
+	0x44, 0x92, 0xe4, 0xf2, 0x61, 0xa2, 0x97, 0x43, 0xed, 0x3a, 0x74, 0xcc, 0xf0, 0xc4, 0xf0, 0x5c,
+	0x43, 0x02, 0xc4, 0x4d, 0xbd, 0x65, 0x86, 0x27, 0x2f, 0xdc, 0x87, 0xe7, 0x50, 0xb6, 0x50, 0xb3,
+	0x5b, 0x53, 0x50, 0xa8, 0xba, 0xd6, 0x81, 0xb2, 0x73, 0xca, 0x73, 0x7e, 0xab, 0x57, 0x76, 0x4e,
+	0xf5, 0x2d, 0x20, 0x42, 0x19, 0x6a, 0x27, 0xea, 0x24, 0xf2, 0x95, 0x14, 0xf9, 0xf4, 0x18, 0x3a,
+	0x0f, 0xc3, 0xc8, 0x19, 0x99, 0x11, 0x3d, 0x3c, 0x3b, 0x70, 0x5e, 0x51, 0x76, 0xde, 0x7a, 0x71,
+	0xe4, 0xc7, 0x51, 0x98, 0x49, 0xd6, 0x2d, 0x24, 0x8a, 0x7c, 0xbd, 0x06, 0x2d, 0xc7, 0x55, 0x30,
+	0xe2, 0x6c, 0x6c, 0x3a, 0x6e, 0x0a, 0x99, 0x96, 0x29, 0xf4, 0x35, 0xa8, 0xe1, 0x72, 0x17, 0x61,
+	0x36, 0x3a, 0x33, 0x94, 0xda, 0xba, 0x16, 0xf1, 0x09, 0xfd, 0x27, 0x25, 0xa8, 0xb1, 0xcd, 0x78,
+	0x78, 0xf6, 0x57, 0x11, 0x89, 0x79, 0x2a, 0xdb, 0x59, 0x91, 0x43, 0xf6, 0xb3, 0xa1, 0x67, 0x9d,
+	0x18, 0x91, 0x83, 0x7b, 0xa9, 0xdd, 0xab, 0x33, 0xc2, 0xa1, 0x33, 0xa2, 0xfa, 0x9f, 0x4b, 0xd0,
+	0x3a, 0x70, 0x46, 0xfe, 0x90, 0xa2, 0xb0, 0xb7, 0xa1, 0x26, 0xd6, 0xe4, 0x31, 0xd3, 0x4c, 0x6f,
+	0x65, 0x87, 0x67, 0x3c, 0x03, 0xf2, 0xdc, 0x87, 0x10, 0xed, 0x2e, 0xcc, 0xa2, 0x12, 0xdd, 0x32,
+	0x47, 0x2f, 0xa6, 0xe8, 0x17, 0x71, 0x24, 0xe1, 0x12, 0xa4, 0x7d, 0x02, 0xad, 0x28, 0x30, 0xdd,
+	0xd0, 0xe4, 0x47, 0x5b, 0xd8, 0xad, 0xf0, 0x1f, 0x25, 0x29, 0xf6, 0x30, 0x9d, 0xe3, 0xbf, 0xcb,
+	0x80, 0xa7, 0x66, 0x40, 0x55, 0xfd, 0x99, 0x29, 0xea, 0xd7, 0x72, 0xea, 0xff, 0xba, 0x04, 0x8d,
+	0xc3, 0xe4, 0x5e, 0xf7, 0x21, 0xb4, 0x02, 0xf1, 0x69, 0x28, 0x07, 0x57, 0x62, 0x01, 0xf5, 0xd0,
+	0x6a, 0x06, 0xe9, 0x40, 0xfb, 0x10, 0x66, 0x6d, 0x1a, 0x99, 0xce, 0x30, 0xc4, 0x3a, 0xf4, 0x4a,
+	0x6a, 0x06, 0xfc, 0xd1, 0xae, 0x98, 0x17, 0xe6, 0x40, 0xb0, 0xf6, 0x39, 0x40, 0x48, 0x03, 0xd9,
+	0xf1, 0xa9, 0xf0, 0x9f, 0x5e, 0x3b, 0xf7, 0xd3, 0x83, 0x04, 0x82, 0xe7, 0x4e, 0x32, 0xd6, 0xef,
+	0xc1, 0xcc, 0x21, 0xbf, 0x4f, 0x6e, 0x42, 0x39, 0x3a, 0xc3, 0xfb, 0xcf, 0x44, 0x73, 0x96, 0xa3,
+	0x33, 0xfd, 0x8f, 0x25, 0x68, 0xf2, 0x9a, 0x1c, 0xdd, 0xfd, 0x16, 0x19, 0xee, 0x32, 0x34, 0xfa,
+	0x66, 0x68, 0xf8, 0x81, 0x63, 0xc9, 0x6c, 0x51, 0xef, 0x9b, 0xe1, 0x7e, 0xe0, 0xa4, 0x93, 0x43,
+	0x67, 0xe4, 0x44, 0xdd, 0x6a, 0x32, 0xf9, 0x94, 0x8d, 0xd9, 0x3e, 0x8f, 0xbc, 0x6e, 0x53, 0xec,
+	0xf3, 0xc8, 0x4b, 0xf7, 0x74, 0x4d, 0xcd, 0x39, 0x77, 0x40, 0x63, 0xd7, 0x70, 0x03, 0x5b, 0x5f,
+	0x86, 0x35, 0x88, 0xdd, 0x13, 0xcc, 0x0e, 0x84, 0xcd, 0x60, 0x4f, 0x73, 0x87, 0xd1, 0x59, 0xb5,
+	0xc2, 0xd1, 0x43, 0x51, 0xe5, 0x62, 0xe9, 0xcc, 0x48, 0x4f, 0x39, 0x45, 0xff, 0xef, 0x12, 0x74,
+	0xb8, 0xc6, 0xa9, 0x9f, 0x73, 0xbf, 0x29, 0xe5, 0x7f, 0xc3, 0x00, 0xc9, 0x81, 0x68, 0x9c, 0xe2,
+	0x5e, 0x84, 0x84, 0xf4, 0x32, 0x0b, 0x08, 0xd0, 0x0a, 0x29, 0xa0, 0x97, 0x05, 0x84, 0xb2, 0x41,
+	0x94, 0x90, 0x0e, 0xf4, 0xdb, 0x00, 0x28, 0x15, 0xf3, 0xdf, 0x0a, 0xf0, 0xe5, 0x51, 0x57, 0x91,
+	0xe2, 0x1a, 0x8c, 0xc2, 0x95, 0xd4, 0x9f, 0x00, 0x49, 0x9c, 0xf6, 0xe3, 0x2a, 0x20, 0xfd, 0x18,
+	0x34, 0xce, 0x6a, 0x6a, 0xbd, 0xd2, 0xfa, 0xd1, 0xf5, 0x8a, 0xfe, 0x02, 0x96, 0xf8, 0x3a, 0xd3,
+	0xea, 0x92, 0xca, 0x1b, 0x2c, 0xa5, 0xff, 0x47, 0x09, 0x96, 0x1e, 0x46, 0xec, 0x50, 0x88, 0x47,
+	0x3c, 0x78, 0xc7, 0x3e, 0xb5, 0x79, 0x4b, 0x73, 0xaa, 0x25, 0xb6, 0x61, 0xc9, 0xf6, 0x46, 0xa6,
+	0xe3, 0x1a, 0x21, 0xf5, 0xcd, 0xc0, 0x8c, 0x3c, 0x6c, 0x8f, 0x8a, 0x05, 0x16, 0xc4, 0xe4, 0x81,
+	0x9c, 0xe3, 0x0c, 0xd7, 0xa0, 0x85, 0x6a, 0x08, 0xa8, 0x90, 0xb3, 0x89, 0x34, 0x06, 0xd1, 0x0f,
+	0x61, 0x59, 0x0a, 0xc3, 0x05, 0xd9, 0x35, 0x85, 0x77, 0x84, 0x8e, 0x19, 0x4d, 0x4a, 0x05, 0x46,
+	0x9b, 0x70, 0x8c, 0x7f, 0xcf, 0xb3, 0x71, 0xdf, 0x7d, 0x62, 0x53, 0x37, 0x72, 0xa2, 0xb1, 0x76,
+	0x0f, 0xea, 0x0e, 0x7e, 0xe3, 0xee, 0x4e, 0x32, 0xac, 0xc4, 0x88, 0x2e, 0x81, 0x44, 0xb1, 0xe2,
+	0xdf, 0x1a, 0x98, 0x43, 0x16, 0xda, 0xd4, 0x18, 0x38, 0xb6, 0x4d, 0x5d, 0x54, 0x75, 0x2e, 0xa1,
+	0x3f, 0xe6, 0xe4, 0x2c, 0xf4, 0xd4, 0x09, 0x63, 0x73, 0x88, 0xc7, 0x4a, 0x0a, 0x7d, 0xc9, 0xc9,
+	0x85, 0x8d, 0x9d, 0x6a, 0x51, 0x63, 0x47, 0xef, 0x43, 0x87, 0x69, 0x40, 0xed, 0x44, 0x87, 0xc9,
+	0x85, 0x28, 0xeb, 0x37, 0xf3, 0x26, 0x8e, 0x21, 0x8b, 0x91, 0x56, 0xaf, 0xe1, 0x27, 0x6d, 0x9d,
+	0x8c, 0x15, 0x2b, 0xf9, 0x78, 0xf8, 0xdf, 0x12, 0xcc, 0xb3, 0x0e, 0xd7, 0xce, 0xee, 0x63, 0xec,
+	0xff, 0x7e, 0x41, 0xdf, 0xc6, 0x60, 0x1b, 0x30, 0xe7, 0x53, 0x1a, 0x18, 0xe7, 0x24, 0x69, 0x33,
+	0x72, 0xda, 0x64, 0x2a, 0x32, 0x41, 0xa5, 0xd0, 0x04, 0xef, 0x41, 0x27, 0x27, 0x15, 0xcb, 0x06,
+	0x62, 0x64, 0xa4, 0x55, 0x34, 0x84, 0x09, 0x40, 0xbf, 0x07, 0xed, 0x03, 0x1a, 0x7d, 0xb9, 0xbd,
+	0xa7, 0x5c, 0x77, 0xd5, 0x4b, 0x58, 0xe9, 0x5c, 0x47, 0x60, 0x13, 0xda, 0x7b, 0xd8, 0x47, 0x7f,
+	0xc8, 0x3b, 0xd2, 0x17, 0xa0, 0x96, 0xc9, 0x67, 0x38, 0xd2, 0xef, 0xc3, 0x9c, 0x04, 0xca, 0xfc,
+	0x77, 0x01, 0x6a, 0xde, 0xf1, 0x71, 0x48, 0x65, 0x25, 0x82, 0x23, 0x85, 0x45, 0x39, 0xc3, 0xe2,
+	0x33, 0xe8, 0x48, 0x16, 0x5f, 0xfa, 0xec, 0x2d, 0x81, 0xf9, 0xd4, 0x37, 0xc7, 0xec, 0x53, 0xe6,
+	0x0b, 0x1c, 0xf2, 0x2a, 0x37, 0xdd, 0x5e, 0xfc, 0x5b, 0xbf, 0x0e, 0xf5, 0x03, 0x3a, 0x3c, 0x3e,
+	0x64, 0x6b, 0x4f, 0xfc, 0xa5, 0x7e, 0x0b, 0xe6, 0x77, 0xe9, 0x51, 0xdc, 0x7f, 0xea, 0xb8, 0x27,
+	0xbb, 0xd4, 0x12, 0xef, 0x1a, 0x4b, 0x50, 0x1b, 0xd3, 0xd0, 0x70, 0x3d, 0x6c, 0x70, 0xcc, 0x8c,
+	0x69, 0xf8, 0xdc, 0xd3, 0x17, 0x14, 0xec, 0x23, 0x1a, 0x1d, 0x44, 0x66, 0x44, 0xf5, 0x3f, 0x94,
+	0xa1, 0x93, 0x50, 0x39, 0x89, 0x6b, 0x64, 0x8e, 0xbd, 0x38, 0x92, 0x37, 0x17, 0x31, 0x92, 0xed,
+	0xa0, 0x72, 0xda, 0x0e, 0xba, 0x00, 0xb5, 0x11, 0x6f, 0xcf, 0xa2, 0x53, 0x71, 0x94, 0x69, 0x3e,
+	0x55, 0x27, 0x34, 0x9f, 0x66, 0x5e, 0xd3, 0x7c, 0x9a, 0xd8, 0x1e, 0xa8, 0x4d, 0x69, 0x0f, 0xac,
+	0x00, 0x04, 0x34, 0xa4, 0x11, 0xbf, 0xc3, 0xf3, 0xe3, 0xaf, 0xd1, 0x6b, 0x70, 0x0a, 0xbb, 0x27,
+	0xb3, 0xa2, 0x52, 0x4c, 0xcb, 0x26, 0x46, 0x9d, 0x2b, 0xd8, 0xe2, 0x44, 0xd9, 0xd7, 0xbd, 0x03,
+	0x5a, 0x80, 0x8d, 0x0c, 0xe3, 0xd8, 0x3c, 0x11, 0xfd, 0x00, 0x7c, 0xb7, 0x22, 0x72, 0x66, 0xcf,
+	0x3c, 0xe1, 0x0d, 0x01, 0xed, 0x16, 0xcc, 0x27, 0x68, 0x06, 0x34, 0x7c, 0x2f, 0xe4, 0x37, 0xfc,
+	0x76, 0x6f, 0x4e, 0x4e, 0x30, 0xe0, 0xbe, 0x17, 0xea, 0x73, 0xd0, 0x56, 0x4c, 0xed, 0xf9, 0xfa,
+	0x3e, 0xb4, 0x12, 0xc2, 0x53, 0xaf, 0xcf, 0x5b, 0x13, 0xf4, 0x94, 0x0e, 0xe5, 0x93, 0x07, 0x1f,
+	0x30, 0x2b, 0x1f, 0xc5, 0xd6, 0x09, 0x8d, 0xd0, 0xf4, 0x38, 0xe2, 0x7d, 0x08, 0x7a, 0x16, 0xa1,
+	0xed, 0xf9, 0xb7, 0xfe, 0x08, 0x16, 0x12, 0x8e, 0xcf, 0xe8, 0xc8, 0x0b, 0xc6, 0x3d, 0x2a, 0x42,
+	0x4f, 0x4d, 0x27, 0xed, 0x34, 0x9d, 0x4c, 0x0a, 0xdf, 0x9b, 0x30, 0x97, 0x63, 0xc4, 0xbd, 0xcd,
+	0xbf, 0x64, 0x5c, 0x88, 0x91, 0xfe, 0x2f, 0xb0, 0x98, 0x83, 0x7e, 0x15, 0x38, 0x11, 0x9d, 0xbe,
+	0x28, 0x72, 0x2a, 0xab, 0x9c, 0xf0, 0xc9, 0x07, 0x0f, 0x8f, 0x7a, 0x4f, 0x0c, 0xf4, 0x77, 0x14,
+	0x9d, 0xf6, 0x18, 0x25, 0xd9, 0xbb, 0x21, 0xb5, 0x22, 0x4f, 0x6e, 0x74, 0x1c, 0xdd, 0xfa, 0xd3,
+	0x12, 0x34, 0xf1, 0x00, 0xe5, 0x85, 0xe6, 0x2a, 0x5c, 0x50, 0x86, 0x46, 0xfa, 0xb8, 0x4b, 0xfe,
+	0x66, 0xb9, 0xfa, 0x5f, 0xbf, 0xe8, 0x96, 0xb4, 0x65, 0x20, 0x2a, 0x82, 0xbd, 0x1c, 0x91, 0x12,
+	0xce, 0xad, 0xc0, 0x82, 0x3a, 0x87, 0x4f, 0x35, 0xa4, 0xbc, 0x5c, 0xfd, 0xb6, 0x60, 0x1a, 0x5f,
+	0x61, 0x48, 0x05, 0xa7, 0xaf, 0xc1, 0x92, 0x3a, 0x9d, 0xbc, 0x5c, 0x91, 0x2a, 0xb2, 0xcf, 0x09,
+	0x97, 0xf6, 0x73, 0xc9, 0x0c, 0x22, 0x36, 0xe1, 0x52, 0x66, 0x05, 0x35, 0x7f, 0x91, 0xda, 0x72,
+	0x9d, 0x81, 0x7e, 0xc5, 0x80, 0x5b, 0xb0, 0x5c, 0x04, 0x14, 0xc9, 0x87, 0xcc, 0x2a, 0xc8, 0x9b,
+	0x70, 0xb9, 0x08, 0x89, 0x99, 0x8e, 0xd4, 0x97, 0xeb, 0xdf, 0x4a, 0x68, 0x4e, 0xbe, 0xf4, 0x75,
+	0x84, 0x34, 0x8a, 0x0d, 0x24, 0xa7, 0x01, 0x2d, 0xa0, 0x43, 0x37, 0xc7, 0x20, 0x39, 0x1d, 0x48,
+	0x13, 0x59, 0xe4, 0xac, 0x94, 0x02, 0x5a, 0xc8, 0x24, 0x27, 0x45, 0xda, 0xe6, 0x26, 0x6d, 0x64,
+	0xb1, 0x06, 0x17, 0x55, 0x84, 0xd2, 0xf6, 0x25, 0x1d, 0x84, 0x5c, 0x01, 0x2d, 0xe3, 0x49, 0x5e,
+	0xcb, 0x93, 0x39, 0x9c, 0xbd, 0x9e, 0x95, 0x53, 0xbd, 0xde, 0x11, 0xb2, 0x5c, 0x63, 0x98, 0x7a,
+	0x49, 0xbb, 0x0a, 0x8b, 0x19, 0xcb, 0xe1, 0x5f, 0x01, 0xc8, 0x3c, 0x0a, 0xba, 0x01, 0x57, 0x72,
+	0x91, 0x94, 0x79, 0xdc, 0x22, 0x5a, 0x82, 0xeb, 0x16, 0xe2, 0xee, 0x5b, 0x27, 0x64, 0x41, 0x78,
+	0xea, 0xe7, 0x05, 0x32, 0x8b, 0xc7, 0x2e, 0xb2, 0x58, 0x6c, 0xb7, 0xa4, 0x56, 0x27, 0x4b, 0xb8,
+	0xcc, 0x65, 0x98, 0xcf, 0x02, 0x18, 0xff, 0x0b, 0x89, 0xc6, 0x99, 0x78, 0xc9, 0x76, 0x42, 0xc8,
+	0x45, 0x44, 0xe5, 0xfc, 0xa7, 0x3e, 0x1d, 0x93, 0x2e, 0x62, 0xd6, 0xb3, 0x21, 0x9a, 0x79, 0x4d,
+	0x26, 0x97, 0x8a, 0x41, 0x99, 0xb7, 0x45, 0xb2, 0x8c, 0x02, 0xaf, 0xc3, 0xd2, 0x79, 0x10, 0x13,
+	0xfa, 0xb2, 0x62, 0x94, 0x5c, 0x34, 0xa4, 0x4f, 0xc6, 0xe4, 0x4a, 0xf1, 0xae, 0x4a, 0x9f, 0x70,
+	0xc8, 0x4a, 0x71, 0xd4, 0xca, 0xe9, 0xab, 0x49, 0xd4, 0x66, 0xfc, 0x2c, 0x0f, 0x62, 0xb2, 0xaa,
+	0xec, 0xa2, 0x9c, 0x65, 0xd4, 0x3e, 0x3a, 0xd1, 0x8b, 0x6d, 0x9c, 0xed, 0xad, 0x93, 0xf5, 0xe2,
+	0xf0, 0x4e, 0xfb, 0xed, 0xe4, 0x7a, 0x71, 0x78, 0x2b, 0x57, 0x1a, 0xb2, 0x51, 0x6c, 0xdf, 0xcc,
+	0x55, 0x85, 0x6c, 0x22, 0x28, 0x17, 0x9f, 0xf9, 0x7b, 0x06, 0xd9, 0x42, 0x89, 0x36, 0x61, 0x25,
+	0x13, 0x9f, 0xf9, 0xa7, 0x55, 0x72, 0x33, 0x01, 0x5e, 0x2a, 0x06, 0x32, 0xe9, 0x6f, 0x29, 0x4e,
+	0xdb, 0xc8, 0x59, 0x22, 0xd3, 0x80, 0x22, 0xb7, 0x95, 0x1d, 0xa6, 0x65, 0x43, 0x96, 0xcf, 0xdf,
+	0x59, 0xae, 0x7d, 0x2b, 0xe6, 0x73, 0x16, 0xcd, 0x3e, 0x39, 0x90, 0x77, 0x8a, 0xed, 0xa5, 0x74,
+	0xcf, 0xc9, 0xdd, 0xe2, 0xcc, 0x8d, 0x7d, 0x74, 0xf2, 0x6e, 0xb1, 0xa5, 0xf2, 0xad, 0x35, 0x72,
+	0x2f, 0xd9, 0xc9, 0x39, 0x0f, 0xab, 0xbd, 0x50, 0xf2, 0x5e, 0xa2, 0xd7, 0x16, 0x5c, 0x29, 0xc0,
+	0x25, 0x0d, 0x4c, 0xb2, 0x9d, 0x68, 0x98, 0xe3, 0x98, 0xed, 0xae, 0x92, 0xf7, 0x27, 0x71, 0xcc,
+	0xb7, 0x44, 0xc9, 0x07, 0x09, 0x47, 0x3d, 0x9f, 0xdb, 0xd2, 0xcb, 0x12, 0xf9, 0xdb, 0xe2, 0x48,
+	0xcd, 0x5e, 0x47, 0xc8, 0x87, 0xa8, 0x6d, 0xce, 0xae, 0xca, 0x5f, 0xa3, 0xc8, 0xdf, 0x21, 0xa3,
+	0x1b, 0xd9, 0xc3, 0x25, 0xf7, 0x86, 0x4a, 0x3e, 0x2a, 0xce, 0x2b, 0xea, 0x93, 0x27, 0xf9, 0xfb,
+	0xe2, 0xd5, 0x94, 0x16, 0x0c, 0xf9, 0xb8, 0x58, 0xec, 0x6c, 0xcf, 0x82, 0x7c, 0x52, 0xbc, 0xc1,
+	0xd2, 0x1e, 0x02, 0xf9, 0x34, 0x39, 0x65, 0x57, 0xf2, 0xe7, 0x5c, 0xe6, 0x36, 0x42, 0xfe, 0x61,
+	0xc2, 0x8e, 0xce, 0xa2, 0x3e, 0x4b, 0xf2, 0xd8, 0xa5, 0x6c, 0xfe, 0x50, 0xae, 0x29, 0xe4, 0xf3,
+	0xe2, 0x10, 0xcb, 0x37, 0x2b, 0xc8, 0x3f, 0x22, 0x6e, 0x0b, 0xae, 0x9e, 0xc3, 0x65, 0xb7, 0xf7,
+	0x7d, 0x44, 0xde, 0x86, 0xb5, 0x73, 0xc8, 0x73, 0x7b, 0xfc, 0x01, 0xca, 0xf8, 0x1e, 0x6c, 0x64,
+	0x34, 0x99, 0x78, 0x33, 0x27, 0xbf, 0x95, 0xe5, 0xcc, 0x1d, 0x58, 0x2b, 0xfa, 0x49, 0xa6, 0xb3,
+	0x40, 0x7e, 0x57, 0x49, 0xa4, 0x59, 0xc9, 0x06, 0x68, 0xee, 0x9e, 0x42, 0xec, 0xe5, 0xfa, 0x77,
+	0x32, 0x3f, 0x6c, 0x4e, 0x00, 0xcb, 0x8b, 0x0a, 0xa1, 0xcb, 0xd5, 0xef, 0x0a, 0x1c, 0x90, 0xbd,
+	0xbb, 0x90, 0xe3, 0xe5, 0xea, 0x4f, 0x0b, 0x1c, 0x90, 0x29, 0xbb, 0x49, 0x1f, 0x59, 0xe5, 0x62,
+	0x50, 0x2d, 0xc5, 0xc9, 0x00, 0x19, 0xdd, 0x84, 0x6b, 0x85, 0x98, 0xb4, 0xb8, 0x26, 0x2e, 0xb2,
+	0xcb, 0x45, 0x7e, 0x0e, 0x4a, 0x3c, 0xe4, 0x78, 0x0b, 0x56, 0xa7, 0xc0, 0x78, 0xe9, 0x4c, 0x7c,
+	0x64, 0x39, 0x69, 0xf5, 0xb4, 0x0c, 0x26, 0x5f, 0x0b, 0xe8, 0x83, 0x0f, 0x60, 0xdd, 0xf2, 0x46,
+	0x77, 0x43, 0x33, 0xf2, 0xc2, 0x81, 0x33, 0x34, 0x8f, 0x42, 0x79, 0xb5, 0x1a, 0x3a, 0x47, 0xe2,
+	0xbf, 0x8d, 0x47, 0xf1, 0xf1, 0x83, 0xf6, 0x21, 0x27, 0x22, 0xd7, 0xa3, 0x1a, 0x9f, 0x78, 0xff,
+	0x2f, 0x03, 0x00, 0x5b, 0x2b, 0xce, 0xb5, 0x1b, 0x29, 0x00, 0x00,
 }
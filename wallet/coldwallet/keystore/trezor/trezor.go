@@ -247,6 +247,12 @@ func GetEmptyObj(msgType MessageType) interface{} {
 	case 66:
 		v := reflect.Indirect(reflect.New(t)).Interface().(ThetaMessageSignature)
 		return &v
+	case 469:
+		v := reflect.Indirect(reflect.New(t)).Interface().(EthereumTypedDataSignature)
+		return &v
+	case 470:
+		v := reflect.Indirect(reflect.New(t)).Interface().(EthereumSignTypedHash)
+		return &v
 	case 100:
 		v := reflect.Indirect(reflect.New(t)).Interface().(DebugLinkDecision)
 		return &v
@@ -0,0 +1,113 @@
+package hd
+
+import (
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/crypto/secp256k1"
+)
+
+// HardenedKeyStart is the index of the first hardened child key.
+const HardenedKeyStart = uint32(0x80000000)
+
+var masterKeySecret = []byte("Bitcoin seed")
+
+var errInvalidChildKey = errors.New("invalid child key, use the next index")
+
+// ExtendedKey is a BIP-32 extended private key.
+type ExtendedKey struct {
+	key       []byte // 32-byte private key
+	chainCode []byte
+}
+
+// NewMasterKey creates the BIP-32 master key from the seed of an HD wallet.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, errors.New("seed length must be between 128 and 512 bits")
+	}
+
+	mac := hmac.New(sha512.New, masterKeySecret)
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+
+	k := new(big.Int).SetBytes(sum[:32])
+	if k.Sign() == 0 || k.Cmp(secp256k1.S256().N) >= 0 {
+		return nil, errors.New("invalid master key, use another seed")
+	}
+
+	return &ExtendedKey{
+		key:       sum[:32],
+		chainCode: sum[32:],
+	}, nil
+}
+
+// Child derives the child key at the given index. The indices from HardenedKeyStart on
+// derive hardened keys.
+func (ek *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	var data []byte
+	if index >= HardenedKeyStart {
+		data = append([]byte{0x0}, ek.key...)
+	} else {
+		curve := secp256k1.S256()
+		x, y := curve.ScalarBaseMult(ek.key)
+		data = secp256k1.CompressPubkey(x, y)
+	}
+	indexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(indexBytes, index)
+	data = append(data, indexBytes...)
+
+	mac := hmac.New(sha512.New, ek.chainCode)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+
+	n := secp256k1.S256().N
+	il := new(big.Int).SetBytes(sum[:32])
+	if il.Cmp(n) >= 0 {
+		return nil, errInvalidChildKey
+	}
+	k := new(big.Int).Add(il, new(big.Int).SetBytes(ek.key))
+	k.Mod(k, n)
+	if k.Sign() == 0 {
+		return nil, errInvalidChildKey
+	}
+
+	return &ExtendedKey{
+		key:       padLeft(k.Bytes(), 32),
+		chainCode: sum[32:],
+	}, nil
+}
+
+// Derive derives the descendant key along the derivation path.
+func (ek *ExtendedKey) Derive(path []uint32) (*ExtendedKey, error) {
+	key := ek
+	for _, index := range path {
+		var err error
+		key, err = key.Child(index)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return key, nil
+}
+
+// PrivateKey returns the private key of the extended key.
+func (ek *ExtendedKey) PrivateKey() (*crypto.PrivateKey, error) {
+	return crypto.PrivateKeyFromBytes(ek.key)
+}
+
+// DerivePrivateKey derives the private key at the derivation path from the seed of an HD wallet.
+func DerivePrivateKey(seed []byte, path []uint32) (*crypto.PrivateKey, error) {
+	master, err := NewMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+	key, err := master.Derive(path)
+	if err != nil {
+		return nil, err
+	}
+	return key.PrivateKey()
+}
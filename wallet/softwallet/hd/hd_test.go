@@ -0,0 +1,110 @@
+package hd
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+)
+
+// Test vectors of the BIP-39 reference implementation, with the passphrase "TREZOR"
+var mnemonicTestVectors = []struct {
+	entropy  string
+	mnemonic string
+	seed     string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+	},
+	{
+		"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+	},
+	{
+		"80808080808080808080808080808080",
+		"letter advice cage absurd amount doctor acoustic avoid letter advice cage above",
+		"d71de856f81a8acc65e6fc851a38d4d7ec216fd0796d0a6827a3ad6ed5511a30fa280f12eb2e47ed2ac03b5c462a0358d18d69fe4f985ec81778c1b370b652a8",
+	},
+	{
+		"ffffffffffffffffffffffffffffffff",
+		"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+		"ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c61dee327651a14c34e18231052e48c069",
+	},
+}
+
+func TestMnemonic(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(2048, len(englishWordlist))
+
+	for _, vector := range mnemonicTestVectors {
+		entropy, _ := hex.DecodeString(vector.entropy)
+		mnemonic, err := EntropyToMnemonic(entropy)
+		assert.Nil(err)
+		assert.Equal(vector.mnemonic, mnemonic)
+
+		decoded, err := MnemonicToEntropy(mnemonic)
+		assert.Nil(err)
+		assert.Equal(entropy, decoded)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		assert.Nil(err)
+		assert.Equal(vector.seed, hex.EncodeToString(seed))
+	}
+
+	for _, bits := range []int{128, 160, 192, 224, 256} {
+		mnemonic, err := NewMnemonic(bits)
+		assert.Nil(err)
+		assert.Equal((bits+bits/32)/11, len(strings.Fields(mnemonic)))
+		assert.Nil(ValidateMnemonic(mnemonic))
+	}
+
+	_, err := NewMnemonic(100)
+	assert.NotNil(err)
+	assert.NotNil(ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"))
+	assert.NotNil(ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon thetas"))
+	assert.NotNil(ValidateMnemonic("abandon abandon abandon"))
+}
+
+func TestDerive(t *testing.T) {
+	assert := assert.New(t)
+
+	// Test vector 1 of BIP-32
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	master, err := NewMasterKey(seed)
+	assert.Nil(err)
+	assert.Equal("e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35", hex.EncodeToString(master.key))
+	assert.Equal("873dff81c02f525623fd1fe5167eac3a55a049de3d314bb42ee227ffed37d508", hex.EncodeToString(master.chainCode))
+
+	expected := []struct {
+		path []uint32
+		key  string
+	}{
+		{[]uint32{HardenedKeyStart}, "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{[]uint32{HardenedKeyStart, 1}, "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{[]uint32{HardenedKeyStart, 1, HardenedKeyStart + 2}, "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{[]uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2}, "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{[]uint32{HardenedKeyStart, 1, HardenedKeyStart + 2, 2, 1000000000}, "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, vector := range expected {
+		child, err := master.Derive(vector.path)
+		assert.Nil(err)
+		assert.Equal(vector.key, hex.EncodeToString(child.key), vector.path)
+	}
+
+	// The standard Ethereum accounts of a well known test mnemonic
+	seed, err = MnemonicToSeed("test test test test test test test test test test test junk", "")
+	assert.Nil(err)
+	// m/44'/60'/0'/0/0 and m/44'/60'/0'/0/1
+	privKey, err := DerivePrivateKey(seed, []uint32{HardenedKeyStart + 44, HardenedKeyStart + 60, HardenedKeyStart, 0, 0})
+	assert.Nil(err)
+	assert.Equal(common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"), privKey.PublicKey().Address())
+	privKey, err = DerivePrivateKey(seed, []uint32{HardenedKeyStart + 44, HardenedKeyStart + 60, HardenedKeyStart, 0, 1})
+	assert.Nil(err)
+	assert.Equal(common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8"), privKey.PublicKey().Address())
+}
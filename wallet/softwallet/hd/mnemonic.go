@@ -0,0 +1,139 @@
+package hd
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	mnemonicSeedIterations = 2048
+	mnemonicSeedLength     = 64
+)
+
+var (
+	errInvalidEntropyLength = errors.New("entropy length must be a multiple of 32 bits between 128 and 256 bits")
+	errInvalidMnemonic      = errors.New("invalid mnemonic")
+)
+
+// NewEntropy generates random entropy of the given number of bits for a mnemonic.
+func NewEntropy(bits int) ([]byte, error) {
+	if err := validateEntropyBits(bits); err != nil {
+		return nil, err
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return nil, err
+	}
+	return entropy, nil
+}
+
+// NewMnemonic generates a random BIP-39 mnemonic with the given number of bits of entropy,
+// e.g. 128 bits for 12 words, or 256 bits for 24 words.
+func NewMnemonic(bits int) (string, error) {
+	entropy, err := NewEntropy(bits)
+	if err != nil {
+		return "", err
+	}
+	return EntropyToMnemonic(entropy)
+}
+
+// EntropyToMnemonic encodes the entropy along with its checksum into the words of a BIP-39
+// mnemonic. Each word encodes 11 bits.
+func EntropyToMnemonic(entropy []byte) (string, error) {
+	bits := len(entropy) * 8
+	if err := validateEntropyBits(bits); err != nil {
+		return "", err
+	}
+
+	checksumBits := uint(bits / 32)
+	checksum := sha256.Sum256(entropy)
+	data := new(big.Int).SetBytes(entropy)
+	data.Lsh(data, checksumBits)
+	data.Or(data, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+
+	numWords := (bits + int(checksumBits)) / 11
+	words := make([]string, numWords)
+	mask := big.NewInt(2047)
+	index := new(big.Int)
+	for i := numWords - 1; i >= 0; i-- {
+		index.And(data, mask)
+		words[i] = englishWordlist[index.Int64()]
+		data.Rsh(data, 11)
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy decodes the entropy of a BIP-39 mnemonic, and verifies its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(mnemonic))
+	numWords := len(words)
+	if numWords%3 != 0 || numWords < 12 || numWords > 24 {
+		return nil, fmt.Errorf("%v: the number of words should be 12, 15, 18, 21 or 24", errInvalidMnemonic)
+	}
+
+	data := new(big.Int)
+	for _, word := range words {
+		index, ok := englishWordIndex[strings.ToLower(word)]
+		if !ok {
+			return nil, fmt.Errorf("%v: unknown word %v", errInvalidMnemonic, word)
+		}
+		data.Lsh(data, 11)
+		data.Or(data, big.NewInt(int64(index)))
+	}
+
+	checksumBits := uint(numWords * 11 / 33)
+	checksum := new(big.Int).And(data, big.NewInt(int64(1<<checksumBits-1)))
+	data.Rsh(data, checksumBits)
+
+	entropy := padLeft(data.Bytes(), int(checksumBits)*4)
+	expected := sha256.Sum256(entropy)
+	if checksum.Int64() != int64(expected[0]>>(8-checksumBits)) {
+		return nil, fmt.Errorf("%v: checksum mismatch", errInvalidMnemonic)
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic checks the words and the checksum of a BIP-39 mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicToEntropy(mnemonic)
+	return err
+}
+
+// MnemonicToSeed validates a BIP-39 mnemonic and derives the seed of the HD wallet from it,
+// protected by an optional passphrase.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(norm.NFKD.String(strings.ToLower(mnemonic)))
+	password := []byte(strings.Join(words, " "))
+	salt := []byte(norm.NFKD.String("mnemonic" + passphrase))
+	return pbkdf2.Key(password, salt, mnemonicSeedIterations, mnemonicSeedLength, sha512.New), nil
+}
+
+func validateEntropyBits(bits int) error {
+	if bits%32 != 0 || bits < 128 || bits > 256 {
+		return errInvalidEntropyLength
+	}
+	return nil
+}
+
+func padLeft(data []byte, size int) []byte {
+	if len(data) >= size {
+		return data
+	}
+	padded := make([]byte, size)
+	copy(padded[size-len(data):], data)
+	return padded
+}
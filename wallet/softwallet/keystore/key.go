@@ -11,6 +11,7 @@ type Key struct {
 	Id         uuid.UUID
 	Address    common.Address
 	PrivateKey *crypto.PrivateKey
+	HDSeed     []byte // BIP-39 seed of the mnemonic the key was derived from, if any
 }
 
 func NewKey(privKey *crypto.PrivateKey) *Key {
@@ -0,0 +1,130 @@
+package keystore
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/thetatoken/theta/common"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+//
+// Import and export of the keys as the Ethereum V3 key files, the format of geth and MetaMask.
+// The Theta addresses and keys are the same as the Ethereum ones.
+//
+
+const (
+	// KDFScrypt is the scrypt key derivation function of the V3 key files
+	KDFScrypt = "scrypt"
+
+	// KDFPBKDF2 is the PBKDF2 key derivation function of the V3 key files
+	KDFPBKDF2 = "pbkdf2"
+
+	// StandardPBKDF2C is the iteration count of the PBKDF2 key derivation function, the
+	// same as geth and MetaMask.
+	StandardPBKDF2C = 262144
+
+	pbkdf2PRF = "hmac-sha256"
+)
+
+// ImportKeyV3 decrypts an Ethereum V3 key file, encrypted with either scrypt or PBKDF2.
+func ImportKeyV3(keyjson []byte, auth string) (*Key, error) {
+	encryptedKeyJs := new(encryptedKeyJSON)
+	if err := json.Unmarshal(keyjson, encryptedKeyJs); err != nil {
+		return nil, fmt.Errorf("Invalid key file: %v", err)
+	}
+	if err := validateKDFParams(encryptedKeyJs.Crypto); err != nil {
+		return nil, err
+	}
+
+	key, err := decryptKey(keyjson, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	// The address is optional in the V3 key files
+	if encryptedKeyJs.Address != "" && common.HexToAddress(encryptedKeyJs.Address) != key.Address {
+		return nil, fmt.Errorf("key content mismatch: have account %x, want %v", key.Address, encryptedKeyJs.Address)
+	}
+	return key, nil
+}
+
+// ExportKeyV3 encrypts a key into an Ethereum V3 key file with the given key derivation
+// function, KDFScrypt or KDFPBKDF2. The HD seed of the key is not exported.
+func ExportKeyV3(key *Key, auth string, kdf string) ([]byte, error) {
+	key = &Key{
+		Id:         key.Id,
+		Address:    key.Address,
+		PrivateKey: key.PrivateKey,
+	}
+	switch kdf {
+	case KDFScrypt:
+		return encryptKey(key, auth, StandardScryptN, StandardScryptP)
+	case KDFPBKDF2:
+		return encryptKeyPBKDF2(key, auth, StandardPBKDF2C)
+	}
+	return nil, fmt.Errorf("Unsupported KDF: %s", kdf)
+}
+
+// KeyFileNameV3 returns the name of the V3 key file of the address, in the format of geth,
+// e.g. UTC--2016-03-22T12-57-55.920751759Z--7ef5a6135f1fd6a02593eedc869c6d41d934aef8
+func KeyFileNameV3(address common.Address, t time.Time) string {
+	timestamp := strings.Replace(t.UTC().Format(time.RFC3339Nano), ":", "-", -1)
+	return fmt.Sprintf("UTC--%s--%s", timestamp, hex.EncodeToString(address[:]))
+}
+
+// encryptKeyPBKDF2 encrypts a key using PBKDF2 with the given iteration count into a json
+// blob that can be decrypted later on.
+func encryptKeyPBKDF2(key *Key, auth string, c int) ([]byte, error) {
+	salt := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		panic("reading from crypto/rand failed: " + err.Error())
+	}
+	derivedKey := pbkdf2.Key([]byte(auth), salt, c, scryptDKLen, sha256.New)
+
+	pbkdf2ParamsJSON := make(map[string]interface{}, 4)
+	pbkdf2ParamsJSON["c"] = c
+	pbkdf2ParamsJSON["dklen"] = scryptDKLen
+	pbkdf2ParamsJSON["prf"] = pbkdf2PRF
+	pbkdf2ParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	return encryptKeyWithDerivedKey(key, derivedKey, KDFPBKDF2, pbkdf2ParamsJSON)
+}
+
+// validateKDFParams checks the presence and the types of the KDF parameters of a key file.
+func validateKDFParams(cryptoJSON cryptoJSON) error {
+	var intParams []string
+	var stringParams []string
+	switch cryptoJSON.KDF {
+	case KDFScrypt:
+		intParams = []string{"dklen", "n", "r", "p"}
+		stringParams = []string{"salt"}
+	case KDFPBKDF2:
+		intParams = []string{"dklen", "c"}
+		stringParams = []string{"salt", "prf"}
+	default:
+		return fmt.Errorf("Unsupported KDF: %s", cryptoJSON.KDF)
+	}
+
+	for _, param := range intParams {
+		if _, ok := cryptoJSON.KDFParams[param].(float64); !ok {
+			return fmt.Errorf("Invalid key file: missing or invalid KDF parameter %v", param)
+		}
+	}
+	for _, param := range stringParams {
+		if _, ok := cryptoJSON.KDFParams[param].(string); !ok {
+			return fmt.Errorf("Invalid key file: missing or invalid KDF parameter %v", param)
+		}
+	}
+	if ensureInt(cryptoJSON.KDFParams["dklen"]) < 32 {
+		return fmt.Errorf("Invalid key file: dklen should be at least 32")
+	}
+	return nil
+}
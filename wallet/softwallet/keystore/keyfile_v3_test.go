@@ -0,0 +1,80 @@
+package keystore
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+)
+
+func TestImportKeyV3(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := loadKeyStoreTest("testdata/test_vector.json", t)
+	for _, name := range []string{"wikipage_test_vector_scrypt", "wikipage_test_vector_pbkdf2"} {
+		test := tests[name]
+		keyjson, err := json.Marshal(test.Json)
+		assert.Nil(err)
+
+		_, err = ImportKeyV3(keyjson, test.Password+"bad")
+		assert.Equal(ErrDecrypt, err)
+		key, err := ImportKeyV3(keyjson, test.Password)
+		assert.Nil(err, name)
+		assert.Equal(test.Priv, hex.EncodeToString(key.PrivateKey.ToBytes()))
+	}
+
+	// The address of the key file should match the key
+	test := tests["wikipage_test_vector_pbkdf2"]
+	test.Json.Address = "26d813157f7503a9057fb2db6eb2f83a35c4fdd7"
+	keyjson, _ := json.Marshal(test.Json)
+	_, err := ImportKeyV3(keyjson, test.Password)
+	assert.NotNil(err)
+
+	// Malformed key files are rejected
+	test.Json.Address = ""
+	delete(test.Json.Crypto.KDFParams, "salt")
+	keyjson, _ = json.Marshal(test.Json)
+	_, err = ImportKeyV3(keyjson, test.Password)
+	assert.NotNil(err)
+	_, err = ImportKeyV3([]byte("garbage"), test.Password)
+	assert.NotNil(err)
+}
+
+func TestExportKeyV3(t *testing.T) {
+	assert := assert.New(t)
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	assert.Nil(err)
+	key := NewKey(privKey)
+
+	for _, kdf := range []string{KDFScrypt, KDFPBKDF2} {
+		keyjson, err := ExportKeyV3(key, "password", kdf)
+		assert.Nil(err)
+
+		encryptedKeyJs := new(encryptedKeyJSON)
+		assert.Nil(json.Unmarshal(keyjson, encryptedKeyJs))
+		assert.Equal(version, encryptedKeyJs.Version)
+		assert.Equal(kdf, encryptedKeyJs.Crypto.KDF)
+		assert.Equal(strings.ToLower(key.Address.Hex()[2:]), encryptedKeyJs.Address)
+
+		imported, err := ImportKeyV3(keyjson, "password")
+		assert.Nil(err)
+		assert.Equal(key.Address, imported.Address)
+		assert.Equal(key.PrivateKey.ToBytes(), imported.PrivateKey.ToBytes())
+	}
+
+	_, err = ExportKeyV3(key, "password", "bcrypt")
+	assert.NotNil(err)
+}
+
+func TestKeyFileNameV3(t *testing.T) {
+	address := common.HexToAddress("7ef5a6135f1fd6a02593eedc869c6d41d934aef8")
+	timestamp := time.Date(2016, 3, 22, 12, 57, 55, 920751759, time.UTC)
+	assert.Equal(t, "UTC--2016-03-22T12-57-55.920751759Z--7ef5a6135f1fd6a02593eedc869c6d41d934aef8",
+		KeyFileNameV3(address, timestamp))
+}
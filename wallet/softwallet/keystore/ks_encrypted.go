@@ -204,46 +204,67 @@ func encryptKey(key *Key, auth string, scryptN, scryptP int) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	encryptKey := derivedKey[:16]
+
+	scryptParamsJSON := make(map[string]interface{}, 5)
+	scryptParamsJSON["n"] = scryptN
+	scryptParamsJSON["r"] = scryptR
+	scryptParamsJSON["p"] = scryptP
+	scryptParamsJSON["dklen"] = scryptDKLen
+	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
+
+	return encryptKeyWithDerivedKey(key, derivedKey, keyHeaderKDF, scryptParamsJSON)
+}
+
+// encryptKeyWithDerivedKey encrypts a key with the key derived from the password by the KDF
+// into a json blob. The HD seed of the key, if any, is encrypted with the same derived key.
+func encryptKeyWithDerivedKey(key *Key, derivedKey []byte, kdf string, kdfParams map[string]interface{}) ([]byte, error) {
 	keyBytes := math.PaddedBigBytes(key.PrivateKey.D(), 32)
+	cryptoStruct, err := encryptWithDerivedKey(keyBytes, derivedKey, kdf, kdfParams)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedKeyJSON := encryptedKeyJSON{
+		Address: hex.EncodeToString(key.Address[:]),
+		Crypto:  *cryptoStruct,
+		Id:      key.Id.String(),
+		Version: version,
+	}
+	if len(key.HDSeed) > 0 {
+		encryptedKeyJSON.HDSeed, err = encryptWithDerivedKey(key.HDSeed, derivedKey, kdf, kdfParams)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(encryptedKeyJSON)
+}
+
+// encryptWithDerivedKey encrypts a secret with the key derived from the password by the KDF.
+func encryptWithDerivedKey(secret []byte, derivedKey []byte, kdf string, kdfParams map[string]interface{}) (*cryptoJSON, error) {
+	encryptKey := derivedKey[:16]
 
 	iv := make([]byte, aes.BlockSize) // 16
 	if _, err := io.ReadFull(rand.Reader, iv); err != nil {
 		panic("reading from crypto/rand failed: " + err.Error())
 	}
-	cipherText, err := aesCTRXOR(encryptKey, keyBytes, iv)
+	cipherText, err := aesCTRXOR(encryptKey, secret, iv)
 	if err != nil {
 		return nil, err
 	}
 	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
 
-	scryptParamsJSON := make(map[string]interface{}, 5)
-	scryptParamsJSON["n"] = scryptN
-	scryptParamsJSON["r"] = scryptR
-	scryptParamsJSON["p"] = scryptP
-	scryptParamsJSON["dklen"] = scryptDKLen
-	scryptParamsJSON["salt"] = hex.EncodeToString(salt)
-
 	cipherParamsJSON := cipherparamsJSON{
 		IV: hex.EncodeToString(iv),
 	}
 
-	cryptoStruct := cryptoJSON{
+	return &cryptoJSON{
 		Cipher:       "aes-128-ctr",
 		CipherText:   hex.EncodeToString(cipherText),
 		CipherParams: cipherParamsJSON,
-		KDF:          keyHeaderKDF,
-		KDFParams:    scryptParamsJSON,
+		KDF:          kdf,
+		KDFParams:    kdfParams,
 		MAC:          hex.EncodeToString(mac),
-	}
-
-	encryptedKeyJSON := encryptedKeyJSON{
-		hex.EncodeToString(key.Address[:]),
-		cryptoStruct,
-		key.Id.String(),
-		version,
-	}
-	return json.Marshal(encryptedKeyJSON)
+	}, nil
 }
 
 // decryptKey decrypts a key from a json blob, returning the private key itself.
@@ -257,53 +278,65 @@ func decryptKey(keyjson []byte, auth string) (*Key, error) {
 		return nil, fmt.Errorf("Version %v not supported", encryptedKeyJs.Version)
 	}
 
-	if encryptedKeyJs.Crypto.Cipher != "aes-128-ctr" {
-		return nil, fmt.Errorf("Cipher not supported: %v", encryptedKeyJs.Crypto.Cipher)
-	}
-
 	keyId := uuid.Parse(encryptedKeyJs.Id)
 
-	mac, err := hex.DecodeString(encryptedKeyJs.Crypto.MAC)
+	derivedKey, err := getKDFKey(encryptedKeyJs.Crypto, auth)
 	if err != nil {
 		return nil, err
 	}
 
-	iv, err := hex.DecodeString(encryptedKeyJs.Crypto.CipherParams.IV)
+	keyBytes, err := decryptWithDerivedKey(encryptedKeyJs.Crypto, derivedKey)
 	if err != nil {
 		return nil, err
 	}
 
-	cipherText, err := hex.DecodeString(encryptedKeyJs.Crypto.CipherText)
-	if err != nil {
-		return nil, err
+	// Use the "unsafe" convertor to support legacy private keys
+	// whose lengths are less than 32 bytes
+	privKey := crypto.PrivateKeyFromBytesUnsafe(keyBytes)
+
+	key := &Key{
+		Id:         keyId,
+		Address:    privKey.PublicKey().Address(),
+		PrivateKey: privKey,
 	}
 
-	derivedKey, err := getKDFKey(encryptedKeyJs.Crypto, auth)
+	if encryptedKeyJs.HDSeed != nil {
+		key.HDSeed, err = decryptWithDerivedKey(*encryptedKeyJs.HDSeed, derivedKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// decryptWithDerivedKey decrypts a secret with the key derived from the password by the KDF.
+func decryptWithDerivedKey(cryptoJSON cryptoJSON, derivedKey []byte) ([]byte, error) {
+	if cryptoJSON.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("Cipher not supported: %v", cryptoJSON.Cipher)
+	}
+
+	mac, err := hex.DecodeString(cryptoJSON.MAC)
 	if err != nil {
 		return nil, err
 	}
 
-	calculatedMAC := crypto.Keccak256(derivedKey[16:32], cipherText)
-	if !bytes.Equal(calculatedMAC, mac) {
-		return nil, ErrDecrypt
+	iv, err := hex.DecodeString(cryptoJSON.CipherParams.IV)
+	if err != nil {
+		return nil, err
 	}
 
-	keyBytes, err := aesCTRXOR(derivedKey[:16], cipherText, iv)
+	cipherText, err := hex.DecodeString(cryptoJSON.CipherText)
 	if err != nil {
 		return nil, err
 	}
 
-	// Use the "unsafe" convertor to support legacy private keys
-	// whose lengths are less than 32 bytes
-	privKey := crypto.PrivateKeyFromBytesUnsafe(keyBytes)
-
-	key := &Key{
-		Id:         keyId,
-		Address:    privKey.PublicKey().Address(),
-		PrivateKey: privKey,
+	calculatedMAC := crypto.Keccak256(derivedKey[16:32], cipherText)
+	if !bytes.Equal(calculatedMAC, mac) {
+		return nil, ErrDecrypt
 	}
 
-	return key, nil
+	return aesCTRXOR(derivedKey[:16], cipherText, iv)
 }
 
 func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
@@ -320,10 +353,10 @@ func getKDFKey(cryptoJSON cryptoJSON, auth string) ([]byte, error) {
 		p := ensureInt(cryptoJSON.KDFParams["p"])
 		return scrypt.Key(authArray, salt, n, r, p, dkLen)
 
-	} else if cryptoJSON.KDF == "pbkdf2" {
+	} else if cryptoJSON.KDF == KDFPBKDF2 {
 		c := ensureInt(cryptoJSON.KDFParams["c"])
 		prf := cryptoJSON.KDFParams["prf"].(string)
-		if prf != "hmac-sha256" {
+		if prf != pbkdf2PRF {
 			return nil, fmt.Errorf("Unsupported PBKDF2 PRF: %s", prf)
 		}
 		key := pbkdf2.Key(authArray, salt, c, dkLen, sha256.New)
@@ -357,10 +390,11 @@ func ensureInt(x interface{}) int {
 }
 
 type encryptedKeyJSON struct {
-	Address string     `json:"address"`
-	Crypto  cryptoJSON `json:"crypto"`
-	Id      string     `json:"id"`
-	Version int        `json:"version"`
+	Address string      `json:"address"`
+	Crypto  cryptoJSON  `json:"crypto"`
+	HDSeed  *cryptoJSON `json:"hdseed,omitempty"`
+	Id      string      `json:"id"`
+	Version int         `json:"version"`
 }
 
 type cryptoJSON struct {
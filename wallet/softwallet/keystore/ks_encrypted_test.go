@@ -18,13 +18,16 @@
 package keystore
 
 import (
+	"bytes"
 	"crypto/rand"
+	"encoding/hex"
 	"io/ioutil"
 	"os"
 	"reflect"
 	"testing"
 
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
 )
 
 func Test_PBKDF2_1(t *testing.T) {
@@ -72,6 +75,52 @@ func TestKeyStoreEncrypted(t *testing.T) {
 	}
 }
 
+// Tests that the HD seed of a key is stored encrypted with the key, and left out of exports.
+func TestKeyStoreEncryptedHDSeed(t *testing.T) {
+	dir, ks := tmpKeyStoreIface(t, true)
+	defer os.RemoveAll(dir)
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	k1 := NewKey(privKey)
+	k1.HDSeed = bytes.Repeat([]byte{0x5a}, 64)
+	if err := ks.StoreKey(k1, "foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	keyjson, err := ioutil.ReadFile(ks.(KeystoreEncrypted).getFilePath(k1.Address, mixedCase))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(keyjson, []byte(hex.EncodeToString(k1.HDSeed))) {
+		t.Fatal("HD seed stored in plain text")
+	}
+	if _, err := ks.GetKey(k1.Address, "bar"); err != ErrDecrypt {
+		t.Fatalf("wrong error for invalid password\ngot %q\nwant %q", err, ErrDecrypt)
+	}
+	k2, err := ks.GetKey(k1.Address, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k1.HDSeed, k2.HDSeed) {
+		t.Fatalf("HD seed mismatch: have %x, want %x", k2.HDSeed, k1.HDSeed)
+	}
+
+	exported, err := ExportKeyV3(k2, "foo", KDFPBKDF2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k3, err := ImportKeyV3(exported, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k3.HDSeed != nil {
+		t.Fatal("HD seed exported with the key")
+	}
+}
+
 func TestKeyStoreEncryptedDecryptionFail(t *testing.T) {
 	dir, ks := tmpKeyStoreIface(t, true)
 	defer os.RemoveAll(dir)
@@ -93,12 +93,20 @@ func (ks KeystorePlain) GetKey(address common.Address, auth string) (*Key, error
 		return nil, err
 	}
 
+	hdSeed, err := hex.DecodeString(plainKeyJs.HDSeed)
+	if err != nil {
+		return nil, err
+	}
+
 	keyId := uuid.Parse(plainKeyJs.Id)
 	key := &Key{
 		Id:         keyId,
 		Address:    common.HexToAddress(plainKeyJs.Address),
 		PrivateKey: privKey,
 	}
+	if len(hdSeed) > 0 {
+		key.HDSeed = hdSeed
+	}
 	return key, nil
 }
 
@@ -108,6 +116,7 @@ func (ks KeystorePlain) StoreKey(key *Key, auth string) error {
 	plainKeyJs := &plainKeyJSON{
 		Address:    hex.EncodeToString(key.Address[:]),
 		PrivateKey: hex.EncodeToString(key.PrivateKey.ToBytes()),
+		HDSeed:     hex.EncodeToString(key.HDSeed),
 		Id:         key.Id.String(),
 		Version:    version,
 	}
@@ -143,6 +152,7 @@ func (ks KeystorePlain) getFilePath(address common.Address, addrFormat AddressFo
 type plainKeyJSON struct {
 	Address    string `json:"address"`
 	PrivateKey string `json:"privatekey"`
+	HDSeed     string `json:"hdseed,omitempty"`
 	Id         string `json:"id"`
 	Version    int    `json:"version"`
 }
@@ -37,3 +37,33 @@ func TestKeyStorePlain(t *testing.T) {
 	t.Logf("k1.PrivateKey = %v", hex.EncodeToString(k1.PrivateKey.ToBytes()))
 	t.Logf("k2.PrivateKey = %v", hex.EncodeToString(k2.PrivateKey.ToBytes()))
 }
+
+func TestKeyStorePlainHDSeed(t *testing.T) {
+	dir, ks := tmpKeyStoreIface(t, false)
+	defer os.RemoveAll(dir)
+
+	pass := "" // not used but required by API
+	k1, err := storeNewKeyTest(ks, rand.Reader, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k2, err := ks.GetKey(k1.Address, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if k2.HDSeed != nil {
+		t.Fatalf("unexpected HD seed %x", k2.HDSeed)
+	}
+
+	k2.HDSeed = []byte{0x1, 0x2, 0x3}
+	if err := ks.StoreKey(k2, pass); err != nil {
+		t.Fatal(err)
+	}
+	k3, err := ks.GetKey(k1.Address, pass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(k2.HDSeed, k3.HDSeed) {
+		t.Fatalf("HD seed mismatch: have %x, want %x", k3.HDSeed, k2.HDSeed)
+	}
+}
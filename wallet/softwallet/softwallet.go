@@ -1,11 +1,13 @@
 package softwallet
 
 import (
+	"bytes"
 	"fmt"
 	"sync"
 
 	"github.com/thetatoken/theta/common"
 	"github.com/thetatoken/theta/crypto"
+	"github.com/thetatoken/theta/wallet/softwallet/hd"
 	ks "github.com/thetatoken/theta/wallet/softwallet/keystore"
 	"github.com/thetatoken/theta/wallet/types"
 )
@@ -98,6 +100,101 @@ func (w *SoftWallet) NewKey(password string) (common.Address, error) {
 	return address, nil
 }
 
+// NewKeyWithMnemonic creates a new key derived at the derivation path from a new random BIP-39
+// mnemonic with the given bits of entropy. It returns the mnemonic, from which the key can be
+// recovered.
+func (w *SoftWallet) NewKeyWithMnemonic(password string, bits int, path types.DerivationPath) (common.Address, string, error) {
+	mnemonic, err := hd.NewMnemonic(bits)
+	if err != nil {
+		return common.Address{}, "", err
+	}
+
+	address, err := w.RecoverKey(mnemonic, "", path, password)
+	if err != nil {
+		return common.Address{}, "", err
+	}
+	return address, mnemonic, nil
+}
+
+// RecoverKey restores the key derived at the derivation path from a BIP-39 mnemonic and its
+// optional passphrase, and stores it encrypted with the password
+func (w *SoftWallet) RecoverKey(mnemonic, passphrase string, path types.DerivationPath, password string) (common.Address, error) {
+	addresses, err := w.DeriveKeys(mnemonic, passphrase, []types.DerivationPath{path}, password)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return addresses[0], nil
+}
+
+// DeriveKeys derives the keys at the derivation paths from a BIP-39 mnemonic and its optional
+// passphrase, and stores them encrypted with the password, along with the seed of the mnemonic
+// from which Derive and DeriveKey derive further keys once they are unlocked.
+func (w *SoftWallet) DeriveKeys(mnemonic, passphrase string, paths []types.DerivationPath, password string) ([]common.Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	seed, err := hd.MnemonicToSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer zeroBytes(seed)
+
+	keys := []*ks.Key{}
+	for _, path := range paths {
+		key, err := deriveKey(seed, path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	addresses := []common.Address{}
+	for _, key := range keys {
+		if err := w.keystore.StoreKey(key, password); err != nil {
+			return nil, err
+		}
+
+		// derived key is considerred unlocked
+		w.unlockedKeyMap[key.Address] = &UnlockedKey{
+			Key: key,
+		}
+		addresses = append(addresses, key.Address)
+	}
+
+	return addresses, nil
+}
+
+// ImportKey imports a key from an Ethereum V3 key file encrypted with the auth password, and
+// stores it encrypted with the password
+func (w *SoftWallet) ImportKey(keyjson []byte, auth string, password string) (common.Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key, err := ks.ImportKeyV3(keyjson, auth)
+	if err != nil {
+		return common.Address{}, err
+	}
+
+	if err := w.keystore.StoreKey(key, password); err != nil {
+		return common.Address{}, err
+	}
+	return key.Address, nil
+}
+
+// ExportKey exports a key as an Ethereum V3 key file encrypted with the auth password, using
+// the given key derivation function
+func (w *SoftWallet) ExportKey(address common.Address, password string, auth string, kdf string) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key, err := w.keystore.GetKey(address, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return ks.ExportKeyV3(key, auth, kdf)
+}
+
 // Unlock unlocks a key if the password is correct
 func (w *SoftWallet) Unlock(address common.Address, password string, derivationPath types.DerivationPath) error {
 	w.mu.Lock()
@@ -174,9 +271,67 @@ func (w *SoftWallet) UpdatePassword(address common.Address, oldPassword, newPass
 	return err
 }
 
-// Derive is not supported for SoftWallet
+// Derive derives the key at the derivation path from the seed of the unlocked keys, which have
+// to come from the same mnemonic. The derived key is unlocked but not stored, as with the cold
+// wallets it can be derived again from the seed. Use DeriveKey to store it.
 func (w *SoftWallet) Derive(path types.DerivationPath, pin bool) (common.Address, error) {
-	return common.Address{}, fmt.Errorf("Not supported for software wallet")
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var seed []byte
+	for _, unlockedKey := range w.unlockedKeyMap {
+		if len(unlockedKey.HDSeed) == 0 {
+			continue
+		}
+		if seed != nil && !bytes.Equal(seed, unlockedKey.HDSeed) {
+			return common.Address{}, fmt.Errorf("Keys from different mnemonics are unlocked, lock all but one to derive keys")
+		}
+		seed = unlockedKey.HDSeed
+	}
+	if seed == nil {
+		return common.Address{}, fmt.Errorf("No key derived from a mnemonic is unlocked")
+	}
+
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	w.unlockedKeyMap[key.Address] = &UnlockedKey{
+		Key: key,
+	}
+
+	return key.Address, nil
+}
+
+// DeriveKey derives the key at the derivation path from the seed stored with the key of the
+// address, and stores it encrypted with the password of that key
+func (w *SoftWallet) DeriveKey(address common.Address, password string, path types.DerivationPath) (common.Address, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	parentKey, err := w.keystore.GetKey(address, password)
+	if err != nil {
+		return common.Address{}, err
+	}
+	defer w.zeroKey(&UnlockedKey{Key: parentKey})
+	if len(parentKey.HDSeed) == 0 {
+		return common.Address{}, fmt.Errorf("Key %v is not derived from a mnemonic", address)
+	}
+
+	key, err := deriveKey(parentKey.HDSeed, path)
+	if err != nil {
+		return common.Address{}, err
+	}
+	if err := w.keystore.StoreKey(key, password); err != nil {
+		return common.Address{}, err
+	}
+
+	// derived key is considerred unlocked
+	w.unlockedKeyMap[key.Address] = &UnlockedKey{
+		Key: key,
+	}
+
+	return key.Address, nil
 }
 
 // GetPublicKey returns the public key of the address if the address has been unlocked
@@ -207,11 +362,26 @@ func (w *SoftWallet) Sign(address common.Address, txrlp common.Bytes) (*crypto.S
 	return signature, err
 }
 
-// zeroKey zeroes a private key in memory
+// SignMessage signs a message as personal_sign (EIP-191) for an address if the address has been unlocked
+func (w *SoftWallet) SignMessage(address common.Address, message common.Bytes) (*crypto.Signature, error) {
+	return w.Sign(address, types.PersonalMessage(message))
+}
+
+// SignTypedData signs EIP-712 typed data for an address if the address has been unlocked
+func (w *SoftWallet) SignTypedData(address common.Address, typedData *types.TypedData) (*crypto.Signature, error) {
+	message, err := typedData.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+	return w.Sign(address, message)
+}
+
+// zeroKey zeroes a private key and its HD seed in memory
 func (w *SoftWallet) zeroKey(unlockedKey *UnlockedKey) {
 	if unlockedKey == nil {
 		return
 	}
+	zeroBytes(unlockedKey.HDSeed)
 
 	privKey := unlockedKey.PrivateKey
 	if privKey == nil || privKey.D() == nil {
@@ -223,3 +393,21 @@ func (w *SoftWallet) zeroKey(unlockedKey *UnlockedKey) {
 		bits[i] = 0
 	}
 }
+
+// deriveKey derives the key at the derivation path from the seed, which the key keeps a copy of
+func deriveKey(seed []byte, path types.DerivationPath) (*ks.Key, error) {
+	privKey, err := hd.DerivePrivateKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	key := ks.NewKey(privKey)
+	key.HDSeed = common.CopyBytes(seed)
+	return key, nil
+}
+
+// zeroBytes zeroes a secret in memory
+func zeroBytes(data []byte) {
+	for i := range data {
+		data[i] = 0
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"io/ioutil"
 	"os"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/wallet/types"
 )
 
 func TestPlainSoftWalletBasics(t *testing.T) {
@@ -26,6 +28,154 @@ func TestEncryptedSoftWalletMultipleKeys(t *testing.T) {
 	testSoftWalletMultipleKeys(t, KeystoreTypeEncrypted)
 }
 
+func TestSoftWalletMnemonic(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir := createTempDir()
+	defer os.RemoveAll(tmpdir)
+
+	wallet, err := NewSoftWallet(tmpdir, KeystoreTypeEncrypted)
+	assert.Nil(err)
+
+	password := "abcd"
+	addr, mnemonic, err := wallet.NewKeyWithMnemonic(password, 128, types.DefaultBaseDerivationPath)
+	assert.Nil(err)
+	assert.Equal(12, len(strings.Fields(mnemonic)))
+
+	// Keys are derived from the seed of the unlocked key
+	path, err := types.ParseDerivationPath("m/44'/60'/0'/0/1")
+	assert.Nil(err)
+	derivedAddr, err := wallet.Derive(types.DefaultBaseDerivationPath, false)
+	assert.Nil(err)
+	assert.Equal(addr, derivedAddr)
+	childAddr, err := wallet.Derive(path, false)
+	assert.Nil(err)
+	assert.NotEqual(addr, childAddr)
+	assert.True(wallet.IsUnlocked(childAddr))
+	signature, err := wallet.Sign(childAddr, common.Bytes("hello world"))
+	assert.Nil(err)
+	assert.True(signature.Verify(common.Bytes("hello world"), childAddr))
+
+	// The seed is zeroed with the key on Lock, and stored encrypted with the key
+	unlockedKey := wallet.unlockedKeyMap[addr]
+	assert.Nil(wallet.Lock(addr))
+	assert.Equal(make([]byte, 64), unlockedKey.HDSeed)
+	assert.Nil(wallet.Lock(childAddr))
+	_, err = wallet.Derive(path, false)
+	assert.NotNil(err)
+	assert.Nil(wallet.Unlock(addr, password, nil))
+	derivedAddr, err = wallet.Derive(path, false)
+	assert.Nil(err)
+	assert.Equal(childAddr, derivedAddr)
+
+	// Derived keys are only stored by DeriveKey
+	addresses, err := wallet.List()
+	assert.Nil(err)
+	assert.Equal([]common.Address{addr}, addresses)
+	_, err = wallet.DeriveKey(addr, "wrong", path)
+	assert.NotNil(err)
+	derivedAddr, err = wallet.DeriveKey(addr, password, path)
+	assert.Nil(err)
+	assert.Equal(childAddr, derivedAddr)
+	addresses, err = wallet.List()
+	assert.Nil(err)
+	assert.Equal(sortAddresses([]common.Address{addr, childAddr}), sortAddresses(addresses))
+
+	// Keys without a seed do not derive keys
+	plainAddr, err := wallet.NewKey(password)
+	assert.Nil(err)
+	_, err = wallet.DeriveKey(plainAddr, password, path)
+	assert.NotNil(err)
+
+	// Recover the key in another wallet
+	tmpdir2 := createTempDir()
+	defer os.RemoveAll(tmpdir2)
+	wallet2, err := NewSoftWallet(tmpdir2, KeystoreTypeEncrypted)
+	assert.Nil(err)
+	_, err = wallet2.RecoverKey("abandon abandon abandon", "", types.DefaultBaseDerivationPath, password)
+	assert.NotNil(err)
+	recoveredAddr, err := wallet2.RecoverKey(mnemonic, "", types.DefaultBaseDerivationPath, password)
+	assert.Nil(err)
+	assert.Equal(addr, recoveredAddr)
+
+	// The recovered key is stored encrypted with the password
+	assert.Nil(wallet2.Lock(addr))
+	assert.NotNil(wallet2.Unlock(addr, "wrong", nil))
+	assert.Nil(wallet2.Unlock(addr, password, nil))
+	signature, err = wallet2.Sign(addr, common.Bytes("hello world"))
+	assert.Nil(err)
+	assert.True(signature.Verify(common.Bytes("hello world"), addr))
+
+	// A different passphrase yields a different key
+	otherAddr, err := wallet2.RecoverKey(mnemonic, "passphrase", types.DefaultBaseDerivationPath, password)
+	assert.Nil(err)
+	assert.NotEqual(addr, otherAddr)
+
+	// Keys from different seeds are ambiguous
+	_, err = wallet2.Derive(path, false)
+	assert.NotNil(err)
+	assert.Nil(wallet2.Lock(otherAddr))
+
+	// Derived keys are stored encrypted with the password
+	derivedAddrs, err := wallet2.DeriveKeys(mnemonic, "", []types.DerivationPath{types.DefaultBaseDerivationPath, path}, password)
+	assert.Nil(err)
+	assert.Equal(2, len(derivedAddrs))
+	assert.Equal(addr, derivedAddrs[0])
+	assert.Equal(childAddr, derivedAddrs[1])
+	signature, err = wallet2.Sign(childAddr, common.Bytes("hello world"))
+	assert.Nil(err)
+	assert.True(signature.Verify(common.Bytes("hello world"), childAddr))
+
+	wallet3, err := NewSoftWallet(tmpdir2, KeystoreTypeEncrypted)
+	assert.Nil(err)
+	assert.NotNil(wallet3.Unlock(childAddr, "wrong", nil))
+	assert.Nil(wallet3.Unlock(childAddr, password, nil))
+	signature, err = wallet3.Sign(childAddr, common.Bytes("hello world"))
+	assert.Nil(err)
+	assert.True(signature.Verify(common.Bytes("hello world"), childAddr))
+}
+
+func TestSoftWalletSignMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	tmpdir := createTempDir()
+	defer os.RemoveAll(tmpdir)
+
+	wallet, err := NewSoftWallet(tmpdir, KeystoreTypeEncrypted)
+	assert.Nil(err)
+	password := "abcd"
+	addr, err := wallet.NewKey(password)
+	assert.Nil(err)
+
+	message := common.Bytes("hello world")
+	assert.Nil(wallet.Lock(addr))
+	_, err = wallet.SignMessage(addr, message)
+	assert.NotNil(err)
+
+	assert.Nil(wallet.Unlock(addr, password, nil))
+	signature, err := wallet.SignMessage(addr, message)
+	assert.Nil(err)
+	signer, err := types.RecoverMessageSigner(message, signature)
+	assert.Nil(err)
+	assert.Equal(addr, signer)
+
+	// A signed message can not pass as a signed transaction
+	assert.False(signature.Verify(message, addr))
+
+	typedData, err := types.ParseTypedData([]byte(`{
+		"types": {"Login": [{"name": "challenge", "type": "string"}, {"name": "expiry", "type": "uint64"}]},
+		"primaryType": "Login",
+		"domain": {"name": "Theta", "chainId": 361},
+		"message": {"challenge": "4f2a", "expiry": 1700000000}
+	}`))
+	assert.Nil(err)
+	signature, err = wallet.SignTypedData(addr, typedData)
+	assert.Nil(err)
+	signer, err = types.RecoverTypedDataSigner(typedData, signature)
+	assert.Nil(err)
+	assert.Equal(addr, signer)
+}
+
 // ---------------- Test Utilities ---------------- //
 
 func testSoftWalletBasics(t *testing.T, ksType KeystoreType) {
@@ -1,5 +1,13 @@
 package types
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/thetatoken/theta/wallet/softwallet/hd"
+)
+
 // DerivationPath represents the computer friendly version of a hierarchical
 // deterministic wallet account derivaion path.
 type DerivationPath []uint32
@@ -18,3 +26,53 @@ var DefaultBaseDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 60,
 // are incremented. As such, the first account will be at m/44'/60'/0'/0, the second
 // at m/44'/60'/0'/1, etc.
 var DefaultLedgerBaseDerivationPath = DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0}
+
+// ParseDerivationPath parses a derivation path such as m/44'/60'/0'/0/0. The hardened
+// indices are marked with ' or h, or are negative.
+func ParseDerivationPath(path string) (DerivationPath, error) {
+	components := strings.Split(strings.TrimSpace(path), "/")
+
+	// m/a/b/c => a/b/c
+	if components[0] == "m" {
+		components = components[1:]
+	}
+	if len(components) == 0 {
+		return nil, fmt.Errorf("empty derivation path")
+	}
+
+	derivationPath := DerivationPath{}
+	for _, component := range components {
+		hardened := false
+		if strings.HasPrefix(component, "-") {
+			component = component[1:]
+			hardened = true
+		} else if strings.HasSuffix(component, "h") || strings.HasSuffix(component, "'") {
+			component = component[:len(component)-1]
+			hardened = true
+		}
+
+		index, err := strconv.ParseUint(component, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index in derivation path %v: %v", path, err)
+		}
+		if hardened {
+			index |= uint64(hd.HardenedKeyStart)
+		}
+		derivationPath = append(derivationPath, uint32(index))
+	}
+
+	return derivationPath, nil
+}
+
+// String returns the human readable form of the derivation path, e.g. m/44'/60'/0'/0/0.
+func (path DerivationPath) String() string {
+	result := "m"
+	for _, index := range path {
+		if index >= hd.HardenedKeyStart {
+			result += fmt.Sprintf("/%d'", index-hd.HardenedKeyStart)
+		} else {
+			result += fmt.Sprintf("/%d", index)
+		}
+	}
+	return result
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/wallet/softwallet/hd"
+)
+
+func TestParseDerivationPath(t *testing.T) {
+	assert := assert.New(t)
+
+	tests := []struct {
+		input    string
+		expected DerivationPath
+		str      string
+	}{
+		{"m/44'/60'/0'/0/0", DefaultBaseDerivationPath, "m/44'/60'/0'/0/0"},
+		{"m/44h/60h/0h/0", DefaultLedgerBaseDerivationPath, "m/44'/60'/0'/0"},
+		{"-44/-60/-0/0", DefaultRootDerivationPath, "m/44'/60'/0'/0"},
+		{"m/0'/1/2'/2/1000000000", DerivationPath{hd.HardenedKeyStart, 1, hd.HardenedKeyStart + 2, 2, 1000000000}, "m/0'/1/2'/2/1000000000"},
+	}
+	for _, test := range tests {
+		path, err := ParseDerivationPath(test.input)
+		assert.Nil(err, test.input)
+		assert.Equal(test.expected, path, test.input)
+		assert.Equal(test.str, path.String())
+	}
+
+	for _, input := range []string{"", "m", "m/44'/x", "m/2147483648"} {
+		_, err := ParseDerivationPath(input)
+		assert.NotNil(err, input)
+	}
+}
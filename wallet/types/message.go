@@ -0,0 +1,55 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/crypto"
+)
+
+// PersonalMessage returns the EIP-191 form of a message signed by personal_sign, i.e.
+// "\x19Ethereum Signed Message:\n" ‖ len(message) ‖ message, whose Keccak256 hash is signed.
+// The prefix prevents the signature of a message from being a valid transaction signature.
+func PersonalMessage(message common.Bytes) common.Bytes {
+	prefix := fmt.Sprintf("\x19Ethereum Signed Message:\n%d", len(message))
+	return append([]byte(prefix), message...)
+}
+
+// RecoverMessageSigner recovers the address that signed the message with personal_sign.
+func RecoverMessageSigner(message common.Bytes, sig *crypto.Signature) (common.Address, error) {
+	return sig.RecoverSignerAddress(PersonalMessage(message))
+}
+
+// RecoverTypedDataSigner recovers the address that signed the EIP-712 typed data.
+func RecoverTypedDataSigner(typedData *TypedData, sig *crypto.Signature) (common.Address, error) {
+	message, err := typedData.SigningMessage()
+	if err != nil {
+		return common.Address{}, err
+	}
+	return sig.RecoverSignerAddress(message)
+}
+
+// EncodeEthSignature encodes a signature in the Ethereum format r ‖ s ‖ v, with v being 27 or 28.
+func EncodeEthSignature(sig *crypto.Signature) common.Bytes {
+	sigBytes := append(common.Bytes{}, sig.ToBytes()...)
+	if len(sigBytes) == crypto.SignatureLength && sigBytes[64] < 27 {
+		sigBytes[64] += 27
+	}
+	return sigBytes
+}
+
+// DecodeEthSignature decodes a signature in the Ethereum format r ‖ s ‖ v, with v being 27 or
+// 28, or 0 or 1.
+func DecodeEthSignature(sigBytes common.Bytes) (*crypto.Signature, error) {
+	if len(sigBytes) != crypto.SignatureLength {
+		return nil, fmt.Errorf("Signature should be %v bytes long", crypto.SignatureLength)
+	}
+	sigBytes = append(common.Bytes{}, sigBytes...)
+	if sigBytes[64] >= 27 {
+		sigBytes[64] -= 27
+	}
+	if sigBytes[64] > 1 {
+		return nil, fmt.Errorf("Invalid signature recovery id %v", sigBytes[64])
+	}
+	return crypto.SignatureFromBytes(sigBytes)
+}
@@ -0,0 +1,341 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/common/math"
+	"github.com/thetatoken/theta/crypto"
+)
+
+// EIP712DomainType is the name of the type of the EIP-712 domain.
+const EIP712DomainType = "EIP712Domain"
+
+var (
+	typedDataArrayRegexp  = regexp.MustCompile(`^(.+)\[(\d*)\]$`)
+	typedDataIntRegexp    = regexp.MustCompile(`^(u?)int(\d*)$`)
+	typedDataBytesNRegexp = regexp.MustCompile(`^bytes(\d+)$`)
+)
+
+// eip712DomainFields are the fields of the EIP-712 domain, in their canonical order.
+var eip712DomainFields = []TypedDataField{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// TypedDataField is a field of a struct type of the typed data.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is the EIP-712 typed structured data, in the JSON format of eth_signTypedData_v4.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]interface{}      `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// ParseTypedData parses the JSON of EIP-712 typed data, keeping the precision of the numbers.
+func ParseTypedData(data []byte) (*TypedData, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	typedData := &TypedData{}
+	if err := decoder.Decode(typedData); err != nil {
+		return nil, fmt.Errorf("Invalid typed data: %v", err)
+	}
+	if typedData.PrimaryType == "" {
+		return nil, fmt.Errorf("Invalid typed data: primary type not specified")
+	}
+	if _, ok := typedData.Types[typedData.PrimaryType]; !ok {
+		return nil, fmt.Errorf("Invalid typed data: primary type %v not defined", typedData.PrimaryType)
+	}
+	return typedData, nil
+}
+
+// DomainSeparator returns the hash of the EIP-712 domain.
+func (td *TypedData) DomainSeparator() (common.Hash, error) {
+	return td.HashStruct(EIP712DomainType, td.Domain)
+}
+
+// MessageHash returns the hash of the message.
+func (td *TypedData) MessageHash() (common.Hash, error) {
+	return td.HashStruct(td.PrimaryType, td.Message)
+}
+
+// SigningMessage returns "\x19\x01" ‖ domainSeparator ‖ hashStruct(message), whose Keccak256
+// hash is signed.
+func (td *TypedData) SigningMessage() (common.Bytes, error) {
+	domainSeparator, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	messageHash, err := td.MessageHash()
+	if err != nil {
+		return nil, err
+	}
+
+	message := []byte{0x19, 0x01}
+	message = append(message, domainSeparator[:]...)
+	message = append(message, messageHash[:]...)
+	return message, nil
+}
+
+// HashStruct returns the hash of the struct data of the given type.
+func (td *TypedData) HashStruct(typeName string, data map[string]interface{}) (common.Hash, error) {
+	encoded, err := td.encodeData(typeName, data)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// EncodeType returns the encoding of the type, followed by the encodings of the struct types it
+// references in alphabetical order, e.g. Mail(Person from,Person to,string contents)Person(string name,address wallet)
+func (td *TypedData) EncodeType(typeName string) string {
+	deps := td.dependencies(typeName, map[string]bool{})
+	sort.Strings(deps)
+
+	var buf bytes.Buffer
+	for _, dep := range append([]string{typeName}, deps...) {
+		fields := td.fields(dep)
+		names := make([]string, len(fields))
+		for i, field := range fields {
+			names[i] = field.Type + " " + field.Name
+		}
+		buf.WriteString(dep + "(" + strings.Join(names, ",") + ")")
+	}
+	return buf.String()
+}
+
+// TypeHash returns the hash of the encoding of the type.
+func (td *TypedData) TypeHash(typeName string) common.Hash {
+	return crypto.Keccak256Hash([]byte(td.EncodeType(typeName)))
+}
+
+// fields returns the fields of the struct type. Unless defined, the fields of the domain are
+// the standard ones present in the domain.
+func (td *TypedData) fields(typeName string) []TypedDataField {
+	if fields, ok := td.Types[typeName]; ok || typeName != EIP712DomainType {
+		return fields
+	}
+
+	fields := []TypedDataField{}
+	for _, field := range eip712DomainFields {
+		if _, ok := td.Domain[field.Name]; ok {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (td *TypedData) isStruct(typeName string) bool {
+	_, ok := td.Types[typeName]
+	return ok || typeName == EIP712DomainType
+}
+
+// dependencies returns the struct types referenced by the type, directly or indirectly.
+func (td *TypedData) dependencies(typeName string, found map[string]bool) []string {
+	deps := []string{}
+	for _, field := range td.fields(typeName) {
+		fieldType := baseType(field.Type)
+		if !td.isStruct(fieldType) || fieldType == typeName || found[fieldType] {
+			continue
+		}
+		found[fieldType] = true
+		deps = append(deps, fieldType)
+		deps = append(deps, td.dependencies(fieldType, found)...)
+	}
+	return deps
+}
+
+func (td *TypedData) encodeData(typeName string, data map[string]interface{}) ([]byte, error) {
+	if !td.isStruct(typeName) {
+		return nil, fmt.Errorf("Type %v not defined", typeName)
+	}
+
+	typeHash := td.TypeHash(typeName)
+	encoded := append([]byte{}, typeHash[:]...)
+	for _, field := range td.fields(typeName) {
+		value, ok := data[field.Name]
+		if !ok {
+			return nil, fmt.Errorf("Field %v of %v is missing", field.Name, typeName)
+		}
+		encodedValue, err := td.encodeValue(field.Type, value)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to encode field %v of %v: %v", field.Name, typeName, err)
+		}
+		encoded = append(encoded, encodedValue...)
+	}
+	return encoded, nil
+}
+
+// encodeValue encodes a value into 32 bytes.
+func (td *TypedData) encodeValue(typeName string, value interface{}) ([]byte, error) {
+	if match := typedDataArrayRegexp.FindStringSubmatch(typeName); match != nil {
+		items, ok := value.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not an array", value)
+		}
+		if match[2] != "" {
+			length, _ := strconv.Atoi(match[2])
+			if len(items) != length {
+				return nil, fmt.Errorf("array of %v items, expected %v", len(items), length)
+			}
+		}
+		encoded := []byte{}
+		for _, item := range items {
+			encodedItem, err := td.encodeValue(match[1], item)
+			if err != nil {
+				return nil, err
+			}
+			encoded = append(encoded, encodedItem...)
+		}
+		return crypto.Keccak256(encoded), nil
+	}
+
+	if td.isStruct(typeName) {
+		data, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%v is not a %v struct", value, typeName)
+		}
+		hash, err := td.HashStruct(typeName, data)
+		return hash[:], err
+	}
+
+	switch typeName {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a string", value)
+		}
+		return crypto.Keccak256([]byte(str)), nil
+	case "bytes":
+		data, err := decodeTypedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		return crypto.Keccak256(data), nil
+	case "address":
+		str, ok := value.(string)
+		if !ok || !common.IsHexAddress(str) {
+			return nil, fmt.Errorf("%v is not an address", value)
+		}
+		return common.LeftPadBytes(common.HexToAddress(str).Bytes(), 32), nil
+	case "bool":
+		b, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%v is not a bool", value)
+		}
+		if b {
+			return math.PaddedBigBytes(big.NewInt(1), 32), nil
+		}
+		return make([]byte, 32), nil
+	}
+
+	if match := typedDataBytesNRegexp.FindStringSubmatch(typeName); match != nil {
+		size, _ := strconv.Atoi(match[1])
+		if size < 1 || size > 32 {
+			return nil, fmt.Errorf("invalid type %v", typeName)
+		}
+		data, err := decodeTypedDataBytes(value)
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != size {
+			return nil, fmt.Errorf("%v is not %v bytes long", value, size)
+		}
+		return common.RightPadBytes(data, 32), nil
+	}
+
+	if match := typedDataIntRegexp.FindStringSubmatch(typeName); match != nil {
+		bits := 256
+		if match[2] != "" {
+			bits, _ = strconv.Atoi(match[2])
+		}
+		if bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("invalid type %v", typeName)
+		}
+		n, err := parseTypedDataInteger(value)
+		if err != nil {
+			return nil, err
+		}
+		if match[1] == "u" {
+			if n.Sign() < 0 || n.BitLen() > bits {
+				return nil, fmt.Errorf("%v out of the range of %v", n, typeName)
+			}
+			return math.PaddedBigBytes(n, 32), nil
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(bits-1))
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%v out of the range of %v", n, typeName)
+		}
+		return math.PaddedBigBytes(math.U256(n), 32), nil
+	}
+
+	return nil, fmt.Errorf("Type %v not supported", typeName)
+}
+
+// baseType strips the array dimensions of a type, e.g. Person[][2] => Person
+func baseType(typeName string) string {
+	for {
+		match := typedDataArrayRegexp.FindStringSubmatch(typeName)
+		if match == nil {
+			return typeName
+		}
+		typeName = match[1]
+	}
+}
+
+func decodeTypedDataBytes(value interface{}) ([]byte, error) {
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("%v is not a hex string", value)
+	}
+	data, err := hexutil.Decode(str)
+	if err != nil {
+		return nil, fmt.Errorf("%v is not a hex string: %v", value, err)
+	}
+	return data, nil
+}
+
+func parseTypedDataInteger(value interface{}) (*big.Int, error) {
+	var str string
+	switch v := value.(type) {
+	case json.Number:
+		str = v.String()
+	case string:
+		str = v
+	case float64:
+		if v != float64(int64(v)) {
+			return nil, fmt.Errorf("%v is not an integer", v)
+		}
+		return big.NewInt(int64(v)), nil
+	default:
+		return nil, fmt.Errorf("%v is not an integer", value)
+	}
+
+	n, ok := math.ParseBig256(str)
+	if !ok {
+		if strings.HasPrefix(str, "-") {
+			if n, ok = math.ParseBig256(str[1:]); ok {
+				return n.Neg(n), nil
+			}
+		}
+		return nil, fmt.Errorf("%v is not an integer", str)
+	}
+	return n, nil
+}
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thetatoken/theta/common"
+	"github.com/thetatoken/theta/common/hexutil"
+	"github.com/thetatoken/theta/crypto"
+)
+
+// The example of EIP-712
+const mailTypedData = `{
+	"types": {
+		"EIP712Domain": [
+			{"name": "name", "type": "string"},
+			{"name": "version", "type": "string"},
+			{"name": "chainId", "type": "uint256"},
+			{"name": "verifyingContract", "type": "address"}
+		],
+		"Person": [
+			{"name": "name", "type": "string"},
+			{"name": "wallet", "type": "address"}
+		],
+		"Mail": [
+			{"name": "from", "type": "Person"},
+			{"name": "to", "type": "Person"},
+			{"name": "contents", "type": "string"}
+		]
+	},
+	"primaryType": "Mail",
+	"domain": {
+		"name": "Ether Mail",
+		"version": "1",
+		"chainId": 1,
+		"verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+	},
+	"message": {
+		"from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+		"to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+		"contents": "Hello, Bob!"
+	}
+}`
+
+func TestTypedData(t *testing.T) {
+	assert := assert.New(t)
+
+	typedData, err := ParseTypedData([]byte(mailTypedData))
+	assert.Nil(err)
+
+	assert.Equal("Mail(Person from,Person to,string contents)Person(string name,address wallet)", typedData.EncodeType("Mail"))
+	assert.Equal(common.HexToHash("0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2"), typedData.TypeHash("Mail"))
+
+	domainSeparator, err := typedData.DomainSeparator()
+	assert.Nil(err)
+	assert.Equal(common.HexToHash("0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f"), domainSeparator)
+
+	messageHash, err := typedData.MessageHash()
+	assert.Nil(err)
+	assert.Equal(common.HexToHash("0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e"), messageHash)
+
+	// Signed with the key keccak256("cow")
+	privKey, err := crypto.PrivateKeyFromBytes(crypto.Keccak256([]byte("cow")))
+	assert.Nil(err)
+	signingMessage, err := typedData.SigningMessage()
+	assert.Nil(err)
+	sig, err := privKey.Sign(signingMessage)
+	assert.Nil(err)
+	assert.Equal("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+"1c",
+		hexutil.Encode(EncodeEthSignature(sig)))
+
+	signer, err := RecoverTypedDataSigner(typedData, sig)
+	assert.Nil(err)
+	assert.Equal(common.HexToAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"), signer)
+
+	// Invalid typed data
+	_, err = ParseTypedData([]byte(`{"types": {}, "primaryType": "Mail"}`))
+	assert.NotNil(err)
+	typedData.Message["contents"] = 1
+	_, err = typedData.SigningMessage()
+	assert.NotNil(err)
+	delete(typedData.Message, "contents")
+	_, err = typedData.SigningMessage()
+	assert.NotNil(err)
+}
+
+func TestPersonalMessage(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(common.Bytes("\x19Ethereum Signed Message:\n5hello"), PersonalMessage(common.Bytes("hello")))
+
+	privKey, _, err := crypto.GenerateKeyPair()
+	assert.Nil(err)
+	message := common.Bytes("Login challenge 4f2a")
+	sig, err := privKey.Sign(PersonalMessage(message))
+	assert.Nil(err)
+
+	ethSig := EncodeEthSignature(sig)
+	assert.True(ethSig[64] == 27 || ethSig[64] == 28)
+	decoded, err := DecodeEthSignature(ethSig)
+	assert.Nil(err)
+	signer, err := RecoverMessageSigner(message, decoded)
+	assert.Nil(err)
+	assert.Equal(privKey.PublicKey().Address(), signer)
+
+	signer, err = RecoverMessageSigner(common.Bytes("Login challenge 4f2b"), decoded)
+	assert.Nil(err)
+	assert.NotEqual(privKey.PublicKey().Address(), signer)
+
+	_, err = DecodeEthSignature(ethSig[:64])
+	assert.NotNil(err)
+	ethSig[64] = 29
+	_, err = DecodeEthSignature(ethSig)
+	assert.NotNil(err)
+}
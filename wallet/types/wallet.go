@@ -26,4 +26,6 @@ type Wallet interface {
 	Derive(path DerivationPath, pin bool) (common.Address, error)
 	GetPublicKey(address common.Address) (*crypto.PublicKey, error)
 	Sign(address common.Address, txrlp common.Bytes) (*crypto.Signature, error)
+	SignMessage(address common.Address, message common.Bytes) (*crypto.Signature, error)
+	SignTypedData(address common.Address, typedData *TypedData) (*crypto.Signature, error)
 }